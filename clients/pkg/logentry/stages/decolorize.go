@@ -0,0 +1,32 @@
+package stages
+
+import (
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/grafana/loki/pkg/logql/log"
+)
+
+const ansiEscape = "\x1b"
+
+func newDecolorizeStage(_ interface{}) (Stage, error) {
+	return toStage(&decolorizeStage{}), nil
+}
+
+// decolorizeStage removes ANSI escape sequences, such as colors, from the log line.
+type decolorizeStage struct{}
+
+// Process implements Stage
+func (m *decolorizeStage) Process(labels model.LabelSet, extracted map[string]interface{}, t *time.Time, entry *string) {
+	if !strings.Contains(*entry, ansiEscape) {
+		return
+	}
+	*entry = string(log.StripANSI(make([]byte, 0, len(*entry)), []byte(*entry)))
+}
+
+// Name implements Stage
+func (m *decolorizeStage) Name() string {
+	return StageTypeDecolorize
+}
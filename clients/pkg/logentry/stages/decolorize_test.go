@@ -0,0 +1,50 @@
+package stages
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	util_log "github.com/grafana/loki/pkg/util/log"
+)
+
+var testDecolorizeYaml = `
+pipeline_stages:
+- decolorize: {}
+- regex:
+    expression: "^level=(?P<level>\\w+)"
+- labels:
+    level:
+`
+
+func Test_decolorizeStage_Process(t *testing.T) {
+	tests := []struct {
+		name     string
+		entry    string
+		expected string
+	}{
+		{"no colors", "level=info msg=hello", "level=info msg=hello"},
+		{"colors", "\033[0;32mlevel=info\033[0m msg=hello", "level=info msg=hello"},
+		{"cursor movement", "\033[2K\033[1Glevel=info progress 50%", "level=info progress 50%"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st, err := newDecolorizeStage(nil)
+			require.NoError(t, err)
+			out := processEntries(st, newEntry(nil, nil, tt.entry, time.Now()))[0]
+			assert.Equal(t, tt.expected, out.Line)
+		})
+	}
+}
+
+func TestPipeline_Decolorize(t *testing.T) {
+	pl, err := NewPipeline(util_log.Logger, loadConfig(testDecolorizeYaml), nil, prometheus.DefaultRegisterer)
+	require.NoError(t, err)
+
+	out := processEntries(pl, newEntry(nil, nil, "\033[31mlevel=error\033[0m msg=boom", time.Now()))[0]
+	assert.Equal(t, "level=error msg=boom", out.Line)
+	assert.Equal(t, "error", string(out.Labels["level"]))
+}
@@ -36,6 +36,7 @@ const (
 	StageTypePack         = "pack"
 	StageTypeLabelAllow   = "labelallow"
 	StageTypeStaticLabels = "static_labels"
+	StageTypeDecolorize   = "decolorize"
 )
 
 // Processor takes an existing set of labels, timestamp and log entry and returns either a possibly mutated
@@ -209,6 +210,11 @@ func New(logger log.Logger, jobName *string, stageType string,
 		if err != nil {
 			return nil, err
 		}
+	case StageTypeDecolorize:
+		s, err = newDecolorizeStage(cfg)
+		if err != nil {
+			return nil, err
+		}
 	default:
 		return nil, errors.Errorf("Unknown stage type: %s", stageType)
 	}
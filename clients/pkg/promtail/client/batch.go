@@ -23,6 +23,11 @@ type batch struct {
 	streams   map[string]*logproto.Stream
 	bytes     int
 	createdAt time.Time
+
+	// walSegment is the oldest segment of the WAL with entries of the batch, and walAppendedAt the time its
+	// oldest entry was appended to the WAL.
+	walSegment    int
+	walAppendedAt time.Time
 }
 
 func newBatch(entries ...api.Entry) *batch {
@@ -40,6 +45,14 @@ func newBatch(entries ...api.Entry) *batch {
 	return b
 }
 
+// newWALBatch creates a batch with the entry, appended to the given segment of the WAL at the given time.
+func newWALBatch(entry api.Entry, walSegment int, appendedAt time.Time) *batch {
+	b := newBatch(entry)
+	b.walSegment = walSegment
+	b.walAppendedAt = appendedAt
+	return b
+}
+
 // add an entry to the batch
 func (b *batch) add(entry api.Entry) {
 	b.bytes += len(entry.Line)
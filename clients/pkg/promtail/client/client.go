@@ -38,6 +38,9 @@ const (
 	LatencyLabel = "filename"
 	HostLabel    = "host"
 	ClientLabel  = "client"
+
+	// currentWALSegment stands for the segment of the WAL the entries are being appended to.
+	currentWALSegment = -1
 )
 
 var UserAgent = fmt.Sprintf("promtail/%s", build.Version)
@@ -52,6 +55,11 @@ type Metrics struct {
 	batchRetries     *prometheus.CounterVec
 	countersWithHost []*prometheus.CounterVec
 	streamLag        *prometheus.GaugeVec
+
+	walLag             *prometheus.GaugeVec
+	walSize            *prometheus.GaugeVec
+	walReplayedEntries *prometheus.CounterVec
+	walRemovedBytes    *prometheus.CounterVec
 }
 
 func NewMetrics(reg prometheus.Registerer, streamLagLabels []string) *Metrics {
@@ -105,6 +113,27 @@ func NewMetrics(reg prometheus.Registerer, streamLagLabels []string) *Metrics {
 		Help:      "Difference between current time and last batch timestamp for successful sends",
 	}, streamLagLabelsMerged)
 
+	m.walLag = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "promtail",
+		Name:      "wal_lag_seconds",
+		Help:      "Time since the oldest entry of the WAL not pushed yet was appended to it.",
+	}, []string{HostLabel, ClientLabel})
+	m.walSize = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "promtail",
+		Name:      "wal_size_bytes",
+		Help:      "Size of the WAL on disk.",
+	}, []string{HostLabel, ClientLabel})
+	m.walReplayedEntries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "promtail",
+		Name:      "wal_replayed_entries_total",
+		Help:      "Number of log entries replayed from the WAL on start.",
+	}, []string{HostLabel, ClientLabel})
+	m.walRemovedBytes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "promtail",
+		Name:      "wal_removed_bytes_total",
+		Help:      "Number of bytes of the WAL segments removed before being pushed because the WAL exceeded its maximum size.",
+	}, []string{HostLabel, ClientLabel})
+
 	if reg != nil {
 		m.encodedBytes = mustRegisterOrGet(reg, m.encodedBytes).(*prometheus.CounterVec)
 		m.sentBytes = mustRegisterOrGet(reg, m.sentBytes).(*prometheus.CounterVec)
@@ -114,6 +143,10 @@ func NewMetrics(reg prometheus.Registerer, streamLagLabels []string) *Metrics {
 		m.requestDuration = mustRegisterOrGet(reg, m.requestDuration).(*prometheus.HistogramVec)
 		m.batchRetries = mustRegisterOrGet(reg, m.batchRetries).(*prometheus.CounterVec)
 		m.streamLag = mustRegisterOrGet(reg, m.streamLag).(*prometheus.GaugeVec)
+		m.walLag = mustRegisterOrGet(reg, m.walLag).(*prometheus.GaugeVec)
+		m.walSize = mustRegisterOrGet(reg, m.walSize).(*prometheus.GaugeVec)
+		m.walReplayedEntries = mustRegisterOrGet(reg, m.walReplayedEntries).(*prometheus.CounterVec)
+		m.walRemovedBytes = mustRegisterOrGet(reg, m.walRemovedBytes).(*prometheus.CounterVec)
 	}
 
 	return &m
@@ -147,6 +180,12 @@ type client struct {
 	client          *http.Client
 	entries         chan api.Entry
 
+	// wal keeps the entries on disk until they are pushed, nil when disabled.
+	wal *clientWAL
+	// failed are the batches which failed to be sent after all retries, sent again while running
+	// when the WAL is enabled.
+	failed []failedBatch
+
 	once sync.Once
 	wg   sync.WaitGroup
 
@@ -157,6 +196,13 @@ type client struct {
 	cancel context.CancelFunc
 }
 
+// failedBatch is a batch which failed to be sent after all retries, and the last time it was sent.
+type failedBatch struct {
+	tenantID string
+	batch    *batch
+	sentAt   time.Time
+}
+
 // Tripperware can wrap a roundtripper.
 type Tripperware func(http.RoundTripper) http.RoundTripper
 
@@ -204,6 +250,18 @@ func newClient(metrics *Metrics, cfg Config, streamLagLabels []string, logger lo
 
 	c.client.Timeout = cfg.Timeout
 
+	if cfg.WAL.Enabled {
+		// The name of the client is a hash of its whole config unless set, which isn't stable across restarts.
+		walName := cfg.Name
+		if walName == "" {
+			walName = asSha256(cfg.URL.String() + "/" + cfg.TenantID)
+		}
+		c.wal, err = newClientWAL(cfg.WAL, walName, c.logger)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Initialize counters to 0 so the metrics are exported before the first
 	// occurrence of incrementing to avoid missing metrics.
 	for _, counter := range c.metrics.countersWithHost {
@@ -250,38 +308,35 @@ func (c *client) run() {
 		maxWaitCheck.Stop()
 		// Send all pending batches
 		for tenantID, batch := range batches {
-			c.sendBatch(tenantID, batch)
+			c.pushBatch(tenantID, batch)
+			delete(batches, tenantID)
+		}
+		// and the failed ones a last time, those failing again being replayed from the WAL on the next start.
+		c.retryFailedBatches(time.Time{})
+		if c.wal != nil {
+			c.wal.close()
 		}
 
 		c.wg.Done()
 	}()
 
+	if c.wal != nil {
+		c.replayWAL(batches)
+	}
+
 	for {
 		select {
 		case e, ok := <-c.entries:
 			if !ok {
 				return
 			}
-			e, tenantID := c.processEntry(e)
-			batch, ok := batches[tenantID]
-
-			// If the batch doesn't exist yet, we create a new one with the entry
-			if !ok {
-				batches[tenantID] = newBatch(e)
-				break
-			}
-
-			// If adding the entry to the batch will increase the size over the max
-			// size allowed, we do send the current batch and then create a new one
-			if batch.sizeBytesAfter(e) > c.cfg.BatchSize {
-				c.sendBatch(tenantID, batch)
-
-				batches[tenantID] = newBatch(e)
-				break
+			now := time.Now()
+			if c.wal != nil {
+				if err := c.wal.append(e, now); err != nil {
+					level.Error(c.logger).Log("msg", "failed to append the entry to the WAL", "error", err)
+				}
 			}
-
-			// The max size of the batch isn't reached, so we can add the entry
-			batch.add(e)
+			c.addEntry(batches, e, currentWALSegment, now)
 
 		case <-maxWaitCheck.C:
 			// Send all batches whose max wait time has been reached
@@ -290,13 +345,149 @@ func (c *client) run() {
 					continue
 				}
 
-				c.sendBatch(tenantID, batch)
+				c.pushBatch(tenantID, batch)
 				delete(batches, tenantID)
 			}
+			c.retryFailedBatches(time.Now().Add(-c.cfg.BackoffConfig.MaxBackoff))
+			c.updateWALMetrics(batches)
 		}
 	}
 }
 
+// addEntry adds the entry to the batch of its tenant, sending the batch first if the entry doesn't fit in it.
+// The segment of the WAL the entry was appended to, and the time it was appended at, are tracked by the
+// batches to only truncate the WAL once its entries are pushed. The segment is only looked up when
+// a batch is created if it is the current one.
+func (c *client) addEntry(batches map[string]*batch, e api.Entry, walSegment int, appendedAt time.Time) {
+	e, tenantID := c.processEntry(e)
+	batch, ok := batches[tenantID]
+
+	// If the batch doesn't exist yet, we create a new one with the entry
+	if !ok {
+		batches[tenantID] = c.newBatch(e, walSegment, appendedAt)
+		return
+	}
+
+	// If adding the entry to the batch will increase the size over the max
+	// size allowed, we do send the current batch and then create a new one
+	if batch.sizeBytesAfter(e) > c.cfg.BatchSize {
+		c.pushBatch(tenantID, batch)
+
+		batches[tenantID] = c.newBatch(e, walSegment, appendedAt)
+		return
+	}
+
+	// The max size of the batch isn't reached, so we can add the entry
+	batch.add(e)
+}
+
+// replayWAL adds the entries of the WAL to the batches, sending them as they fill up.
+func (c *client) replayWAL(batches map[string]*batch) {
+	replayed := 0
+	err := c.wal.replay(func(e api.Entry, segment int, appendedAt time.Time) {
+		c.addEntry(batches, e, segment, appendedAt)
+		replayed++
+	})
+	if err != nil {
+		level.Error(c.logger).Log("msg", "failed to replay the WAL", "error", err)
+	}
+	level.Info(c.logger).Log("msg", "replayed the WAL", "entries", replayed)
+	c.metrics.walReplayedEntries.WithLabelValues(c.cfg.URL.Host, c.name).Add(float64(replayed))
+}
+
+// newBatch creates a batch with the entry, tracking the segment of the WAL it was appended to until the batch
+// is acknowledged. The segment is only looked up if it is the current one.
+func (c *client) newBatch(e api.Entry, walSegment int, appendedAt time.Time) *batch {
+	if c.wal == nil {
+		return newWALBatch(e, walSegment, appendedAt)
+	}
+	if walSegment == currentWALSegment {
+		walSegment = c.wal.segment()
+	}
+	c.wal.track(walSegment)
+	return newWALBatch(e, walSegment, appendedAt)
+}
+
+// pushBatch sends the batch, and acknowledges it in the WAL unless it failed to be sent after all retries,
+// in which case it is sent again later.
+func (c *client) pushBatch(tenantID string, b *batch) {
+	if c.sendBatch(tenantID, b) || c.wal == nil {
+		c.ackBatch(b)
+		return
+	}
+	c.failed = append(c.failed, failedBatch{tenantID: tenantID, batch: b, sentAt: time.Now()})
+}
+
+// retryFailedBatches sends again the failed batches last sent before the given time, stopping at the first one
+// failing again.
+func (c *client) retryFailedBatches(before time.Time) {
+	for len(c.failed) > 0 {
+		f := &c.failed[0]
+		if !before.IsZero() && f.sentAt.After(before) {
+			return
+		}
+		if !c.sendBatch(f.tenantID, f.batch) {
+			f.sentAt = time.Now()
+			return
+		}
+		c.ackBatch(f.batch)
+		c.failed = c.failed[1:]
+	}
+}
+
+// ackBatch acknowledges the entries of the batch in the WAL, removing the segments whose entries were all
+// acknowledged.
+func (c *client) ackBatch(b *batch) {
+	if c.wal == nil {
+		return
+	}
+	c.wal.ack(b.walSegment)
+	if err := c.wal.truncate(); err != nil {
+		level.Error(c.logger).Log("msg", "failed to truncate the WAL", "error", err)
+	}
+}
+
+// updateWALMetrics enforces the maximum size of the WAL and updates its metrics.
+func (c *client) updateWALMetrics(batches map[string]*batch) {
+	if c.wal == nil {
+		return
+	}
+	size, removed, err := c.wal.enforceMaxSize()
+	if err != nil {
+		level.Error(c.logger).Log("msg", "failed to enforce the maximum size of the WAL", "error", err)
+	}
+	c.metrics.walSize.WithLabelValues(c.cfg.URL.Host, c.name).Set(float64(size))
+	c.metrics.walRemovedBytes.WithLabelValues(c.cfg.URL.Host, c.name).Add(float64(removed))
+
+	// The failed batches whose segments were removed are dropped, as they would be on the next start.
+	failed := c.failed[:0]
+	for _, f := range c.failed {
+		if f.batch.walSegment >= c.wal.first {
+			failed = append(failed, f)
+			continue
+		}
+		level.Error(c.logger).Log("msg", "dropping failed batch whose entries were removed from the WAL")
+		if buf, entriesCount, err := f.batch.encode(); err == nil {
+			c.metrics.droppedBytes.WithLabelValues(c.cfg.URL.Host).Add(float64(len(buf)))
+			c.metrics.droppedEntries.WithLabelValues(c.cfg.URL.Host).Add(float64(entriesCount))
+		}
+	}
+	c.failed = failed
+
+	var lag time.Duration
+	for _, b := range batches {
+		if l := time.Since(b.walAppendedAt); l > lag {
+			lag = l
+		}
+	}
+	for _, f := range c.failed {
+		if l := time.Since(f.batch.walAppendedAt); l > lag {
+			lag = l
+		}
+	}
+	c.metrics.walLag.WithLabelValues(c.cfg.URL.Host, c.name).Set(lag.Seconds())
+}
+
 func (c *client) Chan() chan<- api.Entry {
 	return c.entries
 }
@@ -309,11 +500,13 @@ func asSha256(o interface{}) string {
 	return temp[:6]
 }
 
-func (c *client) sendBatch(tenantID string, batch *batch) {
+// sendBatch sends the batch, retrying on the errors which may be transient. It returns false if the batch
+// failed to be sent after all retries, and true if it was sent or was dropped for good, e.g. rejected by Loki.
+func (c *client) sendBatch(tenantID string, batch *batch) bool {
 	buf, entriesCount, err := batch.encode()
 	if err != nil {
 		level.Error(c.logger).Log("msg", "error encoding batch", "error", err)
-		return
+		return true
 	}
 	bufBytes := float64(len(buf))
 	c.metrics.encodedBytes.WithLabelValues(c.cfg.URL.Host).Add(bufBytes)
@@ -335,7 +528,7 @@ func (c *client) sendBatch(tenantID string, batch *batch) {
 				if err != nil {
 					// is this possible?
 					level.Warn(c.logger).Log("msg", "error converting stream label string to label.Labels, cannot update lagging metric", "error", err)
-					return
+					return true
 				}
 				lblSet := make(prometheus.Labels)
 				for _, lbl := range c.streamLagLabels {
@@ -358,12 +551,15 @@ func (c *client) sendBatch(tenantID string, batch *batch) {
 					c.metrics.streamLag.With(lblSet).Set(time.Since(s.Entries[len(s.Entries)-1].Timestamp).Seconds())
 				}
 			}
-			return
+			return true
 		}
 
 		// Only retry 429s, 500s and connection-level errors.
 		if status > 0 && status != 429 && status/100 != 5 {
-			break
+			level.Error(c.logger).Log("msg", "final error sending batch", "status", status, "error", err)
+			c.metrics.droppedBytes.WithLabelValues(c.cfg.URL.Host).Add(bufBytes)
+			c.metrics.droppedEntries.WithLabelValues(c.cfg.URL.Host).Add(float64(entriesCount))
+			return true
 		}
 
 		level.Warn(c.logger).Log("msg", "error sending batch, will retry", "status", status, "error", err)
@@ -376,11 +572,15 @@ func (c *client) sendBatch(tenantID string, batch *batch) {
 		}
 	}
 
-	if err != nil {
-		level.Error(c.logger).Log("msg", "final error sending batch", "status", status, "error", err)
-		c.metrics.droppedBytes.WithLabelValues(c.cfg.URL.Host).Add(bufBytes)
-		c.metrics.droppedEntries.WithLabelValues(c.cfg.URL.Host).Add(float64(entriesCount))
+	if c.wal != nil {
+		// The entries are kept in the WAL to be sent again later.
+		level.Warn(c.logger).Log("msg", "error sending batch, will retry later", "status", status, "error", err)
+		return false
 	}
+	level.Error(c.logger).Log("msg", "final error sending batch", "status", status, "error", err)
+	c.metrics.droppedBytes.WithLabelValues(c.cfg.URL.Host).Add(bufBytes)
+	c.metrics.droppedEntries.WithLabelValues(c.cfg.URL.Host).Add(float64(entriesCount))
+	return false
 }
 
 func (c *client) send(ctx context.Context, tenantID string, buf []byte) (int, error) {
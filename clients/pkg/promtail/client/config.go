@@ -41,6 +41,8 @@ type Config struct {
 
 	// deprecated use StreamLagLabels from config.Config instead
 	StreamLagLabels flagext.StringSliceCSV `yaml:"stream_lag_labels"`
+
+	WAL WALConfig `yaml:"wal"`
 }
 
 // RegisterFlags with prefix registers flags where every name is prefixed by
@@ -57,6 +59,7 @@ func (c *Config) RegisterFlagsWithPrefix(prefix string, f *flag.FlagSet) {
 	f.Var(&c.ExternalLabels, prefix+"client.external-labels", "list of external labels to add to each log (e.g: --client.external-labels=lb1=v1,lb2=v2) (deprecated).")
 
 	f.StringVar(&c.TenantID, prefix+"client.tenant-id", "", "Tenant ID to use when pushing logs to Loki (deprecated).")
+	c.WAL.RegisterFlagsWithPrefix(prefix, f)
 }
 
 // RegisterFlags registers flags.
@@ -82,6 +85,9 @@ func (c *Config) UnmarshalYAML(unmarshal func(interface{}) error) error {
 			BatchSize: BatchSize,
 			BatchWait: BatchWait,
 			Timeout:   Timeout,
+			WAL: WALConfig{
+				MaxSize: DefaultWALMaxSize,
+			},
 		}
 	}
 
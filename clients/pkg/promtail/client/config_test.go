@@ -28,6 +28,10 @@ backoff_config:
 batchwait: 5s
 batchsize: 204800
 timeout: 5s
+wal:
+  enabled: true
+  dir: /var/lib/promtail/wal
+  max_size: 100MB
 `
 
 func Test_Config(t *testing.T) {
@@ -51,6 +55,9 @@ func Test_Config(t *testing.T) {
 				BatchSize: BatchSize,
 				BatchWait: BatchWait,
 				Timeout:   Timeout,
+				WAL: WALConfig{
+					MaxSize: DefaultWALMaxSize,
+				},
 			},
 		},
 		{
@@ -67,6 +74,11 @@ func Test_Config(t *testing.T) {
 				BatchSize: 100 * 2048,
 				BatchWait: 5 * time.Second,
 				Timeout:   5 * time.Second,
+				WAL: WALConfig{
+					Enabled: true,
+					Dir:     "/var/lib/promtail/wal",
+					MaxSize: 100 * 1024 * 1024,
+				},
 			},
 		},
 	}
@@ -0,0 +1,284 @@
+package client
+
+import (
+	"encoding/binary"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/promql/parser"
+	"github.com/prometheus/prometheus/tsdb/wal"
+
+	"github.com/grafana/loki/clients/pkg/promtail/api"
+
+	"github.com/grafana/loki/pkg/logproto"
+	lokiflag "github.com/grafana/loki/pkg/util/flagext"
+)
+
+const (
+	// walSegmentSize is the size above which the WAL writes to a new segment. It bounds the number of entries
+	// sent again after a restart, since a segment is only removed once all its entries are pushed.
+	walSegmentSize = 8 * 1024 * 1024
+	// DefaultWALMaxSize is the default maximum size of the WAL on disk.
+	DefaultWALMaxSize = 1024 * 1024 * 1024
+
+	walRecordV1 byte = 1
+)
+
+var errWALDirRequired = errors.New("the WAL of the client requires a directory")
+
+// WALConfig configures the write-ahead log of a client, which keeps the entries on disk until they are pushed,
+// so that they are replayed on start after a restart or an outage of Loki.
+type WALConfig struct {
+	Enabled bool              `yaml:"enabled"`
+	Dir     string            `yaml:"dir"`
+	MaxSize lokiflag.ByteSize `yaml:"max_size"`
+}
+
+// RegisterFlagsWithPrefix registers the flags of the WAL, prefixing them with the prefix of the client flags.
+func (c *WALConfig) RegisterFlagsWithPrefix(prefix string, f *flag.FlagSet) {
+	f.BoolVar(&c.Enabled, prefix+"client.wal.enabled", false, "Keep the entries in a write-ahead log on disk until they are pushed, and replay them on start (deprecated).")
+	f.StringVar(&c.Dir, prefix+"client.wal.dir", "", "Directory of the write-ahead log, in which each client writes to its own sub directory (deprecated).")
+	c.MaxSize = DefaultWALMaxSize
+	f.Var(&c.MaxSize, prefix+"client.wal.max-size", "Maximum size of the write-ahead log on disk, above which its oldest segments are removed. 0 means unlimited (deprecated).")
+}
+
+// clientWAL is the write-ahead log of a client. It isn't safe for concurrent use, all its methods being
+// called from the goroutine of the client.
+type clientWAL struct {
+	wal     *wal.WAL
+	logger  log.Logger
+	maxSize int64
+
+	// first is the index of the oldest segment on disk.
+	first int
+	// pending counts the batches not acknowledged yet per oldest segment of their entries.
+	pending map[int]int
+	// written is whether records were appended to the current segment.
+	written bool
+}
+
+func newClientWAL(cfg WALConfig, name string, logger log.Logger) (*clientWAL, error) {
+	if cfg.Dir == "" {
+		return nil, errWALDirRequired
+	}
+	w, err := wal.NewSize(logger, nil, filepath.Join(cfg.Dir, name), walSegmentSize, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the WAL: %w", err)
+	}
+	first, _, err := wal.Segments(w.Dir())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the WAL segments: %w", err)
+	}
+	return &clientWAL{
+		wal:     w,
+		logger:  logger,
+		maxSize: int64(cfg.MaxSize),
+		first:   first,
+		pending: map[int]int{},
+	}, nil
+}
+
+// append appends the entry to the WAL, along with the time it was appended at.
+func (w *clientWAL) append(e api.Entry, now time.Time) error {
+	rec, err := encodeWALRecord(e, now)
+	if err != nil {
+		return err
+	}
+	w.written = true
+	return w.wal.Log(rec)
+}
+
+// segment returns the index of the segment the WAL is writing to.
+func (w *clientWAL) segment() int {
+	seg, _, err := w.wal.LastSegmentAndOffset()
+	if err != nil {
+		level.Warn(w.logger).Log("msg", "failed to get the current WAL segment", "err", err)
+		return w.first
+	}
+	return seg
+}
+
+// replay calls fn for each entry of the WAL, with the index of its segment and the time it was appended at.
+func (w *clientWAL) replay(fn func(e api.Entry, segment int, appendedAt time.Time)) error {
+	segments, err := wal.NewSegmentsReader(w.wal.Dir())
+	if err != nil {
+		return err
+	}
+	defer segments.Close()
+
+	r := wal.NewReader(segments)
+	for r.Next() {
+		e, appendedAt, err := decodeWALRecord(r.Record())
+		if err != nil {
+			level.Warn(w.logger).Log("msg", "skipping invalid WAL record", "segment", r.Segment(), "err", err)
+			continue
+		}
+		fn(e, r.Segment(), appendedAt)
+	}
+	return r.Err()
+}
+
+// track keeps the segments starting at the given one, the oldest segment of the entries of a batch,
+// until the batch is acknowledged.
+func (w *clientWAL) track(segment int) {
+	w.pending[segment]++
+}
+
+// ack acknowledges a batch whose entries were pushed, or dropped for good, so that the segments are removed
+// by the next truncate once no older batch is pending.
+func (w *clientWAL) ack(segment int) {
+	if w.pending[segment] <= 1 {
+		delete(w.pending, segment)
+		return
+	}
+	w.pending[segment]--
+}
+
+// truncate removes the segments older than the oldest segment with entries not acknowledged yet. When all the
+// entries were acknowledged, the WAL writes to a new segment so that the current one is removed too.
+func (w *clientWAL) truncate() error {
+	oldest := -1
+	for segment := range w.pending {
+		if oldest < 0 || segment < oldest {
+			oldest = segment
+		}
+	}
+	if oldest < 0 {
+		if w.written {
+			if err := w.wal.NextSegment(); err != nil {
+				return err
+			}
+			w.written = false
+		}
+		oldest = w.segment()
+	}
+	if oldest <= w.first {
+		return nil
+	}
+	if err := w.wal.Truncate(oldest); err != nil {
+		return err
+	}
+	w.first = oldest
+	return nil
+}
+
+// enforceMaxSize removes the oldest segments until the WAL fits in its maximum size, without ever removing
+// the current segment. It returns the size of the WAL, and the size of the removed segments.
+func (w *clientWAL) enforceMaxSize() (size, removed int64, err error) {
+	segments, err := listWALSegments(w.wal.Dir())
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, s := range segments {
+		size += s.size
+	}
+	if w.maxSize <= 0 || size <= w.maxSize {
+		return size, 0, nil
+	}
+
+	oldest := w.first
+	for _, s := range segments[:len(segments)-1] {
+		if size-removed <= w.maxSize {
+			break
+		}
+		removed += s.size
+		oldest = s.index + 1
+	}
+	if oldest <= w.first {
+		return size, 0, nil
+	}
+	if err := w.wal.Truncate(oldest); err != nil {
+		return size, 0, err
+	}
+	level.Warn(w.logger).Log("msg", "removed the oldest WAL segments above the maximum size of the WAL", "bytes", removed)
+	w.first = oldest
+	for segment := range w.pending {
+		if segment < oldest {
+			delete(w.pending, segment)
+		}
+	}
+	return size - removed, removed, nil
+}
+
+func (w *clientWAL) close() {
+	if err := w.wal.Close(); err != nil {
+		level.Error(w.logger).Log("msg", "failed to close the WAL", "err", err)
+	}
+}
+
+type walSegment struct {
+	index int
+	size  int64
+}
+
+// listWALSegments returns the segments of the WAL sorted by index.
+func listWALSegments(dir string) ([]walSegment, error) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	segments := make([]walSegment, 0, len(files))
+	for _, f := range files {
+		index, err := strconv.Atoi(f.Name())
+		if err != nil {
+			continue
+		}
+		info, err := f.Info()
+		if err != nil {
+			return nil, err
+		}
+		segments = append(segments, walSegment{index: index, size: info.Size()})
+	}
+	sort.Slice(segments, func(i, j int) bool { return segments[i].index < segments[j].index })
+	return segments, nil
+}
+
+// encodeWALRecord encodes the entry as the version of the record, the time it was appended at in
+// nanoseconds, then the entry as a stream with a single entry.
+func encodeWALRecord(e api.Entry, appendedAt time.Time) ([]byte, error) {
+	stream := logproto.Stream{
+		Labels:  labelsMapToString(e.Labels),
+		Entries: []logproto.Entry{e.Entry},
+	}
+	rec := make([]byte, 9, 9+stream.Size())
+	rec[0] = walRecordV1
+	binary.BigEndian.PutUint64(rec[1:], uint64(appendedAt.UnixNano()))
+	buf, err := stream.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return append(rec, buf...), nil
+}
+
+func decodeWALRecord(rec []byte) (api.Entry, time.Time, error) {
+	if len(rec) < 9 || rec[0] != walRecordV1 {
+		return api.Entry{}, time.Time{}, errors.New("unknown WAL record version")
+	}
+	appendedAt := time.Unix(0, int64(binary.BigEndian.Uint64(rec[1:9])))
+
+	var stream logproto.Stream
+	if err := stream.Unmarshal(rec[9:]); err != nil {
+		return api.Entry{}, time.Time{}, err
+	}
+	if len(stream.Entries) != 1 {
+		return api.Entry{}, time.Time{}, fmt.Errorf("WAL record with %d entries", len(stream.Entries))
+	}
+	ls, err := parser.ParseMetric(stream.Labels)
+	if err != nil {
+		return api.Entry{}, time.Time{}, err
+	}
+	labels := make(model.LabelSet, len(ls))
+	for _, l := range ls {
+		labels[model.LabelName(l.Name)] = model.LabelValue(l.Value)
+	}
+	return api.Entry{Labels: labels, Entry: stream.Entries[0]}, appendedAt, nil
+}
@@ -0,0 +1,195 @@
+package client
+
+import (
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/grafana/dskit/backoff"
+	"github.com/grafana/dskit/flagext"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/loki/clients/pkg/promtail/api"
+
+	"github.com/grafana/loki/pkg/logproto"
+)
+
+func TestClient_WALReplay(t *testing.T) {
+	dir := t.TempDir()
+	entries := []api.Entry{
+		{Labels: model.LabelSet{"app": "foo"}, Entry: logproto.Entry{Timestamp: time.Unix(1, 0).UTC(), Line: "line1"}},
+		{Labels: model.LabelSet{"app": "foo", ReservedLabelTenantID: "tenant-1"}, Entry: logproto.Entry{Timestamp: time.Unix(2, 0).UTC(), Line: "line2"}},
+	}
+
+	runClient := func(status int, entries []api.Entry) []receivedReq {
+		received := make(chan receivedReq, 10)
+		server := httptest.NewServer(createServerHandler(received, status))
+		defer server.Close()
+
+		serverURL := flagext.URLValue{}
+		require.NoError(t, serverURL.Set(server.URL))
+		cfg := Config{
+			Name:          "wal-test",
+			URL:           serverURL,
+			BatchWait:     10 * time.Millisecond,
+			BatchSize:     1024,
+			BackoffConfig: backoff.Config{MinBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond, MaxRetries: 1},
+			Timeout:       time.Second,
+			WAL:           WALConfig{Enabled: true, Dir: dir, MaxSize: DefaultWALMaxSize},
+		}
+		c, err := New(NewMetrics(prometheus.NewRegistry(), nil), cfg, nil, log.NewNopLogger())
+		require.NoError(t, err)
+		for _, e := range entries {
+			c.Chan() <- e
+		}
+		c.Stop()
+		close(received)
+
+		var reqs []receivedReq
+		for req := range received {
+			reqs = append(reqs, req)
+		}
+		return reqs
+	}
+
+	// The entries failing to be pushed are kept in the WAL.
+	reqs := runClient(http.StatusInternalServerError, entries)
+	require.NotEmpty(t, reqs)
+
+	// They are replayed on the next start, then removed from the WAL once pushed.
+	reqs = runClient(http.StatusNoContent, nil)
+	require.ElementsMatch(t, []receivedReq{
+		{tenantID: "", pushReq: logproto.PushRequest{Streams: []logproto.Stream{{Labels: `{app="foo"}`, Entries: []logproto.Entry{entries[0].Entry}}}}},
+		{tenantID: "tenant-1", pushReq: logproto.PushRequest{Streams: []logproto.Stream{{Labels: `{app="foo"}`, Entries: []logproto.Entry{entries[1].Entry}}}}},
+	}, reqs)
+
+	require.Empty(t, runClient(http.StatusNoContent, nil))
+}
+
+func TestClient_WALFailedBatches(t *testing.T) {
+	entry := api.Entry{Labels: model.LabelSet{"app": "foo"}, Entry: logproto.Entry{Timestamp: time.Unix(1, 0).UTC(), Line: "line1"}}
+	pushed := logproto.PushRequest{Streams: []logproto.Stream{{Labels: `{app="foo"}`, Entries: []logproto.Entry{entry.Entry}}}}
+
+	for _, tc := range []struct {
+		name     string
+		statuses []int
+		expected int
+	}{
+		{
+			// The batches rejected by Loki are dropped instead of being kept in the WAL.
+			name:     "rejected batch",
+			statuses: []int{http.StatusBadRequest},
+			expected: 1,
+		},
+		{
+			// The batches failing after all retries are sent again while running.
+			name:     "failed batch",
+			statuses: []int{http.StatusInternalServerError, http.StatusInternalServerError, http.StatusNoContent},
+			expected: 3,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			runClient := func(statuses []int, entries []api.Entry, expected int) []receivedReq {
+				received := make(chan receivedReq, 10)
+				var reqs int32
+				server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+					status := statuses[len(statuses)-1]
+					if n := int(atomic.AddInt32(&reqs, 1)); n <= len(statuses) {
+						status = statuses[n-1]
+					}
+					createServerHandler(received, status).ServeHTTP(rw, req)
+				}))
+				defer server.Close()
+
+				serverURL := flagext.URLValue{}
+				require.NoError(t, serverURL.Set(server.URL))
+				cfg := Config{
+					Name:          "wal-test",
+					URL:           serverURL,
+					BatchWait:     10 * time.Millisecond,
+					BatchSize:     1024,
+					BackoffConfig: backoff.Config{MinBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond, MaxRetries: 1},
+					Timeout:       time.Second,
+					WAL:           WALConfig{Enabled: true, Dir: dir, MaxSize: DefaultWALMaxSize},
+				}
+				c, err := New(NewMetrics(prometheus.NewRegistry(), nil), cfg, nil, log.NewNopLogger())
+				require.NoError(t, err)
+				for _, e := range entries {
+					c.Chan() <- e
+				}
+				require.Eventually(t, func() bool {
+					return atomic.LoadInt32(&reqs) >= int32(expected)
+				}, 5*time.Second, time.Millisecond)
+				c.Stop()
+				close(received)
+
+				var res []receivedReq
+				for req := range received {
+					res = append(res, req)
+				}
+				return res
+			}
+
+			reqs := runClient(tc.statuses, []api.Entry{entry}, tc.expected)
+			require.Len(t, reqs, tc.expected)
+			for _, req := range reqs {
+				require.Equal(t, pushed, req.pushReq)
+			}
+
+			// Nothing is left in the WAL to be replayed on the next start.
+			require.Empty(t, runClient([]int{http.StatusNoContent}, nil, 0))
+		})
+	}
+}
+
+func TestClientWAL_EnforceMaxSize(t *testing.T) {
+	w, err := newClientWAL(WALConfig{Enabled: true, Dir: t.TempDir(), MaxSize: 64 * 1024}, "client", log.NewNopLogger())
+	require.NoError(t, err)
+	defer w.close()
+
+	// Random lines, so that the compressed records still take about 32KB in each segment.
+	line := make([]byte, 1024)
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 32; j++ {
+			_, _ = rand.Read(line)
+			e := api.Entry{Labels: model.LabelSet{"app": "foo"}, Entry: logproto.Entry{Timestamp: time.Now(), Line: string(line)}}
+			require.NoError(t, w.append(e, time.Now()))
+		}
+		require.NoError(t, w.wal.NextSegment())
+	}
+
+	size, removed, err := w.enforceMaxSize()
+	require.NoError(t, err)
+	require.LessOrEqual(t, size, int64(64*1024))
+	require.Greater(t, removed, int64(0))
+
+	segments, err := listWALSegments(w.wal.Dir())
+	require.NoError(t, err)
+	require.Equal(t, w.first, segments[0].index)
+	require.Equal(t, w.segment(), segments[len(segments)-1].index)
+}
+
+func TestWALRecord(t *testing.T) {
+	e := api.Entry{
+		Labels: model.LabelSet{"app": "foo", ReservedLabelTenantID: "tenant-1"},
+		Entry:  logproto.Entry{Timestamp: time.Unix(0, 123).UTC(), Line: "line"},
+	}
+	appendedAt := time.Unix(10, 0)
+
+	rec, err := encodeWALRecord(e, appendedAt)
+	require.NoError(t, err)
+	decoded, decodedAppendedAt, err := decodeWALRecord(rec)
+	require.NoError(t, err)
+	require.Equal(t, e, decoded)
+	require.True(t, appendedAt.Equal(decodedAppendedAt))
+
+	_, _, err = decodeWALRecord([]byte{0})
+	require.Error(t, err)
+}
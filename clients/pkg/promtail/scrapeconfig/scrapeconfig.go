@@ -33,17 +33,20 @@ import (
 
 // Config describes a job to scrape.
 type Config struct {
-	JobName          string                     `yaml:"job_name,omitempty"`
-	PipelineStages   stages.PipelineStages      `yaml:"pipeline_stages,omitempty"`
-	JournalConfig    *JournalTargetConfig       `yaml:"journal,omitempty"`
-	SyslogConfig     *SyslogTargetConfig        `yaml:"syslog,omitempty"`
-	GcplogConfig     *GcplogTargetConfig        `yaml:"gcplog,omitempty"`
-	PushConfig       *PushTargetConfig          `yaml:"loki_push_api,omitempty"`
-	WindowsConfig    *WindowsEventsTargetConfig `yaml:"windows_events,omitempty"`
-	KafkaConfig      *KafkaTargetConfig         `yaml:"kafka,omitempty"`
-	GelfConfig       *GelfTargetConfig          `yaml:"gelf,omitempty"`
-	CloudflareConfig *CloudflareConfig          `yaml:"cloudflare,omitempty"`
-	RelabelConfigs   []*relabel.Config          `yaml:"relabel_configs,omitempty"`
+	JobName           string                     `yaml:"job_name,omitempty"`
+	PipelineStages    stages.PipelineStages      `yaml:"pipeline_stages,omitempty"`
+	JournalConfig     *JournalTargetConfig       `yaml:"journal,omitempty"`
+	SyslogConfig      *SyslogTargetConfig        `yaml:"syslog,omitempty"`
+	GcplogConfig      *GcplogTargetConfig        `yaml:"gcplog,omitempty"`
+	PushConfig        *PushTargetConfig          `yaml:"loki_push_api,omitempty"`
+	WindowsConfig     *WindowsEventsTargetConfig `yaml:"windows_events,omitempty"`
+	KafkaConfig       *KafkaTargetConfig         `yaml:"kafka,omitempty"`
+	GelfConfig        *GelfTargetConfig          `yaml:"gelf,omitempty"`
+	CloudflareConfig  *CloudflareConfig          `yaml:"cloudflare,omitempty"`
+	HerokuDrainConfig *HerokuDrainTargetConfig   `yaml:"heroku_drain,omitempty"`
+	ForwardConfig     *ForwardTargetConfig       `yaml:"forward,omitempty"`
+	OTLPConfig        *OTLPTargetConfig          `yaml:"otlp,omitempty"`
+	RelabelConfigs    []*relabel.Config          `yaml:"relabel_configs,omitempty"`
 	// List of Docker service discovery configurations.
 	DockerSDConfigs        []*moby.DockerSDConfig `yaml:"docker_sd_configs,omitempty"`
 	ServiceDiscoveryConfig ServiceDiscoveryConfig `yaml:",inline"`
@@ -316,6 +319,36 @@ type GelfTargetConfig struct {
 	UseIncomingTimestamp bool `yaml:"use_incoming_timestamp"`
 }
 
+// ForwardTargetConfig describes a scrape config that listens for Fluentd Forward protocol messages,
+// as sent by Fluentd and Fluent Bit.
+type ForwardTargetConfig struct {
+	// ListenAddress is the address to listen on TCP for Forward messages. (Default to `:24224`)
+	ListenAddress string `yaml:"listen_address"`
+
+	// IdleTimeout is the idle timeout for tcp connections.
+	IdleTimeout time.Duration `yaml:"idle_timeout"`
+
+	// Labels optionally holds labels to associate with each record read from Forward messages.
+	Labels model.LabelSet `yaml:"labels"`
+
+	// UseIncomingTimestamp sets the timestamp to the incoming Forward messages
+	// timestamp if it's set.
+	UseIncomingTimestamp bool `yaml:"use_incoming_timestamp"`
+}
+
+// OTLPTargetConfig describes a scrape config to receive OpenTelemetry logs over OTLP/gRPC and OTLP/HTTP.
+type OTLPTargetConfig struct {
+	// Server is the weaveworks server config for listening connections
+	Server server.Config `yaml:"server"`
+
+	// Labels optionally holds labels to associate with each log record received.
+	Labels model.LabelSet `yaml:"labels"`
+
+	// UseIncomingTimestamp sets the timestamp to the incoming log records
+	// timestamp if it's set.
+	UseIncomingTimestamp bool `yaml:"use_incoming_timestamp"`
+}
+
 type CloudflareConfig struct {
 	// APIToken is the API key for the Cloudflare account.
 	APIToken string `yaml:"api_token"`
@@ -366,6 +399,19 @@ type PushTargetConfig struct {
 	KeepTimestamp bool `yaml:"use_incoming_timestamp"`
 }
 
+// HerokuDrainTargetConfig describes a scrape config to receive the logs of Heroku apps from a Logplex HTTPS drain.
+type HerokuDrainTargetConfig struct {
+	// Server is the weaveworks server config for listening connections
+	Server server.Config `yaml:"server"`
+
+	// Labels optionally holds labels to associate with each record received from the drain.
+	Labels model.LabelSet `yaml:"labels"`
+
+	// UseIncomingTimestamp sets the timestamp to the incoming syslog messages
+	// timestamp if it's set.
+	UseIncomingTimestamp bool `yaml:"use_incoming_timestamp"`
+}
+
 // DefaultScrapeConfig is the default Config.
 var DefaultScrapeConfig = Config{
 	PipelineStages: stages.PipelineStages{},
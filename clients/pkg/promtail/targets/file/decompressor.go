@@ -0,0 +1,386 @@
+package file
+
+import (
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/klauspost/compress/zstd"
+	"github.com/prometheus/common/model"
+	"go.uber.org/atomic"
+
+	"github.com/grafana/loki/clients/pkg/promtail/api"
+	"github.com/grafana/loki/clients/pkg/promtail/positions"
+
+	"github.com/grafana/loki/pkg/logproto"
+)
+
+type compressionFormat string
+
+const (
+	compressionNone  compressionFormat = ""
+	compressionGzip  compressionFormat = "gzip"
+	compressionBzip2 compressionFormat = "bzip2"
+	compressionZstd  compressionFormat = "zstd"
+
+	// completedSuffix suffixes the position of a compressed file read to the end, so that it isn't read again
+	// after a restart.
+	completedSuffix = ":completed"
+
+	// maxDecompressAttempts is the number of times in a row a compressed file fails to be decompressed
+	// without being modified before it isn't decompressed again until it is, like a corrupt file.
+	maxDecompressAttempts = 3
+)
+
+var (
+	compressionExtensions = map[string]compressionFormat{
+		".gz":   compressionGzip,
+		".bz2":  compressionBzip2,
+		".zst":  compressionZstd,
+		".zstd": compressionZstd,
+	}
+
+	compressionMagics = []struct {
+		magic  []byte
+		format compressionFormat
+	}{
+		{magic: []byte{0x1f, 0x8b}, format: compressionGzip},
+		{magic: []byte("BZh"), format: compressionBzip2},
+		{magic: []byte{0x28, 0xb5, 0x2f, 0xfd}, format: compressionZstd},
+	}
+)
+
+// detectCompression returns the compression format of the file from its extension, or else from its first
+// bytes if fromContent is set. The first bytes are only looked at on demand, since a plain file can start
+// like a compressed one, like a line starting with BZh.
+func detectCompression(path string, fromContent bool) (compressionFormat, error) {
+	if format, ok := compressionExtensions[filepath.Ext(path)]; ok {
+		return format, nil
+	}
+	if !fromContent {
+		return compressionNone, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return compressionNone, err
+	}
+	defer f.Close()
+
+	header := make([]byte, 4)
+	n, err := io.ReadFull(f, header)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return compressionNone, err
+	}
+	for _, m := range compressionMagics {
+		if bytes.HasPrefix(header[:n], m.magic) {
+			return m.format, nil
+		}
+	}
+	return compressionNone, nil
+}
+
+// rotatedCompressedSiblings returns the compressed files, by extension, named after one of the plain files
+// followed by a rotation suffix, like app.log.1.gz or app.log-20220101.gz for app.log. Their lines were
+// already read from the plain file before it was rotated.
+func rotatedCompressedSiblings(paths []string) map[string]struct{} {
+	var live []string
+	for _, p := range paths {
+		if _, ok := compressionExtensions[filepath.Ext(p)]; !ok {
+			live = append(live, p)
+		}
+	}
+
+	siblings := map[string]struct{}{}
+	for _, p := range paths {
+		ext := filepath.Ext(p)
+		if _, ok := compressionExtensions[ext]; !ok {
+			continue
+		}
+		rotated := strings.TrimSuffix(p, ext)
+		for _, l := range live {
+			if len(rotated) > len(l) && strings.HasPrefix(rotated, l) && strings.ContainsRune(".-_", rune(rotated[len(l)])) {
+				siblings[p] = struct{}{}
+				break
+			}
+		}
+	}
+	return siblings
+}
+
+// readPosition returns the position of the file, and whether it's a compressed file read to the end.
+func readPosition(ps positions.Positions, path string) (int64, bool, error) {
+	s := ps.GetString(path)
+	if s == "" {
+		return 0, false, nil
+	}
+	completed := strings.HasSuffix(s, completedSuffix)
+	pos, err := strconv.ParseInt(strings.TrimSuffix(s, completedSuffix), 10, 64)
+	return pos, completed, err
+}
+
+// decompressor reads a compressed file once from start to end. Compressed files are rotated or archived,
+// so unlike the tailer it doesn't follow the file. Its position is the number of decompressed bytes read,
+// since the compressed file can't be seeked into.
+type decompressor struct {
+	metrics   *Metrics
+	logger    log.Logger
+	handler   api.EntryHandler
+	positions positions.Positions
+
+	path    string
+	format  compressionFormat
+	modTime time.Time // modification time of the file when the decompressor started.
+
+	posAndSizeMtx sync.Mutex
+	stopOnce      sync.Once
+	cleanupOnce   sync.Once
+
+	position  *atomic.Int64
+	read      *atomic.Int64
+	completed *atomic.Bool
+
+	running *atomic.Bool
+	quit    chan struct{}
+	posdone chan struct{}
+	done    chan struct{}
+}
+
+func newDecompressor(metrics *Metrics, logger log.Logger, handler api.EntryHandler, positions positions.Positions, path string, format compressionFormat) (*decompressor, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	pos, completed, err := readPosition(positions, path)
+	if err != nil {
+		return nil, err
+	}
+	read := int64(0)
+	if completed {
+		read = fi.Size()
+	}
+
+	logger = log.With(logger, "component", "decompressor")
+	decompressor := &decompressor{
+		metrics:   metrics,
+		logger:    logger,
+		handler:   api.AddLabelsMiddleware(model.LabelSet{FilenameLabel: model.LabelValue(path)}).Wrap(handler),
+		positions: positions,
+		path:      path,
+		format:    format,
+		modTime:   fi.ModTime(),
+		position:  atomic.NewInt64(pos),
+		read:      atomic.NewInt64(read),
+		completed: atomic.NewBool(completed),
+		running:   atomic.NewBool(true),
+		quit:      make(chan struct{}),
+		posdone:   make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+
+	go decompressor.readLines()
+	go decompressor.updatePosition()
+	metrics.filesActive.Add(1.)
+	return decompressor, nil
+}
+
+// updatePosition is run in a goroutine and saves the position of the decompressor at a regular interval,
+// until the file is read to the end.
+func (d *decompressor) updatePosition() {
+	positionWait := time.NewTicker(d.positions.SyncPeriod())
+	defer func() {
+		positionWait.Stop()
+		close(d.posdone)
+	}()
+
+	for {
+		select {
+		case <-positionWait.C:
+			if err := d.markPositionAndSize(); err != nil {
+				level.Error(d.logger).Log("msg", "position timer: error getting position and/or size", "path", d.path, "error", err)
+			}
+		case <-d.done:
+			return
+		}
+	}
+}
+
+// readLines runs in a goroutine and sends the lines of the decompressed file, starting from the saved
+// position. If it fails, the decompressor stops running and the filetarget sync method starts a new one,
+// which resumes from the last line sent.
+func (d *decompressor) readLines() {
+	defer close(d.done)
+
+	if d.completed.Load() {
+		level.Debug(d.logger).Log("msg", "skipping compressed file already read to the end", "path", d.path)
+		return
+	}
+	level.Info(d.logger).Log("msg", "decompressing file", "path", d.path, "format", d.format, "position", d.position.Load())
+
+	f, err := os.Open(d.path)
+	if err != nil {
+		d.fail(err)
+		return
+	}
+	defer f.Close()
+
+	r, err := newDecompressingReader(d.format, &countingReader{r: f, n: d.read})
+	if err != nil {
+		d.fail(err)
+		return
+	}
+	defer r.Close()
+
+	if _, err := io.CopyN(ioutil.Discard, r, d.position.Load()); err != nil {
+		if err == io.EOF {
+			// The file is shorter than the position, it was replaced since: read it again from the start.
+			d.position.Store(0)
+		}
+		d.fail(fmt.Errorf("failed to skip to the position: %w", err))
+		return
+	}
+
+	br := bufio.NewReader(r)
+	entries := d.handler.Chan()
+	for {
+		line, err := br.ReadString('\n')
+		if err != nil && err != io.EOF {
+			// A truncated file may be still being compressed, its partial line is read again on the next attempt.
+			d.fail(err)
+			return
+		}
+		if line != "" {
+			d.metrics.readLines.WithLabelValues(d.path).Inc()
+			select {
+			case entries <- api.Entry{
+				Labels: model.LabelSet{},
+				Entry: logproto.Entry{
+					Timestamp: time.Now(),
+					Line:      strings.TrimRight(line, "\n"),
+				},
+			}:
+			case <-d.quit:
+				return
+			}
+			d.position.Add(int64(len(line)))
+		}
+		if err == io.EOF {
+			break
+		}
+	}
+
+	d.completed.Store(true)
+	if err := d.markPositionAndSize(); err != nil {
+		level.Error(d.logger).Log("msg", "error marking the position of the file read to the end", "path", d.path, "error", err)
+	}
+	level.Info(d.logger).Log("msg", "read compressed file to the end", "path", d.path, "position", d.position.Load())
+}
+
+func (d *decompressor) fail(err error) {
+	level.Error(d.logger).Log("msg", "error decompressing file, stopping decompressor", "path", d.path, "error", err)
+	if err := d.markPositionAndSize(); err != nil {
+		level.Error(d.logger).Log("msg", "error marking file position", "path", d.path, "error", err)
+	}
+	d.running.Store(false)
+	// The filetarget doesn't stop the decompressors which stopped running before starting a new one.
+	d.cleanup()
+}
+
+func (d *decompressor) markPositionAndSize() error {
+	// Lock this update as there are 2 timers calling this routine, the sync in filetarget and the positions sync in this file.
+	d.posAndSizeMtx.Lock()
+	defer d.posAndSizeMtx.Unlock()
+
+	fi, err := os.Stat(d.path)
+	if err != nil {
+		// If the file no longer exists, no need to save position information
+		if os.IsNotExist(err) {
+			level.Info(d.logger).Log("msg", "skipping update of position for a file which does not currently exist", "path", d.path)
+			return nil
+		}
+		return err
+	}
+	d.metrics.totalBytes.WithLabelValues(d.path).Set(float64(fi.Size()))
+	d.metrics.readBytes.WithLabelValues(d.path).Set(float64(d.read.Load()))
+
+	pos := strconv.FormatInt(d.position.Load(), 10)
+	if d.completed.Load() {
+		pos += completedSuffix
+	}
+	d.positions.PutString(d.path, pos)
+	return nil
+}
+
+func (d *decompressor) stop() {
+	d.stopOnce.Do(func() {
+		close(d.quit)
+		<-d.done
+		<-d.posdone
+
+		// Save the current position before shutting down the decompressor, unless it failed and saved it already.
+		if d.isRunning() {
+			if err := d.markPositionAndSize(); err != nil {
+				level.Error(d.logger).Log("msg", "error marking file position when stopping decompressor", "path", d.path, "error", err)
+			}
+		}
+		d.cleanup()
+		level.Info(d.logger).Log("msg", "stopped decompressing file", "path", d.path)
+	})
+}
+
+func (d *decompressor) isRunning() bool {
+	return d.running.Load()
+}
+
+// cleanup removes all metrics exported by this decompressor, and stops its handler.
+func (d *decompressor) cleanup() {
+	d.cleanupOnce.Do(func() {
+		d.metrics.filesActive.Add(-1.)
+		d.metrics.readLines.DeleteLabelValues(d.path)
+		d.metrics.readBytes.DeleteLabelValues(d.path)
+		d.metrics.totalBytes.DeleteLabelValues(d.path)
+		d.handler.Stop()
+	})
+}
+
+// newDecompressingReader returns a reader decompressing r with the given format.
+func newDecompressingReader(format compressionFormat, r io.Reader) (io.ReadCloser, error) {
+	switch format {
+	case compressionGzip:
+		return gzip.NewReader(r)
+	case compressionBzip2:
+		return ioutil.NopCloser(bzip2.NewReader(r)), nil
+	case compressionZstd:
+		zr, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+		return zr.IOReadCloser(), nil
+	default:
+		return nil, fmt.Errorf("unsupported compression format %q", format)
+	}
+}
+
+// countingReader counts the bytes read from r.
+type countingReader struct {
+	r io.Reader
+	n *atomic.Int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n.Add(int64(n))
+	return n, err
+}
@@ -0,0 +1,220 @@
+package file
+
+import (
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/loki/clients/pkg/promtail/client/fake"
+)
+
+const testDecompressedContent = "line1\nline2\nline3"
+
+// testBzip2Content is testDecompressedContent compressed with bzip2, which the standard library can't write.
+var testBzip2Content = []byte{
+	0x42, 0x5a, 0x68, 0x39, 0x31, 0x41, 0x59, 0x26, 0x53, 0x59, 0x99, 0xd0, 0xeb, 0x53, 0x00, 0x00, 0x05, 0xc9,
+	0x00, 0x00, 0x10, 0x38, 0x00, 0x02, 0x25, 0x20, 0x00, 0x22, 0x3d, 0x41, 0x88, 0x43, 0x02, 0x2f, 0x38, 0x84,
+	0x1b, 0xa8, 0x78, 0xbb, 0x92, 0x29, 0xc2, 0x84, 0x84, 0xce, 0x87, 0x5a, 0x98,
+}
+
+func compressTestContent(t *testing.T, format compressionFormat) []byte {
+	var buf bytes.Buffer
+	switch format {
+	case compressionGzip:
+		w := gzip.NewWriter(&buf)
+		_, err := w.Write([]byte(testDecompressedContent))
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+	case compressionBzip2:
+		buf.Write(testBzip2Content)
+	case compressionZstd:
+		w, err := zstd.NewWriter(&buf)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(testDecompressedContent))
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+	default:
+		buf.WriteString(testDecompressedContent)
+	}
+	return buf.Bytes()
+}
+
+func TestDetectCompression(t *testing.T) {
+	dir := t.TempDir()
+	for _, tc := range []struct {
+		name        string
+		content     compressionFormat
+		fromContent bool
+		expected    compressionFormat
+	}{
+		{name: "app.log", content: compressionNone, fromContent: true, expected: compressionNone},
+		{name: "app.log.gz", content: compressionGzip, expected: compressionGzip},
+		{name: "app.log.1", content: compressionGzip, fromContent: true, expected: compressionGzip},
+		{name: "app.log.bz2", content: compressionBzip2, expected: compressionBzip2},
+		{name: "app.log.2", content: compressionBzip2, fromContent: true, expected: compressionBzip2},
+		{name: "app.log.zst", content: compressionZstd, expected: compressionZstd},
+		{name: "app.log.3", content: compressionZstd, fromContent: true, expected: compressionZstd},
+		// the first bytes are only looked at on demand.
+		{name: "app.log.4", content: compressionGzip, expected: compressionNone},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(dir, tc.name)
+			require.NoError(t, os.WriteFile(path, compressTestContent(t, tc.content), 0600))
+			format, err := detectCompression(path, tc.fromContent)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, format)
+		})
+	}
+
+	empty := filepath.Join(dir, "empty.log")
+	require.NoError(t, os.WriteFile(empty, nil, 0600))
+	format, err := detectCompression(empty, true)
+	require.NoError(t, err)
+	require.Equal(t, compressionNone, format)
+
+	// A plain file starting like a bzip2 file is only mistaken for one when looking at its first bytes.
+	plain := filepath.Join(dir, "bzh.log")
+	require.NoError(t, os.WriteFile(plain, []byte("BZh started\n"), 0600))
+	format, err = detectCompression(plain, false)
+	require.NoError(t, err)
+	require.Equal(t, compressionNone, format)
+}
+
+func TestSkipRotatedCompressed(t *testing.T) {
+	matches := []string{
+		"/var/log/app.log",
+		"/var/log/app.log.1.gz",
+		"/var/log/app.log-20220101.bz2",
+		"/var/log/app.log_2.zst",
+		"/var/log/app.logger.gz",
+		"/var/log/archive.log.gz",
+		"/var/log/other/app.log.1.gz",
+	}
+	require.Equal(t, []string{
+		"/var/log/app.log",
+		"/var/log/app.logger.gz",
+		"/var/log/archive.log.gz",
+		"/var/log/other/app.log.1.gz",
+	}, skipRotatedCompressed(matches))
+}
+
+func TestDecompressor(t *testing.T) {
+	for _, format := range []compressionFormat{compressionGzip, compressionBzip2, compressionZstd} {
+		t.Run(string(format), func(t *testing.T) {
+			logger := log.NewNopLogger()
+			dir := t.TempDir()
+			path := filepath.Join(dir, "app.log.1")
+			require.NoError(t, os.WriteFile(path, compressTestContent(t, format), 0600))
+			ps, err := newTestPositions(logger, filepath.Join(dir, "positions.yml"))
+			require.NoError(t, err)
+			defer ps.Stop()
+
+			// Resume after the first line.
+			ps.Put(path, int64(len("line1\n")))
+
+			run := func() []string {
+				client := fake.New(func() {})
+				defer client.Stop()
+				d, err := newDecompressor(NewMetrics(nil), logger, client, ps, path, format)
+				require.NoError(t, err)
+				require.Eventually(t, func() bool {
+					return d.completed.Load()
+				}, 5*time.Second, time.Millisecond)
+				d.stop()
+				require.True(t, d.isRunning())
+
+				var lines []string
+				for _, e := range client.Received() {
+					lines = append(lines, e.Line)
+				}
+				return lines
+			}
+
+			require.Equal(t, []string{"line2", "line3"}, run())
+			require.Equal(t, "17"+completedSuffix, ps.GetString(path))
+			pos, completed, err := readPosition(ps, path)
+			require.NoError(t, err)
+			require.Equal(t, int64(len(testDecompressedContent)), pos)
+			require.True(t, completed)
+
+			// The file read to the end isn't read again.
+			require.Empty(t, run())
+		})
+	}
+}
+
+func TestFileTargetGivesUpCorruptCompressedFile(t *testing.T) {
+	logger := log.NewNopLogger()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log.gz")
+	require.NoError(t, os.WriteFile(path, []byte("not gzip"), 0600))
+	ps, err := newTestPositions(logger, filepath.Join(dir, "positions.yml"))
+	require.NoError(t, err)
+	defer ps.Stop()
+
+	client := fake.New(func() {})
+	defer client.Stop()
+	// The target isn't run, its sync is called by the test.
+	target := &FileTarget{
+		metrics:            NewMetrics(nil),
+		logger:             logger,
+		handler:            client,
+		positions:          ps,
+		path:               path,
+		targetEventHandler: make(chan fileTargetEvent, 10),
+		tails:              map[string]reader{},
+		decompressFailures: map[string]decompressFailure{},
+		targetConfig:       &Config{},
+	}
+
+	// syncFailed syncs the target, and waits for the decompressor it started, if any, to fail.
+	syncFailed := func() bool {
+		require.NoError(t, target.sync())
+		r, ok := target.tails[path]
+		if ok {
+			require.Eventually(t, func() bool { return !r.isRunning() }, 5*time.Second, time.Millisecond)
+		}
+		return ok
+	}
+	for i := 0; i < maxDecompressAttempts; i++ {
+		require.True(t, syncFailed(), "attempt %d", i)
+	}
+	require.False(t, syncFailed())
+	require.False(t, syncFailed())
+
+	// The file is decompressed again once modified.
+	modTime := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+	require.True(t, syncFailed())
+}
+
+func TestDecompressorTruncatedFile(t *testing.T) {
+	logger := log.NewNopLogger()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log.gz")
+	content := compressTestContent(t, compressionGzip)
+	require.NoError(t, os.WriteFile(path, content[:len(content)-8], 0600))
+	ps, err := newTestPositions(logger, filepath.Join(dir, "positions.yml"))
+	require.NoError(t, err)
+	defer ps.Stop()
+
+	client := fake.New(func() {})
+	defer client.Stop()
+	d, err := newDecompressor(NewMetrics(nil), logger, client, ps, path, compressionGzip)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return !d.isRunning()
+	}, 5*time.Second, time.Millisecond)
+	d.stop()
+
+	_, completed, err := readPosition(ps, path)
+	require.NoError(t, err)
+	require.False(t, completed)
+}
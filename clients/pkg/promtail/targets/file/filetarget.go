@@ -28,6 +28,14 @@ const (
 type Config struct {
 	SyncPeriod time.Duration `yaml:"sync_period"`
 	Stdin      bool          `yaml:"stdin"`
+
+	// SkipRotatedCompressed skips the compressed files named after a plain file also matched by the target,
+	// like app.log.1.gz for app.log, since their lines were read before the plain file was rotated.
+	SkipRotatedCompressed bool `yaml:"skip_rotated_compressed"`
+
+	// DetectCompressionFromContent detects the compressed files without a compression extension from their
+	// first bytes.
+	DetectCompressionFromContent bool `yaml:"detect_compression_from_content"`
 }
 
 // RegisterFlags with prefix registers flags where every name is prefixed by
@@ -35,6 +43,8 @@ type Config struct {
 func (cfg *Config) RegisterFlagsWithPrefix(prefix string, f *flag.FlagSet) {
 	f.DurationVar(&cfg.SyncPeriod, prefix+"target.sync-period", 10*time.Second, "Period to resync directories being watched and files being tailed.")
 	f.BoolVar(&cfg.Stdin, prefix+"stdin", false, "Set to true to pipe logs to promtail.")
+	f.BoolVar(&cfg.SkipRotatedCompressed, prefix+"target.skip-rotated-compressed", false, "Skip the compressed files named after a rotation of a plain file matched by the same target, like app.log.1.gz for app.log.")
+	f.BoolVar(&cfg.DetectCompressionFromContent, prefix+"target.detect-compression-from-content", false, "Detect the files compressed with gzip, bzip2 or zstd without a .gz, .bz2, .zst or .zstd extension from their first bytes.")
 }
 
 // RegisterFlags register flags.
@@ -54,6 +64,13 @@ type fileTargetEvent struct {
 	eventType fileTargetEventType
 }
 
+// reader reads the lines of a file, following it for a plain file or decompressing it once for a compressed file.
+type reader interface {
+	stop()
+	isRunning() bool
+	markPositionAndSize() error
+}
+
 // FileTarget describes a particular set of logs.
 // nolint:revive
 type FileTarget struct {
@@ -73,11 +90,21 @@ type FileTarget struct {
 	quit               chan struct{}
 	done               chan struct{}
 
-	tails map[string]*tailer
+	tails map[string]reader
+
+	// decompressFailures tracks the compressed files failing to be decompressed, to give up on the corrupt ones.
+	decompressFailures map[string]decompressFailure
 
 	targetConfig *Config
 }
 
+// decompressFailure is the number of times in a row a compressed file failed to be decompressed since it was
+// last modified.
+type decompressFailure struct {
+	attempts int
+	modTime  time.Time
+}
+
 // NewFileTarget create a new FileTarget.
 func NewFileTarget(
 	metrics *Metrics,
@@ -103,7 +130,8 @@ func NewFileTarget(
 		positions:          positions,
 		quit:               make(chan struct{}),
 		done:               make(chan struct{}),
-		tails:              map[string]*tailer{},
+		tails:              map[string]reader{},
+		decompressFailures: map[string]decompressFailure{},
 		targetConfig:       targetConfig,
 		fileEventWatcher:   fileEventWatcher,
 		targetEventHandler: targetEventHandler,
@@ -144,7 +172,7 @@ func (t *FileTarget) Labels() model.LabelSet {
 func (t *FileTarget) Details() interface{} {
 	files := map[string]int64{}
 	for fileName := range t.tails {
-		files[fileName], _ = t.positions.Get(fileName)
+		files[fileName], _, _ = readPosition(t.positions, fileName)
 	}
 	return files
 }
@@ -232,6 +260,10 @@ func (t *FileTarget) sync() error {
 		}
 	}
 
+	if t.targetConfig.SkipRotatedCompressed {
+		matches = skipRotatedCompressed(matches)
+	}
+
 	// Record the size of all the files matched by the Glob pattern.
 	t.reportSize(matches)
 
@@ -263,6 +295,9 @@ func (t *FileTarget) sync() error {
 	toStopTailing := toStopTailing(matches, t.tails)
 	t.stopTailingAndRemovePosition(toStopTailing)
 
+	// Forget the failures of the compressed files which no longer exist.
+	t.forgetDecompressFailures(matches)
+
 	return nil
 }
 
@@ -311,13 +346,28 @@ func (t *FileTarget) startTailing(ps []string) {
 			continue
 		}
 
-		level.Debug(t.logger).Log("msg", "tailing new file", "filename", p)
-		tailer, err := newTailer(t.metrics, t.logger, t.handler, t.positions, p)
+		format, err := detectCompression(p, t.targetConfig.DetectCompressionFromContent)
+		if err != nil {
+			level.Error(t.logger).Log("msg", "failed to detect the compression of file", "error", err, "filename", p)
+			continue
+		}
+		if format != compressionNone && t.gaveUpDecompressing(p, fi.ModTime()) {
+			continue
+		}
+
+		var r reader
+		if format != compressionNone {
+			level.Debug(t.logger).Log("msg", "decompressing new file", "filename", p, "format", format)
+			r, err = newDecompressor(t.metrics, t.logger, t.handler, t.positions, p, format)
+		} else {
+			level.Debug(t.logger).Log("msg", "tailing new file", "filename", p)
+			r, err = newTailer(t.metrics, t.logger, t.handler, t.positions, p)
+		}
 		if err != nil {
 			level.Error(t.logger).Log("msg", "failed to start tailer", "error", err, "filename", p)
 			continue
 		}
-		t.tails[p] = tailer
+		t.tails[p] = r
 	}
 }
 
@@ -325,9 +375,9 @@ func (t *FileTarget) startTailing(ps []string) {
 // Call this when a file no longer exists and you want to remove all traces of it.
 func (t *FileTarget) stopTailingAndRemovePosition(ps []string) {
 	for _, p := range ps {
-		if tailer, ok := t.tails[p]; ok {
-			tailer.stop()
-			t.positions.Remove(tailer.path)
+		if r, ok := t.tails[p]; ok {
+			r.stop()
+			t.positions.Remove(p)
 			delete(t.tails, p)
 		}
 		if h, ok := t.handler.(api.InstrumentedEntryHandler); ok {
@@ -340,9 +390,12 @@ func (t *FileTarget) stopTailingAndRemovePosition(ps []string) {
 // the list of active tailers. This allows them to be restarted if there were errors.
 func (t *FileTarget) pruneStoppedTailers() {
 	toRemove := make([]string, 0, len(t.tails))
-	for k, t := range t.tails {
-		if !t.isRunning() {
+	for k, r := range t.tails {
+		if !r.isRunning() {
 			toRemove = append(toRemove, k)
+			if d, ok := r.(*decompressor); ok {
+				t.recordDecompressFailure(k, d.modTime)
+			}
 		}
 	}
 	for _, tr := range toRemove {
@@ -350,7 +403,45 @@ func (t *FileTarget) pruneStoppedTailers() {
 	}
 }
 
-func toStopTailing(nt []string, et map[string]*tailer) []string {
+// recordDecompressFailure records that the compressed file, with the given modification time, failed to be
+// decompressed. The attempts are counted again from the start once the file is modified, since a file still
+// being written fails until it is complete.
+func (t *FileTarget) recordDecompressFailure(path string, modTime time.Time) {
+	f := t.decompressFailures[path]
+	if !f.modTime.Equal(modTime) {
+		f = decompressFailure{modTime: modTime}
+	}
+	f.attempts++
+	t.decompressFailures[path] = f
+	if f.attempts == maxDecompressAttempts {
+		level.Warn(t.logger).Log("msg", "giving up decompressing file until it is modified", "filename", path, "attempts", f.attempts)
+	}
+}
+
+// gaveUpDecompressing returns whether the compressed file failed to be decompressed too many times in a row
+// without being modified since.
+func (t *FileTarget) gaveUpDecompressing(path string, modTime time.Time) bool {
+	f, ok := t.decompressFailures[path]
+	return ok && f.attempts >= maxDecompressAttempts && f.modTime.Equal(modTime)
+}
+
+// forgetDecompressFailures forgets the failures of the files which aren't matched anymore.
+func (t *FileTarget) forgetDecompressFailures(matches []string) {
+	if len(t.decompressFailures) == 0 {
+		return
+	}
+	matched := make(map[string]struct{}, len(matches))
+	for _, m := range matches {
+		matched[m] = struct{}{}
+	}
+	for p := range t.decompressFailures {
+		if _, ok := matched[p]; !ok {
+			delete(t.decompressFailures, p)
+		}
+	}
+}
+
+func toStopTailing(nt []string, et map[string]reader) []string {
 	// Make a set of all existing tails
 	existingTails := make(map[string]struct{}, len(et))
 	for file := range et {
@@ -375,8 +466,8 @@ func toStopTailing(nt []string, et map[string]*tailer) []string {
 func (t *FileTarget) reportSize(ms []string) {
 	for _, m := range ms {
 		// Ask the tailer to update the size if a tailer exists, this keeps position and size metrics in sync
-		if tailer, ok := t.tails[m]; ok {
-			err := tailer.markPositionAndSize()
+		if r, ok := t.tails[m]; ok {
+			err := r.markPositionAndSize()
 			if err != nil {
 				level.Warn(t.logger).Log("msg", "failed to get file size from tailer, ", "file", m, "error", err)
 				return
@@ -396,6 +487,21 @@ func (t *FileTarget) reportSize(ms []string) {
 	}
 }
 
+// skipRotatedCompressed returns the matches without the rotated compressed siblings of the plain files.
+func skipRotatedCompressed(matches []string) []string {
+	siblings := rotatedCompressedSiblings(matches)
+	if len(siblings) == 0 {
+		return matches
+	}
+	kept := make([]string, 0, len(matches)-len(siblings))
+	for _, m := range matches {
+		if _, ok := siblings[m]; !ok {
+			kept = append(kept, m)
+		}
+	}
+	return kept
+}
+
 // Returns the elements from set b which are missing from set a
 func missing(as map[string]struct{}, bs map[string]struct{}) map[string]struct{} {
 	c := map[string]struct{}{}
@@ -0,0 +1,38 @@
+package forward
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Metrics holds a set of forward metrics.
+type Metrics struct {
+	reg prometheus.Registerer
+
+	forwardEntries prometheus.Counter
+	forwardErrors  prometheus.Counter
+}
+
+// NewMetrics creates a new set of forward metrics. If reg is non-nil, the
+// metrics will be registered.
+func NewMetrics(reg prometheus.Registerer) *Metrics {
+	var m Metrics
+	m.reg = reg
+
+	m.forwardEntries = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "promtail",
+		Name:      "forward_target_entries_total",
+		Help:      "Total number of successful entries sent to the forward target",
+	})
+	m.forwardErrors = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "promtail",
+		Name:      "forward_target_parsing_errors_total",
+		Help:      "Total number of parsing errors while receiving forward messages",
+	})
+
+	if reg != nil {
+		reg.MustRegister(
+			m.forwardEntries,
+			m.forwardErrors,
+		)
+	}
+
+	return &m
+}
@@ -0,0 +1,204 @@
+package forward
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"time"
+
+	"github.com/ugorji/go/codec"
+)
+
+// eventTimeExtType is the msgpack extension type of the Forward protocol EventTime, made of the seconds and
+// nanoseconds since the epoch as big-endian uint32.
+const eventTimeExtType = 0
+
+// msgpackHandle decodes the Forward protocol messages, with the maps decoded as map[string]interface{} and
+// the strings and binaries as strings. It encodes the acks with the current msgpack spec.
+var msgpackHandle = func() *codec.MsgpackHandle {
+	h := &codec.MsgpackHandle{WriteExt: true}
+	h.RawToString = true
+	h.MapType = reflect.TypeOf(map[string]interface{}(nil))
+	return h
+}()
+
+type event struct {
+	time   time.Time
+	record map[string]interface{}
+}
+
+// message is a Forward protocol message, with its events and the chunk id to acknowledge, if any.
+type message struct {
+	tag    string
+	events []event
+	chunk  string
+}
+
+// parseMessage parses a Forward protocol message in any of its modes:
+//   - Message: [tag, time, record, option]
+//   - Forward: [tag, [[time, record], ...], option]
+//   - PackedForward: [tag, msgpack stream of [time, record] entries, option]
+//   - CompressedPackedForward: the same as PackedForward, gzipped, with the option {"compressed": "gzip"}.
+func parseMessage(raw []interface{}) (message, error) {
+	if len(raw) < 2 {
+		return message{}, fmt.Errorf("message with %d elements", len(raw))
+	}
+	tag, ok := raw[0].(string)
+	if !ok {
+		return message{}, fmt.Errorf("invalid tag of type %T", raw[0])
+	}
+
+	var (
+		msg  = message{tag: tag}
+		opts map[string]interface{}
+		err  error
+	)
+	switch entries := raw[1].(type) {
+	case []interface{}:
+		if opts, err = parseOptions(raw, 2); err != nil {
+			return message{}, err
+		}
+		for _, e := range entries {
+			ev, err := parseEntry(e)
+			if err != nil {
+				return message{}, err
+			}
+			msg.events = append(msg.events, ev)
+		}
+	case string:
+		if opts, err = parseOptions(raw, 2); err != nil {
+			return message{}, err
+		}
+		if msg.events, err = parsePackedEntries([]byte(entries), opts); err != nil {
+			return message{}, err
+		}
+	case []byte:
+		if opts, err = parseOptions(raw, 2); err != nil {
+			return message{}, err
+		}
+		if msg.events, err = parsePackedEntries(entries, opts); err != nil {
+			return message{}, err
+		}
+	default:
+		if len(raw) < 3 {
+			return message{}, errors.New("message without record")
+		}
+		if opts, err = parseOptions(raw, 3); err != nil {
+			return message{}, err
+		}
+		ev, err := parseEvent(raw[1], raw[2])
+		if err != nil {
+			return message{}, err
+		}
+		msg.events = []event{ev}
+	}
+
+	if chunk, ok := opts["chunk"]; ok {
+		if msg.chunk, ok = chunk.(string); !ok {
+			return message{}, fmt.Errorf("invalid chunk option of type %T", chunk)
+		}
+	}
+	return msg, nil
+}
+
+func parseOptions(raw []interface{}, i int) (map[string]interface{}, error) {
+	if len(raw) <= i || raw[i] == nil {
+		return nil, nil
+	}
+	opts, ok := raw[i].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid option of type %T", raw[i])
+	}
+	return opts, nil
+}
+
+// parsePackedEntries parses the msgpack stream of entries of the PackedForward and CompressedPackedForward modes.
+func parsePackedEntries(data []byte, opts map[string]interface{}) ([]event, error) {
+	var r io.Reader = bytes.NewReader(data)
+	if compressed, ok := opts["compressed"]; ok {
+		if compressed != "gzip" {
+			return nil, fmt.Errorf("unsupported compression %v", compressed)
+		}
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	var events []event
+	dec := codec.NewDecoder(r, msgpackHandle)
+	for {
+		var entry interface{}
+		if err := dec.Decode(&entry); err != nil {
+			if err == io.EOF {
+				return events, nil
+			}
+			return nil, fmt.Errorf("failed to decode packed entries: %w", err)
+		}
+		ev, err := parseEntry(entry)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, ev)
+	}
+}
+
+// parseEntry parses an entry of the Forward and PackedForward modes, [time, record].
+func parseEntry(raw interface{}) (event, error) {
+	entry, ok := raw.([]interface{})
+	if !ok || len(entry) < 2 {
+		return event{}, errors.New("invalid entry, expected [time, record]")
+	}
+	return parseEvent(entry[0], entry[1])
+}
+
+func parseEvent(rawTime, rawRecord interface{}) (event, error) {
+	t, err := parseTime(rawTime)
+	if err != nil {
+		return event{}, err
+	}
+	record, ok := rawRecord.(map[string]interface{})
+	if !ok {
+		return event{}, fmt.Errorf("invalid record of type %T", rawRecord)
+	}
+	return event{time: t, record: record}, nil
+}
+
+// parseTime parses the time of an event, either an EventTime or a number of seconds since the epoch.
+func parseTime(raw interface{}) (time.Time, error) {
+	switch t := raw.(type) {
+	case uint64:
+		return time.Unix(int64(t), 0), nil
+	case int64:
+		return time.Unix(t, 0), nil
+	case float64:
+		sec, frac := math.Modf(t)
+		return time.Unix(int64(sec), int64(frac*float64(time.Second))), nil
+	case codec.RawExt:
+		return parseEventTime(t)
+	case *codec.RawExt:
+		return parseEventTime(*t)
+	case []interface{}:
+		// Fluent Bit sends the time along with the metadata of the event as [time, metadata].
+		if len(t) > 0 {
+			return parseTime(t[0])
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time of type %T", raw)
+}
+
+func parseEventTime(ext codec.RawExt) (time.Time, error) {
+	if ext.Tag != eventTimeExtType || len(ext.Data) != 8 {
+		return time.Time{}, fmt.Errorf("invalid EventTime extension of type %d and length %d", ext.Tag, len(ext.Data))
+	}
+	sec := binary.BigEndian.Uint32(ext.Data[:4])
+	nsec := binary.BigEndian.Uint32(ext.Data[4:])
+	return time.Unix(int64(sec), int64(nsec)), nil
+}
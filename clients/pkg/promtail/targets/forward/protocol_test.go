@@ -0,0 +1,114 @@
+package forward
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ugorji/go/codec"
+)
+
+func encodeMsgpack(t *testing.T, v interface{}) []byte {
+	var buf []byte
+	require.NoError(t, codec.NewEncoderBytes(&buf, msgpackHandle).Encode(v))
+	return buf
+}
+
+// decodeMessage decodes the message as the target does.
+func decodeMessage(t *testing.T, v []interface{}) []interface{} {
+	var raw []interface{}
+	require.NoError(t, codec.NewDecoderBytes(encodeMsgpack(t, v), msgpackHandle).Decode(&raw))
+	return raw
+}
+
+func eventTime(ts time.Time) codec.RawExt {
+	data := make([]byte, 8)
+	binary.BigEndian.PutUint32(data[:4], uint32(ts.Unix()))
+	binary.BigEndian.PutUint32(data[4:], uint32(ts.Nanosecond()))
+	return codec.RawExt{Tag: eventTimeExtType, Data: data}
+}
+
+func TestParseMessage(t *testing.T) {
+	ts := time.Unix(1650000000, 123456789)
+	record := map[string]interface{}{"log": "hello", "level": "info"}
+	entries := []interface{}{
+		[]interface{}{eventTime(ts), record},
+		[]interface{}{ts.Unix(), map[string]interface{}{"log": "world"}},
+	}
+	packed := append(encodeMsgpack(t, entries[0]), encodeMsgpack(t, entries[1])...)
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	_, err := gz.Write(packed)
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+
+	expected := []event{
+		{time: ts, record: map[string]interface{}{"log": "hello", "level": "info"}},
+		{time: time.Unix(ts.Unix(), 0), record: map[string]interface{}{"log": "world"}},
+	}
+
+	for _, tc := range []struct {
+		name     string
+		raw      []interface{}
+		expected message
+	}{
+		{
+			name:     "message",
+			raw:      []interface{}{"app", eventTime(ts), record},
+			expected: message{tag: "app", events: expected[:1]},
+		},
+		{
+			name:     "message with seconds and option",
+			raw:      []interface{}{"app", ts.Unix(), record, map[string]interface{}{"chunk": "c1"}},
+			expected: message{tag: "app", events: []event{{time: time.Unix(ts.Unix(), 0), record: record}}, chunk: "c1"},
+		},
+		{
+			name:     "forward",
+			raw:      []interface{}{"app", entries, map[string]interface{}{"chunk": "c2", "size": 2}},
+			expected: message{tag: "app", events: expected, chunk: "c2"},
+		},
+		{
+			name:     "packed forward",
+			raw:      []interface{}{"app", packed},
+			expected: message{tag: "app", events: expected},
+		},
+		{
+			name:     "compressed packed forward",
+			raw:      []interface{}{"app", compressed.Bytes(), map[string]interface{}{"compressed": "gzip", "chunk": "c3"}},
+			expected: message{tag: "app", events: expected, chunk: "c3"},
+		},
+		{
+			name:     "fluent bit metadata",
+			raw:      []interface{}{"app", []interface{}{[]interface{}{[]interface{}{eventTime(ts), map[string]interface{}{}}, record}}},
+			expected: message{tag: "app", events: expected[:1]},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			msg, err := parseMessage(decodeMessage(t, tc.raw))
+			require.NoError(t, err)
+			require.Equal(t, tc.expected.tag, msg.tag)
+			require.Equal(t, tc.expected.chunk, msg.chunk)
+			require.Len(t, msg.events, len(tc.expected.events))
+			for i, ev := range msg.events {
+				require.True(t, tc.expected.events[i].time.Equal(ev.time), ev.time)
+				require.Equal(t, tc.expected.events[i].record, ev.record)
+			}
+		})
+	}
+
+	for _, raw := range [][]interface{}{
+		{"app"},
+		{1, ts.Unix(), record},
+		{"app", ts.Unix()},
+		{"app", "not a time", record},
+		{"app", ts.Unix(), "not a record"},
+		{"app", packed, map[string]interface{}{"compressed": "lz4"}},
+		{"app", []interface{}{"not an entry"}},
+	} {
+		_, err := parseMessage(decodeMessage(t, raw))
+		require.Error(t, err, raw)
+	}
+}
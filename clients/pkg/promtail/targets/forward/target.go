@@ -0,0 +1,311 @@
+package forward
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/grafana/dskit/backoff"
+	"github.com/mwitkow/go-conntrack"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/model/relabel"
+	"github.com/ugorji/go/codec"
+
+	"github.com/grafana/loki/clients/pkg/promtail/api"
+	"github.com/grafana/loki/clients/pkg/promtail/scrapeconfig"
+	"github.com/grafana/loki/clients/pkg/promtail/targets/target"
+
+	"github.com/grafana/loki/pkg/logproto"
+)
+
+var (
+	defaultListenAddress = ":24224"
+	defaultIdleTimeout   = 120 * time.Second
+
+	invalidLabelCharRE = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+)
+
+// Target listens to Fluentd Forward protocol messages on tcp.
+type Target struct {
+	metrics       *Metrics
+	logger        log.Logger
+	handler       api.EntryHandler
+	config        *scrapeconfig.ForwardTargetConfig
+	relabelConfig []*relabel.Config
+
+	listener net.Listener
+
+	ctx             context.Context
+	ctxCancel       context.CancelFunc
+	openConnections *sync.WaitGroup
+}
+
+// NewTarget configures a new Forward Target.
+func NewTarget(
+	metrics *Metrics,
+	logger log.Logger,
+	handler api.EntryHandler,
+	relabel []*relabel.Config,
+	config *scrapeconfig.ForwardTargetConfig,
+) (*Target, error) {
+
+	if config.ListenAddress == "" {
+		config.ListenAddress = defaultListenAddress
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	t := &Target{
+		metrics:       metrics,
+		logger:        logger,
+		handler:       handler,
+		config:        config,
+		relabelConfig: relabel,
+
+		ctx:             ctx,
+		ctxCancel:       cancel,
+		openConnections: new(sync.WaitGroup),
+	}
+
+	if err := t.run(); err != nil {
+		cancel()
+		return nil, err
+	}
+	return t, nil
+}
+
+func (t *Target) run() error {
+	l, err := net.Listen("tcp", t.config.ListenAddress)
+	if err != nil {
+		return fmt.Errorf("error setting up forward target: %w", err)
+	}
+	t.listener = conntrack.NewListener(l, conntrack.TrackWithName("forward_target/"+t.config.ListenAddress))
+	level.Info(t.logger).Log("msg", "forward listening on address", "address", t.ListenAddress().String())
+
+	t.openConnections.Add(1)
+	go t.acceptConnections()
+
+	return nil
+}
+
+func (t *Target) acceptConnections() {
+	defer t.openConnections.Done()
+
+	l := log.With(t.logger, "address", t.listener.Addr().String())
+
+	backoff := backoff.New(t.ctx, backoff.Config{
+		MinBackoff: 5 * time.Millisecond,
+		MaxBackoff: 1 * time.Second,
+	})
+
+	for {
+		c, err := t.listener.Accept()
+		if err != nil {
+			if t.ctx.Err() != nil {
+				level.Info(l).Log("msg", "forward server shutting down")
+				return
+			}
+
+			if ne, ok := err.(net.Error); ok && ne.Temporary() {
+				level.Warn(l).Log("msg", "failed to accept forward connection", "err", err, "num_retries", backoff.NumRetries())
+				backoff.Wait()
+				continue
+			}
+
+			level.Error(l).Log("msg", "failed to accept forward connection. quiting", "err", err)
+			return
+		}
+		backoff.Reset()
+
+		t.openConnections.Add(1)
+		go t.handleConnection(c)
+	}
+}
+
+func (t *Target) handleConnection(cn net.Conn) {
+	defer t.openConnections.Done()
+
+	c := &idleTimeoutConn{cn, t.idleTimeout()}
+
+	handlerCtx, cancel := context.WithCancel(t.ctx)
+	defer cancel()
+	go func() {
+		<-handlerCtx.Done()
+		_ = c.Close()
+	}()
+
+	dec := codec.NewDecoder(bufio.NewReader(c), msgpackHandle)
+	enc := codec.NewEncoder(c, msgpackHandle)
+	for {
+		var raw []interface{}
+		if err := dec.Decode(&raw); err != nil {
+			t.handleConnectionError(err)
+			return
+		}
+
+		msg, err := parseMessage(raw)
+		if err != nil {
+			// The message was fully decoded, so that the next one can still be read.
+			level.Warn(t.logger).Log("msg", "error parsing forward message", "err", err)
+			t.metrics.forwardErrors.Inc()
+			continue
+		}
+		if !t.handleMessage(msg) {
+			return
+		}
+
+		if msg.chunk != "" {
+			if err := enc.Encode(map[string]string{"ack": msg.chunk}); err != nil {
+				level.Warn(t.logger).Log("msg", "error acknowledging forward message", "err", err)
+				return
+			}
+		}
+	}
+}
+
+func (t *Target) handleConnectionError(err error) {
+	if errors.Is(err, io.EOF) || t.ctx.Err() != nil {
+		return
+	}
+	var ne net.Error
+	if errors.As(err, &ne) && ne.Timeout() {
+		level.Debug(t.logger).Log("msg", "connection timed out", "err", ne)
+		return
+	}
+	level.Warn(t.logger).Log("msg", "error decoding forward stream", "err", err)
+	t.metrics.forwardErrors.Inc()
+}
+
+// handleMessage sends the events of the message, and returns false if the target was stopped meanwhile.
+func (t *Target) handleMessage(msg message) bool {
+	for _, ev := range msg.events {
+		line, err := json.Marshal(ev.record)
+		if err != nil {
+			level.Warn(t.logger).Log("msg", "error rendering forward record as JSON", "tag", msg.tag, "err", err)
+			t.metrics.forwardErrors.Inc()
+			continue
+		}
+
+		lb := labels.NewBuilder(nil)
+		for k, v := range t.config.Labels {
+			lb.Set(string(k), string(v))
+		}
+		lb.Set("__forward_tag", msg.tag)
+		for name, value := range ev.record {
+			switch value.(type) {
+			case string, bool, int64, uint64, float64:
+				lb.Set("__forward_record_"+invalidLabelCharRE.ReplaceAllString(name, "_"), fmt.Sprint(value))
+			}
+		}
+
+		processed := relabel.Process(lb.Labels(), t.relabelConfig...)
+		if len(processed) == 0 {
+			continue
+		}
+
+		filtered := make(model.LabelSet)
+		for _, lbl := range processed {
+			if strings.HasPrefix(lbl.Name, "__") {
+				continue
+			}
+			filtered[model.LabelName(lbl.Name)] = model.LabelValue(lbl.Value)
+		}
+
+		timestamp := time.Now()
+		if t.config.UseIncomingTimestamp {
+			timestamp = ev.time
+		}
+
+		select {
+		case t.handler.Chan() <- api.Entry{
+			Labels: filtered,
+			Entry: logproto.Entry{
+				Timestamp: timestamp,
+				Line:      string(line),
+			},
+		}:
+			t.metrics.forwardEntries.Inc()
+		case <-t.ctx.Done():
+			return false
+		}
+	}
+	return true
+}
+
+// Type returns ForwardTargetType.
+func (t *Target) Type() target.TargetType {
+	return target.ForwardTargetType
+}
+
+// Ready indicates whether or not the forward target is ready to be read from.
+func (t *Target) Ready() bool {
+	return true
+}
+
+// DiscoveredLabels returns the set of labels discovered by the forward target, which
+// is always nil. Implements Target.
+func (t *Target) DiscoveredLabels() model.LabelSet {
+	return nil
+}
+
+// Labels returns the set of labels that statically apply to all log entries
+// produced by the forward target.
+func (t *Target) Labels() model.LabelSet {
+	return t.config.Labels
+}
+
+// Details returns target-specific details.
+func (t *Target) Details() interface{} {
+	return map[string]string{}
+}
+
+// Stop shuts down the forward target.
+func (t *Target) Stop() error {
+	t.ctxCancel()
+	err := t.listener.Close()
+	t.openConnections.Wait()
+	t.handler.Stop()
+	return err
+}
+
+// ListenAddress returns the address the forward target is listening on.
+func (t *Target) ListenAddress() net.Addr {
+	return t.listener.Addr()
+}
+
+func (t *Target) idleTimeout() time.Duration {
+	if t.config.IdleTimeout != 0 {
+		return t.config.IdleTimeout
+	}
+	return defaultIdleTimeout
+}
+
+type idleTimeoutConn struct {
+	net.Conn
+	idleTimeout time.Duration
+}
+
+func (c *idleTimeoutConn) Write(p []byte) (int, error) {
+	c.setDeadline()
+	return c.Conn.Write(p)
+}
+
+func (c *idleTimeoutConn) Read(b []byte) (int, error) {
+	c.setDeadline()
+	return c.Conn.Read(b)
+}
+
+func (c *idleTimeoutConn) setDeadline() {
+	_ = c.Conn.SetDeadline(time.Now().Add(c.idleTimeout))
+}
@@ -0,0 +1,84 @@
+package forward
+
+import (
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/relabel"
+	"github.com/stretchr/testify/require"
+	"github.com/ugorji/go/codec"
+
+	"github.com/grafana/loki/clients/pkg/promtail/client/fake"
+	"github.com/grafana/loki/clients/pkg/promtail/scrapeconfig"
+)
+
+func TestForwardTarget(t *testing.T) {
+	for _, useIncomingTimestamp := range []bool{false, true} {
+		w := log.NewSyncWriter(os.Stderr)
+		logger := log.NewLogfmtLogger(w)
+
+		handler := fake.New(func() {})
+		defer handler.Stop()
+
+		tgt, err := NewTarget(NewMetrics(prometheus.NewRegistry()), logger, handler, []*relabel.Config{
+			{
+				SourceLabels: model.LabelNames{"__forward_tag"},
+				TargetLabel:  "tag",
+				Regex:        relabel.MustNewRegexp("(.*)"),
+				Replacement:  "$1",
+				Action:       relabel.Replace,
+			},
+			{
+				SourceLabels: model.LabelNames{"__forward_record_kubernetes_namespace"},
+				TargetLabel:  "namespace",
+				Regex:        relabel.MustNewRegexp("(.*)"),
+				Replacement:  "$1",
+				Action:       relabel.Replace,
+			},
+		}, &scrapeconfig.ForwardTargetConfig{
+			ListenAddress:        "127.0.0.1:0",
+			Labels:               model.LabelSet{"job": "forward"},
+			UseIncomingTimestamp: useIncomingTimestamp,
+		})
+		require.NoError(t, err)
+
+		conn, err := net.Dial("tcp", tgt.ListenAddress().String())
+		require.NoError(t, err)
+
+		ts := time.Unix(1650000000, 123456789)
+		entries := append(
+			encodeMsgpack(t, []interface{}{eventTime(ts), map[string]interface{}{"log": "hello", "kubernetes.namespace": "default", "nested": map[string]interface{}{"a": 1}}}),
+			encodeMsgpack(t, []interface{}{ts.Unix(), map[string]interface{}{"log": "world", "kubernetes.namespace": "default"}})...,
+		)
+		enc := codec.NewEncoder(conn, msgpackHandle)
+		require.NoError(t, enc.Encode([]interface{}{"kube.app", entries, map[string]interface{}{"chunk": "chunk-1", "size": 2}}))
+
+		// The message is acknowledged once its entries were sent.
+		var ack map[string]interface{}
+		require.NoError(t, codec.NewDecoder(conn, msgpackHandle).Decode(&ack))
+		require.Equal(t, map[string]interface{}{"ack": "chunk-1"}, ack)
+		require.NoError(t, conn.Close())
+
+		require.Eventually(t, func() bool {
+			return len(handler.Received()) == 2
+		}, 5*time.Second, time.Millisecond)
+		received := handler.Received()
+		expectedLabels := model.LabelSet{"job": "forward", "tag": "kube.app", "namespace": "default"}
+		require.Equal(t, expectedLabels, received[0].Labels)
+		require.Equal(t, `{"kubernetes.namespace":"default","log":"hello","nested":{"a":1}}`, received[0].Line)
+		require.Equal(t, `{"kubernetes.namespace":"default","log":"world"}`, received[1].Line)
+		if useIncomingTimestamp {
+			require.True(t, ts.Equal(received[0].Timestamp))
+			require.True(t, time.Unix(ts.Unix(), 0).Equal(received[1].Timestamp))
+		} else {
+			require.WithinDuration(t, time.Now(), received[0].Timestamp, time.Minute)
+		}
+
+		require.NoError(t, tgt.Stop())
+	}
+}
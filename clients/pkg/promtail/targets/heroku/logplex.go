@@ -0,0 +1,131 @@
+package heroku
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// maxFrameLength is the maximum length of a Logplex frame. Heroku truncates the log lines above 10000 bytes,
+// so that a longer frame means the body isn't octet-counted syslog.
+const maxFrameLength = 64 * 1024
+
+const nilValue = "-"
+
+// logplexMessage is a syslog message sent by Logplex. They look like RFC5424 messages without the structured
+// data: <PRI>VERSION TIMESTAMP HOSTNAME APP-NAME PROCID MSGID MSG.
+type logplexMessage struct {
+	timestamp time.Time
+	hostname  string
+	appname   string
+	procID    string
+	msgID     string
+	message   string
+}
+
+// readFrames calls fn with each of the octet-counted frames of r, as in "83 <40>1 2012-11-30T06:45:29+00:00 ...".
+// It returns on EOF or if the framing is invalid.
+func readFrames(r io.Reader, fn func(frame []byte)) error {
+	buf := bufio.NewReader(r)
+	for {
+		// Skip the whitespace between frames, if any.
+		b, err := buf.ReadByte()
+		for err == nil && (b == ' ' || b == '\n' || b == '\r') {
+			b, err = buf.ReadByte()
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := buf.UnreadByte(); err != nil {
+			return err
+		}
+
+		n, err := readFrameLength(buf)
+		if err != nil {
+			return err
+		}
+
+		frame := make([]byte, n)
+		if _, err := io.ReadFull(buf, frame); err != nil {
+			return fmt.Errorf("failed to read a frame of %d bytes: %w", n, err)
+		}
+		fn(frame)
+	}
+}
+
+// readFrameLength reads the length of a frame, followed by a space.
+func readFrameLength(buf *bufio.Reader) (int, error) {
+	n, digits := 0, 0
+	for {
+		b, err := buf.ReadByte()
+		if err != nil {
+			return 0, fmt.Errorf("failed to read the length of the frame: %w", err)
+		}
+		if b == ' ' && digits > 0 {
+			break
+		}
+		if b < '0' || b > '9' {
+			return 0, fmt.Errorf("invalid or unsupported framing, unexpected byte %q in the length of the frame", b)
+		}
+		n = n*10 + int(b-'0')
+		digits++
+		if n > maxFrameLength {
+			return 0, fmt.Errorf("frame longer than %d bytes", maxFrameLength)
+		}
+	}
+	return n, nil
+}
+
+// parseLogplexMessage parses the syslog message of a frame.
+func parseLogplexMessage(frame []byte) (logplexMessage, error) {
+	frame = bytes.TrimRight(frame, "\r\n")
+	if len(frame) == 0 || frame[0] != '<' {
+		return logplexMessage{}, errors.New("message not starting with a priority")
+	}
+	end := bytes.IndexByte(frame, '>')
+	if end < 0 {
+		return logplexMessage{}, errors.New("message with an unterminated priority")
+	}
+	if _, err := strconv.Atoi(string(frame[1:end])); err != nil {
+		return logplexMessage{}, fmt.Errorf("invalid priority %q", frame[1:end])
+	}
+
+	fields := bytes.SplitN(frame[end+1:], []byte(" "), 7)
+	if len(fields) < 6 {
+		return logplexMessage{}, errors.New("message with missing header fields")
+	}
+	if string(fields[0]) != "1" {
+		return logplexMessage{}, fmt.Errorf("unsupported syslog version %q", fields[0])
+	}
+
+	var msg logplexMessage
+	if ts := string(fields[1]); ts != nilValue {
+		t, err := time.Parse(time.RFC3339Nano, ts)
+		if err != nil {
+			return logplexMessage{}, fmt.Errorf("invalid timestamp %q", ts)
+		}
+		msg.timestamp = t
+	}
+	msg.hostname = headerValue(fields[2])
+	msg.appname = headerValue(fields[3])
+	msg.procID = headerValue(fields[4])
+	msg.msgID = headerValue(fields[5])
+	if len(fields) == 7 {
+		msg.message = string(fields[6])
+	}
+	return msg, nil
+}
+
+func headerValue(field []byte) string {
+	if string(field) == nilValue {
+		return ""
+	}
+	return string(field)
+}
@@ -0,0 +1,78 @@
+package heroku
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// octetCounted frames the messages as Logplex does.
+func octetCounted(msgs ...string) string {
+	var sb strings.Builder
+	for _, msg := range msgs {
+		fmt.Fprintf(&sb, "%d %s", len(msg), msg)
+	}
+	return sb.String()
+}
+
+func TestReadFrames(t *testing.T) {
+	body := "83 <40>1 2012-11-30T06:45:29+00:00 host app web.3 - State changed from starting to up\n" +
+		octetCounted("<40>1 2012-11-30T06:45:26+00:00 host app web.3 - Starting process with command `bundle exec rackup config.ru -p 24405`")
+
+	var frames []string
+	require.NoError(t, readFrames(strings.NewReader(body), func(frame []byte) {
+		frames = append(frames, string(frame))
+	}))
+	require.Equal(t, []string{
+		"<40>1 2012-11-30T06:45:29+00:00 host app web.3 - State changed from starting to up\n",
+		"<40>1 2012-11-30T06:45:26+00:00 host app web.3 - Starting process with command `bundle exec rackup config.ru -p 24405`",
+	}, frames)
+
+	for _, body := range []string{
+		"<40>1 2012-11-30T06:45:29+00:00 host app web.3 - not octet-counted",
+		"10 <40>1 truncated",
+		"999999999 <40>1",
+	} {
+		require.Error(t, readFrames(strings.NewReader(body), func([]byte) {}), body)
+	}
+}
+
+func TestParseLogplexMessage(t *testing.T) {
+	msg, err := parseLogplexMessage([]byte("<40>1 2012-11-30T06:45:29.123+00:00 host app web.3 - State changed from starting to up\n"))
+	require.NoError(t, err)
+	require.Equal(t, logplexMessage{
+		timestamp: time.Date(2012, 11, 30, 6, 45, 29, 123000000, time.UTC),
+		hostname:  "host",
+		appname:   "app",
+		procID:    "web.3",
+		message:   "State changed from starting to up",
+	}, logplexMessage{
+		timestamp: msg.timestamp.UTC(),
+		hostname:  msg.hostname,
+		appname:   msg.appname,
+		procID:    msg.procID,
+		msgID:     msg.msgID,
+		message:   msg.message,
+	})
+
+	msg, err = parseLogplexMessage([]byte("<45>1 - host heroku router - "))
+	require.NoError(t, err)
+	require.True(t, msg.timestamp.IsZero())
+	require.Equal(t, "heroku", msg.appname)
+	require.Equal(t, "", msg.message)
+
+	for _, frame := range []string{
+		"",
+		"40>1 2012-11-30T06:45:29+00:00 host app web.3 - message",
+		"<a>1 2012-11-30T06:45:29+00:00 host app web.3 - message",
+		"<40>2 2012-11-30T06:45:29+00:00 host app web.3 - message",
+		"<40>1 yesterday host app web.3 - message",
+		"<40>1 2012-11-30T06:45:29+00:00 host app",
+	} {
+		_, err := parseLogplexMessage([]byte(frame))
+		require.Error(t, err, frame)
+	}
+}
@@ -0,0 +1,38 @@
+package heroku
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Metrics holds a set of Heroku drain metrics.
+type Metrics struct {
+	reg prometheus.Registerer
+
+	herokuEntries prometheus.Counter
+	herokuErrors  prometheus.Counter
+}
+
+// NewMetrics creates a new set of Heroku drain metrics. If reg is non-nil, the
+// metrics will be registered.
+func NewMetrics(reg prometheus.Registerer) *Metrics {
+	var m Metrics
+	m.reg = reg
+
+	m.herokuEntries = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "promtail",
+		Name:      "heroku_drain_target_entries_total",
+		Help:      "Total number of successful entries sent to the Heroku drain target",
+	})
+	m.herokuErrors = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "promtail",
+		Name:      "heroku_drain_target_parsing_errors_total",
+		Help:      "Total number of parsing errors while receiving Heroku drain messages",
+	})
+
+	if reg != nil {
+		reg.MustRegister(
+			m.herokuEntries,
+			m.herokuErrors,
+		)
+	}
+
+	return &m
+}
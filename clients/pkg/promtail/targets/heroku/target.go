@@ -0,0 +1,219 @@
+package heroku
+
+import (
+	"flag"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/imdario/mergo"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/model/relabel"
+	"github.com/weaveworks/common/server"
+
+	"github.com/grafana/loki/clients/pkg/promtail/api"
+	"github.com/grafana/loki/clients/pkg/promtail/scrapeconfig"
+	"github.com/grafana/loki/clients/pkg/promtail/targets/target"
+
+	"github.com/grafana/loki/pkg/logproto"
+	util_log "github.com/grafana/loki/pkg/util/log"
+)
+
+const (
+	// DrainPath is the path Logplex drains must be pointed to.
+	DrainPath = "/heroku/api/v1/drain"
+
+	drainTokenHeader = "Logplex-Drain-Token"
+)
+
+// Target receives the logs of Heroku apps POSTed by a Logplex HTTPS drain.
+type Target struct {
+	metrics       *Metrics
+	logger        log.Logger
+	handler       api.EntryHandler
+	config        *scrapeconfig.HerokuDrainTargetConfig
+	relabelConfig []*relabel.Config
+	jobName       string
+	server        *server.Server
+}
+
+// NewTarget configures a new Heroku drain Target, and starts its server.
+func NewTarget(
+	metrics *Metrics,
+	logger log.Logger,
+	handler api.EntryHandler,
+	relabel []*relabel.Config,
+	jobName string,
+	config *scrapeconfig.HerokuDrainTargetConfig,
+) (*Target, error) {
+
+	t := &Target{
+		metrics:       metrics,
+		logger:        logger,
+		handler:       handler,
+		relabelConfig: relabel,
+		jobName:       jobName,
+		config:        config,
+	}
+
+	// Bit of a chicken and egg problem trying to register the defaults and apply overrides from the loaded config.
+	// First create an empty config and set defaults.
+	defaults := server.Config{}
+	defaults.RegisterFlags(flag.NewFlagSet("empty", flag.ContinueOnError))
+	// Then apply any config values loaded as overrides to the defaults.
+	if err := mergo.Merge(&defaults, config.Server, mergo.WithOverride); err != nil {
+		level.Error(logger).Log("msg", "failed to parse configs and override defaults when configuring heroku drain server", "err", err)
+	}
+	// The merge won't overwrite with a zero value but in the case of ports 0 value
+	// indicates the desire for a random port so reset these to zero if the incoming config val is 0
+	if config.Server.HTTPListenPort == 0 {
+		defaults.HTTPListenPort = 0
+	}
+	if config.Server.GRPCListenPort == 0 {
+		defaults.GRPCListenPort = 0
+	}
+	// Set the config to the new combined config.
+	config.Server = defaults
+
+	if err := t.run(); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func (t *Target) run() error {
+	level.Info(t.logger).Log("msg", "starting heroku drain server", "job", t.jobName)
+	// To prevent metric collisions because all metrics are going to be registered in the global Prometheus registry.
+	t.config.Server.MetricsNamespace = "promtail_" + t.jobName
+
+	// We don't want the /debug and /metrics endpoints running
+	t.config.Server.RegisterInstrumentation = false
+
+	// The logger registers a metric which will cause a duplicate registry panic unless we provide an empty registry
+	// The metric created is for counting log lines and isn't likely to be missed.
+	util_log.InitLogger(&t.config.Server, prometheus.NewRegistry())
+
+	srv, err := server.New(t.config.Server)
+	if err != nil {
+		return err
+	}
+
+	t.server = srv
+	t.server.HTTP.Path(DrainPath).Methods("POST").Handler(http.HandlerFunc(t.drain))
+
+	go func() {
+		err := srv.Run()
+		if err != nil {
+			level.Error(t.logger).Log("msg", "heroku drain server shutdown with error", "err", err)
+		}
+	}()
+
+	return nil
+}
+
+// drain handles the octet-counted syslog frames POSTed by Logplex.
+func (t *Target) drain(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	entries := t.handler.Chan()
+	token := r.Header.Get(drainTokenHeader)
+
+	err := readFrames(r.Body, func(frame []byte) {
+		msg, err := parseLogplexMessage(frame)
+		if err != nil {
+			level.Debug(t.logger).Log("msg", "failed to parse heroku drain message", "err", err)
+			t.metrics.herokuErrors.Inc()
+			return
+		}
+
+		lb := labels.NewBuilder(nil)
+		for k, v := range t.config.Labels {
+			lb.Set(string(k), string(v))
+		}
+		setLabel(lb, "__heroku_drain_token", token)
+		setLabel(lb, "__heroku_drain_host", msg.hostname)
+		setLabel(lb, "__heroku_drain_app", msg.appname)
+		setLabel(lb, "__heroku_drain_proc", msg.procID)
+		setLabel(lb, "__heroku_drain_log_id", msg.msgID)
+
+		processed := relabel.Process(lb.Labels(), t.relabelConfig...)
+		if len(processed) == 0 {
+			return
+		}
+
+		filtered := make(model.LabelSet)
+		for _, lbl := range processed {
+			if strings.HasPrefix(lbl.Name, "__") {
+				continue
+			}
+			filtered[model.LabelName(lbl.Name)] = model.LabelValue(lbl.Value)
+		}
+
+		timestamp := time.Now()
+		if t.config.UseIncomingTimestamp && !msg.timestamp.IsZero() {
+			timestamp = msg.timestamp
+		}
+
+		entries <- api.Entry{
+			Labels: filtered,
+			Entry: logproto.Entry{
+				Timestamp: timestamp,
+				Line:      msg.message,
+			},
+		}
+		t.metrics.herokuEntries.Inc()
+	})
+	if err != nil {
+		level.Warn(t.logger).Log("msg", "failed to read heroku drain request", "err", err)
+		t.metrics.herokuErrors.Inc()
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func setLabel(lb *labels.Builder, name, value string) {
+	if value != "" {
+		lb.Set(name, value)
+	}
+}
+
+// Type returns HerokuDrainTargetType.
+func (t *Target) Type() target.TargetType {
+	return target.HerokuDrainTargetType
+}
+
+// Ready indicates whether or not the Heroku drain target is ready to be read from.
+func (t *Target) Ready() bool {
+	return true
+}
+
+// DiscoveredLabels returns the set of labels discovered by the Heroku drain target, which
+// is always nil. Implements Target.
+func (t *Target) DiscoveredLabels() model.LabelSet {
+	return nil
+}
+
+// Labels returns the set of labels that statically apply to all log entries
+// produced by the Heroku drain target.
+func (t *Target) Labels() model.LabelSet {
+	return t.config.Labels
+}
+
+// Details returns target-specific details.
+func (t *Target) Details() interface{} {
+	return map[string]string{}
+}
+
+// Stop shuts down the Heroku drain target.
+func (t *Target) Stop() error {
+	level.Info(t.logger).Log("msg", "stopping heroku drain server", "job", t.jobName)
+	t.server.Shutdown()
+	t.handler.Stop()
+	return nil
+}
@@ -0,0 +1,137 @@
+package heroku
+
+import (
+	"flag"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/relabel"
+	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/common/server"
+
+	"github.com/grafana/loki/clients/pkg/promtail/client/fake"
+	"github.com/grafana/loki/clients/pkg/promtail/scrapeconfig"
+)
+
+const localhost = "127.0.0.1"
+
+var testDrainBody = octetCounted(
+	"<40>1 2012-11-30T06:45:29+00:00 host app web.3 - State changed from starting to up\n",
+	"<40>1 2012-11-30T06:45:26+00:00 host app web.3 - Starting process with command `bundle exec rackup config.ru -p 24405`\n",
+	"<40>1 invalid\n",
+)
+
+func TestHerokuDrainTarget(t *testing.T) {
+	for _, useIncomingTimestamp := range []bool{false, true} {
+		t.Run("use_incoming_timestamp="+strconv.FormatBool(useIncomingTimestamp), func(t *testing.T) {
+			w := log.NewSyncWriter(os.Stderr)
+			logger := log.NewLogfmtLogger(w)
+
+			eh := fake.New(func() {})
+			defer eh.Stop()
+
+			// Get a randomly available port by open and closing a TCP socket
+			addr, err := net.ResolveTCPAddr("tcp", localhost+":0")
+			require.NoError(t, err)
+			l, err := net.ListenTCP("tcp", addr)
+			require.NoError(t, err)
+			port := l.Addr().(*net.TCPAddr).Port
+			require.NoError(t, l.Close())
+
+			defaults := server.Config{}
+			defaults.RegisterFlags(flag.NewFlagSet("empty", flag.ContinueOnError))
+			defaults.HTTPListenAddress = localhost
+			defaults.HTTPListenPort = port
+			defaults.GRPCListenAddress = localhost
+			defaults.GRPCListenPort = 0 // Not testing GRPC, a random port will be assigned
+
+			config := &scrapeconfig.HerokuDrainTargetConfig{
+				Server:               defaults,
+				Labels:               model.LabelSet{"job": "heroku"},
+				UseIncomingTimestamp: useIncomingTimestamp,
+			}
+			rlbl := []*relabel.Config{
+				{
+					SourceLabels: model.LabelNames{"__heroku_drain_token"},
+					TargetLabel:  "drain_token",
+					Regex:        relabel.MustNewRegexp("(.*)"),
+					Replacement:  "$1",
+					Action:       relabel.Replace,
+				},
+				{
+					SourceLabels: model.LabelNames{"__heroku_drain_app"},
+					TargetLabel:  "app",
+					Regex:        relabel.MustNewRegexp("(.*)"),
+					Replacement:  "$1",
+					Action:       relabel.Replace,
+				},
+				{
+					SourceLabels: model.LabelNames{"__heroku_drain_proc"},
+					TargetLabel:  "proc",
+					Regex:        relabel.MustNewRegexp("(.*)"),
+					Replacement:  "$1",
+					Action:       relabel.Replace,
+				},
+				{
+					SourceLabels: model.LabelNames{"__heroku_drain_host"},
+					TargetLabel:  "host",
+					Regex:        relabel.MustNewRegexp("(.*)"),
+					Replacement:  "$1",
+					Action:       relabel.Replace,
+				},
+			}
+
+			metrics := NewMetrics(prometheus.NewRegistry())
+			tgt, err := NewTarget(metrics, logger, eh, rlbl, "heroku_drain_"+strconv.FormatBool(useIncomingTimestamp), config)
+			require.NoError(t, err)
+			defer func() {
+				require.NoError(t, tgt.Stop())
+			}()
+
+			req, err := http.NewRequest("POST", "http://"+localhost+":"+strconv.Itoa(port)+DrainPath, strings.NewReader(testDrainBody))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/logplex-1")
+			req.Header.Set("Logplex-Msg-Count", "3")
+			req.Header.Set(drainTokenHeader, "d.01234567-89ab-cdef-0123-456789abcdef")
+			res, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			require.Equal(t, http.StatusNoContent, res.StatusCode)
+			require.NoError(t, res.Body.Close())
+
+			require.Eventually(t, func() bool {
+				return len(eh.Received()) == 2
+			}, 5*time.Second, time.Millisecond)
+
+			expectedLabels := model.LabelSet{
+				"job":         "heroku",
+				"drain_token": "d.01234567-89ab-cdef-0123-456789abcdef",
+				"app":         "app",
+				"proc":        "web.3",
+				"host":        "host",
+			}
+			received := eh.Received()
+			require.Equal(t, expectedLabels, received[0].Labels)
+			require.Equal(t, "State changed from starting to up", received[0].Line)
+			require.Equal(t, "Starting process with command `bundle exec rackup config.ru -p 24405`", received[1].Line)
+			if useIncomingTimestamp {
+				require.True(t, received[0].Timestamp.Equal(time.Date(2012, 11, 30, 6, 45, 29, 0, time.UTC)))
+			} else {
+				require.WithinDuration(t, time.Now(), received[0].Timestamp, time.Minute)
+			}
+
+			// An invalid framing is rejected.
+			res, err = http.Post("http://"+localhost+":"+strconv.Itoa(port)+DrainPath, "application/logplex-1", strings.NewReader("not a frame"))
+			require.NoError(t, err)
+			require.Equal(t, http.StatusBadRequest, res.StatusCode)
+			require.NoError(t, res.Body.Close())
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package heroku
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/grafana/loki/clients/pkg/logentry/stages"
+	"github.com/grafana/loki/clients/pkg/promtail/api"
+	"github.com/grafana/loki/clients/pkg/promtail/scrapeconfig"
+	"github.com/grafana/loki/clients/pkg/promtail/targets/target"
+)
+
+// TargetManager manages a series of Heroku drain Targets.
+type TargetManager struct {
+	logger  log.Logger
+	targets map[string]*Target
+}
+
+// NewTargetManager creates a new Heroku drain TargetManager.
+func NewTargetManager(
+	metrics *Metrics,
+	logger log.Logger,
+	client api.EntryHandler,
+	scrapeConfigs []scrapeconfig.Config,
+) (*TargetManager, error) {
+	reg := metrics.reg
+	if reg == nil {
+		reg = prometheus.DefaultRegisterer
+	}
+
+	tm := &TargetManager{
+		logger:  logger,
+		targets: make(map[string]*Target),
+	}
+
+	if err := validateJobName(scrapeConfigs); err != nil {
+		return nil, err
+	}
+
+	for _, cfg := range scrapeConfigs {
+		pipeline, err := stages.NewPipeline(log.With(logger, "component", "heroku_drain_pipeline_"+cfg.JobName), cfg.PipelineStages, &cfg.JobName, reg)
+		if err != nil {
+			return nil, err
+		}
+
+		t, err := NewTarget(metrics, logger, pipeline.Wrap(client), cfg.RelabelConfigs, cfg.JobName, cfg.HerokuDrainConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		tm.targets[cfg.JobName] = t
+	}
+
+	return tm, nil
+}
+
+func validateJobName(scrapeConfigs []scrapeconfig.Config) error {
+	jobNames := map[string]struct{}{}
+	for i, cfg := range scrapeConfigs {
+		if cfg.JobName == "" {
+			return errors.New("`job_name` must be defined for the `heroku_drain` scrape_config with a " +
+				"unique name to properly register metrics, " +
+				"at least one `heroku_drain` scrape_config has no `job_name` defined")
+		}
+		if _, ok := jobNames[cfg.JobName]; ok {
+			return fmt.Errorf("`job_name` must be unique for each `heroku_drain` scrape_config, "+
+				"a duplicate `job_name` of %s was found", cfg.JobName)
+		}
+		jobNames[cfg.JobName] = struct{}{}
+
+		scrapeConfigs[i].JobName = strings.Replace(cfg.JobName, " ", "_", -1)
+	}
+	return nil
+}
+
+// Ready returns true if at least one Heroku drain Target is also ready.
+func (tm *TargetManager) Ready() bool {
+	for _, t := range tm.targets {
+		if t.Ready() {
+			return true
+		}
+	}
+	return false
+}
+
+// Stop stops the Heroku drain TargetManager and all of its Targets.
+func (tm *TargetManager) Stop() {
+	for _, t := range tm.targets {
+		if err := t.Stop(); err != nil {
+			level.Error(t.logger).Log("msg", "error stopping heroku drain target", "err", err.Error())
+		}
+	}
+}
+
+// ActiveTargets returns the list of Heroku drain Targets where logs
+// are being received. ActiveTargets is an alias to AllTargets as
+// Heroku drain Targets cannot be deactivated, only stopped.
+func (tm *TargetManager) ActiveTargets() map[string][]target.Target {
+	return tm.AllTargets()
+}
+
+// AllTargets returns the list of all targets where logs
+// are currently being received.
+func (tm *TargetManager) AllTargets() map[string][]target.Target {
+	result := make(map[string][]target.Target, len(tm.targets))
+	for k, v := range tm.targets {
+		result[k] = []target.Target{v}
+	}
+	return result
+}
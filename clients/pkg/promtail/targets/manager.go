@@ -14,11 +14,14 @@ import (
 	"github.com/grafana/loki/clients/pkg/promtail/targets/cloudflare"
 	"github.com/grafana/loki/clients/pkg/promtail/targets/docker"
 	"github.com/grafana/loki/clients/pkg/promtail/targets/file"
+	"github.com/grafana/loki/clients/pkg/promtail/targets/forward"
 	"github.com/grafana/loki/clients/pkg/promtail/targets/gcplog"
 	"github.com/grafana/loki/clients/pkg/promtail/targets/gelf"
+	"github.com/grafana/loki/clients/pkg/promtail/targets/heroku"
 	"github.com/grafana/loki/clients/pkg/promtail/targets/journal"
 	"github.com/grafana/loki/clients/pkg/promtail/targets/kafka"
 	"github.com/grafana/loki/clients/pkg/promtail/targets/lokipush"
+	"github.com/grafana/loki/clients/pkg/promtail/targets/otlp"
 	"github.com/grafana/loki/clients/pkg/promtail/targets/stdin"
 	"github.com/grafana/loki/clients/pkg/promtail/targets/syslog"
 	"github.com/grafana/loki/clients/pkg/promtail/targets/target"
@@ -37,6 +40,9 @@ const (
 	CloudflareConfigs    = "cloudflareConfigs"
 	DockerConfigs        = "dockerConfigs"
 	DockerSDConfigs      = "dockerSDConfigs"
+	HerokuDrainConfigs   = "herokuDrainConfigs"
+	ForwardConfigs       = "forwardConfigs"
+	OTLPConfigs          = "otlpConfigs"
 )
 
 type targetManager interface {
@@ -96,6 +102,12 @@ func NewTargetManagers(
 			targetScrapeConfigs[CloudflareConfigs] = append(targetScrapeConfigs[CloudflareConfigs], cfg)
 		case cfg.DockerSDConfigs != nil:
 			targetScrapeConfigs[DockerSDConfigs] = append(targetScrapeConfigs[DockerSDConfigs], cfg)
+		case cfg.HerokuDrainConfig != nil:
+			targetScrapeConfigs[HerokuDrainConfigs] = append(targetScrapeConfigs[HerokuDrainConfigs], cfg)
+		case cfg.ForwardConfig != nil:
+			targetScrapeConfigs[ForwardConfigs] = append(targetScrapeConfigs[ForwardConfigs], cfg)
+		case cfg.OTLPConfig != nil:
+			targetScrapeConfigs[OTLPConfigs] = append(targetScrapeConfigs[OTLPConfigs], cfg)
 		default:
 			return nil, fmt.Errorf("no valid target scrape config defined for %q", cfg.JobName)
 		}
@@ -122,6 +134,9 @@ func NewTargetManagers(
 		gelfMetrics       *gelf.Metrics
 		cloudflareMetrics *cloudflare.Metrics
 		dockerMetrics     *docker.Metrics
+		herokuMetrics     *heroku.Metrics
+		forwardMetrics    *forward.Metrics
+		otlpMetrics       *otlp.Metrics
 	)
 	if len(targetScrapeConfigs[FileScrapeConfigs]) > 0 {
 		fileMetrics = file.NewMetrics(reg)
@@ -141,6 +156,15 @@ func NewTargetManagers(
 	if len(targetScrapeConfigs[DockerConfigs]) > 0 || len(targetScrapeConfigs[DockerSDConfigs]) > 0 {
 		dockerMetrics = docker.NewMetrics(reg)
 	}
+	if len(targetScrapeConfigs[HerokuDrainConfigs]) > 0 {
+		herokuMetrics = heroku.NewMetrics(reg)
+	}
+	if len(targetScrapeConfigs[ForwardConfigs]) > 0 {
+		forwardMetrics = forward.NewMetrics(reg)
+	}
+	if len(targetScrapeConfigs[OTLPConfigs]) > 0 {
+		otlpMetrics = otlp.NewMetrics(reg)
+	}
 
 	for target, scrapeConfigs := range targetScrapeConfigs {
 		switch target {
@@ -258,6 +282,24 @@ func NewTargetManagers(
 				return nil, errors.Wrap(err, "failed to make Docker service discovery target manager")
 			}
 			targetManagers = append(targetManagers, cfTargetManager)
+		case HerokuDrainConfigs:
+			herokuTargetManager, err := heroku.NewTargetManager(herokuMetrics, logger, client, scrapeConfigs)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to make Heroku drain target manager")
+			}
+			targetManagers = append(targetManagers, herokuTargetManager)
+		case ForwardConfigs:
+			forwardTargetManager, err := forward.NewTargetManager(forwardMetrics, logger, client, scrapeConfigs)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to make forward target manager")
+			}
+			targetManagers = append(targetManagers, forwardTargetManager)
+		case OTLPConfigs:
+			otlpTargetManager, err := otlp.NewTargetManager(otlpMetrics, logger, client, scrapeConfigs)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to make OTLP target manager")
+			}
+			targetManagers = append(targetManagers, otlpTargetManager)
 		default:
 			return nil, errors.New("unknown scrape config")
 		}
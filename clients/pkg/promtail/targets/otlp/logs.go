@@ -0,0 +1,99 @@
+package otlp
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/grafana/loki/pkg/loghttp/push/otlp"
+)
+
+const (
+	logsServiceName = "opentelemetry.proto.collector.logs.v1.LogsService"
+	exportMethod    = "/" + logsServiceName + "/Export"
+)
+
+var invalidLabelCharRE = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// logsServiceDesc describes the OTLP LogsService.
+var logsServiceDesc = grpc.ServiceDesc{
+	ServiceName: logsServiceName,
+	HandlerType: (*logsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Export",
+			Handler:    exportHandler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "opentelemetry/proto/collector/logs/v1/logs_service.proto",
+}
+
+type logsServer interface {
+	export(req *otlp.ExportLogsServiceRequest)
+}
+
+func exportHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := &otlp.ExportLogsServiceRequest{}
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	handler := func(_ context.Context, req interface{}) (interface{}, error) {
+		srv.(logsServer).export(req.(*otlp.ExportLogsServiceRequest))
+		return &otlp.ExportLogsServiceResponse{}, nil
+	}
+	if interceptor == nil {
+		return handler(ctx, in)
+	}
+	return interceptor(ctx, in, &grpc.UnaryServerInfo{Server: srv, FullMethod: exportMethod}, handler)
+}
+
+// logLine is the line of the entry of a log record, with the fields of the record that the pipeline stages can
+// extract, e.g. with a json stage.
+type logLine struct {
+	Body           interface{}            `json:"body,omitempty"`
+	Severity       string                 `json:"severity,omitempty"`
+	SeverityNumber int32                  `json:"severity_number,omitempty"`
+	TraceID        string                 `json:"trace_id,omitempty"`
+	SpanID         string                 `json:"span_id,omitempty"`
+	Attributes     map[string]interface{} `json:"attributes,omitempty"`
+}
+
+// formatLogRecord renders the log record as a JSON line.
+func formatLogRecord(record *otlp.LogRecord) (string, error) {
+	line := logLine{
+		Body:           otlp.ValueInterface(record.Body),
+		Severity:       record.SeverityText,
+		SeverityNumber: int32(record.SeverityNumber),
+		TraceID:        hex.EncodeToString(record.TraceId),
+		SpanID:         hex.EncodeToString(record.SpanId),
+	}
+	if len(record.Attributes) > 0 {
+		line.Attributes = otlp.KeyValuesInterface(record.Attributes)
+	}
+	if line.Severity == "" && record.SeverityNumber != otlp.SEVERITY_NUMBER_UNSPECIFIED {
+		line.Severity = strings.TrimPrefix(record.SeverityNumber.String(), "SEVERITY_NUMBER_")
+	}
+	b, err := json.Marshal(line)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// recordTimestamp returns the time of the log record, or else the time it was observed at.
+func recordTimestamp(record *otlp.LogRecord) time.Time {
+	ts := record.TimeUnixNano
+	if ts == 0 {
+		ts = record.ObservedTimeUnixNano
+	}
+	if ts == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, int64(ts))
+}
@@ -0,0 +1,76 @@
+package otlp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/loki/pkg/loghttp/push/otlp"
+)
+
+func stringValue(s string) *otlp.AnyValue {
+	return &otlp.AnyValue{Value: &otlp.AnyValue_StringValue{StringValue: s}}
+}
+
+func TestFormatLogRecord(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		record   *otlp.LogRecord
+		expected string
+	}{
+		{
+			name: "full record",
+			record: &otlp.LogRecord{
+				Body:           stringValue("hello"),
+				SeverityText:   "Information",
+				SeverityNumber: otlp.SEVERITY_NUMBER_INFO,
+				TraceId:        []byte{0x5b, 0x8e, 0xfe, 0xf2, 0x83, 0xc3, 0x4c, 0xa3, 0x88, 0x9a, 0x5e, 0xbc, 0x4a, 0x0d, 0x2c, 0x1f},
+				SpanId:         []byte{0xeb, 0x2c, 0x6e, 0x8b, 0x61, 0xaa, 0x43, 0x9d},
+				Attributes: []*otlp.KeyValue{
+					{Key: "http.method", Value: stringValue("GET")},
+					{Key: "http.status_code", Value: &otlp.AnyValue{Value: &otlp.AnyValue_IntValue{IntValue: 200}}},
+				},
+			},
+			expected: `{"body":"hello","severity":"Information","severity_number":9,"trace_id":"5b8efef283c34ca3889a5ebc4a0d2c1f","span_id":"eb2c6e8b61aa439d","attributes":{"http.method":"GET","http.status_code":200}}`,
+		},
+		{
+			name: "severity number only",
+			record: &otlp.LogRecord{
+				Body:           stringValue("oops"),
+				SeverityNumber: otlp.SEVERITY_NUMBER_ERROR2,
+			},
+			expected: `{"body":"oops","severity":"ERROR2","severity_number":18}`,
+		},
+		{
+			name: "structured body",
+			record: &otlp.LogRecord{
+				Body: &otlp.AnyValue{Value: &otlp.AnyValue_KvlistValue{KvlistValue: &otlp.KeyValueList{
+					Values: []*otlp.KeyValue{
+						{Key: "msg", Value: stringValue("hello")},
+						{Key: "tags", Value: &otlp.AnyValue{Value: &otlp.AnyValue_ArrayValue{ArrayValue: &otlp.ArrayValue{
+							Values: []*otlp.AnyValue{stringValue("a"), {Value: &otlp.AnyValue_BoolValue{BoolValue: true}}},
+						}}}},
+					},
+				}}},
+			},
+			expected: `{"body":{"msg":"hello","tags":["a",true]}}`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			line, err := formatLogRecord(tc.record)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, line)
+		})
+	}
+}
+
+func TestResourceLabelValue(t *testing.T) {
+	require.Equal(t, "", otlp.ValueString(nil))
+	require.Equal(t, "checkout", otlp.ValueString(stringValue("checkout")))
+	require.Equal(t, "true", otlp.ValueString(&otlp.AnyValue{Value: &otlp.AnyValue_BoolValue{BoolValue: true}}))
+	require.Equal(t, "42", otlp.ValueString(&otlp.AnyValue{Value: &otlp.AnyValue_IntValue{IntValue: 42}}))
+	require.Equal(t, "0.5", otlp.ValueString(&otlp.AnyValue{Value: &otlp.AnyValue_DoubleValue{DoubleValue: 0.5}}))
+	require.Equal(t, `["a","b"]`, otlp.ValueString(&otlp.AnyValue{Value: &otlp.AnyValue_ArrayValue{ArrayValue: &otlp.ArrayValue{
+		Values: []*otlp.AnyValue{stringValue("a"), stringValue("b")},
+	}}}))
+}
@@ -0,0 +1,38 @@
+package otlp
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Metrics holds a set of OTLP metrics.
+type Metrics struct {
+	reg prometheus.Registerer
+
+	otlpEntries prometheus.Counter
+	otlpErrors  prometheus.Counter
+}
+
+// NewMetrics creates a new set of OTLP metrics. If reg is non-nil, the
+// metrics will be registered.
+func NewMetrics(reg prometheus.Registerer) *Metrics {
+	var m Metrics
+	m.reg = reg
+
+	m.otlpEntries = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "promtail",
+		Name:      "otlp_target_entries_total",
+		Help:      "Total number of successful entries sent to the OTLP target",
+	})
+	m.otlpErrors = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "promtail",
+		Name:      "otlp_target_parsing_errors_total",
+		Help:      "Total number of parsing errors while receiving OTLP export requests",
+	})
+
+	if reg != nil {
+		reg.MustRegister(
+			m.otlpEntries,
+			m.otlpErrors,
+		)
+	}
+
+	return &m
+}
@@ -0,0 +1,277 @@
+package otlp
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/imdario/mergo"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/model/relabel"
+	"github.com/weaveworks/common/server"
+	_ "google.golang.org/grpc/encoding/gzip" // Register the gzip compressor of OTLP/gRPC exporters.
+
+	"github.com/grafana/loki/clients/pkg/promtail/api"
+	"github.com/grafana/loki/clients/pkg/promtail/scrapeconfig"
+	"github.com/grafana/loki/clients/pkg/promtail/targets/target"
+
+	"github.com/grafana/loki/pkg/loghttp/push/otlp"
+	"github.com/grafana/loki/pkg/logproto"
+	util_log "github.com/grafana/loki/pkg/util/log"
+)
+
+const (
+	// LogsPath is the path of the OTLP/HTTP logs endpoint.
+	LogsPath = "/v1/logs"
+
+	protobufContentType = "application/x-protobuf"
+	jsonContentType     = "application/json"
+)
+
+// Target receives OpenTelemetry logs exported over OTLP/gRPC and OTLP/HTTP.
+type Target struct {
+	metrics       *Metrics
+	logger        log.Logger
+	handler       api.EntryHandler
+	config        *scrapeconfig.OTLPTargetConfig
+	relabelConfig []*relabel.Config
+	jobName       string
+	server        *server.Server
+}
+
+// NewTarget configures a new OTLP Target, and starts its server.
+func NewTarget(
+	metrics *Metrics,
+	logger log.Logger,
+	handler api.EntryHandler,
+	relabel []*relabel.Config,
+	jobName string,
+	config *scrapeconfig.OTLPTargetConfig,
+) (*Target, error) {
+
+	t := &Target{
+		metrics:       metrics,
+		logger:        logger,
+		handler:       handler,
+		relabelConfig: relabel,
+		jobName:       jobName,
+		config:        config,
+	}
+
+	// Bit of a chicken and egg problem trying to register the defaults and apply overrides from the loaded config.
+	// First create an empty config and set defaults.
+	defaults := server.Config{}
+	defaults.RegisterFlags(flag.NewFlagSet("empty", flag.ContinueOnError))
+	// Then apply any config values loaded as overrides to the defaults.
+	if err := mergo.Merge(&defaults, config.Server, mergo.WithOverride); err != nil {
+		level.Error(logger).Log("msg", "failed to parse configs and override defaults when configuring otlp server", "err", err)
+	}
+	// The merge won't overwrite with a zero value but in the case of ports 0 value
+	// indicates the desire for a random port so reset these to zero if the incoming config val is 0
+	if config.Server.HTTPListenPort == 0 {
+		defaults.HTTPListenPort = 0
+	}
+	if config.Server.GRPCListenPort == 0 {
+		defaults.GRPCListenPort = 0
+	}
+	// Set the config to the new combined config.
+	config.Server = defaults
+
+	if err := t.run(); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func (t *Target) run() error {
+	level.Info(t.logger).Log("msg", "starting otlp server", "job", t.jobName)
+	// To prevent metric collisions because all metrics are going to be registered in the global Prometheus registry.
+	t.config.Server.MetricsNamespace = "promtail_" + t.jobName
+
+	// We don't want the /debug and /metrics endpoints running
+	t.config.Server.RegisterInstrumentation = false
+
+	// The logger registers a metric which will cause a duplicate registry panic unless we provide an empty registry
+	// The metric created is for counting log lines and isn't likely to be missed.
+	util_log.InitLogger(&t.config.Server, prometheus.NewRegistry())
+
+	srv, err := server.New(t.config.Server)
+	if err != nil {
+		return err
+	}
+
+	t.server = srv
+	t.server.HTTP.Path(LogsPath).Methods("POST").Handler(http.HandlerFunc(t.handleHTTP))
+	t.server.GRPC.RegisterService(&logsServiceDesc, t)
+
+	go func() {
+		err := srv.Run()
+		if err != nil {
+			level.Error(t.logger).Log("msg", "otlp server shutdown with error", "err", err)
+		}
+	}()
+
+	return nil
+}
+
+// handleHTTP handles the OTLP/HTTP export requests, encoded either as protobuf or as JSON.
+func (t *Target) handleHTTP(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	contentType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || (contentType != protobufContentType && contentType != jsonContentType) {
+		http.Error(w, fmt.Sprintf("unsupported content type %q", r.Header.Get("Content-Type")), http.StatusUnsupportedMediaType)
+		return
+	}
+
+	req, err := decodeHTTPRequest(r, contentType)
+	if err != nil {
+		level.Warn(t.logger).Log("msg", "failed to decode otlp request", "err", err)
+		t.metrics.otlpErrors.Inc()
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	t.export(req)
+
+	// The ExportLogsServiceResponse is empty, so is its protobuf encoding.
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	if contentType == jsonContentType {
+		_, _ = w.Write([]byte("{}"))
+	}
+}
+
+func decodeHTTPRequest(r *http.Request, contentType string) (*otlp.ExportLogsServiceRequest, error) {
+	var body io.Reader = r.Body
+	switch r.Header.Get("Content-Encoding") {
+	case "":
+	case "gzip":
+		gz, err := gzip.NewReader(r.Body)
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+		body = gz
+	default:
+		return nil, fmt.Errorf("unsupported content encoding %q", r.Header.Get("Content-Encoding"))
+	}
+
+	buf, err := ioutil.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &otlp.ExportLogsServiceRequest{}
+	if contentType == jsonContentType {
+		err = json.Unmarshal(buf, req)
+	} else {
+		err = req.Unmarshal(buf)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// export sends the log records of an export request, with the labels of their resource.
+func (t *Target) export(req *otlp.ExportLogsServiceRequest) {
+	entries := t.handler.Chan()
+	for _, rl := range req.ResourceLogs {
+		lb := labels.NewBuilder(nil)
+		for k, v := range t.config.Labels {
+			lb.Set(string(k), string(v))
+		}
+		for _, attr := range rl.GetResource().GetAttributes() {
+			if value := otlp.ValueString(attr.Value); value != "" {
+				lb.Set("__otlp_resource_"+invalidLabelCharRE.ReplaceAllString(attr.Key, "_"), value)
+			}
+		}
+
+		// All the log records of a resource share the same labels.
+		processed := relabel.Process(lb.Labels(), t.relabelConfig...)
+		if len(processed) == 0 {
+			continue
+		}
+
+		filtered := make(model.LabelSet)
+		for _, lbl := range processed {
+			if strings.HasPrefix(lbl.Name, "__") {
+				continue
+			}
+			filtered[model.LabelName(lbl.Name)] = model.LabelValue(lbl.Value)
+		}
+
+		for _, sl := range rl.ScopeLogs {
+			for _, record := range sl.LogRecords {
+				line, err := formatLogRecord(record)
+				if err != nil {
+					level.Warn(t.logger).Log("msg", "error rendering otlp log record as JSON", "err", err)
+					t.metrics.otlpErrors.Inc()
+					continue
+				}
+
+				timestamp := time.Now()
+				if ts := recordTimestamp(record); t.config.UseIncomingTimestamp && !ts.IsZero() {
+					timestamp = ts
+				}
+
+				entries <- api.Entry{
+					Labels: filtered.Clone(),
+					Entry: logproto.Entry{
+						Timestamp: timestamp,
+						Line:      line,
+					},
+				}
+				t.metrics.otlpEntries.Inc()
+			}
+		}
+	}
+}
+
+// Type returns OTLPTargetType.
+func (t *Target) Type() target.TargetType {
+	return target.OTLPTargetType
+}
+
+// Ready indicates whether or not the OTLP target is ready to be read from.
+func (t *Target) Ready() bool {
+	return true
+}
+
+// DiscoveredLabels returns the set of labels discovered by the OTLP target, which
+// is always nil. Implements Target.
+func (t *Target) DiscoveredLabels() model.LabelSet {
+	return nil
+}
+
+// Labels returns the set of labels that statically apply to all log entries
+// produced by the OTLP target.
+func (t *Target) Labels() model.LabelSet {
+	return t.config.Labels
+}
+
+// Details returns target-specific details.
+func (t *Target) Details() interface{} {
+	return map[string]string{}
+}
+
+// Stop shuts down the OTLP target.
+func (t *Target) Stop() error {
+	level.Info(t.logger).Log("msg", "stopping otlp server", "job", t.jobName)
+	t.server.Shutdown()
+	t.handler.Stop()
+	return nil
+}
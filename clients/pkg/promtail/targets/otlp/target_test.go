@@ -0,0 +1,235 @@
+package otlp
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"flag"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/relabel"
+	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/common/server"
+	"google.golang.org/grpc"
+
+	"github.com/grafana/loki/clients/pkg/promtail/client/fake"
+	"github.com/grafana/loki/clients/pkg/promtail/scrapeconfig"
+	"github.com/grafana/loki/pkg/loghttp/push/otlp"
+)
+
+const localhost = "127.0.0.1"
+
+var testTime = time.Date(2022, 4, 15, 10, 30, 0, 123456789, time.UTC)
+
+var testLogs = &otlp.ExportLogsServiceRequest{
+	ResourceLogs: []*otlp.ResourceLogs{
+		{
+			Resource: &otlp.Resource{
+				Attributes: []*otlp.KeyValue{
+					{Key: "service.name", Value: stringValue("checkout")},
+					{Key: "k8s.namespace.name", Value: stringValue("shop")},
+				},
+			},
+			ScopeLogs: []*otlp.ScopeLogs{
+				{
+					LogRecords: []*otlp.LogRecord{
+						{
+							TimeUnixNano:   uint64(testTime.UnixNano()),
+							Body:           stringValue("order placed"),
+							SeverityText:   "INFO",
+							SeverityNumber: otlp.SEVERITY_NUMBER_INFO,
+							TraceId:        []byte{0x5b, 0x8e, 0xfe, 0xf2, 0x83, 0xc3, 0x4c, 0xa3, 0x88, 0x9a, 0x5e, 0xbc, 0x4a, 0x0d, 0x2c, 0x1f},
+							SpanId:         []byte{0xeb, 0x2c, 0x6e, 0x8b, 0x61, 0xaa, 0x43, 0x9d},
+						},
+						{
+							ObservedTimeUnixNano: uint64(testTime.UnixNano()),
+							Body:                 stringValue("payment failed"),
+							SeverityNumber:       otlp.SEVERITY_NUMBER_ERROR,
+						},
+					},
+				},
+			},
+		},
+	},
+}
+
+const testLogsJSON = `{"resourceLogs":[{"resource":{"attributes":[
+	{"key":"service.name","value":{"stringValue":"checkout"}},
+	{"key":"k8s.namespace.name","value":{"stringValue":"shop"}}
+]},"scopeLogs":[{"logRecords":[
+	{"timeUnixNano":"1650018600123456789","body":{"stringValue":"order placed"},"severityText":"INFO","severityNumber":9,
+	 "traceId":"5b8efef283c34ca3889a5ebc4a0d2c1f","spanId":"eb2c6e8b61aa439d"},
+	{"observedTimeUnixNano":"1650018600123456789","body":{"stringValue":"payment failed"},"severityNumber":17}
+]}]}]}`
+
+func freePort(t *testing.T) int {
+	// Get a randomly available port by open and closing a TCP socket
+	addr, err := net.ResolveTCPAddr("tcp", localhost+":0")
+	require.NoError(t, err)
+	l, err := net.ListenTCP("tcp", addr)
+	require.NoError(t, err)
+	port := l.Addr().(*net.TCPAddr).Port
+	require.NoError(t, l.Close())
+	return port
+}
+
+func TestOTLPTarget(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		send func(t *testing.T, httpURL, grpcAddr string)
+	}{
+		{
+			name: "http protobuf",
+			send: func(t *testing.T, httpURL, grpcAddr string) {
+				body, err := testLogs.Marshal()
+				require.NoError(t, err)
+				res, err := http.Post(httpURL, protobufContentType, bytes.NewReader(body))
+				require.NoError(t, err)
+				require.Equal(t, http.StatusOK, res.StatusCode)
+				require.NoError(t, res.Body.Close())
+			},
+		},
+		{
+			name: "http gzipped json",
+			send: func(t *testing.T, httpURL, grpcAddr string) {
+				var body bytes.Buffer
+				gz := gzip.NewWriter(&body)
+				_, err := gz.Write([]byte(testLogsJSON))
+				require.NoError(t, err)
+				require.NoError(t, gz.Close())
+
+				req, err := http.NewRequest("POST", httpURL, &body)
+				require.NoError(t, err)
+				req.Header.Set("Content-Type", jsonContentType)
+				req.Header.Set("Content-Encoding", "gzip")
+				res, err := http.DefaultClient.Do(req)
+				require.NoError(t, err)
+				require.Equal(t, http.StatusOK, res.StatusCode)
+				require.NoError(t, res.Body.Close())
+			},
+		},
+		{
+			name: "grpc",
+			send: func(t *testing.T, httpURL, grpcAddr string) {
+				conn, err := grpc.Dial(grpcAddr, grpc.WithInsecure())
+				require.NoError(t, err)
+				defer conn.Close()
+				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+				require.NoError(t, conn.Invoke(ctx, exportMethod, testLogs, &otlp.ExportLogsServiceResponse{}))
+			},
+		},
+	} {
+		for _, useIncomingTimestamp := range []bool{false, true} {
+			t.Run(tc.name+"/use_incoming_timestamp="+strconv.FormatBool(useIncomingTimestamp), func(t *testing.T) {
+				w := log.NewSyncWriter(os.Stderr)
+				logger := log.NewLogfmtLogger(w)
+
+				eh := fake.New(func() {})
+				defer eh.Stop()
+
+				httpPort, grpcPort := freePort(t), freePort(t)
+				defaults := server.Config{}
+				defaults.RegisterFlags(flag.NewFlagSet("empty", flag.ContinueOnError))
+				defaults.HTTPListenAddress = localhost
+				defaults.HTTPListenPort = httpPort
+				defaults.GRPCListenAddress = localhost
+				defaults.GRPCListenPort = grpcPort
+
+				config := &scrapeconfig.OTLPTargetConfig{
+					Server:               defaults,
+					Labels:               model.LabelSet{"job": "otlp"},
+					UseIncomingTimestamp: useIncomingTimestamp,
+				}
+				rlbl := []*relabel.Config{
+					{
+						SourceLabels: model.LabelNames{"__otlp_resource_service_name"},
+						TargetLabel:  "service",
+						Regex:        relabel.MustNewRegexp("(.*)"),
+						Replacement:  "$1",
+						Action:       relabel.Replace,
+					},
+					{
+						SourceLabels: model.LabelNames{"__otlp_resource_k8s_namespace_name"},
+						TargetLabel:  "namespace",
+						Regex:        relabel.MustNewRegexp("(.*)"),
+						Replacement:  "$1",
+						Action:       relabel.Replace,
+					},
+				}
+
+				jobName := strings.Replace(tc.name, " ", "_", -1) + "_" + strconv.FormatBool(useIncomingTimestamp)
+				tgt, err := NewTarget(NewMetrics(prometheus.NewRegistry()), logger, eh, rlbl, jobName, config)
+				require.NoError(t, err)
+				defer func() {
+					require.NoError(t, tgt.Stop())
+				}()
+
+				tc.send(t, "http://"+localhost+":"+strconv.Itoa(httpPort)+LogsPath, localhost+":"+strconv.Itoa(grpcPort))
+
+				require.Eventually(t, func() bool {
+					return len(eh.Received()) == 2
+				}, 5*time.Second, time.Millisecond)
+
+				expectedLabels := model.LabelSet{
+					"job":       "otlp",
+					"service":   "checkout",
+					"namespace": "shop",
+				}
+				received := eh.Received()
+				require.Equal(t, expectedLabels, received[0].Labels)
+				require.Equal(t, expectedLabels, received[1].Labels)
+				require.Equal(t, `{"body":"order placed","severity":"INFO","severity_number":9,"trace_id":"5b8efef283c34ca3889a5ebc4a0d2c1f","span_id":"eb2c6e8b61aa439d"}`, received[0].Line)
+				require.Equal(t, `{"body":"payment failed","severity":"ERROR","severity_number":17}`, received[1].Line)
+				if useIncomingTimestamp {
+					require.True(t, received[0].Timestamp.Equal(testTime))
+					require.True(t, received[1].Timestamp.Equal(testTime))
+				} else {
+					require.WithinDuration(t, time.Now(), received[0].Timestamp, time.Minute)
+				}
+			})
+		}
+	}
+}
+
+func TestOTLPTargetRejectsInvalidRequests(t *testing.T) {
+	logger := log.NewNopLogger()
+	eh := fake.New(func() {})
+	defer eh.Stop()
+
+	port := freePort(t)
+	defaults := server.Config{}
+	defaults.RegisterFlags(flag.NewFlagSet("empty", flag.ContinueOnError))
+	defaults.HTTPListenAddress = localhost
+	defaults.HTTPListenPort = port
+	defaults.GRPCListenAddress = localhost
+	defaults.GRPCListenPort = 0 // Not testing GRPC, a random port will be assigned
+
+	tgt, err := NewTarget(NewMetrics(prometheus.NewRegistry()), logger, eh, nil, "otlp_invalid", &scrapeconfig.OTLPTargetConfig{Server: defaults})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, tgt.Stop())
+	}()
+
+	url := "http://" + localhost + ":" + strconv.Itoa(port) + LogsPath
+	for contentType, status := range map[string]int{
+		"text/plain":        http.StatusUnsupportedMediaType,
+		jsonContentType:     http.StatusBadRequest,
+		protobufContentType: http.StatusBadRequest,
+	} {
+		res, err := http.Post(url, contentType, strings.NewReader("not a request"))
+		require.NoError(t, err)
+		require.Equal(t, status, res.StatusCode, contentType)
+		require.NoError(t, res.Body.Close())
+	}
+	require.Empty(t, eh.Received())
+}
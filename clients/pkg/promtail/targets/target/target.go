@@ -41,6 +41,15 @@ const (
 
 	// DockerTargetType is a Docker target
 	DockerTargetType = TargetType("Docker")
+
+	// HerokuDrainTargetType is a Heroku Logplex drain target
+	HerokuDrainTargetType = TargetType("HerokuDrain")
+
+	// ForwardTargetType is a Fluentd Forward protocol target
+	ForwardTargetType = TargetType("Forward")
+
+	// OTLPTargetType is an OpenTelemetry OTLP logs target
+	OTLPTargetType = TargetType("OTLP")
 )
 
 // Target is a promtail scrape target
@@ -18,6 +18,7 @@ import (
 	"github.com/grafana/loki/pkg/logcli/output"
 	"github.com/grafana/loki/pkg/logcli/query"
 	"github.com/grafana/loki/pkg/logcli/seriesquery"
+	"github.com/grafana/loki/pkg/logcli/statsquery"
 	_ "github.com/grafana/loki/pkg/util/build"
 )
 
@@ -78,6 +79,8 @@ the "instant-query" command instead.`)
 	tail       = queryCmd.Flag("tail", "Tail the logs").Short('t').Default("false").Bool()
 	follow     = queryCmd.Flag("follow", "Alias for --tail").Short('f').Default("false").Bool()
 	delayFor   = queryCmd.Flag("delay-for", "Delay in tailing by number of seconds to accumulate logs for re-ordering").Default("0").Int()
+	tailSample = queryCmd.Flag("tail-sample", "Ratio of the entries to keep when tailing, between 0 and 1. Entries are kept deterministically by stream and content.").Default("0").Float64()
+	tailRate   = queryCmd.Flag("tail-rate", "Maximum number of entries per second each ingester sends when tailing.").Default("0").Int()
 
 	instantQueryCmd = app.Command("instant-query", `Run an instant LogQL query.
 
@@ -109,6 +112,22 @@ Use the --analyze-labels flag to get a summary of the labels found in all stream
 This is helpful to find high cardinality labels.
 `)
 	seriesQuery = newSeriesQuery(seriesCmd)
+
+	statsCmd = app.Command("stats", `Run an index stats query.
+
+The "stats" command will take the provided stream selector and return
+the number of streams, chunks, entries and bytes it matches in the time window,
+which helps to estimate how expensive a query would be before running it.
+
+The stats are computed from the index and the ingesters without reading any chunk,
+so they are an approximation: chunks replicated across ingesters or spanning
+over multiple days may be accounted for more than once.
+
+Example:
+
+	logcli stats --since=24h '{job="varlogs"}'
+`)
+	statsQuery = newStatsQuery(statsCmd)
 )
 
 func main() {
@@ -187,7 +206,7 @@ func main() {
 		}
 
 		if *tail || *follow {
-			rangeQuery.TailQuery(time.Duration(*delayFor)*time.Second, queryClient, out)
+			rangeQuery.TailQuery(time.Duration(*delayFor)*time.Second, *tailSample, *tailRate, queryClient, out)
 		} else {
 			rangeQuery.DoQuery(queryClient, out, *statistics)
 		}
@@ -213,6 +232,8 @@ func main() {
 		labelsQuery.DoLabels(queryClient)
 	case seriesCmd.FullCommand():
 		seriesQuery.DoSeries(queryClient)
+	case statsCmd.FullCommand():
+		statsQuery.DoStats(queryClient)
 	}
 }
 
@@ -303,6 +324,33 @@ func newSeriesQuery(cmd *kingpin.CmdClause) *seriesquery.SeriesQuery {
 	return q
 }
 
+func newStatsQuery(cmd *kingpin.CmdClause) *statsquery.StatsQuery {
+	// calculate stats range from cli params
+	var from, to string
+	var since time.Duration
+
+	q := &statsquery.StatsQuery{}
+
+	// executed after all command flags are parsed
+	cmd.Action(func(c *kingpin.ParseContext) error {
+
+		defaultEnd := time.Now()
+		defaultStart := defaultEnd.Add(-since)
+
+		q.Start = mustParse(from, defaultStart)
+		q.End = mustParse(to, defaultEnd)
+		q.Quiet = *quiet
+		return nil
+	})
+
+	cmd.Arg("query", "eg '{foo=\"bar\",baz=~\".*blip\"}'").Required().StringVar(&q.QueryString)
+	cmd.Flag("since", "Lookback window.").Default("1h").DurationVar(&since)
+	cmd.Flag("from", "Start looking for logs at this absolute time (inclusive)").StringVar(&from)
+	cmd.Flag("to", "Stop looking for logs at this absolute time (exclusive)").StringVar(&to)
+
+	return q
+}
+
 func newQuery(instant bool, cmd *kingpin.CmdClause) *query.Query {
 	// calculate query range from cli params
 	var now, from, to string
@@ -0,0 +1,135 @@
+package chunkenc
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+	"sync"
+
+	"github.com/prometheus/prometheus/model/labels"
+
+	"github.com/grafana/loki/pkg/logproto"
+	"github.com/grafana/loki/pkg/logql/log"
+)
+
+const (
+	// The lines are tokenized into all their n-grams of bloomNGramLength bytes, so that
+	// the bloom filter of a block can tell whether any substring of at least that length
+	// may be found in its lines.
+	bloomNGramLength = 4
+	// With 10 bits per token and 7 hash functions, the false positive rate is about 1%.
+	bloomBitsPerToken = 10
+	bloomHashes       = 7
+
+	// bloomFiltersFormatV1 is the format of the section holding the bloom filters of the blocks,
+	// written after the metas of the blocks.
+	bloomFiltersFormatV1 = byte(1)
+)
+
+var bloomTokensPool = sync.Pool{
+	New: func() interface{} {
+		return map[uint32]struct{}{}
+	},
+}
+
+func putBloomTokens(tokens map[uint32]struct{}) {
+	for token := range tokens {
+		delete(tokens, token)
+	}
+	bloomTokensPool.Put(tokens)
+}
+
+// bloomFilter tells whether a substring may be found in the lines of a block.
+type bloomFilter struct {
+	bits []byte
+}
+
+// newBloomFilter builds the bloom filter of the given n-grams.
+func newBloomFilter(tokens map[uint32]struct{}) *bloomFilter {
+	size := (len(tokens)*bloomBitsPerToken + 7) / 8
+	if size < 8 {
+		size = 8
+	}
+	f := &bloomFilter{bits: make([]byte, size)}
+	for token := range tokens {
+		f.add(token)
+	}
+	return f
+}
+
+func (f *bloomFilter) add(token uint32) {
+	h1, h2 := bloomHash(token)
+	m := uint32(len(f.bits) * 8)
+	for i := uint32(0); i < bloomHashes; i++ {
+		bit := (h1 + i*h2) % m
+		f.bits[bit/8] |= 1 << (bit % 8)
+	}
+}
+
+func (f *bloomFilter) test(token uint32) bool {
+	h1, h2 := bloomHash(token)
+	m := uint32(len(f.bits) * 8)
+	for i := uint32(0); i < bloomHashes; i++ {
+		bit := (h1 + i*h2) % m
+		if f.bits[bit/8]&(1<<(bit%8)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// mayContain returns false if none of the lines contains the substring.
+// Substrings shorter than an n-gram can't be tested and may always be contained.
+func (f *bloomFilter) mayContain(substring string) bool {
+	for i := 0; i+bloomNGramLength <= len(substring); i++ {
+		if !f.test(bloomToken(substring[i:])) {
+			return false
+		}
+	}
+	return true
+}
+
+// headBloomFilter builds the bloom filter of the lines of the head block.
+func headBloomFilter(head HeadBlock) *bloomFilter {
+	tokens := bloomTokensPool.Get().(map[uint32]struct{})
+	defer putBloomTokens(tokens)
+
+	it := head.Iterator(context.Background(), logproto.FORWARD, math.MinInt64, math.MaxInt64, log.NewNoopPipeline().ForStream(labels.Labels{}))
+	defer it.Close()
+	for it.Next() {
+		bloomTokens(tokens, it.Entry().Line)
+	}
+	return newBloomFilter(tokens)
+}
+
+// bloomTokens adds the n-grams of the line to the tokens.
+func bloomTokens(tokens map[uint32]struct{}, line string) {
+	for i := 0; i+bloomNGramLength <= len(line); i++ {
+		tokens[bloomToken(line[i:])] = struct{}{}
+	}
+}
+
+// bloomToken returns the n-gram at the start of s.
+func bloomToken(s string) uint32 {
+	return uint32(s[0]) | uint32(s[1])<<8 | uint32(s[2])<<16 | uint32(s[3])<<24
+}
+
+// bloomHash returns the two hashes used to derive the bits of the token, using the
+// finalizer of MurmurHash3 to mix the bytes of the n-gram.
+func bloomHash(token uint32) (uint32, uint32) {
+	h := uint64(token)
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return uint32(h), uint32(h>>32) | 1
+}
+
+// size returns the number of bytes used to encode the bloom filter.
+func (f *bloomFilter) size() int {
+	if f == nil {
+		return binary.MaxVarintLen32
+	}
+	return binary.MaxVarintLen32 + len(f.bits)
+}
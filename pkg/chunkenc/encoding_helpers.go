@@ -15,7 +15,8 @@ type encbuf struct {
 func (e *encbuf) reset()      { e.b = e.b[:0] }
 func (e *encbuf) get() []byte { return e.b }
 
-func (e *encbuf) putByte(c byte) { e.b = append(e.b, c) }
+func (e *encbuf) putByte(c byte)    { e.b = append(e.b, c) }
+func (e *encbuf) putBytes(b []byte) { e.b = append(e.b, b...) }
 
 func (e *encbuf) putBE64int(x int) { e.putBE64(uint64(x)) }
 func (e *encbuf) putUvarint(x int) { e.putUvarint64(uint64(x)) }
@@ -40,6 +41,11 @@ func (e *encbuf) putVarint64(x int64) {
 	e.b = append(e.b, e.c[:n]...)
 }
 
+func (e *encbuf) putUvarintStr(s string) {
+	e.putUvarint(len(s))
+	e.b = append(e.b, s...)
+}
+
 // putHash appends a hash over the buffers current contents to the buffer.
 func (e *encbuf) putHash(h hash.Hash) {
 	h.Reset()
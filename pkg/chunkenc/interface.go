@@ -22,6 +22,9 @@ var (
 	ErrInvalidSize     = errors.New("invalid size")
 	ErrInvalidFlag     = errors.New("invalid flag")
 	ErrInvalidChecksum = errors.New("invalid chunk checksum")
+	// ErrHeadBlockFmtNotSupported is returned when appending an entry with structured metadata to a chunk
+	// whose blocks were cut without it. A new chunk has to be cut.
+	ErrHeadBlockFmtNotSupported = errors.New("head block format not supported by the chunk format")
 )
 
 func IsOutOfOrderErr(err error) bool {
@@ -145,4 +148,7 @@ type Block interface {
 	Iterator(ctx context.Context, pipeline log.StreamPipeline) iter.EntryIterator
 	// SampleIterator returns a sample iterator for the block.
 	SampleIterator(ctx context.Context, extractor log.StreamSampleExtractor) iter.SampleIterator
+	// MayContain returns false if the bloom filter of the block tells that none of its lines
+	// can contain all the substrings. It always returns true if the block has no bloom filter.
+	MayContain(substrings []string) bool
 }
@@ -32,6 +32,8 @@ const (
 	chunkFormatV1
 	chunkFormatV2
 	chunkFormatV3
+	// chunkFormatV4 blocks store the structured metadata of each entry after its line.
+	chunkFormatV4
 
 	DefaultChunkFormat = chunkFormatV3 // the currently used chunk format
 
@@ -44,7 +46,7 @@ const (
 	defaultBlockSize = 256 * 1024
 )
 
-var HeadBlockFmts = []HeadBlockFmt{OrderedHeadBlockFmt, UnorderedHeadBlockFmt}
+var HeadBlockFmts = []HeadBlockFmt{OrderedHeadBlockFmt, UnorderedHeadBlockFmt, UnorderedWithStructuredMetadataHeadBlockFmt}
 
 type HeadBlockFmt byte
 
@@ -56,6 +58,8 @@ func (f HeadBlockFmt) String() string {
 		return "ordered"
 	case f == UnorderedHeadBlockFmt:
 		return "unordered"
+	case f == UnorderedWithStructuredMetadataHeadBlockFmt:
+		return "unordered with structured metadata"
 	default:
 		return fmt.Sprintf("unknown: %v", byte(f))
 	}
@@ -66,7 +70,7 @@ func (f HeadBlockFmt) NewBlock() HeadBlock {
 	case f < UnorderedHeadBlockFmt:
 		return &headBlock{}
 	default:
-		return newUnorderedHeadBlock()
+		return newUnorderedHeadBlock(f)
 	}
 }
 
@@ -78,6 +82,7 @@ const (
 	_
 	OrderedHeadBlockFmt
 	UnorderedHeadBlockFmt
+	UnorderedWithStructuredMetadataHeadBlockFmt
 )
 
 var magicNumber = uint32(0x12EE56A)
@@ -116,6 +121,9 @@ type MemChunk struct {
 	format   byte
 	encoding Encoding
 	headFmt  HeadBlockFmt
+
+	// bloomFilters tells if a bloom filter of the lines is built for the blocks cut.
+	bloomFilters bool
 }
 
 type block struct {
@@ -127,6 +135,8 @@ type block struct {
 
 	offset           int // The offset of the block in the chunk.
 	uncompressedSize int // Total uncompressed size in bytes when the chunk is cut.
+
+	bloom *bloomFilter // The bloom filter of the lines, nil if the block has none.
 }
 
 // This block holds the un-compressed entries. Once it has enough data, this is
@@ -160,7 +170,9 @@ func (hb *headBlock) Reset() {
 
 func (hb *headBlock) Bounds() (int64, int64) { return hb.mint, hb.maxt }
 
-func (hb *headBlock) Append(ts int64, line string) error {
+// Append appends an entry to the head block.
+// The ordered head block doesn't keep the structured metadata of the entries.
+func (hb *headBlock) Append(ts int64, line string, _ labels.Labels) error {
 	if !hb.IsEmpty() && hb.maxt > ts {
 		return ErrOutOfOrder
 	}
@@ -175,7 +187,7 @@ func (hb *headBlock) Append(ts int64, line string) error {
 	return nil
 }
 
-func (hb *headBlock) Serialise(pool WriterPool) ([]byte, error) {
+func (hb *headBlock) Serialise(pool WriterPool, format byte) ([]byte, error) {
 	inBuf := serializeBytesBufferPool.Get().(*bytes.Buffer)
 	defer func() {
 		inBuf.Reset()
@@ -187,13 +199,7 @@ func (hb *headBlock) Serialise(pool WriterPool) ([]byte, error) {
 	compressedWriter := pool.GetWriter(outBuf)
 	defer pool.PutWriter(compressedWriter)
 	for _, logEntry := range hb.entries {
-		n := binary.PutVarint(encBuf, logEntry.t)
-		inBuf.Write(encBuf[:n])
-
-		n = binary.PutUvarint(encBuf, uint64(len(logEntry.s)))
-		inBuf.Write(encBuf[:n])
-
-		inBuf.WriteString(logEntry.s)
+		serialiseEntry(inBuf, encBuf, format, logEntry.t, logEntry.s, nil)
 	}
 
 	if _, err := compressedWriter.Write(inBuf.Bytes()); err != nil {
@@ -316,10 +322,10 @@ func (hb *headBlock) Convert(version HeadBlockFmt) (HeadBlock, error) {
 	if version < UnorderedHeadBlockFmt {
 		return hb, nil
 	}
-	out := newUnorderedHeadBlock()
+	out := version.NewBlock()
 
 	for _, e := range hb.entries {
-		if err := out.Append(e.t, e.s); err != nil {
+		if err := out.Append(e.t, e.s, nil); err != nil {
 			return nil, err
 		}
 	}
@@ -331,7 +337,45 @@ type entry struct {
 	s string
 }
 
+// serialiseEntry writes an entry to the uncompressed bytes of a block: its timestamp and line,
+// followed by its structured metadata for the chunk formats supporting it.
+func serialiseEntry(buf *bytes.Buffer, encBuf []byte, format byte, ts int64, line string, structuredMetadata labels.Labels) {
+	n := binary.PutVarint(encBuf, ts)
+	buf.Write(encBuf[:n])
+
+	n = binary.PutUvarint(encBuf, uint64(len(line)))
+	buf.Write(encBuf[:n])
+
+	buf.WriteString(line)
+
+	if format < chunkFormatV4 {
+		return
+	}
+	n = binary.PutUvarint(encBuf, uint64(len(structuredMetadata)))
+	buf.Write(encBuf[:n])
+	for _, l := range structuredMetadata {
+		n = binary.PutUvarint(encBuf, uint64(len(l.Name)))
+		buf.Write(encBuf[:n])
+		buf.WriteString(l.Name)
+
+		n = binary.PutUvarint(encBuf, uint64(len(l.Value)))
+		buf.Write(encBuf[:n])
+		buf.WriteString(l.Value)
+	}
+}
+
+// structuredMetadataSize returns the number of bytes of the names and values of the structured metadata.
+func structuredMetadataSize(structuredMetadata labels.Labels) int {
+	size := 0
+	for _, l := range structuredMetadata {
+		size += len(l.Name) + len(l.Value)
+	}
+	return size
+}
+
 // NewMemChunk returns a new in-mem chunk.
+// The chunk is only upgraded to the format v4 once an entry with structured metadata is appended,
+// so that the chunks without any remain readable by the older versions.
 func NewMemChunk(enc Encoding, head HeadBlockFmt, blockSize, targetSize int) *MemChunk {
 	return &MemChunk{
 		blockSize:  blockSize,  // The blockSize in bytes.
@@ -346,6 +390,22 @@ func NewMemChunk(enc Encoding, head HeadBlockFmt, blockSize, targetSize int) *Me
 	}
 }
 
+// EnableBloomFilters builds a bloom filter of the lines of the blocks cut from now on,
+// so that the queries can skip the blocks which can't match their line filters.
+func (c *MemChunk) EnableBloomFilters() {
+	c.bloomFilters = true
+}
+
+// hasBloomFilters returns true if any block of the chunk has a bloom filter.
+func (c *MemChunk) hasBloomFilters() bool {
+	for _, b := range c.blocks {
+		if b.bloom != nil {
+			return true
+		}
+	}
+	return false
+}
+
 // NewByteChunk returns a MemChunk on the passed bytes.
 func NewByteChunk(b []byte, blockSize, targetSize int) (*MemChunk, error) {
 	bc := &MemChunk{
@@ -367,7 +427,7 @@ func NewByteChunk(b []byte, blockSize, targetSize int) (*MemChunk, error) {
 	switch version {
 	case chunkFormatV1:
 		bc.encoding = EncGZIP
-	case chunkFormatV2, chunkFormatV3:
+	case chunkFormatV2, chunkFormatV3, chunkFormatV4:
 		// format v2+ has a byte for block encoding.
 		enc := Encoding(db.byte())
 		if db.err() != nil {
@@ -390,6 +450,8 @@ func NewByteChunk(b []byte, blockSize, targetSize int) (*MemChunk, error) {
 	// Read the number of blocks.
 	num := db.uvarint()
 	bc.blocks = make([]block, 0, num)
+	// The index of the valid blocks, to match them with their bloom filter.
+	valid := make([]int, 0, num)
 
 	for i := 0; i < num; i++ {
 		var blk block
@@ -402,7 +464,7 @@ func NewByteChunk(b []byte, blockSize, targetSize int) (*MemChunk, error) {
 
 		// Read offset and length.
 		blk.offset = db.uvarint()
-		if version == chunkFormatV3 {
+		if version >= chunkFormatV3 {
 			blk.uncompressedSize = db.uvarint()
 		}
 		l := db.uvarint()
@@ -416,6 +478,7 @@ func NewByteChunk(b []byte, blockSize, targetSize int) (*MemChunk, error) {
 		}
 
 		bc.blocks = append(bc.blocks, blk)
+		valid = append(valid, i)
 
 		// Update the counter used to track the size of cut blocks.
 		bc.cutBlockSize += len(blk.b)
@@ -425,6 +488,27 @@ func NewByteChunk(b []byte, blockSize, targetSize int) (*MemChunk, error) {
 		}
 	}
 
+	// The bloom filters of the blocks are optional and written after their metas,
+	// where they are ignored by the readers which don't know about them.
+	if len(db.b) > 0 {
+		if f := db.byte(); f != bloomFiltersFormatV1 {
+			return nil, errors.Errorf("invalid bloom filters format %d", f)
+		}
+		blooms := make([]*bloomFilter, num)
+		for i := 0; i < num; i++ {
+			if l := db.uvarint(); l > 0 {
+				blooms[i] = &bloomFilter{bits: db.bytes(l)}
+			}
+		}
+		if db.err() != nil {
+			return nil, errors.Wrap(db.err(), "decoding bloom filters")
+		}
+		for i, j := range valid {
+			bc.blocks[i].bloom = blooms[j]
+		}
+		bc.bloomFilters = true
+	}
+
 	return bc, nil
 }
 
@@ -461,12 +545,19 @@ func (c *MemChunk) BytesSize() int {
 		size += binary.MaxVarintLen64 // mint
 		size += binary.MaxVarintLen64 // maxt
 		size += binary.MaxVarintLen32 // offset
-		if c.format == chunkFormatV3 {
+		if c.format >= chunkFormatV3 {
 			size += binary.MaxVarintLen32 // uncompressed size
 		}
 		size += binary.MaxVarintLen32 // len(b)
 	}
 
+	if c.hasBloomFilters() {
+		size++ // bloom filters format
+		for _, b := range c.blocks {
+			size += b.bloom.size()
+		}
+	}
+
 	// blockmeta
 	size += binary.MaxVarintLen32 // len  blocks
 
@@ -535,11 +626,24 @@ func (c *MemChunk) WriteTo(w io.Writer) (int64, error) {
 		eb.putVarint64(b.mint)
 		eb.putVarint64(b.maxt)
 		eb.putUvarint(b.offset)
-		if c.format == chunkFormatV3 {
+		if c.format >= chunkFormatV3 {
 			eb.putUvarint(b.uncompressedSize)
 		}
 		eb.putUvarint(len(b.b))
 	}
+
+	// Write the bloom filters.
+	if c.hasBloomFilters() {
+		eb.putByte(bloomFiltersFormatV1)
+		for _, b := range c.blocks {
+			if b.bloom == nil {
+				eb.putUvarint(0)
+				continue
+			}
+			eb.putUvarint(len(b.bloom.bits))
+			eb.putBytes(b.bloom.bits)
+		}
+	}
 	eb.putHash(crc32Hash)
 
 	n, err = w.Write(eb.get())
@@ -596,7 +700,11 @@ func MemchunkFromCheckpoint(chk, head []byte, desired HeadBlockFmt, blockSize in
 	}
 
 	mc.head = h
-	mc.headFmt = desired
+	mc.headFmt = h.Format()
+	// the blocks of the chunk may not support the desired head block format.
+	if err := mc.ConvertHead(desired); err != nil {
+		return nil, err
+	}
 	return mc, nil
 }
 
@@ -624,6 +732,9 @@ func (c *MemChunk) BlockCount() int {
 
 // SpaceFor implements Chunk.
 func (c *MemChunk) SpaceFor(e *logproto.Entry) bool {
+	if !c.canStoreStructuredMetadata(e) {
+		return false
+	}
 	if c.targetSize > 0 {
 		// This is looking to see if the uncompressed lines will fit which is not
 		// a great check, but it will guarantee we are always under the target size
@@ -675,9 +786,16 @@ func (c *MemChunk) Append(entry *logproto.Entry) error {
 		return ErrOutOfOrder
 	}
 
-	if err := c.head.Append(entryTimestamp, entry.Line); err != nil {
+	if !c.canStoreStructuredMetadata(entry) {
+		return ErrHeadBlockFmtNotSupported
+	}
+
+	if err := c.head.Append(entryTimestamp, entry.Line, logproto.FromLabelAdaptersToLabels(entry.StructuredMetadata)); err != nil {
 		return err
 	}
+	if len(entry.StructuredMetadata) > 0 && c.headFmt >= UnorderedWithStructuredMetadataHeadBlockFmt {
+		c.format = chunkFormatV4
+	}
 
 	if c.head.UncompressedSize() >= c.blockSize {
 		return c.cut()
@@ -722,6 +840,16 @@ func (c *MemChunk) reorder() error {
 	return nil
 }
 
+// canStoreStructuredMetadata returns false if the head block keeps the structured metadata of the entry
+// while the blocks of the chunk were already cut in a format without it.
+func (c *MemChunk) canStoreStructuredMetadata(e *logproto.Entry) bool {
+	if len(e.StructuredMetadata) == 0 || c.headFmt < UnorderedWithStructuredMetadataHeadBlockFmt {
+		return true
+	}
+	return c.format >= chunkFormatV4 || len(c.blocks) == 0
+}
+
+// ConvertHead converts the head block of the chunk to the desired format.
 func (c *MemChunk) ConvertHead(desired HeadBlockFmt) error {
 	if c.head != nil && c.head.Format() != desired {
 		newH, err := c.head.Convert(desired)
@@ -741,11 +869,16 @@ func (c *MemChunk) cut() error {
 		return nil
 	}
 
-	b, err := c.head.Serialise(getWriterPool(c.encoding))
+	b, err := c.head.Serialise(getWriterPool(c.encoding), c.format)
 	if err != nil {
 		return err
 	}
 
+	var bloom *bloomFilter
+	if c.bloomFilters {
+		bloom = headBloomFilter(c.head)
+	}
+
 	mint, maxt := c.head.Bounds()
 	c.blocks = append(c.blocks, block{
 		b:                b,
@@ -753,6 +886,7 @@ func (c *MemChunk) cut() error {
 		mint:             mint,
 		maxt:             maxt,
 		uncompressedSize: c.head.UncompressedSize(),
+		bloom:            bloom,
 	})
 
 	c.cutBlockSize += len(b)
@@ -798,7 +932,7 @@ func (c *MemChunk) Iterator(ctx context.Context, mintT, maxtT time.Time, directi
 		}
 		lastMax = b.maxt
 
-		blockItrs = append(blockItrs, encBlock{c.encoding, b}.Iterator(ctx, pipeline))
+		blockItrs = append(blockItrs, encBlock{c.encoding, c.format, b}.Iterator(ctx, pipeline))
 	}
 
 	if !c.head.IsEmpty() {
@@ -872,7 +1006,7 @@ func (c *MemChunk) SampleIterator(ctx context.Context, from, through time.Time,
 			ordered = false
 		}
 		lastMax = b.maxt
-		its = append(its, encBlock{c.encoding, b}.SampleIterator(ctx, extractor))
+		its = append(its, encBlock{c.encoding, c.format, b}.SampleIterator(ctx, extractor))
 	}
 
 	if !c.head.IsEmpty() {
@@ -904,7 +1038,7 @@ func (c *MemChunk) Blocks(mintT, maxtT time.Time) []Block {
 
 	for _, b := range c.blocks {
 		if maxt >= b.mint && b.maxt >= mint {
-			blocks = append(blocks, encBlock{c.encoding, b})
+			blocks = append(blocks, encBlock{c.encoding, c.format, b})
 		}
 	}
 	return blocks
@@ -918,18 +1052,26 @@ func (c *MemChunk) Rebound(start, end time.Time, filter filter.Func) (Chunk, err
 		return nil, err
 	}
 
+	headFmt := c.headFmt
+	if c.format >= chunkFormatV4 {
+		// chunks read from bytes don't know their head block format, keep the structured metadata of their entries.
+		headFmt = UnorderedWithStructuredMetadataHeadBlockFmt
+	}
+
 	var newChunk *MemChunk
 	// as close as possible, respect the block/target sizes specified. However,
 	// if the blockSize is not set, use reasonable defaults.
 	if c.blockSize > 0 {
-		newChunk = NewMemChunk(c.Encoding(), c.headFmt, c.blockSize, c.targetSize)
+		newChunk = NewMemChunk(c.Encoding(), headFmt, c.blockSize, c.targetSize)
 	} else {
 		// Using defaultBlockSize for target block size.
 		// The alternative here could be going over all the blocks and using the size of the largest block as target block size but I(Sandeep) feel that it is not worth the complexity.
 		// For target chunk size I am using compressed size of original chunk since the newChunk should anyways be lower in size than that.
-		newChunk = NewMemChunk(c.Encoding(), c.headFmt, defaultBlockSize, c.CompressedSize())
+		newChunk = NewMemChunk(c.Encoding(), headFmt, defaultBlockSize, c.CompressedSize())
 	}
 
+	newChunk.bloomFilters = c.bloomFilters
+
 	for itr.Next() {
 		entry := itr.Entry()
 		if filter != nil && filter(entry.Line) {
@@ -956,7 +1098,8 @@ func (c *MemChunk) Rebound(start, end time.Time, filter filter.Func) (Chunk, err
 // then allows us to bind a decoding context to a block when requested, but otherwise helps reduce the
 // chances of chunk<>block encoding drift in the codebase as the latter is parameterized by the former.
 type encBlock struct {
-	enc Encoding
+	enc    Encoding
+	format byte
 	block
 }
 
@@ -964,14 +1107,27 @@ func (b encBlock) Iterator(ctx context.Context, pipeline log.StreamPipeline) ite
 	if len(b.b) == 0 {
 		return iter.NoopIterator
 	}
-	return newEntryIterator(ctx, getReaderPool(b.enc), b.b, pipeline)
+	return newEntryIterator(ctx, getReaderPool(b.enc), b.b, b.format, pipeline)
 }
 
 func (b encBlock) SampleIterator(ctx context.Context, extractor log.StreamSampleExtractor) iter.SampleIterator {
 	if len(b.b) == 0 {
 		return iter.NoopIterator
 	}
-	return newSampleIterator(ctx, getReaderPool(b.enc), b.b, extractor)
+	return newSampleIterator(ctx, getReaderPool(b.enc), b.b, b.format, extractor)
+}
+
+// MayContain implements Block.
+func (b block) MayContain(substrings []string) bool {
+	if b.bloom == nil {
+		return true
+	}
+	for _, s := range substrings {
+		if !b.bloom.mayContain(s) {
+			return false
+		}
+	}
+	return true
 }
 
 func (b block) Offset() int {
@@ -1111,6 +1267,7 @@ func unsafeGetBytes(s string) []byte {
 
 type bufferedIterator struct {
 	origBytes []byte
+	format    byte
 	stats     *stats.Context
 
 	bufReader *bufio.Reader
@@ -1123,15 +1280,19 @@ type bufferedIterator struct {
 	currLine []byte // the current line, this is the same as the buffer but sliced the the line size.
 	currTs   int64
 
+	currStructuredMetadata labels.Labels // the structured metadata of the current entry, for chunk format v4+.
+	structuredMetadataBuf  []byte        // The buffer for reading the structured metadata names and values.
+
 	closed bool
 }
 
-func newBufferedIterator(ctx context.Context, pool ReaderPool, b []byte) *bufferedIterator {
+func newBufferedIterator(ctx context.Context, pool ReaderPool, b []byte, format byte) *bufferedIterator {
 	stats := stats.FromContext(ctx)
 	stats.AddCompressedBytes(int64(len(b)))
 	return &bufferedIterator{
 		stats:     stats,
 		origBytes: b,
+		format:    format,
 		reader:    nil, // will be initialized later
 		bufReader: nil, // will be initialized later
 		pool:      pool,
@@ -1160,6 +1321,17 @@ func (si *bufferedIterator) Next() bool {
 
 	si.currTs = ts
 	si.currLine = line
+
+	if si.format < chunkFormatV4 {
+		return true
+	}
+	structuredMetadata, ok := si.moveNextStructuredMetadata()
+	if !ok {
+		si.Close()
+		return false
+	}
+	si.stats.AddDecompressedBytes(int64(structuredMetadataSize(structuredMetadata)))
+	si.currStructuredMetadata = structuredMetadata
 	return true
 }
 
@@ -1215,6 +1387,52 @@ func (si *bufferedIterator) moveNext() (int64, []byte, bool) {
 	return ts, si.buf[:lineSize], true
 }
 
+// moveNextStructuredMetadata reads the structured metadata following the line of the current entry.
+// The labels are allocated since they can outlive the current entry.
+func (si *bufferedIterator) moveNextStructuredMetadata() (labels.Labels, bool) {
+	n, err := binary.ReadUvarint(si.bufReader)
+	if err != nil {
+		si.err = errors.Wrap(err, "reading structured metadata count")
+		return nil, false
+	}
+	if n == 0 {
+		return nil, true
+	}
+	res := make(labels.Labels, 0, n)
+	for i := uint64(0); i < n; i++ {
+		name, err := si.readString()
+		if err != nil {
+			si.err = errors.Wrap(err, "reading structured metadata name")
+			return nil, false
+		}
+		value, err := si.readString()
+		if err != nil {
+			si.err = errors.Wrap(err, "reading structured metadata value")
+			return nil, false
+		}
+		res = append(res, labels.Label{Name: name, Value: value})
+	}
+	return res, true
+}
+
+func (si *bufferedIterator) readString() (string, error) {
+	l, err := binary.ReadUvarint(si.bufReader)
+	if err != nil {
+		return "", err
+	}
+	if l >= maxLineLength {
+		return "", fmt.Errorf("structured metadata too long %d, maximum %d", l, maxLineLength)
+	}
+	if uint64(cap(si.structuredMetadataBuf)) < l {
+		si.structuredMetadataBuf = make([]byte, l)
+	}
+	b := si.structuredMetadataBuf[:l]
+	if _, err := io.ReadFull(si.bufReader, b); err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
 func (si *bufferedIterator) Error() error { return si.err }
 
 func (si *bufferedIterator) Close() error {
@@ -1242,9 +1460,9 @@ func (si *bufferedIterator) close() {
 	si.origBytes = nil
 }
 
-func newEntryIterator(ctx context.Context, pool ReaderPool, b []byte, pipeline log.StreamPipeline) iter.EntryIterator {
+func newEntryIterator(ctx context.Context, pool ReaderPool, b []byte, format byte, pipeline log.StreamPipeline) iter.EntryIterator {
 	return &entryBufferedIterator{
-		bufferedIterator: newBufferedIterator(ctx, pool, b),
+		bufferedIterator: newBufferedIterator(ctx, pool, b, format),
 		pipeline:         pipeline,
 	}
 }
@@ -1267,21 +1485,22 @@ func (e *entryBufferedIterator) StreamHash() uint64 { return e.pipeline.BaseLabe
 
 func (e *entryBufferedIterator) Next() bool {
 	for e.bufferedIterator.Next() {
-		newLine, lbs, ok := e.pipeline.Process(e.currTs, e.currLine)
+		newLine, lbs, ok := e.pipeline.Process(e.currTs, e.currLine, e.currStructuredMetadata...)
 		if !ok {
 			continue
 		}
 		e.cur.Timestamp = time.Unix(0, e.currTs)
 		e.cur.Line = string(newLine)
+		e.cur.StructuredMetadata = logproto.FromLabelsToLabelAdapters(e.currStructuredMetadata)
 		e.currLabels = lbs
 		return true
 	}
 	return false
 }
 
-func newSampleIterator(ctx context.Context, pool ReaderPool, b []byte, extractor log.StreamSampleExtractor) iter.SampleIterator {
+func newSampleIterator(ctx context.Context, pool ReaderPool, b []byte, format byte, extractor log.StreamSampleExtractor) iter.SampleIterator {
 	it := &sampleBufferedIterator{
-		bufferedIterator: newBufferedIterator(ctx, pool, b),
+		bufferedIterator: newBufferedIterator(ctx, pool, b, format),
 		extractor:        extractor,
 	}
 	return it
@@ -1298,7 +1517,7 @@ type sampleBufferedIterator struct {
 
 func (e *sampleBufferedIterator) Next() bool {
 	for e.bufferedIterator.Next() {
-		val, labels, ok := e.extractor.Process(e.currTs, e.currLine)
+		val, labels, ok := e.extractor.Process(e.currTs, e.currLine, e.currStructuredMetadata...)
 		if !ok {
 			continue
 		}
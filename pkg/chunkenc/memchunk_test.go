@@ -677,10 +677,10 @@ func BenchmarkWrite(b *testing.B) {
 type nomatchPipeline struct{}
 
 func (nomatchPipeline) BaseLabels() log.LabelsResult { return log.EmptyLabelsResult }
-func (nomatchPipeline) Process(_ int64, line []byte) ([]byte, log.LabelsResult, bool) {
+func (nomatchPipeline) Process(_ int64, line []byte, _ ...labels.Label) ([]byte, log.LabelsResult, bool) {
 	return line, nil, false
 }
-func (nomatchPipeline) ProcessString(_ int64, line string) (string, log.LabelsResult, bool) {
+func (nomatchPipeline) ProcessString(_ int64, line string, _ ...labels.Label) (string, log.LabelsResult, bool) {
 	return line, nil, false
 }
 
@@ -809,7 +809,7 @@ func BenchmarkHeadBlockIterator(b *testing.B) {
 			h := headBlock{}
 
 			for i := 0; i < j; i++ {
-				if err := h.Append(int64(i), "this is the append string"); err != nil {
+				if err := h.Append(int64(i), "this is the append string", nil); err != nil {
 					b.Fatal(err)
 				}
 			}
@@ -833,7 +833,7 @@ func BenchmarkHeadBlockSampleIterator(b *testing.B) {
 			h := headBlock{}
 
 			for i := 0; i < j; i++ {
-				if err := h.Append(int64(i), "this is the append string"); err != nil {
+				if err := h.Append(int64(i), "this is the append string", nil); err != nil {
 					b.Fatal(err)
 				}
 			}
@@ -1325,3 +1325,186 @@ func buildFilterableTestMemChunk(t *testing.T, from, through time.Time, matching
 
 	return chk
 }
+
+func TestMemChunk_StructuredMetadata(t *testing.T) {
+	entries := []logproto.Entry{
+		{Timestamp: time.Unix(0, 1), Line: "lineA", StructuredMetadata: []logproto.LabelAdapter{{Name: "traceID", Value: "123"}, {Name: "user", Value: "a"}}},
+		{Timestamp: time.Unix(0, 2), Line: "lineB"},
+		{Timestamp: time.Unix(0, 3), Line: "lineC", StructuredMetadata: []logproto.LabelAdapter{{Name: "traceID", Value: "456"}, {Name: "app", Value: "bar"}}},
+	}
+	streamLabels := labels.Labels{{Name: "app", Value: "foo"}}
+
+	for _, tc := range []struct {
+		name     string
+		headFmt  HeadBlockFmt
+		cut      bool
+		expected []string // expected labels of each entry
+	}{
+		{
+			name:     "head block",
+			headFmt:  UnorderedWithStructuredMetadataHeadBlockFmt,
+			expected: []string{`{app="foo", traceID="123", user="a"}`, `{app="foo"}`, `{app="foo", app_extracted="bar", traceID="456"}`},
+		},
+		{
+			name:     "blocks",
+			headFmt:  UnorderedWithStructuredMetadataHeadBlockFmt,
+			cut:      true,
+			expected: []string{`{app="foo", traceID="123", user="a"}`, `{app="foo"}`, `{app="foo", app_extracted="bar", traceID="456"}`},
+		},
+		{
+			name:     "head block without structured metadata",
+			headFmt:  UnorderedHeadBlockFmt,
+			expected: []string{`{app="foo"}`, `{app="foo"}`, `{app="foo"}`},
+		},
+		{
+			name:     "blocks without structured metadata",
+			headFmt:  UnorderedHeadBlockFmt,
+			cut:      true,
+			expected: []string{`{app="foo"}`, `{app="foo"}`, `{app="foo"}`},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var chk Chunk = NewMemChunk(EncSnappy, tc.headFmt, testBlockSize, testTargetSize)
+			for i := range entries {
+				require.NoError(t, chk.Append(&entries[i]))
+			}
+			if tc.cut {
+				require.NoError(t, chk.Close())
+				b, err := chk.Bytes()
+				require.NoError(t, err)
+				chk, err = NewByteChunk(b, testBlockSize, testTargetSize)
+				require.NoError(t, err)
+			}
+
+			it, err := chk.Iterator(context.Background(), time.Unix(0, 0), time.Unix(0, 4), logproto.FORWARD, log.NewNoopPipeline().ForStream(streamLabels))
+			require.NoError(t, err)
+			var i int
+			for ; it.Next(); i++ {
+				require.Equal(t, tc.expected[i], it.Labels())
+				require.Equal(t, entries[i].Line, it.Entry().Line)
+				if tc.headFmt == UnorderedWithStructuredMetadataHeadBlockFmt {
+					require.Equal(t, entries[i].StructuredMetadata, it.Entry().StructuredMetadata)
+				} else {
+					require.Empty(t, it.Entry().StructuredMetadata)
+				}
+			}
+			require.NoError(t, it.Close())
+			require.Equal(t, len(entries), i)
+
+			expr, err := syntax.ParseSampleExpr(`count_over_time({app="foo"} | traceID="456"[1m])`)
+			require.NoError(t, err)
+			extractor, err := expr.Extractor()
+			require.NoError(t, err)
+			sampleIt := chk.SampleIterator(context.Background(), time.Unix(0, 0), time.Unix(0, 4), extractor.ForStream(streamLabels))
+			var samples int
+			for sampleIt.Next() {
+				samples++
+				require.Equal(t, int64(3), sampleIt.Sample().Timestamp)
+				require.Equal(t, `{app="foo", app_extracted="bar", traceID="456"}`, sampleIt.Labels())
+			}
+			require.NoError(t, sampleIt.Close())
+			if tc.headFmt == UnorderedWithStructuredMetadataHeadBlockFmt {
+				require.Equal(t, 1, samples)
+			} else {
+				require.Equal(t, 0, samples)
+			}
+		})
+	}
+}
+
+func TestMemChunk_StructuredMetadataChunkFormat(t *testing.T) {
+	entry := &logproto.Entry{Timestamp: time.Unix(0, 1), Line: "lineA"}
+	withStructuredMetadata := &logproto.Entry{
+		Timestamp:          time.Unix(0, 2),
+		Line:               "lineB",
+		StructuredMetadata: logproto.FromLabelsToLabelAdapters(labels.Labels{{Name: "traceID", Value: "123"}}),
+	}
+
+	// the chunk format is only upgraded once structured metadata is appended.
+	c := NewMemChunk(EncSnappy, UnorderedWithStructuredMetadataHeadBlockFmt, testBlockSize, testTargetSize)
+	require.NoError(t, c.Append(entry))
+	require.Equal(t, DefaultChunkFormat, c.format)
+	require.True(t, c.SpaceFor(withStructuredMetadata))
+	require.NoError(t, c.Append(withStructuredMetadata))
+	require.Equal(t, chunkFormatV4, c.format)
+
+	// the head blocks without structured metadata never upgrade the chunk format.
+	c = NewMemChunk(EncSnappy, UnorderedHeadBlockFmt, testBlockSize, testTargetSize)
+	require.NoError(t, c.Append(withStructuredMetadata))
+	require.Equal(t, DefaultChunkFormat, c.format)
+
+	// once blocks are cut without structured metadata, the entries with some need a new chunk.
+	c = NewMemChunk(EncSnappy, UnorderedWithStructuredMetadataHeadBlockFmt, testBlockSize, testTargetSize)
+	require.NoError(t, c.Append(entry))
+	require.NoError(t, c.cut())
+	require.True(t, c.SpaceFor(entry))
+	require.False(t, c.SpaceFor(withStructuredMetadata))
+	require.Equal(t, ErrHeadBlockFmtNotSupported, c.Append(withStructuredMetadata))
+	require.Equal(t, DefaultChunkFormat, c.format)
+
+	// the chunks replayed from a checkpoint keep their format.
+	var chk, head bytes.Buffer
+	require.NoError(t, c.SerializeForCheckpointTo(&chk, &head))
+	replayed, err := MemchunkFromCheckpoint(chk.Bytes(), head.Bytes(), UnorderedWithStructuredMetadataHeadBlockFmt, testBlockSize, testTargetSize)
+	require.NoError(t, err)
+	require.Equal(t, DefaultChunkFormat, replayed.format)
+	require.Equal(t, UnorderedWithStructuredMetadataHeadBlockFmt, replayed.head.Format())
+	require.False(t, replayed.SpaceFor(withStructuredMetadata))
+}
+
+func TestMemChunk_BloomFilters(t *testing.T) {
+	newChunk := func(bloomFilters bool) *MemChunk {
+		c := NewMemChunk(EncSnappy, UnorderedHeadBlockFmt, testBlockSize, testTargetSize)
+		if bloomFilters {
+			c.EnableBloomFilters()
+		}
+		for i := 0; i < 100; i++ {
+			require.NoError(t, c.Append(logprotoEntry(int64(i), fmt.Sprintf("level=info msg=ok trace_id=aaaa%04d", i))))
+		}
+		require.NoError(t, c.cut())
+		for i := 100; i < 200; i++ {
+			require.NoError(t, c.Append(logprotoEntry(int64(i), fmt.Sprintf("level=error msg=failed trace_id=bbbb%04d", i))))
+		}
+		require.NoError(t, c.Close())
+		return c
+	}
+	mayContain := func(c Chunk, substrings ...string) []bool {
+		var res []bool
+		for _, b := range c.Blocks(time.Unix(0, 0), time.Unix(0, math.MaxInt64)) {
+			res = append(res, b.MayContain(substrings))
+		}
+		return res
+	}
+
+	c := newChunk(true)
+	b, err := c.Bytes()
+	require.NoError(t, err)
+	fromBytes, err := NewByteChunk(b, testBlockSize, testTargetSize)
+	require.NoError(t, err)
+	rebound, err := fromBytes.Rebound(time.Unix(0, 50), time.Unix(0, 150), nil)
+	require.NoError(t, err)
+
+	for _, c := range []Chunk{c, fromBytes} {
+		require.Equal(t, []bool{true, true}, mayContain(c))
+		require.Equal(t, []bool{true, true}, mayContain(c, "msg="))
+		require.Equal(t, []bool{true, false}, mayContain(c, "trace_id=aaaa0042"))
+		require.Equal(t, []bool{false, true}, mayContain(c, "level=error", "trace_id=bbbb0142"))
+		require.Equal(t, []bool{false, false}, mayContain(c, "level=error", "trace_id=aaaa0042"))
+		require.Equal(t, []bool{false, false}, mayContain(c, "not found"))
+		// too short to be tested.
+		require.Equal(t, []bool{true, true}, mayContain(c, "zz"))
+	}
+	// the entries of the rebound chunk fit in a single block.
+	require.Equal(t, []bool{true}, mayContain(rebound, "trace_id=aaaa0060", "trace_id=bbbb0150"))
+	require.Equal(t, []bool{false}, mayContain(rebound, "trace_id=aaaa0042"))
+
+	// the chunks without bloom filters may always contain the substrings.
+	c = newChunk(false)
+	b, err = c.Bytes()
+	require.NoError(t, err)
+	fromBytes, err = NewByteChunk(b, testBlockSize, testTargetSize)
+	require.NoError(t, err)
+	for _, c := range []Chunk{c, fromBytes} {
+		require.Equal(t, []bool{true, true}, mayContain(c, "not found"))
+	}
+}
@@ -30,9 +30,9 @@ func iterEq(t *testing.T, exp []entry, got iter.EntryIterator) {
 }
 
 func Test_forEntriesEarlyReturn(t *testing.T) {
-	hb := newUnorderedHeadBlock()
+	hb := newUnorderedHeadBlock(UnorderedHeadBlockFmt)
 	for i := 0; i < 10; i++ {
-		require.Nil(t, hb.Append(int64(i), fmt.Sprint(i)))
+		require.Nil(t, hb.Append(int64(i), fmt.Sprint(i), nil))
 	}
 
 	// forward
@@ -43,7 +43,7 @@ func Test_forEntriesEarlyReturn(t *testing.T) {
 		logproto.FORWARD,
 		0,
 		math.MaxInt64,
-		func(ts int64, line string) error {
+		func(ts int64, line string, _ labels.Labels) error {
 			forwardCt++
 			forwardStop = ts
 			if ts == 5 {
@@ -64,7 +64,7 @@ func Test_forEntriesEarlyReturn(t *testing.T) {
 		logproto.BACKWARD,
 		0,
 		math.MaxInt64,
-		func(ts int64, line string) error {
+		func(ts int64, line string, _ labels.Labels) error {
 			backwardCt++
 			backwardStop = ts
 			if ts == 5 {
@@ -143,9 +143,9 @@ func Test_Unordered_InsertRetrieval(t *testing.T) {
 		},
 	} {
 		t.Run(tc.desc, func(t *testing.T) {
-			hb := newUnorderedHeadBlock()
+			hb := newUnorderedHeadBlock(UnorderedHeadBlockFmt)
 			for _, e := range tc.input {
-				require.Nil(t, hb.Append(e.t, e.s))
+				require.Nil(t, hb.Append(e.t, e.s, nil))
 			}
 
 			itr := hb.Iterator(
@@ -205,9 +205,9 @@ func Test_UnorderedBoundedIter(t *testing.T) {
 		},
 	} {
 		t.Run(tc.desc, func(t *testing.T) {
-			hb := newUnorderedHeadBlock()
+			hb := newUnorderedHeadBlock(UnorderedHeadBlockFmt)
 			for _, e := range tc.input {
-				require.Nil(t, hb.Append(e.t, e.s))
+				require.Nil(t, hb.Append(e.t, e.s, nil))
 			}
 
 			itr := hb.Iterator(
@@ -224,10 +224,10 @@ func Test_UnorderedBoundedIter(t *testing.T) {
 }
 
 func TestHeadBlockInterop(t *testing.T) {
-	unordered, ordered := newUnorderedHeadBlock(), &headBlock{}
+	unordered, ordered := newUnorderedHeadBlock(UnorderedHeadBlockFmt), &headBlock{}
 	for i := 0; i < 100; i++ {
-		require.Nil(t, unordered.Append(int64(99-i), fmt.Sprint(99-i)))
-		require.Nil(t, ordered.Append(int64(i), fmt.Sprint(i)))
+		require.Nil(t, unordered.Append(int64(99-i), fmt.Sprint(99-i), nil))
+		require.Nil(t, ordered.Append(int64(i), fmt.Sprint(i), nil))
 	}
 
 	// turn to bytes
@@ -257,6 +257,28 @@ func TestHeadBlockInterop(t *testing.T) {
 	require.Equal(t, unordered, recovered)
 }
 
+func TestHeadBlockStructuredMetadataCheckpoint(t *testing.T) {
+	withMetadata, unordered := newUnorderedHeadBlock(UnorderedWithStructuredMetadataHeadBlockFmt), newUnorderedHeadBlock(UnorderedHeadBlockFmt)
+	for i := 0; i < 100; i++ {
+		structuredMetadata := labels.Labels{{Name: "traceID", Value: fmt.Sprint(i)}}
+		require.Nil(t, withMetadata.Append(int64(99-i), fmt.Sprint(99-i), structuredMetadata))
+		require.Nil(t, unordered.Append(int64(99-i), fmt.Sprint(99-i), nil))
+	}
+
+	b, err := withMetadata.CheckpointBytes(nil)
+	require.Nil(t, err)
+
+	// Ensure the structured metadata is recovered.
+	recovered, err := HeadFromCheckpoint(b, UnorderedWithStructuredMetadataHeadBlockFmt)
+	require.Nil(t, err)
+	require.Equal(t, withMetadata, recovered)
+
+	// Ensure the structured metadata is dropped by head blocks not keeping it.
+	recovered, err = HeadFromCheckpoint(b, UnorderedHeadBlockFmt)
+	require.Nil(t, err)
+	require.Equal(t, unordered, recovered)
+}
+
 // ensure backwards compatibility from when chunk format
 // and head block format was split
 func TestChunkBlockFmt(t *testing.T) {
@@ -274,14 +296,14 @@ func BenchmarkHeadBlockWrites(b *testing.B) {
 	headBlockFn := func() func(int64, string) {
 		hb := &headBlock{}
 		return func(ts int64, line string) {
-			_ = hb.Append(ts, line)
+			_ = hb.Append(ts, line, nil)
 		}
 	}
 
 	unorderedHeadBlockFn := func() func(int64, string) {
-		hb := newUnorderedHeadBlock()
+		hb := newUnorderedHeadBlock(UnorderedHeadBlockFmt)
 		return func(ts int64, line string) {
-			_ = hb.Append(ts, line)
+			_ = hb.Append(ts, line, nil)
 		}
 	}
 
@@ -618,11 +640,11 @@ func Test_HeadIteratorHash(t *testing.T) {
 	}
 
 	for name, b := range map[string]HeadBlock{
-		"unordered": newUnorderedHeadBlock(),
+		"unordered": newUnorderedHeadBlock(UnorderedHeadBlockFmt),
 		"ordered":   &headBlock{},
 	} {
 		t.Run(name, func(t *testing.T) {
-			require.NoError(t, b.Append(1, "foo"))
+			require.NoError(t, b.Append(1, "foo", nil))
 			eit := b.Iterator(context.Background(), logproto.BACKWARD, 0, 2, log.NewNoopPipeline().ForStream(lbs))
 
 			for eit.Next() {
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/go-kit/log/level"
 	"github.com/grafana/dskit/kv"
 	"github.com/grafana/dskit/limiter"
 	"github.com/grafana/dskit/ring"
@@ -16,6 +17,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/prometheus/model/labels"
 	"github.com/weaveworks/common/httpgrpc"
 	"github.com/weaveworks/common/user"
 	"go.uber.org/atomic"
@@ -49,6 +51,8 @@ type Config struct {
 	// Distributors ring
 	DistributorRing RingConfig `yaml:"ring,omitempty"`
 
+	HATrackerConfig HATrackerConfig `yaml:"ha_tracker,omitempty"`
+
 	// For testing.
 	factory ring_client.PoolFactory `yaml:"-"`
 }
@@ -56,6 +60,12 @@ type Config struct {
 // RegisterFlags registers distributor-related flags.
 func (cfg *Config) RegisterFlags(fs *flag.FlagSet) {
 	cfg.DistributorRing.RegisterFlags(fs)
+	cfg.HATrackerConfig.RegisterFlags(fs)
+}
+
+// Validate validates the distributor config.
+func (cfg *Config) Validate() error {
+	return cfg.HATrackerConfig.Validate()
 }
 
 // Distributor coordinates replicates and distribution of log streams.
@@ -69,12 +79,17 @@ type Distributor struct {
 	ingestersRing    ring.ReadRing
 	validator        *Validator
 	pool             *ring_client.Pool
+	limits           Limits
+	streamRates      *streamRates
 
 	// The global rate limiter requires a distributors ring to count
 	// the number of healthy instances.
 	distributorsRing       *ring.Ring
 	distributorsLifecycler *ring.Lifecycler
 
+	// The HA tracker deduplicates the pushes of the HA pairs of clients, nil when disabled.
+	haTracker *haTracker
+
 	rateLimitStrat string
 
 	subservices        *services.Manager
@@ -88,6 +103,9 @@ type Distributor struct {
 	ingesterAppends        *prometheus.CounterVec
 	ingesterAppendFailures *prometheus.CounterVec
 	replicationFactor      prometheus.Gauge
+	streamShards           *prometheus.CounterVec
+	redactions             *prometheus.CounterVec
+	dedupedSamples         *prometheus.CounterVec
 }
 
 // New a distributor creates.
@@ -139,6 +157,15 @@ func New(cfg Config, clientCfg client.Config, configs *runtime.TenantConfigs, in
 		ingestionRateStrategy = newLocalIngestionRateStrategy(overrides)
 	}
 
+	var haTracker *haTracker
+	if cfg.HATrackerConfig.EnableHATracker {
+		haTracker, err = newHATracker(cfg.HATrackerConfig, overrides, registerer, util_log.Logger)
+		if err != nil {
+			return nil, errors.Wrap(err, "create HA tracker")
+		}
+		servs = append(servs, haTracker)
+	}
+
 	labelCache, err := lru.New(maxLabelCacheSize)
 	if err != nil {
 		return nil, err
@@ -151,7 +178,10 @@ func New(cfg Config, clientCfg client.Config, configs *runtime.TenantConfigs, in
 		ingestersRing:          ingestersRing,
 		distributorsRing:       distributorsRing,
 		distributorsLifecycler: distributorsLifecycler,
+		haTracker:              haTracker,
 		validator:              validator,
+		limits:                 overrides,
+		streamRates:            newStreamRates(streamRateWindow),
 		pool:                   clientpool.NewPool(clientCfg.PoolConfig, ingestersRing, factory, util_log.Logger),
 		ingestionRateLimiter:   limiter.NewRateLimiter(ingestionRateStrategy, 10*time.Second),
 		labelCache:             labelCache,
@@ -171,6 +201,21 @@ func New(cfg Config, clientCfg client.Config, configs *runtime.TenantConfigs, in
 			Name:      "distributor_replication_factor",
 			Help:      "The configured replication factor.",
 		}),
+		streamShards: promauto.With(registerer).NewCounterVec(prometheus.CounterOpts{
+			Namespace: "loki",
+			Name:      "distributor_stream_shards_total",
+			Help:      "The total number of shards pushed to ingesters for the streams above the desired rate.",
+		}, []string{"tenant"}),
+		redactions: promauto.With(registerer).NewCounterVec(prometheus.CounterOpts{
+			Namespace: "loki",
+			Name:      "distributor_redactions_total",
+			Help:      "The total number of matches of the redaction rules replaced in the pushed lines, structured metadata and label values.",
+		}, []string{"tenant", "rule"}),
+		dedupedSamples: promauto.With(registerer).NewCounterVec(prometheus.CounterOpts{
+			Namespace: "loki",
+			Name:      "distributor_deduped_samples_total",
+			Help:      "The total number of entries pushed by the replicas of the HA clusters which weren't elected.",
+		}, []string{"tenant", "cluster"}),
 	}
 	d.replicationFactor.Set(float64(ingestersRing.ReplicationFactor()))
 	rfStats.Set(int64(ingestersRing.ReplicationFactor()))
@@ -192,11 +237,18 @@ func (d *Distributor) starting(ctx context.Context) error {
 }
 
 func (d *Distributor) running(ctx context.Context) error {
-	select {
-	case <-ctx.Done():
-		return nil
-	case err := <-d.subservicesWatcher.Chan():
-		return errors.Wrap(err, "distributor subservice failed")
+	ticker := time.NewTicker(streamRateWindow)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-d.subservicesWatcher.Chan():
+			return errors.Wrap(err, "distributor subservice failed")
+		case now := <-ticker.C:
+			d.streamRates.removeStale(now)
+		}
 	}
 }
 
@@ -233,6 +285,12 @@ func (d *Distributor) Push(ctx context.Context, req *logproto.PushRequest) (*log
 		return &logproto.PushResponse{}, nil
 	}
 
+	if d.haTracker != nil && d.limits.AcceptHASamples(userID) {
+		if err := d.checkHAReplica(ctx, userID, req); err != nil {
+			return nil, err
+		}
+	}
+
 	// First we flatten out the request into a list of samples.
 	// We use the heuristic of 1 sample per TS to size the array.
 	// We also work out the hash value at the same time.
@@ -243,6 +301,7 @@ func (d *Distributor) Push(ctx context.Context, req *logproto.PushRequest) (*log
 
 	var validationErr error
 	validationContext := d.validator.getValidationContextForTime(time.Now(), userID)
+	shardStreams := d.limits.ShardStreams(userID)
 
 	for _, stream := range req.Streams {
 		// Return early if stream does not contain any entries
@@ -250,6 +309,9 @@ func (d *Distributor) Push(ctx context.Context, req *logproto.PushRequest) (*log
 			continue
 		}
 
+		// Redact before anything else so that no secret is truncated, validated or stored.
+		d.redactEntries(validationContext, &stream)
+
 		// Truncate first so subsequent steps have consistent line lengths
 		d.truncateLines(validationContext, &stream)
 
@@ -265,7 +327,13 @@ func (d *Distributor) Push(ctx context.Context, req *logproto.PushRequest) (*log
 			continue
 		}
 
+		// Drop before rate limiting so that the dropped entries don't count towards the ingestion rate.
+		if d.dropEntries(validationContext, &stream); len(stream.Entries) == 0 {
+			continue
+		}
+
 		n := 0
+		streamSize := 0
 		for _, entry := range stream.Entries {
 			if err := d.validator.ValidateEntry(validationContext, stream.Labels, entry); err != nil {
 				validationErr = err
@@ -287,11 +355,25 @@ func (d *Distributor) Push(ctx context.Context, req *logproto.PushRequest) (*log
 			}
 
 			n++
+			streamSize += len(entry.Line)
 			validatedSamplesSize += len(entry.Line)
 			validatedSamplesCount++
 		}
 		stream.Entries = stream.Entries[:n]
 
+		if shardStreams.Enabled && n > 0 {
+			// The rate of every stream is recorded, including the streams pushed one entry at a time
+			// which can't be split.
+			rate := d.streamRates.add(time.Now(), streamKey{userID: userID, labels: stream.Labels}, streamSize)
+			if shards := d.shardStream(userID, stream, rate, shardStreams.DesiredRate.Val()); shards != nil {
+				for _, shard := range shards {
+					keys = append(keys, util.TokenFor(userID, shard.Labels))
+					streams = append(streams, streamTracker{stream: shard})
+				}
+				continue
+			}
+		}
+
 		keys = append(keys, util.TokenFor(userID, stream.Labels))
 		streams = append(streams, streamTracker{stream: stream})
 	}
@@ -355,6 +437,148 @@ func (d *Distributor) Push(ctx context.Context, req *logproto.PushRequest) (*log
 	}
 }
 
+// shardStream returns the shards the stream must be split into when its rate is above the desired rate,
+// or nil when the stream doesn't need to be sharded or has a single entry.
+func (d *Distributor) shardStream(userID string, stream logproto.Stream, rate float64, desiredRate int) []logproto.Stream {
+	shardCount := shardCountFor(rate, desiredRate, len(stream.Entries))
+	if shardCount <= 1 {
+		return nil
+	}
+
+	shards, err := shardStream(stream, shardCount)
+	if err != nil {
+		level.Warn(util_log.Logger).Log("msg", "failed to shard stream", "tenant", userID, "stream", stream.Labels, "err", err)
+		return nil
+	}
+	d.streamShards.WithLabelValues(userID).Add(float64(shardCount))
+	return shards
+}
+
+// checkHAReplica returns an error if the streams were pushed by a replica of an HA cluster which isn't the
+// elected one, in which case they are dropped, and removes the replica label from the streams otherwise.
+func (d *Distributor) checkHAReplica(ctx context.Context, userID string, req *logproto.PushRequest) error {
+	replicaLabel := d.limits.HAReplicaLabel(userID)
+	cluster, replica := findHALabels(d.limits.HAClusterLabel(userID), replicaLabel, req.Streams)
+	// The streams not pushed by an HA cluster are accepted as they are.
+	if cluster == "" || replica == "" {
+		return nil
+	}
+
+	err := d.haTracker.checkReplica(ctx, userID, cluster, replica, time.Now())
+	switch {
+	case err == nil:
+		removeReplicaLabel(replicaLabel, req.Streams)
+		return nil
+	case errors.As(err, &replicasNotMatchError{}):
+		entries := 0
+		for _, stream := range req.Streams {
+			entries += len(stream.Entries)
+		}
+		d.dedupedSamples.WithLabelValues(userID, cluster).Add(float64(entries))
+		// Return a 202 so that the replica which isn't elected doesn't retry.
+		return httpgrpc.Errorf(http.StatusAccepted, err.Error())
+	case errors.As(err, &tooManyClustersError{}):
+		return httpgrpc.Errorf(http.StatusBadRequest, err.Error())
+	default:
+		return err
+	}
+}
+
+// dropEntries drops the entries of the stream matched by the first drop rule of the tenant matching them,
+// but for the ratio of them kept by the rule.
+func (d *Distributor) dropEntries(vContext validationContext, stream *logproto.Stream) {
+	if len(vContext.dropRules) == 0 {
+		return
+	}
+	ls, err := syntax.ParseLabels(stream.Labels)
+	if err != nil {
+		return
+	}
+	rules := make([]*validation.DropRule, 0, len(vContext.dropRules))
+	for _, rule := range vContext.dropRules {
+		if rule.MatchesStream(ls) {
+			rules = append(rules, rule)
+		}
+	}
+	if len(rules) == 0 {
+		return
+	}
+
+	var droppedSamples, droppedBytes int
+	n := 0
+	for _, e := range stream.Entries {
+		if dropEntry(rules, e.Line) {
+			droppedSamples++
+			droppedBytes += len(e.Line)
+			continue
+		}
+		stream.Entries[n] = e
+		n++
+	}
+	stream.Entries = stream.Entries[:n]
+
+	validation.DiscardedSamples.WithLabelValues(validation.DroppedByRule, vContext.userID).Add(float64(droppedSamples))
+	validation.DiscardedBytes.WithLabelValues(validation.DroppedByRule, vContext.userID).Add(float64(droppedBytes))
+}
+
+func dropEntry(rules []*validation.DropRule, line string) bool {
+	for _, rule := range rules {
+		if rule.MatchesLine(line) {
+			return rule.Drop()
+		}
+	}
+	return false
+}
+
+// redactEntries redacts the lines and the structured metadata values of the entries of the stream.
+func (d *Distributor) redactEntries(vContext validationContext, stream *logproto.Stream) {
+	if len(vContext.redactionRules) == 0 {
+		return
+	}
+
+	var redactedSamples int
+	for i, e := range stream.Entries {
+		line, redacted := d.redact(vContext, e.Line)
+		if redacted {
+			stream.Entries[i].Line = line
+		}
+		for j, l := range e.StructuredMetadata {
+			if value, ok := d.redact(vContext, l.Value); ok {
+				stream.Entries[i].StructuredMetadata[j].Value = value
+				redacted = true
+			}
+		}
+		if redacted {
+			redactedSamples++
+		}
+	}
+
+	validation.MutatedSamples.WithLabelValues(validation.Redacted, vContext.userID).Add(float64(redactedSamples))
+}
+
+// redactLabels redacts the values of the labels of a stream.
+func (d *Distributor) redactLabels(vContext validationContext, ls labels.Labels) labels.Labels {
+	for i, l := range ls {
+		if value, ok := d.redact(vContext, l.Value); ok {
+			ls[i].Value = value
+		}
+	}
+	return ls
+}
+
+// redact applies the redaction rules of the tenant to the text, and returns whether it was redacted.
+func (d *Distributor) redact(vContext validationContext, text string) (string, bool) {
+	redacted := false
+	for _, rule := range vContext.redactionRules {
+		var n int
+		if text, n = rule.Redact(text); n > 0 {
+			d.redactions.WithLabelValues(vContext.userID, rule.Name).Add(float64(n))
+			redacted = true
+		}
+	}
+	return text, redacted
+}
+
 func (d *Distributor) truncateLines(vContext validationContext, stream *logproto.Stream) {
 	if !vContext.maxLineSizeTruncate {
 		return
@@ -434,19 +658,31 @@ func (*Distributor) Check(_ context.Context, _ *grpc_health_v1.HealthCheckReques
 }
 
 func (d *Distributor) parseStreamLabels(vContext validationContext, key string, stream *logproto.Stream) (string, error) {
-	labelVal, ok := d.labelCache.Get(key)
-	if ok {
-		return labelVal.(string), nil
+	// The labels of the tenants with a label policy or redaction rules are neither cached nor read from the
+	// cache, since they are mapped by a policy or redacted by rules which are specific to the tenant and which
+	// can be reloaded.
+	cacheable := (vContext.labelPolicy == nil || vContext.labelPolicy.IsEmpty()) && len(vContext.redactionRules) == 0
+	if cacheable {
+		labelVal, ok := d.labelCache.Get(key)
+		if ok {
+			return labelVal.(string), nil
+		}
 	}
 	ls, err := syntax.ParseLabels(key)
 	if err != nil {
 		return "", httpgrpc.Errorf(http.StatusBadRequest, validation.InvalidLabelsErrorMsg, key, err)
 	}
+	if ls, err = d.validator.ApplyLabelPolicy(vContext, ls, *stream); err != nil {
+		return "", err
+	}
+	ls = d.redactLabels(vContext, ls)
 	// ensure labels are correctly sorted.
 	if err := d.validator.ValidateLabels(vContext, ls, *stream); err != nil {
 		return "", err
 	}
 	lsVal := ls.String()
-	d.labelCache.Add(key, lsVal)
+	if cacheable {
+		d.labelCache.Add(key, lsVal)
+	}
 	return lsVal, nil
 }
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -19,6 +20,7 @@ import (
 	ring_client "github.com/grafana/dskit/ring/client"
 	"github.com/grafana/dskit/services"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/prometheus/common/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -323,6 +325,223 @@ func Test_SortLabelsOnPush(t *testing.T) {
 	require.Equal(t, `{a="b", buzz="f"}`, ingester.pushed[0].Streams[0].Labels)
 }
 
+func Test_LabelPolicyOnPush(t *testing.T) {
+	limits := &validation.Limits{}
+	flagext.DefaultValues(limits)
+	limits.EnforceMetricName = false
+	limits.LabelPolicy = validation.LabelPolicy{
+		ForbiddenLabels:         []string{"pod_ip"},
+		ForbiddenLabelsFallback: "other",
+	}
+	require.NoError(t, limits.Validate())
+	ingester := &mockIngester{}
+	d := prepare(t, limits, nil, func(addr string) (ring_client.PoolClient, error) { return ingester, nil })
+	defer services.StopAndAwaitTerminated(context.Background(), d) //nolint:errcheck
+
+	// The same labels are mapped on every push, rather than cached.
+	for i := 0; i < 2; i++ {
+		request := makeWriteRequest(10, 10)
+		request.Streams[0].Labels = `{app="foo", pod_ip="10.0.0.1"}`
+		_, err := d.Push(ctx, request)
+		require.NoError(t, err)
+		require.Equal(t, `{app="foo", pod_ip="other"}`, ingester.pushed[i].Streams[0].Labels)
+	}
+}
+
+func Test_RedactionOnPush(t *testing.T) {
+	limits := &validation.Limits{}
+	flagext.DefaultValues(limits)
+	limits.EnforceMetricName = false
+	limits.MaxLineSize = 60
+	limits.MaxLineSizeTruncate = true
+	limits.RedactionRules = []*validation.RedactionRule{
+		{Detector: validation.RedactionDetectorPAN, Replacement: "<pan>"},
+		{Name: "password", Regex: `password=(\S+)`},
+	}
+	require.NoError(t, limits.Validate())
+	ingester := &mockIngester{}
+	d := prepare(t, limits, nil, func(addr string) (ring_client.PoolClient, error) { return ingester, nil })
+	defer services.StopAndAwaitTerminated(context.Background(), d) //nolint:errcheck
+
+	request := makeWriteRequest(2, 10)
+	request.Streams[0].Entries[0].Line = "card 4111 1111 1111 1111, other 4111 1111 1111 1112"
+	// The password is redacted before the line is truncated.
+	request.Streams[0].Entries[1].Line = "login with password=" + strings.Repeat("s", 100)
+	_, err := d.Push(ctx, request)
+	require.NoError(t, err)
+
+	entries := ingester.pushed[0].Streams[0].Entries
+	require.Equal(t, "card <pan>, other 4111 1111 1111 1112", entries[0].Line)
+	require.Equal(t, "login with password=[REDACTED]", entries[1].Line)
+	require.Equal(t, 1.0, testutil.ToFloat64(d.redactions.WithLabelValues("test", "pan")))
+	require.Equal(t, 1.0, testutil.ToFloat64(d.redactions.WithLabelValues("test", "password")))
+}
+
+func Test_RedactionOfStructuredMetadataAndLabelsOnPush(t *testing.T) {
+	limits := &validation.Limits{}
+	flagext.DefaultValues(limits)
+	limits.EnforceMetricName = false
+	limits.AllowStructuredMetadata = true
+	limits.RedactionRules = []*validation.RedactionRule{
+		{Detector: validation.RedactionDetectorPAN, Replacement: "<pan>"},
+	}
+	require.NoError(t, limits.Validate())
+	ingester := &mockIngester{}
+	d := prepare(t, limits, nil, func(addr string) (ring_client.PoolClient, error) { return ingester, nil })
+	defer services.StopAndAwaitTerminated(context.Background(), d) //nolint:errcheck
+
+	request := makeWriteRequest(1, 10)
+	request.Streams[0].Labels = `{foo="bar", card="4111111111111111"}`
+	request.Streams[0].Entries[0].StructuredMetadata = []logproto.LabelAdapter{
+		{Name: "card", Value: "5500-0000-0000-0004"},
+		{Name: "order", Value: "123"},
+	}
+	_, err := d.Push(ctx, request)
+	require.NoError(t, err)
+
+	stream := ingester.pushed[0].Streams[0]
+	require.Equal(t, `{card="<pan>", foo="bar"}`, stream.Labels)
+	require.Equal(t, []logproto.LabelAdapter{{Name: "card", Value: "<pan>"}, {Name: "order", Value: "123"}}, stream.Entries[0].StructuredMetadata)
+	require.Equal(t, 2.0, testutil.ToFloat64(d.redactions.WithLabelValues("test", "pan")))
+}
+
+func Test_DropRulesOnPush(t *testing.T) {
+	limits := &validation.Limits{}
+	flagext.DefaultValues(limits)
+	limits.EnforceMetricName = false
+	limits.DropRules = []*validation.DropRule{
+		{Selector: `{foo="bar"}`, LineFilter: `|= "debug"`},
+		{Selector: `{foo="baz"}`},
+	}
+	require.NoError(t, limits.Validate())
+	ingester := &mockIngester{}
+	d := prepare(t, limits, nil, func(addr string) (ring_client.PoolClient, error) { return ingester, nil })
+	defer services.StopAndAwaitTerminated(context.Background(), d) //nolint:errcheck
+
+	request := makeWriteRequest(3, 10)
+	request.Streams[0].Entries[1].Line = "debug line"
+	request.Streams = append(request.Streams, logproto.Stream{
+		Labels:  `{foo="baz"}`,
+		Entries: []logproto.Entry{{Timestamp: time.Now(), Line: "dropped"}},
+	})
+	_, err := d.Push(ctx, request)
+	require.NoError(t, err)
+
+	require.Len(t, ingester.pushed[0].Streams, 1)
+	require.Len(t, ingester.pushed[0].Streams[0].Entries, 2)
+	require.Equal(t, 2.0, testutil.ToFloat64(validation.DiscardedSamples.WithLabelValues(validation.DroppedByRule, "test")))
+}
+
+func Test_HATrackerOnPush(t *testing.T) {
+	limits := &validation.Limits{}
+	flagext.DefaultValues(limits)
+	limits.EnforceMetricName = false
+	limits.AcceptHASamples = true
+	ingester := &mockIngester{}
+	d := prepare(t, limits, nil, func(addr string) (ring_client.PoolClient, error) { return ingester, nil })
+	defer services.StopAndAwaitTerminated(context.Background(), d) //nolint:errcheck
+	d.haTracker = newTestHATracker(t, 0)
+
+	pushFrom := func(replica string) error {
+		request := makeWriteRequest(10, 10)
+		request.Streams[0].Labels = fmt.Sprintf(`{cluster="syslog", __replica__="%s", foo="bar"}`, replica)
+		_, err := d.Push(ctx, request)
+		return err
+	}
+
+	// The streams of the elected replica are accepted without the replica label.
+	require.NoError(t, pushFrom("a"))
+	require.Equal(t, `{cluster="syslog", foo="bar"}`, ingester.pushed[0].Streams[0].Labels)
+	pushed := len(ingester.pushed)
+
+	// The streams of the other replica are dropped with a 202.
+	err := pushFrom("b")
+	resp, ok := httpgrpc.HTTPResponseFromError(err)
+	require.True(t, ok)
+	require.Equal(t, int32(http.StatusAccepted), resp.Code)
+	require.Len(t, ingester.pushed, pushed)
+	require.Equal(t, 10.0, testutil.ToFloat64(d.dedupedSamples.WithLabelValues("test", "syslog")))
+}
+
+func Test_StreamSharding(t *testing.T) {
+	for _, tc := range []struct {
+		desc           string
+		enabled        bool
+		desiredRate    string
+		expectedShards int
+	}{
+		{desc: "disabled", enabled: false, desiredRate: "100B", expectedShards: 0},
+		{desc: "below the desired rate", enabled: true, desiredRate: "10KB", expectedShards: 0},
+		// 100 lines of 100 bytes over a 10s window.
+		{desc: "above the desired rate", enabled: true, desiredRate: "100B", expectedShards: 10},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			limits := &validation.Limits{}
+			flagext.DefaultValues(limits)
+			limits.EnforceMetricName = false
+			limits.ShardStreams.Enabled = tc.enabled
+			require.NoError(t, limits.ShardStreams.DesiredRate.Set(tc.desiredRate))
+
+			ingester := &mockIngester{}
+			d := prepare(t, limits, nil, func(addr string) (ring_client.PoolClient, error) { return ingester, nil })
+			defer services.StopAndAwaitTerminated(context.Background(), d) //nolint:errcheck
+
+			_, err := d.Push(ctx, makeWriteRequest(100, 100))
+			require.NoError(t, err)
+
+			entries := map[string]int{}
+			for _, req := range ingester.pushedRequests() {
+				for _, stream := range req.Streams {
+					entries[stream.Labels] = len(stream.Entries)
+				}
+			}
+
+			if tc.expectedShards == 0 {
+				require.Equal(t, map[string]int{`{foo="bar"}`: 100}, entries)
+				return
+			}
+			require.Len(t, entries, tc.expectedShards)
+			for i := 0; i < tc.expectedShards; i++ {
+				require.Equal(t, 100/tc.expectedShards, entries[fmt.Sprintf(`{__stream_shard__="%d", foo="bar"}`, i)])
+			}
+		})
+	}
+}
+
+func Test_StreamShardingOfSingleEntryPushes(t *testing.T) {
+	limits := &validation.Limits{}
+	flagext.DefaultValues(limits)
+	limits.EnforceMetricName = false
+	limits.ShardStreams.Enabled = true
+	require.NoError(t, limits.ShardStreams.DesiredRate.Set("100B"))
+
+	ingester := &mockIngester{}
+	d := prepare(t, limits, nil, func(addr string) (ring_client.PoolClient, error) { return ingester, nil })
+	defer services.StopAndAwaitTerminated(context.Background(), d) //nolint:errcheck
+
+	// The pushes of a single entry can't be split, but count towards the rate of the stream.
+	for i := 0; i < 50; i++ {
+		_, err := d.Push(ctx, makeWriteRequest(1, 100))
+		require.NoError(t, err)
+	}
+	for _, req := range ingester.pushedRequests() {
+		require.Equal(t, `{foo="bar"}`, req.Streams[0].Labels)
+	}
+
+	// 60 lines of 100 bytes over a 10s window.
+	_, err := d.Push(ctx, makeWriteRequest(10, 100))
+	require.NoError(t, err)
+	shards := map[string]struct{}{}
+	for _, req := range ingester.pushedRequests() {
+		for _, stream := range req.Streams {
+			if strings.Contains(stream.Labels, "__stream_shard__") {
+				shards[stream.Labels] = struct{}{}
+			}
+		}
+	}
+	require.Len(t, shards, 6)
+}
+
 func Test_TruncateLogLines(t *testing.T) {
 	setup := func() (*validation.Limits, *mockIngester) {
 		limits := &validation.Limits{}
@@ -589,14 +808,24 @@ type mockIngester struct {
 	grpc_health_v1.HealthClient
 	logproto.PusherClient
 
+	mtx    sync.Mutex
 	pushed []*logproto.PushRequest
 }
 
 func (i *mockIngester) Push(ctx context.Context, in *logproto.PushRequest, opts ...grpc.CallOption) (*logproto.PushResponse, error) {
+	i.mtx.Lock()
+	defer i.mtx.Unlock()
 	i.pushed = append(i.pushed, in)
 	return nil, nil
 }
 
+// pushedRequests returns the requests pushed so far, which the replicas not awaited by a push may still be adding to.
+func (i *mockIngester) pushedRequests() []*logproto.PushRequest {
+	i.mtx.Lock()
+	defer i.mtx.Unlock()
+	return append([]*logproto.PushRequest(nil), i.pushed...)
+}
+
 func (i *mockIngester) Close() error {
 	return nil
 }
@@ -0,0 +1,329 @@
+package distributor
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/gogo/protobuf/proto"
+	"github.com/grafana/dskit/kv"
+	"github.com/grafana/dskit/kv/codec"
+	"github.com/grafana/dskit/services"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/prometheus/model/labels"
+
+	"github.com/grafana/loki/pkg/logproto"
+	"github.com/grafana/loki/pkg/logql/syntax"
+)
+
+const (
+	// haTrackerCleanupPeriod is the interval at which the clusters without any push for haTrackerCleanupAge
+	// are removed from the KV store.
+	haTrackerCleanupPeriod = time.Minute
+	haTrackerCleanupAge    = 30 * time.Minute
+)
+
+var errMemberlistUnsupported = errors.New("memberlist is not supported by the HA tracker, since it doesn't provide compare-and-swap semantics")
+
+// HATrackerConfig configures the tracker electing, through the KV store, one replica of each cluster of
+// clients pushing the same streams for high availability, so that the pushes of the other replicas are dropped.
+type HATrackerConfig struct {
+	EnableHATracker bool `yaml:"enable_ha_tracker"`
+	// We should only update the timestamp if the difference
+	// between the stored timestamp and the time we received a push at
+	// is more than this duration.
+	UpdateTimeout          time.Duration `yaml:"ha_tracker_update_timeout"`
+	UpdateTimeoutJitterMax time.Duration `yaml:"ha_tracker_update_timeout_jitter_max"`
+	// We should only failover to accepting pushes from a replica
+	// other than the replica written in the KVStore if the difference
+	// between the stored timestamp and the time we received a push is
+	// more than this duration.
+	FailoverTimeout time.Duration `yaml:"ha_tracker_failover_timeout"`
+
+	KVStore kv.Config `yaml:"kvstore"`
+}
+
+// RegisterFlags adds the flags required to config this to the given FlagSet.
+func (cfg *HATrackerConfig) RegisterFlags(f *flag.FlagSet) {
+	f.BoolVar(&cfg.EnableHATracker, "distributor.ha-tracker.enable", false, "Enable the HA tracker, deduplicating the pushes of the HA pairs of clients of the tenants accepting them.")
+	f.DurationVar(&cfg.UpdateTimeout, "distributor.ha-tracker.update-timeout", 15*time.Second, "Update the timestamp of the elected replica in the KV store at most this often.")
+	f.DurationVar(&cfg.UpdateTimeoutJitterMax, "distributor.ha-tracker.update-timeout-jitter-max", 5*time.Second, "Maximum jitter applied to the update timeout, in order to spread the updates of the KV store over time.")
+	f.DurationVar(&cfg.FailoverTimeout, "distributor.ha-tracker.failover-timeout", 30*time.Second, "Elect another replica of a cluster after the elected one hasn't pushed for this long. It must be greater than the update timeout plus its maximum jitter.")
+
+	cfg.KVStore.RegisterFlagsWithPrefix("distributor.ha-tracker.", "ha-tracker/", f)
+}
+
+// Validate validates the HA tracker config.
+func (cfg *HATrackerConfig) Validate() error {
+	if !cfg.EnableHATracker {
+		return nil
+	}
+	if cfg.UpdateTimeoutJitterMax < 0 {
+		return errors.New("HA tracker max update timeout jitter shouldn't be negative")
+	}
+	if min := cfg.UpdateTimeout + cfg.UpdateTimeoutJitterMax + time.Second; cfg.FailoverTimeout < min {
+		return fmt.Errorf("HA tracker failover timeout (%v) must be at least 1s greater than update timeout plus max jitter (%v)", cfg.FailoverTimeout, min)
+	}
+	if cfg.KVStore.Store == "memberlist" {
+		return errMemberlistUnsupported
+	}
+	return nil
+}
+
+// replicasNotMatchError is returned for the pushes of a replica which isn't the elected one of its cluster.
+type replicasNotMatchError struct {
+	replica, elected string
+}
+
+func (e replicasNotMatchError) Error() string {
+	return fmt.Sprintf("replicas did not match, rejecting push: replica=%s, elected=%s", e.replica, e.elected)
+}
+
+// tooManyClustersError is returned when a tenant pushes from more clusters than allowed.
+type tooManyClustersError struct {
+	limit int
+}
+
+func (e tooManyClustersError) Error() string {
+	return fmt.Sprintf("too many HA clusters (limit: %d)", e.limit)
+}
+
+type haTrackerLimits interface {
+	HAMaxClusters(userID string) int
+}
+
+// haTracker elects one replica of each cluster of a tenant through the KV store, and keeps a local cache of
+// the elected replicas up to date by watching the KV store.
+type haTracker struct {
+	services.Service
+
+	logger              log.Logger
+	cfg                 HATrackerConfig
+	client              kv.Client
+	limits              haTrackerLimits
+	updateTimeoutJitter time.Duration
+
+	electedLock sync.RWMutex
+	elected     map[string]ReplicaDesc // Replicas elected for each tenant and cluster, keyed by tenant/cluster.
+	clusters    map[string]int         // Number of clusters of each tenant.
+
+	electedReplicaChanges   *prometheus.CounterVec
+	electedReplicaTimestamp *prometheus.GaugeVec
+	kvCASCalls              *prometheus.CounterVec
+}
+
+func newHATracker(cfg HATrackerConfig, limits haTrackerLimits, registerer prometheus.Registerer, logger log.Logger) (*haTracker, error) {
+	var jitter time.Duration
+	if cfg.UpdateTimeoutJitterMax > 0 {
+		jitter = time.Duration(rand.Int63n(int64(2*cfg.UpdateTimeoutJitterMax))) - cfg.UpdateTimeoutJitterMax
+	}
+
+	client, err := kv.NewClient(
+		cfg.KVStore,
+		GetReplicaDescCodec(),
+		kv.RegistererWithKVName(prometheus.WrapRegistererWithPrefix("loki_", registerer), "distributor-hatracker"),
+		logger)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &haTracker{
+		logger:              logger,
+		cfg:                 cfg,
+		client:              client,
+		limits:              limits,
+		updateTimeoutJitter: jitter,
+		elected:             map[string]ReplicaDesc{},
+		clusters:            map[string]int{},
+		electedReplicaChanges: promauto.With(registerer).NewCounterVec(prometheus.CounterOpts{
+			Namespace: "loki",
+			Name:      "ha_tracker_elected_replica_changes_total",
+			Help:      "The total number of times the elected replica has changed for a tenant and cluster.",
+		}, []string{"tenant", "cluster"}),
+		electedReplicaTimestamp: promauto.With(registerer).NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "loki",
+			Name:      "ha_tracker_elected_replica_timestamp_seconds",
+			Help:      "The timestamp stored for the elected replica of a tenant and cluster.",
+		}, []string{"tenant", "cluster"}),
+		kvCASCalls: promauto.With(registerer).NewCounterVec(prometheus.CounterOpts{
+			Namespace: "loki",
+			Name:      "ha_tracker_kv_store_cas_total",
+			Help:      "The total number of compare-and-swap calls to the KV store for a tenant and cluster.",
+		}, []string{"tenant", "cluster"}),
+	}
+	t.Service = services.NewBasicService(nil, t.loop, nil)
+	return t, nil
+}
+
+func (t *haTracker) loop(ctx context.Context) error {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		t.client.WatchPrefix(ctx, "", func(key string, value interface{}) bool {
+			if desc, ok := value.(*ReplicaDesc); ok {
+				t.setElected(key, *desc)
+			}
+			return true
+		})
+	}()
+
+	ticker := time.NewTicker(haTrackerCleanupPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return nil
+		case now := <-ticker.C:
+			t.cleanupStaleReplicas(ctx, now)
+		}
+	}
+}
+
+func (t *haTracker) setElected(key string, desc ReplicaDesc) {
+	tenant, cluster, ok := splitHAKey(key)
+	if !ok {
+		return
+	}
+
+	t.electedLock.Lock()
+	defer t.electedLock.Unlock()
+	prev, exists := t.elected[key]
+	if !exists {
+		t.clusters[tenant]++
+	}
+	if !exists || prev.Replica != desc.Replica {
+		t.electedReplicaChanges.WithLabelValues(tenant, cluster).Inc()
+	}
+	t.elected[key] = desc
+	t.electedReplicaTimestamp.WithLabelValues(tenant, cluster).Set(float64(desc.ReceivedAt) / 1000)
+}
+
+// cleanupStaleReplicas removes the clusters which haven't pushed for haTrackerCleanupAge from the KV store
+// and from the local cache.
+func (t *haTracker) cleanupStaleReplicas(ctx context.Context, now time.Time) {
+	deadline := now.Add(-haTrackerCleanupAge).UnixMilli()
+
+	t.electedLock.Lock()
+	var stale []string
+	for key, desc := range t.elected {
+		if desc.ReceivedAt < deadline {
+			stale = append(stale, key)
+			delete(t.elected, key)
+			tenant, cluster, _ := splitHAKey(key)
+			if t.clusters[tenant]--; t.clusters[tenant] <= 0 {
+				delete(t.clusters, tenant)
+			}
+			t.electedReplicaChanges.DeleteLabelValues(tenant, cluster)
+			t.electedReplicaTimestamp.DeleteLabelValues(tenant, cluster)
+			t.kvCASCalls.DeleteLabelValues(tenant, cluster)
+		}
+	}
+	t.electedLock.Unlock()
+
+	for _, key := range stale {
+		value, err := t.client.Get(ctx, key)
+		if err != nil {
+			level.Warn(t.logger).Log("msg", "failed to get the HA replica to clean up", "key", key, "err", err)
+			continue
+		}
+		// Another distributor may have accepted a push from the cluster in the meantime.
+		if desc, ok := value.(*ReplicaDesc); !ok || desc.ReceivedAt >= deadline {
+			continue
+		}
+		if err := t.client.Delete(ctx, key); err != nil {
+			level.Warn(t.logger).Log("msg", "failed to clean up the HA replica", "key", key, "err", err)
+		}
+	}
+}
+
+// checkReplica returns nil if the push of the replica of the cluster of the tenant should be accepted,
+// electing the replica if the cluster doesn't have an elected replica, or if the elected one hasn't
+// pushed for longer than the failover timeout. It returns a replicasNotMatchError otherwise.
+func (t *haTracker) checkReplica(ctx context.Context, userID, cluster, replica string, now time.Time) error {
+	key := userID + "/" + cluster
+
+	t.electedLock.RLock()
+	entry, ok := t.elected[key]
+	clusters := t.clusters[userID]
+	t.electedLock.RUnlock()
+
+	if ok && now.Sub(time.UnixMilli(entry.ReceivedAt)) < t.cfg.UpdateTimeout+t.updateTimeoutJitter {
+		if entry.Replica != replica {
+			return replicasNotMatchError{replica: replica, elected: entry.Replica}
+		}
+		return nil
+	}
+	if !ok {
+		if limit := t.limits.HAMaxClusters(userID); limit > 0 && clusters >= limit {
+			return tooManyClustersError{limit: limit}
+		}
+	}
+
+	t.kvCASCalls.WithLabelValues(userID, cluster).Inc()
+	err := t.client.CAS(ctx, key, func(in interface{}) (out interface{}, retry bool, err error) {
+		if desc, ok := in.(*ReplicaDesc); ok {
+			elapsed := now.Sub(time.UnixMilli(desc.ReceivedAt))
+			// The elected replica was updated recently enough, there's no need to update it again.
+			if desc.Replica == replica && elapsed < t.cfg.UpdateTimeout {
+				return nil, false, nil
+			}
+			// The elected replica is still pushing, there's no failover to the other one.
+			if desc.Replica != replica && elapsed < t.cfg.FailoverTimeout {
+				return nil, false, replicasNotMatchError{replica: replica, elected: desc.Replica}
+			}
+		}
+		return &ReplicaDesc{Replica: replica, ReceivedAt: now.UnixMilli()}, true, nil
+	})
+	if err != nil && !errors.As(err, &replicasNotMatchError{}) {
+		level.Error(t.logger).Log("msg", "failed to update the elected HA replica", "tenant", userID, "cluster", cluster, "replica", replica, "err", err)
+	}
+	return err
+}
+
+// GetReplicaDescCodec returns the codec of the replicas elected by the HA tracker in the KV store.
+func GetReplicaDescCodec() codec.Proto {
+	return codec.NewProtoCodec("replicaDesc", func() proto.Message { return &ReplicaDesc{} })
+}
+
+func splitHAKey(key string) (tenant, cluster string, ok bool) {
+	parts := strings.SplitN(key, "/", 2)
+	if len(parts) != 2 {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+// findHALabels returns the values of the cluster and replica labels of the streams, taken from their
+// first stream since they are set by the client on all of them.
+func findHALabels(clusterLabel, replicaLabel string, streams []logproto.Stream) (cluster, replica string) {
+	if len(streams) == 0 {
+		return "", ""
+	}
+	ls, err := syntax.ParseLabels(streams[0].Labels)
+	if err != nil {
+		return "", ""
+	}
+	return ls.Get(clusterLabel), ls.Get(replicaLabel)
+}
+
+// removeReplicaLabel removes the replica label from the streams, so that the same streams are stored
+// whichever replica of the cluster is elected.
+func removeReplicaLabel(replicaLabel string, streams []logproto.Stream) {
+	for i := range streams {
+		ls, err := syntax.ParseLabels(streams[i].Labels)
+		if err != nil {
+			continue
+		}
+		streams[i].Labels = labels.NewBuilder(ls).Del(replicaLabel).Labels().String()
+	}
+}
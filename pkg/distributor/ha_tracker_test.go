@@ -0,0 +1,123 @@
+package distributor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/grafana/dskit/flagext"
+	"github.com/grafana/dskit/kv/consul"
+	"github.com/grafana/dskit/services"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/loki/pkg/logproto"
+	"github.com/grafana/loki/pkg/util/test"
+)
+
+type mockHATrackerLimits int
+
+func (l mockHATrackerLimits) HAMaxClusters(_ string) int { return int(l) }
+
+func newTestHATracker(t *testing.T, maxClusters int) *haTracker {
+	t.Helper()
+	cfg := HATrackerConfig{}
+	flagext.DefaultValues(&cfg)
+	cfg.EnableHATracker = true
+	cfg.UpdateTimeoutJitterMax = 0
+
+	kvStore, closer := consul.NewInMemoryClient(GetReplicaDescCodec(), log.NewNopLogger(), nil)
+	t.Cleanup(func() { _ = closer.Close() })
+	cfg.KVStore.Mock = kvStore
+
+	tracker, err := newHATracker(cfg, mockHATrackerLimits(maxClusters), prometheus.NewRegistry(), log.NewNopLogger())
+	require.NoError(t, err)
+	require.NoError(t, services.StartAndAwaitRunning(context.Background(), tracker))
+	t.Cleanup(func() { _ = services.StopAndAwaitTerminated(context.Background(), tracker) })
+	return tracker
+}
+
+func TestHATrackerConfig_Validate(t *testing.T) {
+	cfg := HATrackerConfig{}
+	flagext.DefaultValues(&cfg)
+	require.NoError(t, cfg.Validate())
+
+	cfg.EnableHATracker = true
+	require.NoError(t, cfg.Validate())
+
+	cfg.FailoverTimeout = cfg.UpdateTimeout
+	require.Error(t, cfg.Validate())
+
+	flagext.DefaultValues(&cfg)
+	cfg.EnableHATracker = true
+	cfg.KVStore.Store = "memberlist"
+	require.ErrorIs(t, cfg.Validate(), errMemberlistUnsupported)
+}
+
+func TestHATracker_CheckReplica(t *testing.T) {
+	tracker := newTestHATracker(t, 0)
+	ctx := context.Background()
+	now := time.Now()
+
+	// The first replica pushing is elected, and the other one is rejected.
+	require.NoError(t, tracker.checkReplica(ctx, "tenant", "syslog", "a", now))
+	require.ErrorAs(t, tracker.checkReplica(ctx, "tenant", "syslog", "b", now), &replicasNotMatchError{})
+	require.NoError(t, tracker.checkReplica(ctx, "tenant", "syslog", "a", now.Add(time.Second)))
+
+	// Another cluster and another tenant are elected independently.
+	require.NoError(t, tracker.checkReplica(ctx, "tenant", "kafka", "b", now))
+	require.NoError(t, tracker.checkReplica(ctx, "other", "syslog", "b", now))
+
+	// The other replica is elected once the elected one has stopped pushing for the failover timeout.
+	later := now.Add(tracker.cfg.FailoverTimeout + time.Second)
+	require.NoError(t, tracker.checkReplica(ctx, "tenant", "syslog", "b", later))
+	require.ErrorAs(t, tracker.checkReplica(ctx, "tenant", "syslog", "a", later), &replicasNotMatchError{})
+}
+
+func TestHATracker_MaxClusters(t *testing.T) {
+	tracker := newTestHATracker(t, 1)
+	ctx := context.Background()
+
+	require.NoError(t, tracker.checkReplica(ctx, "tenant", "syslog", "a", time.Now()))
+	test.Poll(t, time.Second, 1, func() interface{} {
+		tracker.electedLock.RLock()
+		defer tracker.electedLock.RUnlock()
+		return tracker.clusters["tenant"]
+	})
+	require.ErrorAs(t, tracker.checkReplica(ctx, "tenant", "kafka", "a", time.Now()), &tooManyClustersError{})
+	require.NoError(t, tracker.checkReplica(ctx, "other", "kafka", "a", time.Now()))
+}
+
+func TestHATracker_CleanupStaleReplicas(t *testing.T) {
+	tracker := newTestHATracker(t, 0)
+	ctx := context.Background()
+	now := time.Now()
+
+	require.NoError(t, tracker.checkReplica(ctx, "tenant", "syslog", "a", now))
+	test.Poll(t, time.Second, 1, func() interface{} {
+		tracker.electedLock.RLock()
+		defer tracker.electedLock.RUnlock()
+		return len(tracker.elected)
+	})
+
+	tracker.cleanupStaleReplicas(ctx, now.Add(haTrackerCleanupAge+time.Minute))
+	value, err := tracker.client.Get(ctx, "tenant/syslog")
+	require.NoError(t, err)
+	require.Nil(t, value)
+	require.Empty(t, tracker.clusters)
+}
+
+func TestRemoveReplicaLabel(t *testing.T) {
+	streams := []logproto.Stream{
+		{Labels: `{__replica__="a", app="foo", cluster="syslog"}`},
+		{Labels: `{app="bar", cluster="syslog"}`},
+	}
+	cluster, replica := findHALabels("cluster", "__replica__", streams)
+	require.Equal(t, "syslog", cluster)
+	require.Equal(t, "a", replica)
+
+	removeReplicaLabel("__replica__", streams)
+	require.Equal(t, `{app="foo", cluster="syslog"}`, streams[0].Labels)
+	require.Equal(t, `{app="bar", cluster="syslog"}`, streams[1].Labels)
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
 	"github.com/weaveworks/common/httpgrpc"
 
@@ -12,12 +13,30 @@ import (
 	"github.com/grafana/dskit/tenant"
 
 	"github.com/grafana/loki/pkg/loghttp/push"
+	"github.com/grafana/loki/pkg/logproto"
 	util_log "github.com/grafana/loki/pkg/util/log"
 	"github.com/grafana/loki/pkg/validation"
 )
 
 // PushHandler reads a snappy-compressed proto from the HTTP body.
 func (d *Distributor) PushHandler(w http.ResponseWriter, r *http.Request) {
+	d.pushHandler(w, r, func(logger log.Logger, tenantID string) (*logproto.PushRequest, error) {
+		return push.ParseRequest(logger, tenantID, r, d.tenantsRetention)
+	}, func() {
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// OTLPPushHandler reads an OTLP logs export request, in protobuf or JSON, from the HTTP body.
+func (d *Distributor) OTLPPushHandler(w http.ResponseWriter, r *http.Request) {
+	d.pushHandler(w, r, func(logger log.Logger, tenantID string) (*logproto.PushRequest, error) {
+		return push.ParseOTLPRequest(logger, tenantID, r, d.tenantsRetention, d.limits.OTLPConfig(tenantID))
+	}, func() {
+		push.WriteOTLPResponse(w, r)
+	})
+}
+
+func (d *Distributor) pushHandler(w http.ResponseWriter, r *http.Request, parse func(logger log.Logger, tenantID string) (*logproto.PushRequest, error), writeResponse func()) {
 	logger := util_log.WithContext(r.Context(), util_log.Logger)
 	tenantID, err := tenant.TenantID(r.Context())
 	if err != nil {
@@ -25,7 +44,7 @@ func (d *Distributor) PushHandler(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-	req, err := push.ParseRequest(logger, tenantID, r, d.tenantsRetention)
+	req, err := parse(logger, tenantID)
 	if err != nil {
 		if d.tenantConfigs.LogPushRequest(tenantID) {
 			level.Debug(logger).Log(
@@ -56,7 +75,7 @@ func (d *Distributor) PushHandler(w http.ResponseWriter, r *http.Request) {
 				"msg", "push request successful",
 			)
 		}
-		w.WriteHeader(http.StatusNoContent)
+		writeResponse()
 		return
 	}
 
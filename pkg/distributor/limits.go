@@ -1,6 +1,12 @@
 package distributor
 
-import "time"
+import (
+	"time"
+
+	"github.com/grafana/loki/pkg/distributor/shardstreams"
+	"github.com/grafana/loki/pkg/loghttp/push"
+	"github.com/grafana/loki/pkg/validation"
+)
 
 // Limits is an interface for distributor limits/related configs
 type Limits interface {
@@ -16,4 +22,16 @@ type Limits interface {
 	RejectOldSamplesMaxAge(userID string) time.Duration
 
 	FudgeDuplicateTimestamps(userID string) bool
+	AllowStructuredMetadata(userID string) bool
+
+	ShardStreams(userID string) shardstreams.Config
+	OTLPConfig(userID string) push.OTLPConfig
+	LabelPolicy(userID string) *validation.LabelPolicy
+	RedactionRules(userID string) []*validation.RedactionRule
+	DropRules(userID string) []*validation.DropRule
+
+	AcceptHASamples(userID string) bool
+	HAClusterLabel(userID string) string
+	HAReplicaLabel(userID string) string
+	HAMaxClusters(userID string) int
 }
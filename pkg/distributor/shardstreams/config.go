@@ -0,0 +1,21 @@
+package shardstreams
+
+import (
+	"flag"
+
+	"github.com/grafana/loki/pkg/util/flagext"
+)
+
+// Config configures the automatic sharding of the streams of a tenant in the distributor.
+type Config struct {
+	Enabled bool `yaml:"enabled" json:"enabled"`
+
+	// DesiredRate is the rate, in bytes per second, above which a stream is split into shards.
+	DesiredRate flagext.ByteSize `yaml:"desired_rate" json:"desired_rate"`
+}
+
+func (cfg *Config) RegisterFlagsWithPrefix(prefix string, fs *flag.FlagSet) {
+	fs.BoolVar(&cfg.Enabled, prefix+".enabled", false, "Automatically shard the streams whose ingestion rate is above the desired rate.")
+	_ = cfg.DesiredRate.Set("1536KB")
+	fs.Var(&cfg.DesiredRate, prefix+".desired-rate", "Ingestion rate of a stream, in bytes per second, above which the stream is split into shards. The stream is split into as many shards as needed for each shard to stay below this rate, each shard being identified by the __stream_shard__ label.")
+}
@@ -0,0 +1,117 @@
+package distributor
+
+import (
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/prometheus/model/labels"
+
+	"github.com/grafana/loki/pkg/logproto"
+	"github.com/grafana/loki/pkg/logql/log"
+	"github.com/grafana/loki/pkg/logql/syntax"
+)
+
+// streamRateWindow is the window over which the ingestion rate of the streams is computed.
+const streamRateWindow = 10 * time.Second
+
+type streamKey struct {
+	userID string
+	labels string
+}
+
+type streamRate struct {
+	windowStart time.Time
+	bytes       int     // bytes pushed since the start of the current window.
+	lastRate    float64 // rate of the previous window, in bytes per second.
+}
+
+// streamRates tracks the ingestion rate of the streams pushed to the distributor.
+// The rate of a stream is the one observed by this distributor only.
+type streamRates struct {
+	mtx    sync.Mutex
+	window time.Duration
+	rates  map[streamKey]*streamRate
+}
+
+func newStreamRates(window time.Duration) *streamRates {
+	return &streamRates{
+		window: window,
+		rates:  map[streamKey]*streamRate{},
+	}
+}
+
+// add records bytes pushed to the stream and returns its current rate in bytes per second.
+// The current rate is the highest of the rate of the previous window and the rate of the
+// bytes pushed so far in the current one, so that a stream going hot is detected before
+// the end of the window.
+func (r *streamRates) add(now time.Time, key streamKey, bytes int) float64 {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	rate, ok := r.rates[key]
+	if !ok {
+		rate = &streamRate{windowStart: now}
+		r.rates[key] = rate
+	}
+	if elapsed := now.Sub(rate.windowStart); elapsed >= r.window {
+		rate.lastRate = 0
+		if elapsed < 2*r.window {
+			rate.lastRate = float64(rate.bytes) / r.window.Seconds()
+		}
+		rate.windowStart = now
+		rate.bytes = 0
+	}
+	rate.bytes += bytes
+
+	return math.Max(rate.lastRate, float64(rate.bytes)/r.window.Seconds())
+}
+
+// removeStale removes the streams that have not been pushed for two windows.
+func (r *streamRates) removeStale(now time.Time) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	for key, rate := range r.rates {
+		if now.Sub(rate.windowStart) >= 2*r.window {
+			delete(r.rates, key)
+		}
+	}
+}
+
+// shardCountFor returns the number of shards the stream must be split into for each shard
+// to stay below the desired rate. The stream is not split into more shards than it has entries.
+func shardCountFor(rate float64, desiredRate int, entries int) int {
+	if desiredRate <= 0 {
+		return 1
+	}
+	shards := int(math.Ceil(rate / float64(desiredRate)))
+	if shards > entries {
+		shards = entries
+	}
+	if shards < 1 {
+		shards = 1
+	}
+	return shards
+}
+
+// shardStream splits the entries of the stream into contiguous shards, each one labeled
+// with its shard number so that the shards are distributed across the ring.
+func shardStream(stream logproto.Stream, shards int) ([]logproto.Stream, error) {
+	ls, err := syntax.ParseLabels(stream.Labels)
+	if err != nil {
+		return nil, err
+	}
+	builder := labels.NewBuilder(ls)
+
+	res := make([]logproto.Stream, 0, shards)
+	for i := 0; i < shards; i++ {
+		from, to := i*len(stream.Entries)/shards, (i+1)*len(stream.Entries)/shards
+		res = append(res, logproto.Stream{
+			Labels:  builder.Set(log.StreamShardLabel, strconv.Itoa(i)).Labels().String(),
+			Entries: stream.Entries[from:to],
+		})
+	}
+	return res, nil
+}
@@ -0,0 +1,72 @@
+package distributor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/loki/pkg/logproto"
+)
+
+func Test_StreamRates(t *testing.T) {
+	rates := newStreamRates(10 * time.Second)
+	key := streamKey{userID: "fake", labels: `{foo="bar"}`}
+	now := time.Now()
+
+	// The rate of the current window is a lower bound until the window is over.
+	require.Equal(t, 100.0, rates.add(now, key, 1000))
+	require.Equal(t, 300.0, rates.add(now.Add(time.Second), key, 2000))
+
+	// The rate of the previous window is kept while the new one is filling up.
+	require.Equal(t, 300.0, rates.add(now.Add(10*time.Second), key, 100))
+	require.Equal(t, 400.0, rates.add(now.Add(11*time.Second), key, 3900))
+
+	// The rate is reset when the stream has not been pushed for a whole window.
+	require.Equal(t, 10.0, rates.add(now.Add(40*time.Second), key, 100))
+
+	rates.removeStale(now.Add(59 * time.Second))
+	require.Len(t, rates.rates, 1)
+	rates.removeStale(now.Add(60 * time.Second))
+	require.Len(t, rates.rates, 0)
+}
+
+func Test_ShardCountFor(t *testing.T) {
+	for _, tc := range []struct {
+		desc        string
+		rate        float64
+		desiredRate int
+		entries     int
+		expected    int
+	}{
+		{desc: "below the desired rate", rate: 100, desiredRate: 1000, entries: 10, expected: 1},
+		{desc: "above the desired rate", rate: 2500, desiredRate: 1000, entries: 10, expected: 3},
+		{desc: "more shards than entries", rate: 10000, desiredRate: 1000, entries: 5, expected: 5},
+		{desc: "no desired rate", rate: 10000, desiredRate: 0, entries: 10, expected: 1},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			require.Equal(t, tc.expected, shardCountFor(tc.rate, tc.desiredRate, tc.entries))
+		})
+	}
+}
+
+func Test_ShardStream(t *testing.T) {
+	now := time.Now()
+	stream := logproto.Stream{
+		Labels: `{foo="bar", job="ingress"}`,
+		Entries: []logproto.Entry{
+			{Timestamp: now, Line: "1"},
+			{Timestamp: now.Add(1), Line: "2"},
+			{Timestamp: now.Add(2), Line: "3"},
+			{Timestamp: now.Add(3), Line: "4"},
+			{Timestamp: now.Add(4), Line: "5"},
+		},
+	}
+
+	shards, err := shardStream(stream, 2)
+	require.NoError(t, err)
+	require.Equal(t, []logproto.Stream{
+		{Labels: `{__stream_shard__="0", foo="bar", job="ingress"}`, Entries: stream.Entries[:2]},
+		{Labels: `{__stream_shard__="1", foo="bar", job="ingress"}`, Entries: stream.Entries[2:]},
+	}, shards)
+}
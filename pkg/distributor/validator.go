@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/weaveworks/common/httpgrpc"
 
@@ -41,6 +42,11 @@ type validationContext struct {
 	maxLabelValueLength    int
 
 	fudgeDuplicateTimestamps bool
+	allowStructuredMetadata  bool
+
+	labelPolicy    *validation.LabelPolicy
+	redactionRules []*validation.RedactionRule
+	dropRules      []*validation.DropRule
 
 	userID string
 }
@@ -57,6 +63,10 @@ func (v Validator) getValidationContextForTime(now time.Time, userID string) val
 		maxLabelNameLength:       v.MaxLabelNameLength(userID),
 		maxLabelValueLength:      v.MaxLabelValueLength(userID),
 		fudgeDuplicateTimestamps: v.FudgeDuplicateTimestamps(userID),
+		allowStructuredMetadata:  v.AllowStructuredMetadata(userID),
+		labelPolicy:              v.LabelPolicy(userID),
+		redactionRules:           v.RedactionRules(userID),
+		dropRules:                v.DropRules(userID),
 	}
 }
 
@@ -90,6 +100,21 @@ func (v Validator) ValidateEntry(ctx validationContext, labels string, entry log
 		return httpgrpc.Errorf(http.StatusBadRequest, validation.LineTooLongErrorMsg, maxSize, labels, len(entry.Line))
 	}
 
+	if len(entry.StructuredMetadata) > 0 {
+		if !ctx.allowStructuredMetadata {
+			validation.DiscardedSamples.WithLabelValues(validation.DisallowedStructuredMetadata, ctx.userID).Inc()
+			validation.DiscardedBytes.WithLabelValues(validation.DisallowedStructuredMetadata, ctx.userID).Add(float64(len(entry.Line)))
+			return httpgrpc.Errorf(http.StatusBadRequest, validation.DisallowedStructuredMetadataErrorMsg, labels)
+		}
+		for _, l := range entry.StructuredMetadata {
+			if !model.LabelName(l.Name).IsValid() {
+				validation.DiscardedSamples.WithLabelValues(validation.InvalidStructuredMetadata, ctx.userID).Inc()
+				validation.DiscardedBytes.WithLabelValues(validation.InvalidStructuredMetadata, ctx.userID).Add(float64(len(entry.Line)))
+				return httpgrpc.Errorf(http.StatusBadRequest, validation.InvalidStructuredMetadataErrorMsg, labels, l.Name)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -127,6 +152,50 @@ func (v Validator) ValidateLabels(ctx validationContext, ls labels.Labels, strea
 	return nil
 }
 
+// ApplyLabelPolicy returns the labels once the label policy of the tenant is applied, with the values of
+// the forbidden labels replaced with the fallback value of the policy, if any. It returns an error if the
+// labels don't comply with the policy.
+func (v Validator) ApplyLabelPolicy(ctx validationContext, ls labels.Labels, stream logproto.Stream) (labels.Labels, error) {
+	policy := ctx.labelPolicy
+	if policy == nil || policy.IsEmpty() {
+		return ls, nil
+	}
+
+	mutated := false
+	for _, name := range policy.ForbiddenLabels {
+		for i := range ls {
+			if ls[i].Name != name {
+				continue
+			}
+			if policy.ForbiddenLabelsFallback == "" {
+				updateMetrics(validation.ForbiddenLabel, ctx.userID, stream)
+				return nil, httpgrpc.Errorf(http.StatusBadRequest, validation.ForbiddenLabelErrorMsg, stream.Labels, name)
+			}
+			ls[i].Value = policy.ForbiddenLabelsFallback
+			mutated = true
+		}
+	}
+
+	for _, name := range policy.RequiredLabels {
+		if !ls.Has(name) {
+			updateMetrics(validation.MissingRequiredLabel, ctx.userID, stream)
+			return nil, httpgrpc.Errorf(http.StatusBadRequest, validation.MissingRequiredLabelErrorMsg, stream.Labels, name)
+		}
+	}
+
+	for _, l := range ls {
+		if re, ok := policy.ValueRegexps[l.Name]; ok && !re.MatchString(l.Value) {
+			updateMetrics(validation.InvalidLabelValue, ctx.userID, stream)
+			return nil, httpgrpc.Errorf(http.StatusBadRequest, validation.InvalidLabelValueErrorMsg, stream.Labels, l.Name, l.Value, policy.LabelValuePatterns[l.Name])
+		}
+	}
+
+	if mutated {
+		validation.MutatedSamples.WithLabelValues(validation.ForbiddenLabel, ctx.userID).Add(float64(len(stream.Entries)))
+	}
+	return ls, nil
+}
+
 func updateMetrics(reason, userID string, stream logproto.Stream) {
 	validation.DiscardedSamples.WithLabelValues(reason, userID).Inc()
 	bytes := 0
@@ -85,6 +85,35 @@ func TestValidator_ValidateEntry(t *testing.T) {
 			logproto.Entry{Timestamp: testTime, Line: "12345678901"},
 			httpgrpc.Errorf(http.StatusBadRequest, validation.LineTooLongErrorMsg, 10, testStreamLabels, 11),
 		},
+		{
+			"disallowed structured metadata",
+			"test",
+			nil,
+			logproto.Entry{Timestamp: testTime, Line: "test", StructuredMetadata: []logproto.LabelAdapter{{Name: "traceID", Value: "123"}}},
+			httpgrpc.Errorf(http.StatusBadRequest, validation.DisallowedStructuredMetadataErrorMsg, testStreamLabels),
+		},
+		{
+			"valid structured metadata",
+			"test",
+			fakeLimits{
+				&validation.Limits{
+					AllowStructuredMetadata: true,
+				},
+			},
+			logproto.Entry{Timestamp: testTime, Line: "test", StructuredMetadata: []logproto.LabelAdapter{{Name: "traceID", Value: "123"}}},
+			nil,
+		},
+		{
+			"invalid structured metadata name",
+			"test",
+			fakeLimits{
+				&validation.Limits{
+					AllowStructuredMetadata: true,
+				},
+			},
+			logproto.Entry{Timestamp: testTime, Line: "test", StructuredMetadata: []logproto.LabelAdapter{{Name: "trace.id", Value: "123"}}},
+			httpgrpc.Errorf(http.StatusBadRequest, validation.InvalidStructuredMetadataErrorMsg, testStreamLabels, "trace.id"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -202,6 +231,85 @@ func TestValidator_ValidateLabels(t *testing.T) {
 	}
 }
 
+func TestValidator_ApplyLabelPolicy(t *testing.T) {
+	policy := validation.LabelPolicy{
+		RequiredLabels:     []string{"namespace"},
+		ForbiddenLabels:    []string{"pod_ip"},
+		LabelValuePatterns: map[string]string{"env": "prod|dev"},
+	}
+	fallbackPolicy := policy
+	fallbackPolicy.ForbiddenLabelsFallback = "other"
+
+	tests := []struct {
+		name     string
+		policy   validation.LabelPolicy
+		labels   string
+		expected string
+		err      error
+	}{
+		{
+			"no policy",
+			validation.LabelPolicy{},
+			`{pod_ip="10.0.0.1"}`,
+			`{pod_ip="10.0.0.1"}`,
+			nil,
+		},
+		{
+			"valid",
+			policy,
+			`{env="prod", namespace="loki"}`,
+			`{env="prod", namespace="loki"}`,
+			nil,
+		},
+		{
+			"missing required label",
+			policy,
+			`{env="prod"}`,
+			"",
+			httpgrpc.Errorf(http.StatusBadRequest, validation.MissingRequiredLabelErrorMsg, `{env="prod"}`, "namespace"),
+		},
+		{
+			"forbidden label",
+			policy,
+			`{namespace="loki", pod_ip="10.0.0.1"}`,
+			"",
+			httpgrpc.Errorf(http.StatusBadRequest, validation.ForbiddenLabelErrorMsg, `{namespace="loki", pod_ip="10.0.0.1"}`, "pod_ip"),
+		},
+		{
+			"forbidden label mapped to the fallback value",
+			fallbackPolicy,
+			`{namespace="loki", pod_ip="10.0.0.1"}`,
+			`{namespace="loki", pod_ip="other"}`,
+			nil,
+		},
+		{
+			"label value not matching the pattern",
+			policy,
+			`{env="production", namespace="loki"}`,
+			"",
+			httpgrpc.Errorf(http.StatusBadRequest, validation.InvalidLabelValueErrorMsg, `{env="production", namespace="loki"}`, "env", "production", "prod|dev"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := &validation.Limits{}
+			flagext.DefaultValues(l)
+			l.LabelPolicy = tt.policy
+			assert.NoError(t, l.Validate())
+			o, err := validation.NewOverrides(*l, nil)
+			assert.NoError(t, err)
+			v, err := NewValidator(o)
+			assert.NoError(t, err)
+
+			ls, err := v.ApplyLabelPolicy(v.getValidationContextForTime(testTime, "test"), mustParseLabels(tt.labels), logproto.Stream{Labels: tt.labels})
+			assert.Equal(t, tt.err, err)
+			if tt.err == nil {
+				assert.Equal(t, tt.expected, ls.String())
+			}
+		})
+	}
+}
+
 func mustParseLabels(s string) labels.Labels {
 	ls, err := syntax.ParseLabels(s)
 	if err != nil {
@@ -1,14 +1,18 @@
 package ingester
 
 import (
+	"bufio"
 	"bytes"
 	"context"
+	"encoding/json"
 	fmt "fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/dustin/go-humanize"
@@ -16,6 +20,7 @@ import (
 	"github.com/go-kit/log/level"
 	"github.com/gogo/protobuf/proto"
 	"github.com/pkg/errors"
+	"github.com/prometheus/prometheus/model/labels"
 	tsdb_errors "github.com/prometheus/prometheus/tsdb/errors"
 	"github.com/prometheus/prometheus/tsdb/fileutil"
 	"github.com/prometheus/prometheus/tsdb/wal"
@@ -90,7 +95,7 @@ func toWireChunks(descs []chunkDesc, wireChunks []chunkWithBuffer) ([]chunkWithB
 	return wireChunks, nil
 }
 
-func fromWireChunks(conf *Config, wireChunks []Chunk) ([]chunkDesc, error) {
+func fromWireChunks(conf *Config, headfmt chunkenc.HeadBlockFmt, wireChunks []Chunk) ([]chunkDesc, error) {
 	descs := make([]chunkDesc, 0, len(wireChunks))
 	for _, c := range wireChunks {
 		desc := chunkDesc{
@@ -100,14 +105,13 @@ func fromWireChunks(conf *Config, wireChunks []Chunk) ([]chunkDesc, error) {
 			lastUpdated: c.LastUpdated,
 		}
 
-		// Always use Unordered headblocks during replay
-		// to ensure Loki can effectively replay an unordered-friendly
-		// WAL into a new configuration that disables unordered writes.
-		hbType := chunkenc.UnorderedHeadBlockFmt
-		mc, err := chunkenc.MemchunkFromCheckpoint(c.Data, c.Head, hbType, conf.BlockSize, conf.TargetChunkSize)
+		mc, err := chunkenc.MemchunkFromCheckpoint(c.Data, c.Head, headfmt, conf.BlockSize, conf.TargetChunkSize)
 		if err != nil {
 			return nil, err
 		}
+		if conf.ChunkBloomFilters {
+			mc.EnableBloomFilters()
+		}
 		desc.chunk = mc
 
 		descs = append(descs, desc)
@@ -306,15 +310,107 @@ type walLogger interface {
 	Dir() string
 }
 
+// checkpointShardPrefix is the prefix of the directories holding the shards of a sharded checkpoint.
+const checkpointShardPrefix = "shard-"
+
+// checkpointLabelsFile is the file of each shard of a checkpoint listing the tenants and the labels of its
+// streams, so that the shards holding the streams matched by a query can be found without replaying them.
+const checkpointLabelsFile = "labels.json"
+
+// checkpointShard is a shard of the checkpoint being written.
+type checkpointShard struct {
+	wal     walLogger
+	bufSize int
+	recs    [][]byte
+
+	labelsFile *os.File
+	labelsBuf  *bufio.Writer
+	labels     *json.Encoder
+}
+
+// checkpointStreamLabels is an entry of the labels file of a checkpoint shard.
+type checkpointStreamLabels struct {
+	UserID      string        `json:"user"`
+	Fingerprint uint64        `json:"fp"`
+	Labels      labels.Labels `json:"labels"`
+}
+
+// newCheckpointShard opens a shard of the checkpoint, writing the labels of its streams along with it if withLabels is set.
+func newCheckpointShard(dir string, withLabels bool) (*checkpointShard, error) {
+	checkpoint, err := wal.NewSize(log.With(util_log.Logger, "component", "checkpoint_wal"), nil, dir, walSegmentSize, false)
+	if err != nil {
+		return nil, err
+	}
+	if !withLabels {
+		return &checkpointShard{wal: checkpoint}, nil
+	}
+	f, err := os.Create(filepath.Join(dir, checkpointLabelsFile))
+	if err != nil {
+		checkpoint.Close()
+		return nil, err
+	}
+	buf := bufio.NewWriter(f)
+	return &checkpointShard{
+		wal:        checkpoint,
+		labelsFile: f,
+		labelsBuf:  buf,
+		labels:     json.NewEncoder(buf),
+	}, nil
+}
+
+func (s *checkpointShard) close() error {
+	if s.labelsFile != nil {
+		if err := s.labelsBuf.Flush(); err != nil {
+			s.labelsFile.Close()
+			return err
+		}
+		if err := s.labelsFile.Close(); err != nil {
+			return err
+		}
+	}
+	return s.wal.Close()
+}
+
+// readCheckpointLabels reads the labels of the streams of a checkpoint shard.
+// It returns nil if the checkpoint doesn't have them, i.e. when it was written by a previous version
+// or without lazy replay.
+func readCheckpointLabels(dir string) ([]checkpointStreamLabels, error) {
+	f, err := os.Open(filepath.Join(dir, checkpointLabelsFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	res := []checkpointStreamLabels{}
+	dec := json.NewDecoder(bufio.NewReader(f))
+	for {
+		var entry checkpointStreamLabels
+		if err := dec.Decode(&entry); err == io.EOF {
+			return res, nil
+		} else if err != nil {
+			return nil, err
+		}
+		res = append(res, entry)
+	}
+}
+
 type WALCheckpointWriter struct {
 	metrics    *ingesterMetrics
 	segmentWAL *wal.WAL
+	// The streams are split by their labels into this many shards, each one written into its own
+	// directory of the checkpoint so that they can be replayed independently.
+	// The checkpoint is not sharded if it is 1 or less.
+	shards int
+	// Whether the labels of the streams are written along with each shard, for the lazy replay.
+	// They are always written if the checkpoint is sharded.
+	writeLabels bool
 
-	checkpointWAL walLogger
-	lastSegment   int    // name of the last segment guaranteed to be covered by the checkpoint
-	final         string // filename to atomically rotate upon completion
-	bufSize       int
-	recs          [][]byte
+	checkpointShards []*checkpointShard
+	checkpointDir    string // temporary directory of the checkpoint being written
+	lastSegment      int    // name of the last segment guaranteed to be covered by the checkpoint
+	final            string // filename to atomically rotate upon completion
 }
 
 func (w *WALCheckpointWriter) Advance() (bool, error) {
@@ -352,12 +448,27 @@ func (w *WALCheckpointWriter) Advance() (bool, error) {
 		return false, errors.Wrap(err, "create checkpoint dir")
 	}
 
-	checkpoint, err := wal.NewSize(log.With(util_log.Logger, "component", "checkpoint_wal"), nil, checkpointDirTemp, walSegmentSize, false)
-	if err != nil {
-		return false, errors.Wrap(err, "open checkpoint")
+	shardDirs := []string{checkpointDirTemp}
+	if w.shards > 1 {
+		shardDirs = make([]string, 0, w.shards)
+		for i := 0; i < w.shards; i++ {
+			shardDirs = append(shardDirs, filepath.Join(checkpointDirTemp, fmt.Sprintf(checkpointShardPrefix+"%03d", i)))
+		}
 	}
 
-	w.checkpointWAL = checkpoint
+	w.checkpointShards = make([]*checkpointShard, 0, len(shardDirs))
+	for _, dir := range shardDirs {
+		shard, err := newCheckpointShard(dir, w.writeLabels || w.shards > 1)
+		if err != nil {
+			for _, shard := range w.checkpointShards {
+				shard.close()
+			}
+			return false, errors.Wrap(err, "open checkpoint")
+		}
+		w.checkpointShards = append(w.checkpointShards, shard)
+	}
+
+	w.checkpointDir = checkpointDirTemp
 	w.lastSegment = lastSegment
 	w.final = checkpointDir
 
@@ -376,33 +487,83 @@ func (w *WALCheckpointWriter) Write(s *Series) error {
 		return err
 	}
 
-	w.recs = append(w.recs, b)
-	w.bufSize += len(b)
+	ls := logproto.FromLabelAdaptersToLabels(s.Labels)
+	shard := w.checkpointShards[0]
+	if len(w.checkpointShards) > 1 {
+		shard = w.checkpointShards[checkpointShardFor(ls, len(w.checkpointShards))]
+	}
+	if shard.labels != nil {
+		if err := shard.labels.Encode(checkpointStreamLabels{UserID: s.UserID, Fingerprint: s.Fingerprint, Labels: ls}); err != nil {
+			return err
+		}
+	}
+	shard.recs = append(shard.recs, b)
+	shard.bufSize += len(b)
 	level.Debug(util_log.Logger).Log("msg", "writing series", "size", humanize.Bytes(uint64(len(b))))
 
 	// 1MB
-	if w.bufSize > 1<<20 {
-		if err := w.flush(); err != nil {
+	if shard.bufSize > 1<<20 {
+		if err := w.flush(shard); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func (w *WALCheckpointWriter) flush() error {
-	level.Debug(util_log.Logger).Log("msg", "flushing series", "totalSize", humanize.Bytes(uint64(w.bufSize)), "series", len(w.recs))
-	if err := w.checkpointWAL.Log(w.recs...); err != nil {
+func (w *WALCheckpointWriter) flush(shard *checkpointShard) error {
+	level.Debug(util_log.Logger).Log("msg", "flushing series", "totalSize", humanize.Bytes(uint64(shard.bufSize)), "series", len(shard.recs))
+	if err := shard.wal.Log(shard.recs...); err != nil {
 		return err
 	}
-	w.metrics.checkpointLoggedBytesTotal.Add(float64(w.bufSize))
-	for _, b := range w.recs {
+	w.metrics.checkpointLoggedBytesTotal.Add(float64(shard.bufSize))
+	for _, b := range shard.recs {
 		recordBufferPool.Put(b)
 	}
-	w.recs = w.recs[:0]
-	w.bufSize = 0
+	shard.recs = shard.recs[:0]
+	shard.bufSize = 0
 	return nil
 }
 
+// checkpointShardFor returns the shard of the checkpoint holding the stream with the given labels.
+func checkpointShardFor(ls labels.Labels, shards int) int {
+	if shards <= 1 {
+		return 0
+	}
+	return int(ls.Hash() % uint64(shards))
+}
+
+// checkpointShardDirs returns the directories of the shards of the checkpoint, ordered by shard.
+// A checkpoint that is not sharded has a single shard, the checkpoint directory itself.
+func checkpointShardDirs(checkpointDir string) ([]string, error) {
+	files, err := ioutil.ReadDir(checkpointDir)
+	if err != nil {
+		return nil, err
+	}
+	shards := map[int]string{}
+	for _, fi := range files {
+		if !fi.IsDir() || !strings.HasPrefix(fi.Name(), checkpointShardPrefix) {
+			continue
+		}
+		idx, err := strconv.Atoi(strings.TrimPrefix(fi.Name(), checkpointShardPrefix))
+		if err != nil {
+			return nil, fmt.Errorf("invalid checkpoint shard %s", fi.Name())
+		}
+		shards[idx] = filepath.Join(checkpointDir, fi.Name())
+	}
+	if len(shards) == 0 {
+		return []string{checkpointDir}, nil
+	}
+
+	dirs := make([]string, len(shards))
+	for idx, dir := range shards {
+		if idx < 0 || idx >= len(dirs) {
+			return nil, fmt.Errorf("checkpoint %s is missing shards", checkpointDir)
+		}
+		dirs[idx] = dir
+	}
+	return dirs, nil
+}
+
 const checkpointPrefix = "checkpoint."
 
 var checkpointRe = regexp.MustCompile("^" + regexp.QuoteMeta(checkpointPrefix) + "(\\d+)(\\.tmp)?$")
@@ -483,23 +644,25 @@ func (w *WALCheckpointWriter) deleteCheckpoints(maxIndex int) (err error) {
 }
 
 func (w *WALCheckpointWriter) Close(abort bool) error {
-	if len(w.recs) > 0 {
-		if err := w.flush(); err != nil {
+	for _, shard := range w.checkpointShards {
+		if len(shard.recs) > 0 {
+			if err := w.flush(shard); err != nil {
+				return err
+			}
+		}
+		if err := shard.close(); err != nil {
 			return err
 		}
 	}
-	if err := w.checkpointWAL.Close(); err != nil {
-		return err
-	}
 
 	if abort {
-		return os.RemoveAll(w.checkpointWAL.Dir())
+		return os.RemoveAll(w.checkpointDir)
 	}
 
-	if err := fileutil.Replace(w.checkpointWAL.Dir(), w.final); err != nil {
+	if err := fileutil.Replace(w.checkpointDir, w.final); err != nil {
 		return errors.Wrap(err, "rename checkpoint directory")
 	}
-	level.Info(util_log.Logger).Log("msg", "atomic checkpoint finished", "old", w.checkpointWAL.Dir(), "new", w.final)
+	level.Info(util_log.Logger).Log("msg", "atomic checkpoint finished", "old", w.checkpointDir, "new", w.final, "shards", len(w.checkpointShards))
 	// We delete the WAL segments which are before the previous checkpoint and not before the
 	// current checkpoint created. This is because if the latest checkpoint is corrupted for any reason, we
 	// should be able to recover from the older checkpoint which would need the older WAL segments.
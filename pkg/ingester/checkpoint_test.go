@@ -123,6 +123,13 @@ func TestIngesterWAL(t *testing.T) {
 
 	require.Nil(t, services.StopAndAwaitTerminated(context.Background(), i))
 
+	// ensure the labels of the streams are only written for the lazy replay or a sharded checkpoint
+	checkpointDir, _, err := lastCheckpoint(walDir)
+	require.NoError(t, err)
+	streams, err := readCheckpointLabels(checkpointDir)
+	require.NoError(t, err)
+	require.Nil(t, streams)
+
 	// restart the ingester
 	i, err = New(ingesterConfig, client.Config{}, newStore(), limits, runtime.DefaultTenantConfigs(), nil)
 	require.NoError(t, err)
@@ -133,6 +140,159 @@ func TestIngesterWAL(t *testing.T) {
 	ensureIngesterData(ctx, t, start, end, i)
 }
 
+func TestIngesterWALLazyReplayShardedCheckpoint(t *testing.T) {
+	walDir := t.TempDir()
+
+	ingesterConfig := defaultIngesterTestConfigWithWAL(t, walDir)
+	ingesterConfig.WAL.CheckpointShards = 4
+	ingesterConfig.WAL.LazyReplay = true
+
+	limits, err := validation.NewOverrides(defaultLimitsTestConfig(), nil)
+	require.NoError(t, err)
+
+	newStore := func() *mockStore {
+		return &mockStore{
+			chunks: map[string][]chunk.Chunk{},
+		}
+	}
+
+	start := time.Now()
+	steps := 10
+	end := start.Add(time.Second * time.Duration(steps))
+	push := func(i *Ingester, from, to int) {
+		// The labels are sorted, as pushed by the distributors, for the pushes to the replayed streams
+		// not to create new streams.
+		req := logproto.PushRequest{
+			Streams: []logproto.Stream{
+				{
+					Labels: `{bar="baz1", foo="bar"}`,
+				},
+				{
+					Labels: `{bar="baz2", foo="bar"}`,
+				},
+			},
+		}
+		for step := from; step < to; step++ {
+			for j := range req.Streams {
+				req.Streams[j].Entries = append(req.Streams[j].Entries, logproto.Entry{
+					Timestamp: start.Add(time.Duration(step) * time.Second),
+					Line:      fmt.Sprintf("line %d", step),
+				})
+			}
+		}
+		_, err := i.Push(user.InjectOrgID(context.Background(), "test"), &req)
+		require.NoError(t, err)
+	}
+
+	i, err := New(ingesterConfig, client.Config{}, newStore(), limits, runtime.DefaultTenantConfigs(), nil)
+	require.NoError(t, err)
+	require.Nil(t, services.StartAndAwaitRunning(context.Background(), i))
+	defer services.StopAndAwaitTerminated(context.Background(), i) //nolint:errcheck
+
+	ctx := user.InjectOrgID(context.Background(), "test")
+	push(i, 0, steps/2)
+	require.Nil(t, services.StopAndAwaitTerminated(context.Background(), i))
+
+	// restart the ingester, the streams being replayed from the WAL segments when pushed to.
+	i, err = New(ingesterConfig, client.Config{}, newStore(), limits, runtime.DefaultTenantConfigs(), nil)
+	require.NoError(t, err)
+	defer services.StopAndAwaitTerminated(context.Background(), i) //nolint:errcheck
+	require.Nil(t, services.StartAndAwaitRunning(context.Background(), i))
+
+	push(i, steps/2, steps)
+	ensureIngesterData(ctx, t, start, end, i)
+
+	// ensure we have checkpointed into shards now
+	expectCheckpoint(t, walDir, true, ingesterConfig.WAL.CheckpointDuration*5)
+	require.Nil(t, services.StopAndAwaitTerminated(context.Background(), i))
+
+	checkpointDir, _, err := lastCheckpoint(walDir)
+	require.NoError(t, err)
+	shardDirs, err := checkpointShardDirs(checkpointDir)
+	require.NoError(t, err)
+	require.Len(t, shardDirs, 4)
+
+	// ensure the labels of the streams are written along with each shard, for the queries to replay them.
+	for _, ls := range []labels.Labels{
+		labels.FromStrings("bar", "baz1", "foo", "bar"),
+		labels.FromStrings("bar", "baz2", "foo", "bar"),
+	} {
+		streams, err := readCheckpointLabels(shardDirs[checkpointShardFor(ls, len(shardDirs))])
+		require.NoError(t, err)
+		var found bool
+		for _, s := range streams {
+			if s.UserID == "test" && labels.Equal(s.Labels, ls) {
+				require.NotZero(t, s.Fingerprint)
+				found = true
+			}
+		}
+		require.True(t, found, "labels %s not found in the checkpoint shard", ls)
+	}
+
+	// restart the ingester, replaying the sharded checkpoint lazily then eagerly.
+	for _, lazy := range []bool{true, false} {
+		ingesterConfig.WAL.LazyReplay = lazy
+		i, err = New(ingesterConfig, client.Config{}, newStore(), limits, runtime.DefaultTenantConfigs(), nil)
+		require.NoError(t, err)
+		defer services.StopAndAwaitTerminated(context.Background(), i) //nolint:errcheck
+		require.Nil(t, services.StartAndAwaitRunning(context.Background(), i))
+		ensureIngesterData(ctx, t, start, end, i)
+		require.Nil(t, services.StopAndAwaitTerminated(context.Background(), i))
+	}
+}
+
+func TestLazyReplayerShardMatches(t *testing.T) {
+	r := &lazyReplayer{
+		shards: 3,
+		shardLabels: []map[string][]labels.Labels{
+			{"test": {labels.FromStrings("app", "foo", "env", "prod")}},
+			{"test": {labels.FromStrings("app", "bar", "env", "dev")}, "other": {labels.FromStrings("app", "baz")}},
+			nil,
+		},
+	}
+
+	for _, tc := range []struct {
+		userID   string
+		matchers [][]*labels.Matcher
+		expected []bool
+	}{
+		{
+			userID:   "test",
+			expected: []bool{true, true, true},
+		},
+		{
+			userID:   "other",
+			expected: []bool{false, true, true},
+		},
+		{
+			userID:   "unknown",
+			expected: []bool{false, false, true},
+		},
+		{
+			userID:   "test",
+			matchers: [][]*labels.Matcher{{labels.MustNewMatcher(labels.MatchEqual, "app", "foo")}},
+			expected: []bool{true, false, true},
+		},
+		{
+			userID: "test",
+			matchers: [][]*labels.Matcher{
+				{labels.MustNewMatcher(labels.MatchEqual, "app", "foo"), labels.MustNewMatcher(labels.MatchEqual, "env", "dev")},
+				{labels.MustNewMatcher(labels.MatchRegexp, "env", "de.*")},
+			},
+			expected: []bool{false, true, true},
+		},
+		{
+			userID:   "test",
+			matchers: [][]*labels.Matcher{{labels.MustNewMatcher(labels.MatchEqual, "app", "baz")}},
+			expected: []bool{false, false, true},
+		},
+	} {
+		for shard, expected := range tc.expected {
+			require.Equal(t, expected, r.shardMatches(shard, tc.userID, tc.matchers), "tenant %s, matchers %v, shard %d", tc.userID, tc.matchers, shard)
+		}
+	}
+}
+
 func TestIngesterWALIgnoresStreamLimits(t *testing.T) {
 	walDir := t.TempDir()
 
@@ -529,8 +689,8 @@ func (noOpWalLogger) Dir() string              { return "" }
 
 func Benchmark_CheckpointWrite(b *testing.B) {
 	writer := WALCheckpointWriter{
-		metrics:       NilMetrics,
-		checkpointWAL: noOpWalLogger{},
+		metrics:          NilMetrics,
+		checkpointShards: []*checkpointShard{{wal: noOpWalLogger{}}},
 	}
 	lbs := labels.Labels{labels.Label{Name: "foo", Value: "bar"}}
 	chunks := buildChunks(b, 10)
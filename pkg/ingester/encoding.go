@@ -25,11 +25,14 @@ const (
 	// WALRecordEntriesV2 is the type for the WAL record for samples with an
 	// additional counter value for use in replaying without the ordering constraint.
 	WALRecordEntriesV2
+	// WALRecordEntriesV3 is the type for the WAL record for samples with their
+	// structured metadata.
+	WALRecordEntriesV3
 )
 
 // The current type of Entries that this distribution writes.
 // Loki can read in a backwards compatible manner, but will write the newest variant.
-const CurrentEntriesRec RecordType = WALRecordEntriesV2
+const CurrentEntriesRec RecordType = WALRecordEntriesV3
 
 // WALRecord is a struct combining the series and samples record.
 type WALRecord struct {
@@ -131,6 +134,14 @@ outer:
 			buf.PutVarint64(s.Timestamp.UnixNano() - first)
 			buf.PutUvarint(len(s.Line))
 			buf.PutString(s.Line)
+
+			if version >= WALRecordEntriesV3 {
+				buf.PutUvarint(len(s.StructuredMetadata))
+				for _, l := range s.StructuredMetadata {
+					buf.PutUvarintStr(l.Name)
+					buf.PutUvarintStr(l.Value)
+				}
+			}
 		}
 	}
 	return buf.Get()
@@ -161,9 +172,22 @@ func decodeEntries(b []byte, version RecordType, rec *WALRecord) error {
 			lineLength := dec.Uvarint()
 			line := dec.Bytes(lineLength)
 
+			var structuredMetadata []logproto.LabelAdapter
+			if version >= WALRecordEntriesV3 {
+				if n := dec.Uvarint(); n > 0 {
+					structuredMetadata = make([]logproto.LabelAdapter, 0, n)
+					for i := 0; dec.Err() == nil && i < n; i++ {
+						name := dec.UvarintStr()
+						value := dec.UvarintStr()
+						structuredMetadata = append(structuredMetadata, logproto.LabelAdapter{Name: name, Value: value})
+					}
+				}
+			}
+
 			refEntries.Entries = append(refEntries.Entries, logproto.Entry{
-				Timestamp: time.Unix(0, baseTime+timeOffset),
-				Line:      string(line),
+				Timestamp:          time.Unix(0, baseTime+timeOffset),
+				Line:               string(line),
+				StructuredMetadata: structuredMetadata,
 			})
 		}
 
@@ -198,7 +222,7 @@ func decodeWALRecord(b []byte, walRec *WALRecord) (err error) {
 	case WALRecordSeries:
 		userID = decbuf.UvarintStr()
 		rSeries, err = dec.Series(decbuf.B, walRec.Series)
-	case WALRecordEntriesV1, WALRecordEntriesV2:
+	case WALRecordEntriesV1, WALRecordEntriesV2, WALRecordEntriesV3:
 		userID = decbuf.UvarintStr()
 		err = decodeEntries(decbuf.B, t, walRec)
 	default:
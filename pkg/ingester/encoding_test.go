@@ -129,6 +129,46 @@ func Test_Encoding_Entries(t *testing.T) {
 			},
 			version: WALRecordEntriesV2,
 		},
+		{
+			desc: "v3",
+			rec: &WALRecord{
+				entryIndexMap: make(map[uint64]int),
+				UserID:        "123",
+				RefEntries: []RefEntries{
+					{
+						Ref:     456,
+						Counter: 1,
+						Entries: []logproto.Entry{
+							{
+								Timestamp:          time.Unix(1000, 0),
+								Line:               "first",
+								StructuredMetadata: []logproto.LabelAdapter{{Name: "traceID", Value: "123"}, {Name: "user", Value: "a"}},
+							},
+							{
+								Timestamp: time.Unix(2000, 0),
+								Line:      "second",
+							},
+						},
+					},
+					{
+						Ref:     789,
+						Counter: 2,
+						Entries: []logproto.Entry{
+							{
+								Timestamp: time.Unix(3000, 0),
+								Line:      "third",
+							},
+							{
+								Timestamp:          time.Unix(4000, 0),
+								Line:               "fourth",
+								StructuredMetadata: []logproto.LabelAdapter{{Name: "traceID", Value: "456"}},
+							},
+						},
+					},
+				},
+			},
+			version: WALRecordEntriesV3,
+		},
 	} {
 		decoded := recordPool.GetRecord()
 		buf := tc.rec.encodeEntries(tc.version, nil)
@@ -281,7 +321,7 @@ func Test_EncodingChunks(t *testing.T) {
 						}
 					}
 
-					backAgain, err := fromWireChunks(&conf, chunks)
+					backAgain, err := fromWireChunks(&conf, chunkenc.UnorderedWithStructuredMetadataHeadBlockFmt, chunks)
 					require.Nil(t, err)
 
 					for i, to := range backAgain {
@@ -1,7 +1,10 @@
 package ingester
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"sort"
 	"sync"
@@ -62,6 +65,40 @@ func TestChunkFlushingShutdown(t *testing.T) {
 	store.checkData(t, testData)
 }
 
+func TestPrepareShutdown(t *testing.T) {
+	walDir := t.TempDir()
+	store, ing := newTestStore(t, defaultIngesterTestConfigWithWAL(t, walDir), nil)
+	defer services.StopAndAwaitTerminated(context.Background(), ing) //nolint:errcheck
+	testData := pushTestSamples(t, ing)
+
+	progress := func(method string) shutdownProgress {
+		w := httptest.NewRecorder()
+		ing.PrepareShutdownHandler(w, httptest.NewRequest(method, "/ingester/prepare_shutdown", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		var p shutdownProgress
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &p))
+		return p
+	}
+	require.Equal(t, shutdownStateNotStarted, progress(http.MethodGet).State)
+	require.Equal(t, shutdownStateFlushing, progress(http.MethodPost).State)
+
+	require.Eventually(t, func() bool {
+		return progress(http.MethodGet).State == shutdownStateDrained
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, ing.AwaitTerminated(context.Background()))
+
+	p := progress(http.MethodGet)
+	require.NotZero(t, p.Streams)
+	require.Equal(t, p.Streams, p.ChunksToFlush)
+	require.Equal(t, 0, p.ChunksRemaining)
+	store.checkData(t, testData)
+
+	_, err := ing.Push(user.InjectOrgID(context.Background(), "test"), &logproto.PushRequest{})
+	require.Equal(t, ErrReadOnly, err)
+	_, err = os.Stat(walDir)
+	require.True(t, os.IsNotExist(err))
+}
+
 type fullWAL struct{}
 
 func (fullWAL) Log(_ *WALRecord) error { return &os.PathError{Err: syscall.ENOSPC} }
@@ -197,6 +197,39 @@ func (ii *InvertedIndex) LabelValues(name string, shard *astmapper.ShardAnnotati
 	return mergeStringSlices(results), nil
 }
 
+// Cardinality returns the number of series matching the matchers, and the number of those series per value
+// of the given label names, limited to the limit values with the most series if limit is not 0.
+// If no label name is given, only the number of series and of values of every label name are returned.
+func (ii *InvertedIndex) Cardinality(matchers []*labels.Matcher, names []string, limit int, shard *astmapper.ShardAnnotation) (*logproto.CardinalityResponse, error) {
+	if err := validateShard(ii.totalShards, shard); err != nil {
+		return nil, err
+	}
+	shards := ii.getShards(shard)
+	results := make([]*logproto.CardinalityResponse, 0, len(shards))
+	for i := range shards {
+		results = append(results, shards[i].cardinality(matchers, names))
+	}
+
+	// the series of the shards are distinct, their counts add up.
+	result := logproto.MergeCardinalityResponses(results)
+	for _, l := range result.Labels {
+		switch {
+		case len(names) == 0:
+			l.Values = nil
+		case limit > 0 && len(l.Values) > limit:
+			sort.Slice(l.Values, func(i, j int) bool {
+				if l.Values[i].Streams != l.Values[j].Streams {
+					return l.Values[i].Streams > l.Values[j].Streams
+				}
+				return l.Values[i].Value < l.Values[j].Value
+			})
+			l.Values = l.Values[:limit]
+			sort.Slice(l.Values, func(i, j int) bool { return l.Values[i].Value < l.Values[j].Value })
+		}
+	}
+	return result, nil
+}
+
 // Delete a fingerprint with the given label pairs.
 func (ii *InvertedIndex) Delete(labels labels.Labels, fp model.Fingerprint) {
 	shard := ii.shards[labelsSeriesIDHash(labels)%ii.totalShards]
@@ -370,6 +403,58 @@ func (shard *indexShard) labelValues(name string) []string {
 	return results
 }
 
+func (shard *indexShard) cardinality(matchers []*labels.Matcher, names []string) *logproto.CardinalityResponse {
+	var fps []model.Fingerprint
+	if len(matchers) == 0 {
+		fps = shard.allFPs()
+	} else {
+		fps = shard.lookup(matchers)
+	}
+	result := &logproto.CardinalityResponse{Streams: uint64(len(fps))}
+	if len(fps) == 0 {
+		return result
+	}
+	matching := make(map[model.Fingerprint]struct{}, len(fps))
+	for _, fp := range fps {
+		matching[fp] = struct{}{}
+	}
+
+	shard.mtx.RLock()
+	defer shard.mtx.RUnlock()
+
+	if len(names) == 0 {
+		names = make([]string, 0, len(shard.idx))
+		for name := range shard.idx {
+			names = append(names, name)
+		}
+	}
+	for _, name := range names {
+		entry, ok := shard.idx[name]
+		if !ok {
+			continue
+		}
+		label := &logproto.LabelCardinality{Name: entry.name}
+		for _, value := range entry.fps {
+			// only the series which were matching when looked up are counted.
+			var n uint64
+			for _, fp := range value.fps {
+				if _, ok := matching[fp]; ok {
+					n++
+				}
+			}
+			if n == 0 {
+				continue
+			}
+			label.Streams += n
+			label.Values = append(label.Values, &logproto.LabelValueCardinality{Value: value.value, Streams: n})
+		}
+		if label.Streams > 0 {
+			result.Labels = append(result.Labels, label)
+		}
+	}
+	return result
+}
+
 func (shard *indexShard) delete(labels labels.Labels, fp model.Fingerprint) {
 	shard.mtx.Lock()
 	defer shard.mtx.Unlock()
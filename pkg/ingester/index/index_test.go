@@ -177,3 +177,48 @@ func Test_ConsistentMapping(t *testing.T) {
 	}
 
 }
+
+func Test_Cardinality(t *testing.T) {
+	ii := NewWithShards(16)
+	for i := 0; i < 10; i++ {
+		lbs := labels.Labels{
+			labels.Label{Name: "app", Value: fmt.Sprint("app-", i%2)},
+			labels.Label{Name: "job", Value: "loki"},
+		}
+		if i < 3 {
+			lbs = append(lbs, labels.Label{Name: "pod", Value: fmt.Sprint("pod-", i)})
+		}
+		ii.Add(logproto.FromLabelsToLabelAdapters(lbs), model.Fingerprint(i))
+	}
+
+	res, err := ii.Cardinality(nil, nil, 0, nil)
+	require.NoError(t, err)
+	require.Equal(t, &logproto.CardinalityResponse{
+		Streams: 10,
+		Labels: []*logproto.LabelCardinality{
+			{Name: "app", Streams: 10, ValuesCount: 2},
+			{Name: "job", Streams: 10, ValuesCount: 1},
+			{Name: "pod", Streams: 3, ValuesCount: 3},
+		},
+	}, res)
+
+	res, err = ii.Cardinality([]*labels.Matcher{labels.MustNewMatcher(labels.MatchEqual, "app", "app-0")}, []string{"pod", "unknown"}, 0, nil)
+	require.NoError(t, err)
+	require.Equal(t, &logproto.CardinalityResponse{
+		Streams: 5,
+		Labels: []*logproto.LabelCardinality{
+			{Name: "pod", Streams: 2, ValuesCount: 2, Values: []*logproto.LabelValueCardinality{{Value: "pod-0", Streams: 1}, {Value: "pod-2", Streams: 1}}},
+		},
+	}, res)
+
+	// the values with the most series are kept.
+	res, err = ii.Cardinality([]*labels.Matcher{labels.MustNewMatcher(labels.MatchRegexp, "pod", ".+")}, []string{"app", "pod"}, 1, nil)
+	require.NoError(t, err)
+	require.Equal(t, &logproto.CardinalityResponse{
+		Streams: 3,
+		Labels: []*logproto.LabelCardinality{
+			{Name: "app", Streams: 3, ValuesCount: 2, Values: []*logproto.LabelValueCardinality{{Value: "app-0", Streams: 2}}},
+			{Name: "pod", Streams: 3, ValuesCount: 3, Values: []*logproto.LabelValueCardinality{{Value: "pod-0", Streams: 1}}},
+		},
+	}, res)
+}
@@ -76,6 +76,7 @@ type Config struct {
 	BlockSize           int               `yaml:"chunk_block_size"`
 	TargetChunkSize     int               `yaml:"chunk_target_size"`
 	ChunkEncoding       string            `yaml:"chunk_encoding"`
+	ChunkBloomFilters   bool              `yaml:"chunk_bloom_filters"`
 	parsedEncoding      chunkenc.Encoding `yaml:"-"` // placeholder for validated encoding
 	MaxChunkAge         time.Duration     `yaml:"max_chunk_age"`
 	AutoForgetUnhealthy bool              `yaml:"autoforget_unhealthy"`
@@ -117,6 +118,7 @@ func (cfg *Config) RegisterFlags(f *flag.FlagSet) {
 	f.IntVar(&cfg.BlockSize, "ingester.chunks-block-size", 256*1024, "")
 	f.IntVar(&cfg.TargetChunkSize, "ingester.chunk-target-size", 1572864, "") // 1.5 MB
 	f.StringVar(&cfg.ChunkEncoding, "ingester.chunk-encoding", chunkenc.EncGZIP.String(), fmt.Sprintf("The algorithm to use for compressing chunk. (%s)", chunkenc.SupportedEncoding()))
+	f.BoolVar(&cfg.ChunkBloomFilters, "ingester.chunk-bloom-filters", false, "Build a bloom filter of the lines of each chunk block, allowing the queriers to skip the chunks and blocks which can't match the line filters of the queries, at the cost of bigger chunks.")
 	f.DurationVar(&cfg.SyncPeriod, "ingester.sync-period", 0, "How often to cut chunks to synchronize ingesters.")
 	f.Float64Var(&cfg.SyncMinUtilization, "ingester.sync-min-utilization", 0, "Minimum utilization of chunk when doing synchronization.")
 	f.IntVar(&cfg.MaxReturnedErrors, "ingester.max-ignored-stream-errors", 10, "Maximum number of ignored stream errors to return. 0 to return all errors.")
@@ -172,6 +174,7 @@ type Interface interface {
 	CheckReady(ctx context.Context) error
 	FlushHandler(w http.ResponseWriter, _ *http.Request)
 	ShutdownHandler(w http.ResponseWriter, r *http.Request)
+	PrepareShutdownHandler(w http.ResponseWriter, r *http.Request)
 	GetOrCreateInstance(instanceID string) *instance
 }
 
@@ -215,6 +218,13 @@ type Ingester struct {
 	metrics *ingesterMetrics
 
 	wal WAL
+	// Replays the WAL in the background when lazy replays are enabled.
+	replayer *lazyReplayer
+
+	// Flushes all the streams before a scale down, see PrepareShutdownHandler.
+	prepareShutdownOnce sync.Once
+	shutdownPreparer    shutdownPreparer
+	drained             bool
 
 	chunkFilter chunk.RequestChunkFilterer
 }
@@ -265,6 +275,13 @@ func New(cfg Config, clientConfig client.Config, store ChunkStore, limits *valid
 	}
 	i.wal = wal
 
+	if cfg.WAL.Enabled && cfg.WAL.LazyReplay {
+		i.replayer, err = newLazyReplayer(i)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	i.lifecycler, err = ring.NewLifecycler(cfg.LifecyclerConfig, i, "ingester", RingKey, !cfg.WAL.Enabled || cfg.WAL.FlushOnShutdown, util_log.Logger, prometheus.WrapRegistererWithPrefix("cortex_", registerer))
 	if err != nil {
 		return nil, err
@@ -277,6 +294,7 @@ func New(cfg Config, clientConfig client.Config, store ChunkStore, limits *valid
 	// which depends on it.
 	i.limiter = NewLimiter(limits, metrics, i.lifecycler, cfg.LifecyclerConfig.RingConfig.ReplicationFactor)
 
+	i.shutdownPreparer.progress.State = shutdownStateNotStarted
 	i.Service = services.NewBasicService(i.starting, i.running, i.stopping)
 
 	i.setupAutoForget()
@@ -367,83 +385,18 @@ func (i *Ingester) starting(ctx context.Context) error {
 	if i.cfg.WAL.Enabled {
 		start := time.Now()
 
-		// Ignore retain period during wal replay.
-		oldRetain := i.cfg.RetainPeriod
-		i.cfg.RetainPeriod = 0
-
 		// Disable the in process stream limit checks while replaying the WAL.
-		// It is re-enabled in the recover's Close() method.
+		// It is re-enabled once the replay is finished.
 		i.limiter.DisableForWALReplay()
-
-		recoverer := newIngesterRecoverer(i)
-
 		i.metrics.walReplayActive.Set(1)
 
-		endReplay := func() func() {
-			var once sync.Once
-			return func() {
-				once.Do(func() {
-					level.Info(util_log.Logger).Log("msg", "closing recoverer")
-					recoverer.Close()
-
-					elapsed := time.Since(start)
-
-					i.metrics.walReplayActive.Set(0)
-					i.metrics.walReplayDuration.Set(elapsed.Seconds())
-					i.cfg.RetainPeriod = oldRetain
-					level.Info(util_log.Logger).Log("msg", "WAL recovery finished", "time", elapsed.String())
-				})
-			}
-		}()
-		defer endReplay()
-
-		level.Info(util_log.Logger).Log("msg", "recovering from checkpoint")
-		checkpointReader, checkpointCloser, err := newCheckpointReader(i.cfg.WAL.Dir)
-		if err != nil {
+		if i.replayer != nil {
+			// The WAL is replayed in the background, the ingester joining the ring right away.
+			level.Info(util_log.Logger).Log("msg", "recovering from WAL lazily")
+			go i.replayer.run(start)
+		} else if err := i.replayWAL(start); err != nil {
 			return err
 		}
-		defer checkpointCloser.Close()
-
-		checkpointRecoveryErr := RecoverCheckpoint(checkpointReader, recoverer)
-		if checkpointRecoveryErr != nil {
-			i.metrics.walCorruptionsTotal.WithLabelValues(walTypeCheckpoint).Inc()
-			level.Error(util_log.Logger).Log(
-				"msg",
-				`Recovered from checkpoint with errors. Some streams were likely not recovered due to WAL checkpoint file corruptions (or WAL file deletions while Loki is running). No administrator action is needed and data loss is only a possibility if more than (replication factor / 2 + 1) ingesters suffer from this.`,
-				"elapsed", time.Since(start).String(),
-			)
-		}
-		level.Info(util_log.Logger).Log(
-			"msg", "recovered WAL checkpoint recovery finished",
-			"elapsed", time.Since(start).String(),
-			"errors", checkpointRecoveryErr != nil,
-		)
-
-		level.Info(util_log.Logger).Log("msg", "recovering from WAL")
-		segmentReader, segmentCloser, err := newWalReader(i.cfg.WAL.Dir, -1)
-		if err != nil {
-			return err
-		}
-		defer segmentCloser.Close()
-
-		segmentRecoveryErr := RecoverWAL(segmentReader, recoverer)
-		if segmentRecoveryErr != nil {
-			i.metrics.walCorruptionsTotal.WithLabelValues(walTypeSegment).Inc()
-			level.Error(util_log.Logger).Log(
-				"msg",
-				"Recovered from WAL segments with errors. Some streams and/or entries were likely not recovered due to WAL segment file corruptions (or WAL file deletions while Loki is running). No administrator action is needed and data loss is only a possibility if more than (replication factor / 2 + 1) ingesters suffer from this.",
-				"elapsed", time.Since(start).String(),
-			)
-		}
-		level.Info(util_log.Logger).Log(
-			"msg", "WAL segment recovery finished",
-			"elapsed", time.Since(start).String(),
-			"errors", segmentRecoveryErr != nil,
-		)
-
-		endReplay()
-
-		i.wal.Start()
 	}
 
 	i.InitFlushQueues()
@@ -465,6 +418,82 @@ func (i *Ingester) starting(ctx context.Context) error {
 	return nil
 }
 
+// replayWAL replays the checkpoint and the segments of the WAL before the ingester joins the ring.
+func (i *Ingester) replayWAL(start time.Time) error {
+	// Ignore retain period during wal replay.
+	oldRetain := i.cfg.RetainPeriod
+	i.cfg.RetainPeriod = 0
+
+	recoverer := newIngesterRecoverer(i)
+
+	endReplay := func() func() {
+		var once sync.Once
+		return func() {
+			once.Do(func() {
+				level.Info(util_log.Logger).Log("msg", "closing recoverer")
+				recoverer.Close()
+
+				elapsed := time.Since(start)
+
+				i.metrics.walReplayActive.Set(0)
+				i.metrics.walReplayDuration.Set(elapsed.Seconds())
+				i.cfg.RetainPeriod = oldRetain
+				level.Info(util_log.Logger).Log("msg", "WAL recovery finished", "time", elapsed.String())
+			})
+		}
+	}()
+	defer endReplay()
+
+	level.Info(util_log.Logger).Log("msg", "recovering from checkpoint")
+	checkpointReader, checkpointCloser, err := newCheckpointReader(i.cfg.WAL.Dir)
+	if err != nil {
+		return err
+	}
+	defer checkpointCloser.Close()
+
+	checkpointRecoveryErr := RecoverCheckpoint(checkpointReader, recoverer)
+	if checkpointRecoveryErr != nil {
+		i.metrics.walCorruptionsTotal.WithLabelValues(walTypeCheckpoint).Inc()
+		level.Error(util_log.Logger).Log(
+			"msg",
+			`Recovered from checkpoint with errors. Some streams were likely not recovered due to WAL checkpoint file corruptions (or WAL file deletions while Loki is running). No administrator action is needed and data loss is only a possibility if more than (replication factor / 2 + 1) ingesters suffer from this.`,
+			"elapsed", time.Since(start).String(),
+		)
+	}
+	level.Info(util_log.Logger).Log(
+		"msg", "recovered WAL checkpoint recovery finished",
+		"elapsed", time.Since(start).String(),
+		"errors", checkpointRecoveryErr != nil,
+	)
+
+	level.Info(util_log.Logger).Log("msg", "recovering from WAL")
+	segmentReader, segmentCloser, err := newWalReader(i.cfg.WAL.Dir, -1)
+	if err != nil {
+		return err
+	}
+	defer segmentCloser.Close()
+
+	segmentRecoveryErr := RecoverWAL(segmentReader, recoverer)
+	if segmentRecoveryErr != nil {
+		i.metrics.walCorruptionsTotal.WithLabelValues(walTypeSegment).Inc()
+		level.Error(util_log.Logger).Log(
+			"msg",
+			"Recovered from WAL segments with errors. Some streams and/or entries were likely not recovered due to WAL segment file corruptions (or WAL file deletions while Loki is running). No administrator action is needed and data loss is only a possibility if more than (replication factor / 2 + 1) ingesters suffer from this.",
+			"elapsed", time.Since(start).String(),
+		)
+	}
+	level.Info(util_log.Logger).Log(
+		"msg", "WAL segment recovery finished",
+		"elapsed", time.Since(start).String(),
+		"errors", segmentRecoveryErr != nil,
+	)
+
+	endReplay()
+
+	i.wal.Start()
+	return nil
+}
+
 func (i *Ingester) running(ctx context.Context) error {
 	var serviceError error
 	select {
@@ -490,8 +519,13 @@ func (i *Ingester) running(ctx context.Context) error {
 // At this point, loop no longer runs, but flushers are still running.
 func (i *Ingester) stopping(_ error) error {
 	i.stopIncomingRequests()
+	if i.replayer != nil {
+		// Wait for the WAL to be replayed for all of it to be flushed or checkpointed.
+		_ = i.replayer.wait(context.Background())
+	}
 	var errs errUtil.MultiError
 	errs.Add(i.wal.Stop())
+	errs.Add(i.removeDrainedWAL())
 
 	if i.flushOnShutdownSwitch.Get() {
 		i.lifecycler.SetFlushOnShutdown(true)
@@ -517,7 +551,8 @@ func (i *Ingester) loop() {
 	for {
 		select {
 		case <-flushTicker.C:
-			i.sweepUsers(false, true)
+			// The streams being replayed must not be removed until their shard is replayed.
+			i.sweepUsers(false, !i.replaying())
 
 		case <-i.loopQuit:
 			return
@@ -537,6 +572,27 @@ func (i *Ingester) ShutdownHandler(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// replaying returns whether the WAL is being replayed lazily.
+func (i *Ingester) replaying() bool {
+	return i.replayer != nil && i.replayer.replaying()
+}
+
+// waitReplay waits for the WAL to be fully replayed.
+func (i *Ingester) waitReplay(ctx context.Context) error {
+	if i.replayer == nil {
+		return nil
+	}
+	return i.replayer.wait(ctx)
+}
+
+// replayMatching replays the streams of the tenant matching any of the given matchers, or all its streams
+// if none is given, if the WAL is still being replayed, for queries to see all the streams they match.
+func (i *Ingester) replayMatching(userID string, matchers ...[]*labels.Matcher) {
+	if i.replaying() {
+		i.replayer.replayMatching(userID, matchers)
+	}
+}
+
 // Push implements logproto.Pusher.
 func (i *Ingester) Push(ctx context.Context, req *logproto.PushRequest) (*logproto.PushResponse, error) {
 	instanceID, err := tenant.TenantID(ctx)
@@ -546,6 +602,11 @@ func (i *Ingester) Push(ctx context.Context, req *logproto.PushRequest) (*logpro
 		return nil, ErrReadOnly
 	}
 
+	// Streams can only be pushed to once replayed.
+	if i.replaying() {
+		i.replayer.replayStreams(req.Streams)
+	}
+
 	instance := i.GetOrCreateInstance(instanceID)
 	err = instance.Push(ctx, req)
 	return &logproto.PushResponse{}, err
@@ -578,8 +639,15 @@ func (i *Ingester) Query(req *logproto.QueryRequest, queryServer logproto.Querie
 		return err
 	}
 
+	params := logql.SelectLogParams{QueryRequest: req}
+	expr, err := params.LogSelector()
+	if err != nil {
+		return err
+	}
+	i.replayMatching(instanceID, expr.Matchers())
+
 	instance := i.GetOrCreateInstance(instanceID)
-	it, err := instance.Query(ctx, logql.SelectLogParams{QueryRequest: req})
+	it, err := instance.Query(ctx, params)
 	if err != nil {
 		return err
 	}
@@ -617,8 +685,15 @@ func (i *Ingester) QuerySample(req *logproto.SampleQueryRequest, queryServer log
 		return err
 	}
 
+	params := logql.SelectSampleParams{SampleQueryRequest: req}
+	expr, err := params.LogSelector()
+	if err != nil {
+		return err
+	}
+	i.replayMatching(instanceID, expr.Matchers())
+
 	instance := i.GetOrCreateInstance(instanceID)
-	it, err := instance.QuerySample(ctx, logql.SelectSampleParams{SampleQueryRequest: req})
+	it, err := instance.QuerySample(ctx, params)
 	if err != nil {
 		return err
 	}
@@ -645,18 +720,18 @@ func (i *Ingester) QuerySample(req *logproto.SampleQueryRequest, queryServer log
 	return sendSampleBatches(ctx, it, queryServer)
 }
 
-// boltdbShipperMaxLookBack returns a max look back period only if active index type is boltdb-shipper.
-// max look back is limited to from time of boltdb-shipper config.
-// It considers previous periodic config's from time if that also has index type set to boltdb-shipper.
+// boltdbShipperMaxLookBack returns a max look back period only if active index type is boltdb-shipper or tsdb.
+// max look back is limited to from time of boltdb-shipper or tsdb config.
+// It considers previous periodic config's from time if that also has the same index type.
 func (i *Ingester) boltdbShipperMaxLookBack() time.Duration {
 	activePeriodicConfigIndex := config.ActivePeriodConfig(i.periodicConfigs)
 	activePeriodicConfig := i.periodicConfigs[activePeriodicConfigIndex]
-	if activePeriodicConfig.IndexType != config.BoltDBShipperType {
+	if activePeriodicConfig.IndexType != config.BoltDBShipperType && activePeriodicConfig.IndexType != config.TSDBType {
 		return 0
 	}
 
 	startTime := activePeriodicConfig.From
-	if activePeriodicConfigIndex != 0 && i.periodicConfigs[activePeriodicConfigIndex-1].IndexType == config.BoltDBShipperType {
+	if activePeriodicConfigIndex != 0 && i.periodicConfigs[activePeriodicConfigIndex-1].IndexType == activePeriodicConfig.IndexType {
 		startTime = i.periodicConfigs[activePeriodicConfigIndex-1].From
 	}
 
@@ -715,6 +790,8 @@ func (i *Ingester) Label(ctx context.Context, req *logproto.LabelRequest) (*logp
 		return nil, err
 	}
 
+	i.replayMatching(userID)
+
 	instance := i.GetOrCreateInstance(userID)
 	resp, err := instance.Label(ctx, req)
 	if err != nil {
@@ -766,6 +843,42 @@ func (i *Ingester) Label(ctx context.Context, req *logproto.LabelRequest) (*logp
 	}, nil
 }
 
+// GetStats returns the stats of the chunks held in memory for the streams matching the request.
+func (i *Ingester) GetStats(ctx context.Context, req *logproto.IndexStatsRequest) (*logproto.IndexStatsResponse, error) {
+	userID, err := tenant.TenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	matchers, err := syntax.ParseMatchers(req.Matchers)
+	if err != nil {
+		return nil, err
+	}
+	i.replayMatching(userID, matchers)
+
+	instance := i.GetOrCreateInstance(userID)
+	return instance.GetStats(ctx, req)
+}
+
+// GetCardinality returns the number of active streams matching the request, per label name and value.
+func (i *Ingester) GetCardinality(ctx context.Context, req *logproto.CardinalityRequest) (*logproto.CardinalityResponse, error) {
+	userID, err := tenant.TenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var matchers []*labels.Matcher
+	if req.Matchers != "" {
+		if matchers, err = syntax.ParseMatchers(req.Matchers); err != nil {
+			return nil, err
+		}
+	}
+	i.replayMatching(userID, matchers)
+
+	instance := i.GetOrCreateInstance(userID)
+	return instance.Cardinality(req)
+}
+
 // Series queries the ingester for log stream identifiers (label sets) matching a set of matchers
 func (i *Ingester) Series(ctx context.Context, req *logproto.SeriesRequest) (*logproto.SeriesResponse, error) {
 	instanceID, err := tenant.TenantID(ctx)
@@ -773,6 +886,12 @@ func (i *Ingester) Series(ctx context.Context, req *logproto.SeriesRequest) (*lo
 		return nil, err
 	}
 
+	groups, err := logql.Match(req.GetGroups())
+	if err != nil {
+		return nil, err
+	}
+	i.replayMatching(instanceID, groups...)
+
 	instance := i.GetOrCreateInstance(instanceID)
 	return instance.Series(ctx, req)
 }
@@ -830,7 +949,7 @@ func (i *Ingester) Tail(req *logproto.TailRequest, queryServer logproto.Querier_
 	}
 
 	instance := i.GetOrCreateInstance(instanceID)
-	tailer, err := newTailer(instanceID, req.Query, queryServer, i.cfg.MaxDroppedStreams)
+	tailer, err := newTailer(instanceID, req, queryServer, i.cfg.MaxDroppedStreams)
 	if err != nil {
 		return err
 	}
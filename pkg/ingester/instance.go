@@ -237,7 +237,7 @@ func (i *instance) createStream(pushReqStream logproto.Stream, record *WALRecord
 	fp := i.getHashForLabels(labels)
 
 	sortedLabels := i.index.Add(logproto.FromLabelsToLabelAdapters(labels), fp)
-	s := newStream(i.cfg, i.limiter, i.instanceID, fp, sortedLabels, i.limiter.UnorderedWrites(i.instanceID), i.metrics)
+	s := newStream(i.cfg, i.limiter, i.instanceID, fp, sortedLabels, i.limiter.UnorderedWrites(i.instanceID), i.limiter.AllowStructuredMetadata(i.instanceID), i.metrics)
 
 	// record will be nil when replaying the wal (we don't want to rewrite wal entries as we replay them).
 	if record != nil {
@@ -268,7 +268,7 @@ func (i *instance) createStream(pushReqStream logproto.Stream, record *WALRecord
 
 func (i *instance) createStreamByFP(ls labels.Labels, fp model.Fingerprint) *stream {
 	sortedLabels := i.index.Add(logproto.FromLabelsToLabelAdapters(ls), fp)
-	s := newStream(i.cfg, i.limiter, i.instanceID, fp, sortedLabels, i.limiter.UnorderedWrites(i.instanceID), i.metrics)
+	s := newStream(i.cfg, i.limiter, i.instanceID, fp, sortedLabels, i.limiter.UnorderedWrites(i.instanceID), i.limiter.AllowStructuredMetadata(i.instanceID), i.metrics)
 
 	i.streamsCreatedTotal.Inc()
 	memoryStreams.WithLabelValues(i.instanceID).Inc()
@@ -521,6 +521,76 @@ func (i *instance) Series(ctx context.Context, req *logproto.SeriesRequest) (*lo
 	return &logproto.SeriesResponse{Series: series}, nil
 }
 
+// GetStats returns the stats of the in-memory chunks of the streams matching the request.
+// The chunks which are already flushed are accounted for by the index of the store.
+// Like for the index of the store, the entries and bytes of the chunks partially overlapping
+// the request time range are prorated by their overlap.
+func (i *instance) GetStats(ctx context.Context, req *logproto.IndexStatsRequest) (*logproto.IndexStatsResponse, error) {
+	matchers, err := syntax.ParseMatchers(req.Matchers)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &logproto.IndexStatsResponse{}
+	from, through := req.From.Time(), req.Through.Time()
+
+	if err = i.forMatchingStreams(ctx, matchers, nil, func(s *stream) error {
+		var hasChunks bool
+
+		s.chunkMtx.RLock()
+		for _, chk := range s.chunks {
+			if !chk.flushed.IsZero() {
+				continue
+			}
+
+			// consider the chunk only if it overlaps the request time range
+			chkFrom, chkThrough := chk.chunk.Bounds()
+			if chkFrom.After(through) || chkThrough.Before(from) {
+				continue
+			}
+
+			ratio := 1.0
+			if span := chkThrough.Sub(chkFrom); span > 0 {
+				start, end := chkFrom, chkThrough
+				if from.After(start) {
+					start = from
+				}
+				if through.Before(end) {
+					end = through
+				}
+				ratio = float64(end.Sub(start)) / float64(span)
+			}
+
+			hasChunks = true
+			res.Chunks++
+			res.Entries += uint64(float64(chk.chunk.Size()) * ratio)
+			res.Bytes += uint64(float64(chk.chunk.CompressedSize()) * ratio)
+		}
+		s.chunkMtx.RUnlock()
+
+		if hasChunks {
+			res.Streams++
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Cardinality returns the number of streams matching the request from the index, per label name and value.
+func (i *instance) Cardinality(req *logproto.CardinalityRequest) (*logproto.CardinalityResponse, error) {
+	var matchers []*labels.Matcher
+	if req.Matchers != "" {
+		var err error
+		if matchers, err = syntax.ParseMatchers(req.Matchers); err != nil {
+			return nil, err
+		}
+	}
+	return i.index.Cardinality(matchers, req.LabelNames, int(req.Limit), nil)
+}
+
 func (i *instance) numStreams() int {
 	return i.streams.Len()
 }
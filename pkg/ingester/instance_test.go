@@ -15,6 +15,7 @@ import (
 	"github.com/grafana/loki/pkg/storage/chunk"
 
 	"github.com/pkg/errors"
+	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/stretchr/testify/require"
 
@@ -183,7 +184,7 @@ func setupTestStreams(t *testing.T) (*instance, time.Time, int) {
 	for _, testStream := range testStreams {
 		stream, err := instance.getOrCreateStream(testStream, recordPool.GetRecord())
 		require.NoError(t, err)
-		chunk := newStream(cfg, limiter, "fake", 0, nil, true, NilMetrics).NewChunk()
+		chunk := newStream(cfg, limiter, "fake", 0, nil, true, false, NilMetrics).NewChunk()
 		for _, entry := range testStream.Entries {
 			err = chunk.Append(&entry)
 			require.NoError(t, err)
@@ -352,6 +353,104 @@ func Test_SeriesQuery(t *testing.T) {
 	}
 }
 
+func Test_GetStats(t *testing.T) {
+	instance, currentTime, _ := setupTestStreams(t)
+	from, through := model.TimeFromUnixNano(currentTime.Add(-time.Millisecond).UnixNano()), model.TimeFromUnixNano(currentTime.Add(time.Millisecond).UnixNano())
+
+	for _, tc := range []struct {
+		name     string
+		req      *logproto.IndexStatsRequest
+		expected *logproto.IndexStatsResponse
+	}{
+		{
+			"non overlapping request",
+			&logproto.IndexStatsRequest{
+				From:     through,
+				Through:  through.Add(time.Millisecond),
+				Matchers: `{job="varlogs"}`,
+			},
+			&logproto.IndexStatsResponse{},
+		},
+		{
+			"overlapping request",
+			&logproto.IndexStatsRequest{
+				From:     from,
+				Through:  through,
+				Matchers: `{job="varlogs"}`,
+			},
+			// each stream has a chunk of 5 entries of 7 bytes.
+			&logproto.IndexStatsResponse{Streams: 2, Chunks: 2, Bytes: 70, Entries: 10},
+		},
+		{
+			"overlapping request with matchers",
+			&logproto.IndexStatsRequest{
+				From:     from,
+				Through:  through,
+				Matchers: `{app="test2"}`,
+			},
+			&logproto.IndexStatsResponse{Streams: 1, Chunks: 1, Bytes: 35, Entries: 5},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := instance.GetStats(context.Background(), tc.req)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, resp)
+		})
+	}
+
+	t.Run("flushed chunks are ignored", func(t *testing.T) {
+		require.NoError(t, instance.forAllStreams(context.Background(), func(s *stream) error {
+			if s.labels.Get("app") == "test" {
+				s.chunks[0].flushed = time.Now()
+			}
+			return nil
+		}))
+
+		resp, err := instance.GetStats(context.Background(), &logproto.IndexStatsRequest{
+			From:     from,
+			Through:  through,
+			Matchers: `{job="varlogs"}`,
+		})
+		require.NoError(t, err)
+		require.Equal(t, &logproto.IndexStatsResponse{Streams: 1, Chunks: 1, Bytes: 35, Entries: 5}, resp)
+	})
+}
+
+func Test_Cardinality(t *testing.T) {
+	instance, _, _ := setupTestStreams(t)
+
+	resp, err := instance.Cardinality(&logproto.CardinalityRequest{})
+	require.NoError(t, err)
+	require.Equal(t, &logproto.CardinalityResponse{
+		Streams: 2,
+		Labels: []*logproto.LabelCardinality{
+			{Name: "app", Streams: 2, ValuesCount: 2},
+			{Name: "job", Streams: 2, ValuesCount: 1},
+		},
+	}, resp)
+
+	resp, err = instance.Cardinality(&logproto.CardinalityRequest{Matchers: `{app="test2"}`, LabelNames: []string{"job"}})
+	require.NoError(t, err)
+	require.Equal(t, &logproto.CardinalityResponse{
+		Streams: 1,
+		Labels: []*logproto.LabelCardinality{
+			{Name: "job", Streams: 1, ValuesCount: 1, Values: []*logproto.LabelValueCardinality{{Value: "varlogs", Streams: 1}}},
+		},
+	}, resp)
+
+	resp, err = instance.Cardinality(&logproto.CardinalityRequest{LabelNames: []string{"app"}, Limit: 1})
+	require.NoError(t, err)
+	require.Equal(t, &logproto.CardinalityResponse{
+		Streams: 2,
+		Labels: []*logproto.LabelCardinality{
+			{Name: "app", Streams: 2, ValuesCount: 2, Values: []*logproto.LabelValueCardinality{{Value: "test", Streams: 1}}},
+		},
+	}, resp)
+
+	_, err = instance.Cardinality(&logproto.CardinalityRequest{Matchers: `{app`})
+	require.Error(t, err)
+}
+
 func entries(n int, t time.Time) []logproto.Entry {
 	result := make([]logproto.Entry, 0, n)
 	for i := 0; i < n; i++ {
@@ -421,7 +520,7 @@ func Benchmark_instance_addNewTailer(b *testing.B) {
 	ctx := context.Background()
 
 	inst := newInstance(&Config{}, "test", limiter, loki_runtime.DefaultTenantConfigs(), noopWAL{}, NilMetrics, &OnceSwitch{}, nil)
-	t, err := newTailer("foo", `{namespace="foo",pod="bar",instance=~"10.*"}`, nil, 10)
+	t, err := newTailer("foo", &logproto.TailRequest{Query: `{namespace="foo",pod="bar",instance=~"10.*"}`}, nil, 10)
 	require.NoError(b, err)
 	for i := 0; i < 10000; i++ {
 		require.NoError(b, inst.Push(ctx, &logproto.PushRequest{
@@ -436,7 +535,7 @@ func Benchmark_instance_addNewTailer(b *testing.B) {
 	lbs := makeRandomLabels()
 	b.Run("addTailersToNewStream", func(b *testing.B) {
 		for n := 0; n < b.N; n++ {
-			inst.addTailersToNewStream(newStream(nil, limiter, "fake", 0, lbs, true, NilMetrics))
+			inst.addTailersToNewStream(newStream(nil, limiter, "fake", 0, lbs, true, false, NilMetrics))
 		}
 	})
 }
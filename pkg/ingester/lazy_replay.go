@@ -0,0 +1,403 @@
+package ingester
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/tsdb/chunks"
+	"github.com/prometheus/prometheus/tsdb/record"
+	"github.com/prometheus/prometheus/tsdb/wal"
+
+	"github.com/grafana/loki/pkg/logproto"
+	"github.com/grafana/loki/pkg/logql/syntax"
+	util_log "github.com/grafana/loki/pkg/util/log"
+)
+
+// lazyReplayer replays the WAL in the background once the ingester joined the ring, shard by shard,
+// with the shards of the last checkpoint. Each shard is replayed from its part of the checkpoint then
+// from the records of its streams in the WAL segments written before the ingester started, which are
+// read once and split by shard by the first replay. Pushes replay the shards of their streams first, so that they are only appended to
+// replayed streams, and queries replay the shards holding the streams they match first, so that they
+// see all of them.
+type lazyReplayer struct {
+	ing       *Ingester
+	recoverer *ingesterRecoverer
+
+	checkpointShards []string // directories of the shards of the last checkpoint, if any.
+	shards           int
+	segments         *wal.SegmentRange // segments written before the ingester started, if any.
+
+	replays []sync.Once
+	done    chan struct{}
+
+	// The labels of the streams of each shard per tenant, and the records of the WAL segments split by
+	// shard, loaded by the first replay so that the segments are only read once.
+	// The labels of a shard are nil if they are unknown, i.e. for a checkpoint written by
+	// a previous version, in which case the shard is replayed by any query.
+	load         sync.Once
+	shardLabels  []map[string][]labels.Labels
+	shardRecords [][][]byte
+	// The records of the entries of the streams whose shard is unknown, replayed by all the shards.
+	unknownRecords [][]byte
+}
+
+func newLazyReplayer(i *Ingester) (*lazyReplayer, error) {
+	r := &lazyReplayer{
+		ing:       i,
+		recoverer: newIngesterRecoverer(i),
+		shards:    i.cfg.WAL.CheckpointShards,
+		done:      make(chan struct{}),
+	}
+
+	checkpointDir, idx, err := lastCheckpoint(i.cfg.WAL.Dir)
+	if err != nil {
+		return nil, err
+	}
+	if idx >= 0 {
+		r.checkpointShards, err = checkpointShardDirs(checkpointDir)
+		if err != nil {
+			return nil, err
+		}
+		r.shards = len(r.checkpointShards)
+	}
+	if r.shards < 1 {
+		r.shards = 1
+	}
+
+	// The last segment is the one created by the WAL for the records written from now on.
+	first, last, err := wal.Segments(i.cfg.WAL.Dir)
+	if err != nil {
+		return nil, err
+	}
+	if last-1 >= first && first >= 0 {
+		r.segments = &wal.SegmentRange{Dir: i.cfg.WAL.Dir, First: first, Last: last - 1}
+	}
+
+	r.replays = make([]sync.Once, r.shards)
+	r.shardRecords = make([][][]byte, r.shards)
+	return r, nil
+}
+
+// run replays all the shards, enables the limiter and starts the WAL checkpoints.
+func (r *lazyReplayer) run(start time.Time) {
+	for shard := range r.replays {
+		r.replayShard(shard)
+	}
+	r.close()
+
+	elapsed := time.Since(start)
+	r.ing.metrics.walReplayActive.Set(0)
+	r.ing.metrics.walReplayDuration.Set(elapsed.Seconds())
+	level.Info(util_log.Logger).Log("msg", "WAL recovery finished", "time", elapsed.String(), "shards", r.shards)
+
+	r.ing.wal.Start()
+	close(r.done)
+}
+
+// replaying returns whether the WAL is still being replayed.
+func (r *lazyReplayer) replaying() bool {
+	select {
+	case <-r.done:
+		return false
+	default:
+		return true
+	}
+}
+
+// wait waits for the whole WAL to be replayed.
+func (r *lazyReplayer) wait(ctx context.Context) error {
+	select {
+	case <-r.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// replayStreams replays the shards of the streams, if they are not replayed yet.
+func (r *lazyReplayer) replayStreams(streams []logproto.Stream) {
+	for _, s := range streams {
+		ls, err := syntax.ParseLabels(s.Labels)
+		if err != nil {
+			// The stream is rejected by the push anyway.
+			continue
+		}
+		r.replayShard(checkpointShardFor(ls, r.shards))
+	}
+}
+
+// replayMatching replays the shards holding the streams of the tenant matching any of the given matchers,
+// or all the streams of the tenant if none is given, if they are not replayed yet.
+func (r *lazyReplayer) replayMatching(userID string, matchers [][]*labels.Matcher) {
+	r.load.Do(r.loadShards)
+
+	for shard := range r.replays {
+		if r.shardMatches(shard, userID, matchers) {
+			r.replayShard(shard)
+		}
+	}
+}
+
+// shardMatches returns whether the shard may hold streams of the tenant matching any of the given matchers.
+func (r *lazyReplayer) shardMatches(shard int, userID string, matchers [][]*labels.Matcher) bool {
+	tenants := r.shardLabels[shard]
+	if tenants == nil {
+		return true
+	}
+	for _, ls := range tenants[userID] {
+		if len(matchers) == 0 {
+			return true
+		}
+		for _, ms := range matchers {
+			if labelsMatch(ls, ms) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func labelsMatch(ls labels.Labels, matchers []*labels.Matcher) bool {
+	for _, m := range matchers {
+		if !m.Matches(ls.Get(m.Name)) {
+			return false
+		}
+	}
+	return true
+}
+
+// loadShards loads the labels of the streams of each shard from the checkpoint, then splits the records
+// of the WAL segments by shard, adding the labels of the streams created after the checkpoint.
+func (r *lazyReplayer) loadShards() {
+	// The shard of the streams per tenant and reference, for the entries records to be split by shard.
+	refs := map[string]map[chunks.HeadSeriesRef]int{}
+
+	r.shardLabels = make([]map[string][]labels.Labels, r.shards)
+	for shard := range r.shardLabels {
+		if r.checkpointShards == nil {
+			r.shardLabels[shard] = map[string][]labels.Labels{}
+			continue
+		}
+		streams, err := readCheckpointLabels(r.checkpointShards[shard])
+		if err != nil {
+			level.Warn(util_log.Logger).Log("msg", "failed to read the labels of the checkpoint shard, it will be replayed by any query", "shard", shard, "err", err)
+		}
+		if streams == nil {
+			continue
+		}
+		tenants := map[string][]labels.Labels{}
+		for _, s := range streams {
+			tenants[s.UserID] = append(tenants[s.UserID], s.Labels)
+			setRefShard(refs, s.UserID, chunks.HeadSeriesRef(s.Fingerprint), shard)
+		}
+		r.shardLabels[shard] = tenants
+	}
+
+	if r.segments == nil {
+		return
+	}
+	if err := r.splitSegments(refs); err != nil {
+		r.ing.metrics.walCorruptionsTotal.WithLabelValues(walTypeSegment).Inc()
+		level.Error(util_log.Logger).Log("msg", "Split WAL segments by shard with errors. Some streams and/or entries were likely not recovered due to WAL segment file corruptions (or WAL file deletions while Loki is running).", "err", err)
+	}
+}
+
+func setRefShard(refs map[string]map[chunks.HeadSeriesRef]int, userID string, ref chunks.HeadSeriesRef, shard int) {
+	tenantRefs, ok := refs[userID]
+	if !ok {
+		tenantRefs = map[chunks.HeadSeriesRef]int{}
+		refs[userID] = tenantRefs
+	}
+	tenantRefs[ref] = shard
+}
+
+// splitSegments reads the WAL segments once and splits their records by the shards of their streams,
+// for each shard to only replay its own records.
+func (r *lazyReplayer) splitSegments(refs map[string]map[chunks.HeadSeriesRef]int) error {
+	segmentReader, err := wal.NewSegmentsRangeReader(*r.segments)
+	if err != nil {
+		return err
+	}
+	defer segmentReader.Close()
+
+	reader := wal.NewReader(segmentReader)
+	rec := recordPool.GetRecord()
+	defer recordPool.PutRecord(rec)
+	// The records of each shard, the last one being for the entries of the streams whose shard is unknown.
+	split := make([]WALRecord, r.shards+1)
+	for reader.Next() {
+		rec.Reset()
+		if err := decodeWALRecord(reader.Record(), rec); err != nil {
+			return err
+		}
+		for shard := range split {
+			split[shard].UserID = rec.UserID
+			split[shard].Series = split[shard].Series[:0]
+			split[shard].RefEntries = split[shard].RefEntries[:0]
+		}
+
+		for _, s := range rec.Series {
+			shard := checkpointShardFor(s.Labels, r.shards)
+			if tenants := r.shardLabels[shard]; tenants != nil {
+				tenants[rec.UserID] = append(tenants[rec.UserID], s.Labels)
+			}
+			setRefShard(refs, rec.UserID, s.Ref, shard)
+			split[shard].Series = append(split[shard].Series, s)
+		}
+		for _, entries := range rec.RefEntries {
+			shard, ok := refs[rec.UserID][entries.Ref]
+			if !ok {
+				shard = r.shards
+			}
+			split[shard].RefEntries = append(split[shard].RefEntries, entries)
+		}
+
+		for shard := range split {
+			var b []byte
+			if len(split[shard].Series) > 0 {
+				b = split[shard].encodeSeries(nil)
+			} else if len(split[shard].RefEntries) > 0 {
+				b = split[shard].encodeEntries(CurrentEntriesRec, nil)
+			} else {
+				continue
+			}
+			if shard == r.shards {
+				r.unknownRecords = append(r.unknownRecords, b)
+			} else {
+				r.shardRecords[shard] = append(r.shardRecords[shard], b)
+			}
+		}
+	}
+	return reader.Err()
+}
+
+// replayShard replays the shard, waiting for it if it is already being replayed.
+func (r *lazyReplayer) replayShard(shard int) {
+	r.replays[shard].Do(func() {
+		start := time.Now()
+		recoverer := &shardRecoverer{ingesterRecoverer: r.recoverer, shard: shard, shards: r.shards}
+
+		if r.checkpointShards != nil {
+			if err := r.recover(walTypeCheckpoint, wal.SegmentRange{Dir: r.checkpointShards[shard], First: -1, Last: -1}, recoverer, RecoverCheckpoint); err != nil {
+				level.Error(util_log.Logger).Log("msg", "Recovered from checkpoint shard with errors. Some streams were likely not recovered due to WAL checkpoint file corruptions (or WAL file deletions while Loki is running).", "shard", shard, "err", err)
+			}
+		}
+		r.load.Do(r.loadShards)
+		for _, records := range [][][]byte{r.shardRecords[shard], r.unknownRecords} {
+			if err := RecoverWAL(&recordsReader{records: records}, recoverer); err != nil {
+				level.Error(util_log.Logger).Log("msg", "Recovered shard from WAL segments with errors. Some streams and/or entries were likely not recovered.", "shard", shard, "err", err)
+			}
+		}
+		// The records of the shard are not needed anymore.
+		r.shardRecords[shard] = nil
+		recoverer.close()
+
+		level.Info(util_log.Logger).Log("msg", "WAL shard recovery finished", "shard", shard, "elapsed", time.Since(start).String())
+	})
+}
+
+func (r *lazyReplayer) recover(walType string, segments wal.SegmentRange, recoverer Recoverer, recoverFn func(WALReader, Recoverer) error) error {
+	segmentReader, err := wal.NewSegmentsRangeReader(segments)
+	if err != nil {
+		r.ing.metrics.walCorruptionsTotal.WithLabelValues(walType).Inc()
+		return err
+	}
+	defer segmentReader.Close()
+
+	if err := recoverFn(wal.NewReader(segmentReader), recoverer); err != nil {
+		r.ing.metrics.walCorruptionsTotal.WithLabelValues(walType).Inc()
+		return err
+	}
+	return nil
+}
+
+// close enables the limiter once all the shards are replayed, and applies the limits of the tenants to
+// the streams replayed or pushed to while it was disabled.
+func (r *lazyReplayer) close() {
+	r.ing.limiter.Enable()
+
+	for _, inst := range r.ing.getInstances() {
+		_ = inst.forAllStreams(context.Background(), func(s *stream) error {
+			s.chunkMtx.Lock()
+			defer s.chunkMtx.Unlock()
+
+			r.recoverer.applyLimits(s)
+			return nil
+		})
+	}
+}
+
+// recordsReader reads the records split from the WAL segments.
+type recordsReader struct {
+	records [][]byte
+	cur     []byte
+}
+
+func (r *recordsReader) Next() bool {
+	if len(r.records) == 0 {
+		return false
+	}
+	r.cur, r.records = r.records[0], r.records[1:]
+	return true
+}
+
+func (r *recordsReader) Err() error { return nil }
+
+func (r *recordsReader) Record() []byte { return r.cur }
+
+// shardRecoverer only recovers the streams of a shard, skipping the records of the other streams.
+type shardRecoverer struct {
+	*ingesterRecoverer
+	shard, shards int
+}
+
+func (r *shardRecoverer) Series(series *Series) error {
+	if checkpointShardFor(logproto.FromLabelAdaptersToLabels(series.Labels), r.shards) != r.shard {
+		return nil
+	}
+	return r.ingesterRecoverer.Series(series)
+}
+
+func (r *shardRecoverer) SetStream(userID string, series record.RefSeries) error {
+	if checkpointShardFor(series.Labels, r.shards) != r.shard {
+		return nil
+	}
+	return r.ingesterRecoverer.SetStream(userID, series)
+}
+
+func (r *shardRecoverer) Push(userID string, entries RefEntries) error {
+	// The streams of the other shards are not set, or were set by their own replay.
+	s, ok := r.stream(userID, entries.Ref)
+	if !ok || checkpointShardFor(s.labels, r.shards) != r.shard {
+		return nil
+	}
+	return r.ingesterRecoverer.Push(userID, entries)
+}
+
+// close resets the counters of the replayed streams of the shard and removes the ones without chunks,
+// before any entry is pushed to them.
+func (r *shardRecoverer) close() {
+	closed := map[*stream]struct{}{}
+	r.users.Range(func(userID, streams interface{}) bool {
+		inst, ok := r.ing.getInstanceByID(userID.(string))
+		if !ok {
+			return true
+		}
+		streams.(*sync.Map).Range(func(_, value interface{}) bool {
+			s := value.(*stream)
+			if _, ok := closed[s]; ok || checkpointShardFor(s.labels, r.shards) != r.shard {
+				return true
+			}
+			closed[s] = struct{}{}
+
+			s.chunkMtx.Lock()
+			defer s.chunkMtx.Unlock()
+			r.closeStream(inst, s)
+			return true
+		})
+		return true
+	})
+}
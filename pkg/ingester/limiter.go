@@ -61,12 +61,21 @@ func (l *Limiter) UnorderedWrites(userID string) bool {
 	// WAL replay should not discard previously ack'd writes,
 	// so allow out of order writes while the limiter is disabled.
 	// This allows replaying unordered WALs into ordered configurations.
+	l.mtx.RLock()
+	defer l.mtx.RUnlock()
 	if l.disabled {
 		return true
 	}
 	return l.limits.UnorderedWrites(userID)
 }
 
+// AllowStructuredMetadata returns whether the chunks of the tenant keep the structured metadata of the entries.
+// Unlike unordered writes, it isn't forced during the WAL replay: the chunks of the tenants not allowing it
+// must keep a format the older versions can read.
+func (l *Limiter) AllowStructuredMetadata(userID string) bool {
+	return l.limits.AllowStructuredMetadata(userID)
+}
+
 // AssertMaxStreamsPerUser ensures limit has not been reached compared to the current
 // number of streams in input and returns an error if so.
 func (l *Limiter) AssertMaxStreamsPerUser(userID string, streams int) error {
@@ -135,6 +144,8 @@ type RateLimiterStrategy interface {
 }
 
 func (l *Limiter) RateLimit(tenant string) validation.RateLimit {
+	l.mtx.RLock()
+	defer l.mtx.RUnlock()
 	if l.disabled {
 		return validation.Unlimited
 	}
@@ -0,0 +1,148 @@
+package ingester
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log/level"
+	"github.com/grafana/dskit/ring"
+	"github.com/grafana/dskit/services"
+
+	"github.com/grafana/loki/pkg/util"
+	util_log "github.com/grafana/loki/pkg/util/log"
+)
+
+const (
+	shutdownStateNotStarted = "not_started"
+	shutdownStateFlushing   = "flushing"
+	shutdownStateDrained    = "drained"
+
+	// prepareShutdownCheckPeriod is the interval at which the remaining chunks to flush are counted.
+	prepareShutdownCheckPeriod = time.Second
+)
+
+// shutdownProgress reports the progress of the preparation of the shutdown.
+type shutdownProgress struct {
+	State           string    `json:"state"`
+	StartedAt       time.Time `json:"started_at,omitempty"`
+	Streams         int       `json:"streams"`
+	ChunksToFlush   int       `json:"chunks_to_flush"`
+	ChunksRemaining int       `json:"chunks_remaining"`
+}
+
+type shutdownPreparer struct {
+	mtx      sync.Mutex
+	progress shutdownProgress
+}
+
+func (p *shutdownPreparer) get() shutdownProgress {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return p.progress
+}
+
+func (p *shutdownPreparer) update(fn func(*shutdownProgress)) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	fn(&p.progress)
+}
+
+// PrepareShutdownHandler prepares the ingester to be scaled down without any peer to hand its chunks off to.
+// On POST, the ingester leaves the ring for writes, flushes all its streams to storage, including the chunks
+// still being written to, then marks itself as drained and shuts down, removing itself from the ring and its WAL,
+// which does not need to be replayed anymore. Both POST and GET report the progress of the preparation.
+//
+// The ring has no instance state for a drained ingester and the lifecycler only lets it move from ACTIVE
+// to LEAVING, so the drained state itself is only reported by this handler: in the ring, the ingester is
+// LEAVING while its streams are flushed, then is removed from it once drained, instead of staying LEAVING
+// until it is restarted as on a regular shutdown.
+func (i *Ingester) PrepareShutdownHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		i.prepareShutdownOnce.Do(func() {
+			i.shutdownPreparer.update(func(p *shutdownProgress) {
+				p.State = shutdownStateFlushing
+				p.StartedAt = time.Now()
+			})
+			go i.prepareShutdown()
+		})
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	util.WriteJSONResponse(w, i.shutdownPreparer.get())
+}
+
+func (i *Ingester) prepareShutdown() {
+	level.Info(util_log.Logger).Log("msg", "preparing shutdown, flushing all the streams")
+
+	// Stop receiving writes before flushing, so that no stream is left with unflushed chunks.
+	if err := i.lifecycler.ChangeState(context.Background(), ring.LEAVING); err != nil {
+		level.Error(util_log.Logger).Log("msg", "failed to change the ingester state to leaving", "err", err)
+	}
+	i.stopIncomingRequests()
+	if err := i.waitReplay(context.Background()); err != nil {
+		level.Error(util_log.Logger).Log("msg", "failed to wait for the WAL to be replayed", "err", err)
+	}
+
+	i.sweepUsers(true, false)
+	ticker := time.NewTicker(prepareShutdownCheckPeriod)
+	defer ticker.Stop()
+	for first := true; ; first = false {
+		streams, chunks := i.countUnflushedChunks()
+		i.shutdownPreparer.update(func(p *shutdownProgress) {
+			if first {
+				p.Streams = streams
+				p.ChunksToFlush = chunks
+			}
+			p.ChunksRemaining = chunks
+		})
+		if chunks == 0 {
+			break
+		}
+		<-ticker.C
+	}
+
+	// Everything is in storage, the ingester leaves the ring on shutdown and its WAL is removed.
+	i.drained = true
+	i.lifecycler.SetUnregisterOnShutdown(true)
+	i.shutdownPreparer.update(func(p *shutdownProgress) {
+		p.State = shutdownStateDrained
+	})
+	level.Info(util_log.Logger).Log("msg", "all the streams are flushed, shutting down")
+
+	if err := services.StopAndAwaitTerminated(context.Background(), i); err != nil {
+		level.Error(util_log.Logger).Log("msg", "failed to shut down the drained ingester", "err", err)
+	}
+}
+
+// countUnflushedChunks returns the number of streams and of their chunks not flushed yet.
+func (i *Ingester) countUnflushedChunks() (streams, chunks int) {
+	for _, instance := range i.getInstances() {
+		_ = instance.streams.ForEach(func(s *stream) (bool, error) {
+			streams++
+			s.chunkMtx.RLock()
+			defer s.chunkMtx.RUnlock()
+			for _, c := range s.chunks {
+				if c.flushed.IsZero() {
+					chunks++
+				}
+			}
+			return true, nil
+		})
+	}
+	return streams, chunks
+}
+
+// removeDrainedWAL removes the WAL of a drained ingester, all its data being in storage.
+func (i *Ingester) removeDrainedWAL() error {
+	if !i.drained || !i.cfg.WAL.Enabled {
+		return nil
+	}
+	level.Info(util_log.Logger).Log("msg", "removing the WAL of the drained ingester", "dir", i.cfg.WAL.Dir)
+	return os.RemoveAll(i.cfg.WAL.Dir)
+}
@@ -75,7 +75,16 @@ func newCheckpointReader(dir string) (WALReader, io.Closer, error) {
 		return reader, reader, nil
 	}
 
-	r, err := wal.NewSegmentsReader(lastCheckpointDir)
+	shardDirs, err := checkpointShardDirs(lastCheckpointDir)
+	if err != nil {
+		return nil, nil, err
+	}
+	ranges := make([]wal.SegmentRange, 0, len(shardDirs))
+	for _, dir := range shardDirs {
+		ranges = append(ranges, wal.SegmentRange{Dir: dir, First: -1, Last: -1})
+	}
+
+	r, err := wal.NewSegmentsRangeReader(ranges...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -202,6 +211,19 @@ func (r *ingesterRecoverer) Push(userID string, entries RefEntries) error {
 	})
 }
 
+// stream returns the stream set for the reference of the user during the replay.
+func (r *ingesterRecoverer) stream(userID string, ref chunks.HeadSeriesRef) (*stream, bool) {
+	out, ok := r.users.Load(userID)
+	if !ok {
+		return nil, false
+	}
+	s, ok := out.(*sync.Map).Load(ref)
+	if !ok {
+		return nil, false
+	}
+	return s.(*stream), true
+}
+
 func (r *ingesterRecoverer) Close() {
 	// Ensure this is only run once.
 	select {
@@ -220,36 +242,48 @@ func (r *ingesterRecoverer) Close() {
 			s.chunkMtx.Lock()
 			defer s.chunkMtx.Unlock()
 
-			// reset all the incrementing stream counters after a successful WAL replay.
-			s.resetCounter()
-
-			if len(s.chunks) == 0 {
-				inst.removeStream(s)
-				return nil
+			if r.closeStream(inst, s) {
+				r.applyLimits(s)
 			}
+			return nil
+		})
+	}
+}
 
-			// If we've replayed a WAL with unordered writes, but the new
-			// configuration disables them, convert all streams/head blocks
-			// to ensure unordered writes are disabled after the replay,
-			// but without dropping any previously accepted data.
-			isAllowed := r.ing.limiter.UnorderedWrites(s.tenant)
-			old := s.unorderedWrites
-			s.unorderedWrites = isAllowed
+// closeStream resets the counters of a replayed stream and removes it if it has no chunks,
+// in which case it returns false. The chunkMtx of the stream must be held.
+func (r *ingesterRecoverer) closeStream(inst *instance, s *stream) bool {
+	// reset all the incrementing stream counters after a successful WAL replay.
+	s.resetCounter()
 
-			if !isAllowed && old {
-				err := s.chunks[len(s.chunks)-1].chunk.ConvertHead(headBlockType(isAllowed))
-				if err != nil {
-					level.Warn(util_log.Logger).Log(
-						"msg", "error converting headblock",
-						"err", err.Error(),
-						"stream", s.labels.String(),
-						"component", "ingesterRecoverer",
-					)
-				}
-			}
+	if len(s.chunks) == 0 {
+		inst.removeStream(s)
+		return false
+	}
+	return true
+}
 
-			return nil
-		})
+// applyLimits applies the limits of the tenant to a stream replayed while the limiter was disabled.
+// The chunkMtx of the stream must be held.
+func (r *ingesterRecoverer) applyLimits(s *stream) {
+	// If we've replayed a WAL with unordered writes, but the new
+	// configuration disables them, convert all streams/head blocks
+	// to ensure unordered writes are disabled after the replay,
+	// but without dropping any previously accepted data.
+	isAllowed := r.ing.limiter.UnorderedWrites(s.tenant)
+	old := s.unorderedWrites
+	s.unorderedWrites = isAllowed
+
+	if len(s.chunks) > 0 && !isAllowed && old {
+		err := s.chunks[len(s.chunks)-1].chunk.ConvertHead(headBlockType(isAllowed, s.structuredMetadata))
+		if err != nil {
+			level.Warn(util_log.Logger).Log(
+				"msg", "error converting headblock",
+				"err", err.Error(),
+				"stream", s.labels.String(),
+				"component", "ingesterRecoverer",
+			)
+		}
 	}
 }
 
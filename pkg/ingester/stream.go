@@ -100,6 +100,8 @@ type stream struct {
 	entryCt int64
 
 	unorderedWrites bool
+	// structuredMetadata tells if the structured metadata of the entries is kept in the chunks.
+	structuredMetadata bool
 }
 
 type chunkDesc struct {
@@ -117,17 +119,18 @@ type entryWithError struct {
 	e     error
 }
 
-func newStream(cfg *Config, limits RateLimiterStrategy, tenant string, fp model.Fingerprint, labels labels.Labels, unorderedWrites bool, metrics *ingesterMetrics) *stream {
+func newStream(cfg *Config, limits RateLimiterStrategy, tenant string, fp model.Fingerprint, labels labels.Labels, unorderedWrites, structuredMetadata bool, metrics *ingesterMetrics) *stream {
 	return &stream{
-		limiter:         NewStreamRateLimiter(limits, tenant, 10*time.Second),
-		cfg:             cfg,
-		fp:              fp,
-		labels:          labels,
-		labelsString:    labels.String(),
-		tailers:         map[uint32]*tailer{},
-		metrics:         metrics,
-		tenant:          tenant,
-		unorderedWrites: unorderedWrites,
+		limiter:            NewStreamRateLimiter(limits, tenant, 10*time.Second),
+		cfg:                cfg,
+		fp:                 fp,
+		labels:             labels,
+		labelsString:       labels.String(),
+		tailers:            map[uint32]*tailer{},
+		metrics:            metrics,
+		tenant:             tenant,
+		unorderedWrites:    unorderedWrites,
+		structuredMetadata: structuredMetadata,
 	}
 }
 
@@ -152,7 +155,10 @@ func (s *stream) consumeChunk(_ context.Context, chunk *logproto.Chunk) error {
 func (s *stream) setChunks(chunks []Chunk) (bytesAdded, entriesAdded int, err error) {
 	s.chunkMtx.Lock()
 	defer s.chunkMtx.Unlock()
-	chks, err := fromWireChunks(s.cfg, chunks)
+	// Always use Unordered headblocks during replay
+	// to ensure Loki can effectively replay an unordered-friendly
+	// WAL into a new configuration that disables unordered writes.
+	chks, err := fromWireChunks(s.cfg, headBlockType(true, s.structuredMetadata), chunks)
 	if err != nil {
 		return 0, 0, err
 	}
@@ -165,7 +171,11 @@ func (s *stream) setChunks(chunks []Chunk) (bytesAdded, entriesAdded int, err er
 }
 
 func (s *stream) NewChunk() *chunkenc.MemChunk {
-	return chunkenc.NewMemChunk(s.cfg.parsedEncoding, headBlockType(s.unorderedWrites), s.cfg.BlockSize, s.cfg.TargetChunkSize)
+	c := chunkenc.NewMemChunk(s.cfg.parsedEncoding, headBlockType(s.unorderedWrites, s.structuredMetadata), s.cfg.BlockSize, s.cfg.TargetChunkSize)
+	if s.cfg.ChunkBloomFilters {
+		c.EnableBloomFilters()
+	}
+	return c
 }
 
 func (s *stream) Push(
@@ -527,8 +537,13 @@ func (s *stream) resetCounter() {
 	s.entryCt = 0
 }
 
-func headBlockType(unorderedWrites bool) chunkenc.HeadBlockFmt {
+// headBlockType returns the head block format of the chunks of a stream.
+// Only unordered head blocks can keep the structured metadata of the entries.
+func headBlockType(unorderedWrites, structuredMetadata bool) chunkenc.HeadBlockFmt {
 	if unorderedWrites {
+		if structuredMetadata {
+			return chunkenc.UnorderedWithStructuredMetadataHeadBlockFmt
+		}
 		return chunkenc.UnorderedHeadBlockFmt
 	}
 	return chunkenc.OrderedHeadBlockFmt
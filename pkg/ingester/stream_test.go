@@ -61,6 +61,7 @@ func TestMaxReturnedStreamsErrors(t *testing.T) {
 					{Name: "foo", Value: "bar"},
 				},
 				true,
+				false,
 				NilMetrics,
 			)
 
@@ -106,6 +107,7 @@ func TestPushDeduplication(t *testing.T) {
 			{Name: "foo", Value: "bar"},
 		},
 		true,
+		false,
 		NilMetrics,
 	)
 
@@ -135,6 +137,7 @@ func TestPushRejectOldCounter(t *testing.T) {
 			{Name: "foo", Value: "bar"},
 		},
 		true,
+		false,
 		NilMetrics,
 	)
 
@@ -229,6 +232,7 @@ func TestUnorderedPush(t *testing.T) {
 			{Name: "foo", Value: "bar"},
 		},
 		true,
+		false,
 		NilMetrics,
 	)
 
@@ -327,6 +331,7 @@ func TestPushRateLimit(t *testing.T) {
 			{Name: "foo", Value: "bar"},
 		},
 		true,
+		false,
 		NilMetrics,
 	)
 
@@ -356,6 +361,7 @@ func TestReplayAppendIgnoresValidityWindow(t *testing.T) {
 			{Name: "foo", Value: "bar"},
 		},
 		true,
+		false,
 		NilMetrics,
 	)
 
@@ -406,8 +412,8 @@ func Benchmark_PushStream(b *testing.B) {
 	require.NoError(b, err)
 	limiter := NewLimiter(limits, NilMetrics, &ringCountMock{count: 1}, 1)
 
-	s := newStream(&Config{MaxChunkAge: 24 * time.Hour}, limiter, "fake", model.Fingerprint(0), ls, true, NilMetrics)
-	t, err := newTailer("foo", `{namespace="loki-dev"}`, &fakeTailServer{}, 10)
+	s := newStream(&Config{MaxChunkAge: 24 * time.Hour}, limiter, "fake", model.Fingerprint(0), ls, true, false, NilMetrics)
+	t, err := newTailer("foo", &logproto.TailRequest{Query: `{namespace="loki-dev"}`}, &fakeTailServer{}, 10)
 	require.NoError(b, err)
 
 	go t.loop()
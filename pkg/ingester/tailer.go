@@ -3,12 +3,15 @@ package ingester
 import (
 	"encoding/binary"
 	"hash/fnv"
+	"math"
 	"sync"
 	"time"
 
+	"github.com/cespare/xxhash/v2"
 	"github.com/go-kit/log/level"
 	"github.com/prometheus/prometheus/model/labels"
 	"golang.org/x/net/context"
+	"golang.org/x/time/rate"
 
 	"github.com/grafana/loki/pkg/logproto"
 	"github.com/grafana/loki/pkg/logql/log"
@@ -32,6 +35,11 @@ type tailer struct {
 	expr        syntax.Expr
 	pipelineMtx sync.Mutex
 
+	// Only the entries with a hash lower than sampleThreshold are sent, all of them if it is 0.
+	sampleThreshold uint64
+	// Caps the number of entries sent per second, if not nil.
+	limiter *rate.Limiter
+
 	sendChan chan *logproto.Stream
 
 	// Signaling channel used to notify once the tailer gets closed
@@ -47,8 +55,8 @@ type tailer struct {
 	conn TailServer
 }
 
-func newTailer(orgID, query string, conn TailServer, maxDroppedStreams int) (*tailer, error) {
-	expr, err := syntax.ParseLogSelector(query, true)
+func newTailer(orgID string, req *logproto.TailRequest, conn TailServer, maxDroppedStreams int) (*tailer, error) {
+	expr, err := syntax.ParseLogSelector(req.Query, true)
 	if err != nil {
 		return nil, err
 	}
@@ -58,6 +66,15 @@ func newTailer(orgID, query string, conn TailServer, maxDroppedStreams int) (*ta
 	}
 	matchers := expr.Matchers()
 
+	var sampleThreshold uint64
+	if req.Sample > 0 && req.Sample < 1 {
+		sampleThreshold = uint64(req.Sample * math.MaxUint64)
+	}
+	var limiter *rate.Limiter
+	if req.Rate > 0 {
+		limiter = rate.NewLimiter(rate.Limit(req.Rate), int(req.Rate))
+	}
+
 	return &tailer{
 		orgID:             orgID,
 		matchers:          matchers,
@@ -66,9 +83,11 @@ func newTailer(orgID, query string, conn TailServer, maxDroppedStreams int) (*ta
 		conn:              conn,
 		droppedStreams:    make([]*logproto.DroppedStream, 0, maxDroppedStreams),
 		maxDroppedStreams: maxDroppedStreams,
-		id:                generateUniqueID(orgID, query),
+		id:                generateUniqueID(orgID, req.Query),
 		closeChan:         make(chan struct{}),
 		expr:              expr,
+		sampleThreshold:   sampleThreshold,
+		limiter:           limiter,
 	}, nil
 }
 
@@ -137,6 +156,26 @@ func (t *tailer) send(stream logproto.Stream, lbs labels.Labels) {
 func (t *tailer) processStream(stream logproto.Stream, lbs labels.Labels) []*logproto.Stream {
 	// Optimization: skip filtering entirely, if no filter is set
 	if log.IsNoopPipeline(t.pipeline) {
+		if t.sampleThreshold == 0 && t.limiter == nil {
+			return []*logproto.Stream{&stream}
+		}
+		var throttled []logproto.Entry
+		entries := make([]logproto.Entry, 0, len(stream.Entries))
+		for _, e := range stream.Entries {
+			if !t.sampled(lbs, e) {
+				continue
+			}
+			if !t.allowed() {
+				throttled = append(throttled, e)
+				continue
+			}
+			entries = append(entries, e)
+		}
+		t.throttleStream(logproto.Stream{Labels: stream.Labels, Entries: throttled})
+		if len(entries) == 0 {
+			return nil
+		}
+		stream.Entries = entries
 		return []*logproto.Stream{&stream}
 	}
 	// pipeline are not thread safe and tailer can process multiple stream at once.
@@ -144,13 +183,21 @@ func (t *tailer) processStream(stream logproto.Stream, lbs labels.Labels) []*log
 	defer t.pipelineMtx.Unlock()
 
 	streams := map[uint64]*logproto.Stream{}
+	var throttled []logproto.Entry
 
 	sp := t.pipeline.ForStream(lbs)
 	for _, e := range stream.Entries {
+		if !t.sampled(lbs, e) {
+			continue
+		}
 		newLine, parsedLbs, ok := sp.ProcessString(e.Timestamp.UnixNano(), e.Line)
 		if !ok {
 			continue
 		}
+		if !t.allowed() {
+			throttled = append(throttled, e)
+			continue
+		}
 		var stream *logproto.Stream
 		if stream, ok = streams[parsedLbs.Hash()]; !ok {
 			stream = &logproto.Stream{
@@ -163,6 +210,8 @@ func (t *tailer) processStream(stream logproto.Stream, lbs labels.Labels) []*log
 			Line:      newLine,
 		})
 	}
+	t.throttleStream(logproto.Stream{Labels: stream.Labels, Entries: throttled})
+
 	streamsResult := make([]*logproto.Stream, 0, len(streams))
 	for _, stream := range streams {
 		streamsResult = append(streamsResult, stream)
@@ -170,6 +219,28 @@ func (t *tailer) processStream(stream logproto.Stream, lbs labels.Labels) []*log
 	return streamsResult
 }
 
+// sampled returns whether the entry is part of the sample of the entries to send.
+// The entries are sampled by the hash of their stream, timestamp and line, so that
+// every ingester and every tail request with the same ratio sends the same entries.
+func (t *tailer) sampled(lbs labels.Labels, e logproto.Entry) bool {
+	if t.sampleThreshold == 0 {
+		return true
+	}
+	var buf [16]byte
+	binary.LittleEndian.PutUint64(buf[:8], lbs.Hash())
+	binary.LittleEndian.PutUint64(buf[8:], uint64(e.Timestamp.UnixNano()))
+
+	h := xxhash.New()
+	_, _ = h.Write(buf[:])
+	_, _ = h.WriteString(e.Line)
+	return h.Sum64() < t.sampleThreshold
+}
+
+// allowed returns whether another entry can be sent without exceeding the rate.
+func (t *tailer) allowed() bool {
+	return t.limiter == nil || t.limiter.Allow()
+}
+
 // isMatching returns true if lbs matches all matchers.
 func isMatching(lbs labels.Labels, matchers []*labels.Matcher) bool {
 	for _, matcher := range matchers {
@@ -220,7 +291,24 @@ func (t *tailer) dropStream(stream logproto.Stream) {
 		blockedAt := time.Now()
 		t.blockedAt = &blockedAt
 	}
+	t.appendDroppedStream(stream)
+}
+
+// throttleStream reports the entries of the stream dropped for exceeding the rate of the tail request
+// along with the next response, without considering the connection blocked.
+func (t *tailer) throttleStream(stream logproto.Stream) {
+	if len(stream.Entries) == 0 {
+		return
+	}
+
+	t.blockedMtx.Lock()
+	defer t.blockedMtx.Unlock()
+
+	t.appendDroppedStream(stream)
+}
 
+// appendDroppedStream adds the stream to the dropped streams. The blockedMtx must be held.
+func (t *tailer) appendDroppedStream(stream logproto.Stream) {
 	if len(t.droppedStreams) >= t.maxDroppedStreams {
 		level.Info(util_log.Logger).Log("msg", "tailer dropped streams is reset", "length", len(t.droppedStreams))
 		t.droppedStreams = nil
@@ -237,7 +325,7 @@ func (t *tailer) popDroppedStreams() []*logproto.DroppedStream {
 	t.blockedMtx.Lock()
 	defer t.blockedMtx.Unlock()
 
-	if t.blockedAt == nil {
+	if t.blockedAt == nil && len(t.droppedStreams) == 0 {
 		return nil
 	}
 
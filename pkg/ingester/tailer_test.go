@@ -2,6 +2,7 @@ package ingester
 
 import (
 	"context"
+	"fmt"
 	"math/rand"
 	"sync"
 	"testing"
@@ -26,7 +27,7 @@ func TestTailer_sendRaceConditionOnSendWhileClosing(t *testing.T) {
 	}
 
 	for run := 0; run < runs; run++ {
-		tailer, err := newTailer("org-id", stream.Labels, nil, 10)
+		tailer, err := newTailer("org-id", &logproto.TailRequest{Query: stream.Labels}, nil, 10)
 		require.NoError(t, err)
 		require.NotNil(t, tailer)
 
@@ -78,7 +79,7 @@ func Test_dropstream(t *testing.T) {
 
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
-			tail, err := newTailer("foo", `{app="foo"} |= "foo"`, &fakeTailServer{}, maxDroppedStreams)
+			tail, err := newTailer("foo", &logproto.TailRequest{Query: `{app="foo"} |= "foo"`}, &fakeTailServer{}, maxDroppedStreams)
 			require.NoError(t, err)
 
 			for i := 0; i < c.drop; i++ {
@@ -99,7 +100,7 @@ func (f *fakeTailServer) Send(*logproto.TailResponse) error { return nil }
 func (f *fakeTailServer) Context() context.Context          { return context.Background() }
 
 func Test_TailerSendRace(t *testing.T) {
-	tail, err := newTailer("foo", `{app="foo"} |= "foo"`, &fakeTailServer{}, 10)
+	tail, err := newTailer("foo", &logproto.TailRequest{Query: `{app="foo"} |= "foo"`}, &fakeTailServer{}, 10)
 	require.NoError(t, err)
 
 	var wg sync.WaitGroup
@@ -137,3 +138,44 @@ func Test_IsMatching(t *testing.T) {
 		})
 	}
 }
+
+func Test_TailerSampleAndRate(t *testing.T) {
+	lbs := labels.Labels{{Name: "app", Value: "foo"}}
+	stream := logproto.Stream{Labels: lbs.String()}
+	for i := 0; i < 1000; i++ {
+		stream.Entries = append(stream.Entries, logproto.Entry{Timestamp: time.Unix(0, int64(i)), Line: fmt.Sprintf("foo %d", i)})
+	}
+	process := func(req *logproto.TailRequest) []logproto.Entry {
+		tail, err := newTailer("foo", req, &fakeTailServer{}, 10)
+		require.NoError(t, err)
+		var entries []logproto.Entry
+		for _, s := range tail.processStream(stream, lbs) {
+			entries = append(entries, s.Entries...)
+		}
+		return entries
+	}
+
+	for _, query := range []string{`{app="foo"}`, `{app="foo"} |= "foo"`} {
+		t.Run(query, func(t *testing.T) {
+			require.Len(t, process(&logproto.TailRequest{Query: query}), 1000)
+			require.Len(t, process(&logproto.TailRequest{Query: query, Sample: 1}), 1000)
+
+			sampled := process(&logproto.TailRequest{Query: query, Sample: 0.1})
+			require.InDelta(t, 100, len(sampled), 50)
+			// Every tailer sends the same sample.
+			require.Equal(t, sampled, process(&logproto.TailRequest{Query: query, Sample: 0.1}))
+
+			require.Len(t, process(&logproto.TailRequest{Query: query, Rate: 10}), 10)
+
+			// The entries exceeding the rate are reported as dropped, without considering the connection blocked.
+			tail, err := newTailer("foo", &logproto.TailRequest{Query: query, Rate: 10}, &fakeTailServer{}, 10)
+			require.NoError(t, err)
+			tail.processStream(stream, lbs)
+			require.Nil(t, tail.blockedSince())
+			require.Equal(t, []*logproto.DroppedStream{
+				{From: time.Unix(0, 10), To: time.Unix(0, 999), Labels: lbs.String()},
+			}, tail.popDroppedStreams())
+			require.Nil(t, tail.popDroppedStreams())
+		})
+	}
+}
@@ -29,12 +29,17 @@ type WALConfig struct {
 	CheckpointDuration  time.Duration    `yaml:"checkpoint_duration"`
 	FlushOnShutdown     bool             `yaml:"flush_on_shutdown"`
 	ReplayMemoryCeiling flagext.ByteSize `yaml:"replay_memory_ceiling"`
+	CheckpointShards    int              `yaml:"checkpoint_shards"`
+	LazyReplay          bool             `yaml:"lazy_replay"`
 }
 
 func (cfg *WALConfig) Validate() error {
 	if cfg.Enabled && cfg.CheckpointDuration < 1 {
 		return errors.Errorf("invalid checkpoint duration: %v", cfg.CheckpointDuration)
 	}
+	if cfg.CheckpointShards > 1000 {
+		return errors.Errorf("invalid checkpoint shards: %d, must not be greater than 1000", cfg.CheckpointShards)
+	}
 	return nil
 }
 
@@ -48,6 +53,8 @@ func (cfg *WALConfig) RegisterFlags(f *flag.FlagSet) {
 	// Need to set default here
 	cfg.ReplayMemoryCeiling = flagext.ByteSize(defaultCeiling)
 	f.Var(&cfg.ReplayMemoryCeiling, "ingester.wal-replay-memory-ceiling", "How much memory the WAL may use during replay before it needs to flush chunks to storage, i.e. 10GB. We suggest setting this to a high percentage (~75%) of available memory.")
+	f.IntVar(&cfg.CheckpointShards, "ingester.checkpoint-shards", 1, "Number of shards the streams of the checkpoints are split into by their labels, each shard being written into its own directory of the checkpoint. Sharded checkpoints can be replayed lazily, see -ingester.wal-lazy-replay.")
+	f.BoolVar(&cfg.LazyReplay, "ingester.wal-lazy-replay", false, "Replay the WAL in the background once the ingester joined the ring instead of before. The streams are replayed shard by shard, with the shards of the last checkpoint, the shards of the streams pushed to or matched by the queries being replayed first. The per-tenant limits are only enforced once the whole WAL is replayed.")
 }
 
 // WAL interface allows us to have a no-op WAL when the WAL is disabled.
@@ -147,8 +154,10 @@ func (w *walWrapper) Stop() error {
 
 func (w *walWrapper) checkpointWriter() *WALCheckpointWriter {
 	return &WALCheckpointWriter{
-		metrics:    w.metrics,
-		segmentWAL: w.wal,
+		metrics:     w.metrics,
+		segmentWAL:  w.wal,
+		shards:      w.cfg.CheckpointShards,
+		writeLabels: w.cfg.LazyReplay,
 	}
 }
 
@@ -27,6 +27,7 @@ const (
 	labelsPath      = "/loki/api/v1/labels"
 	labelValuesPath = "/loki/api/v1/label/%s/values"
 	seriesPath      = "/loki/api/v1/series"
+	statsPath       = "/loki/api/v1/index/stats"
 	tailPath        = "/loki/api/v1/tail"
 )
 
@@ -39,7 +40,8 @@ type Client interface {
 	ListLabelNames(quiet bool, start, end time.Time) (*loghttp.LabelResponse, error)
 	ListLabelValues(name string, quiet bool, start, end time.Time) (*loghttp.LabelResponse, error)
 	Series(matchers []string, start, end time.Time, quiet bool) (*loghttp.SeriesResponse, error)
-	LiveTailQueryConn(queryStr string, delayFor time.Duration, limit int, start time.Time, quiet bool) (*websocket.Conn, error)
+	LiveTailQueryConn(queryStr string, delayFor time.Duration, limit int, start time.Time, sample float64, rate int, quiet bool) (*websocket.Conn, error)
+	GetStats(queryStr string, start, end time.Time, quiet bool) (*logproto.IndexStatsResponse, error)
 	GetOrgID() string
 }
 
@@ -136,8 +138,22 @@ func (c *DefaultClient) Series(matchers []string, start, end time.Time, quiet bo
 	return &seriesResponse, nil
 }
 
+// GetStats uses the /loki/api/v1/index/stats endpoint to get the stats of the streams matching the query
+func (c *DefaultClient) GetStats(queryStr string, start, end time.Time, quiet bool) (*logproto.IndexStatsResponse, error) {
+	params := util.NewQueryStringBuilder()
+	params.SetInt("start", start.UnixNano())
+	params.SetInt("end", end.UnixNano())
+	params.SetString("query", queryStr)
+
+	var statsResponse logproto.IndexStatsResponse
+	if err := c.doRequest(statsPath, params.Encode(), quiet, &statsResponse); err != nil {
+		return nil, err
+	}
+	return &statsResponse, nil
+}
+
 // LiveTailQueryConn uses /api/prom/tail to set up a websocket connection and returns it
-func (c *DefaultClient) LiveTailQueryConn(queryStr string, delayFor time.Duration, limit int, start time.Time, quiet bool) (*websocket.Conn, error) {
+func (c *DefaultClient) LiveTailQueryConn(queryStr string, delayFor time.Duration, limit int, start time.Time, sample float64, rate int, quiet bool) (*websocket.Conn, error) {
 	params := util.NewQueryStringBuilder()
 	params.SetString("query", queryStr)
 	if delayFor != 0 {
@@ -145,6 +161,12 @@ func (c *DefaultClient) LiveTailQueryConn(queryStr string, delayFor time.Duratio
 	}
 	params.SetInt("limit", int64(limit))
 	params.SetInt("start", start.UnixNano())
+	if sample != 0 {
+		params.SetFloat("sample", sample)
+	}
+	if rate != 0 {
+		params.SetInt("rate", int64(rate))
+	}
 
 	return c.wsConnect(tailPath, params.Encode(), quiet)
 }
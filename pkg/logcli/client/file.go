@@ -174,10 +174,14 @@ func (f *FileClient) Series(matchers []string, start, end time.Time, quiet bool)
 	}, nil
 }
 
-func (f *FileClient) LiveTailQueryConn(queryStr string, delayFor time.Duration, limit int, start time.Time, quiet bool) (*websocket.Conn, error) {
+func (f *FileClient) LiveTailQueryConn(queryStr string, delayFor time.Duration, limit int, start time.Time, sample float64, rate int, quiet bool) (*websocket.Conn, error) {
 	return nil, fmt.Errorf("LiveTailQuery: %w", ErrNotSupported)
 }
 
+func (f *FileClient) GetStats(queryStr string, start, end time.Time, quiet bool) (*logproto.IndexStatsResponse, error) {
+	return nil, fmt.Errorf("GetStats: %w", ErrNotSupported)
+}
+
 func (f *FileClient) GetOrgID() string {
 	return f.orgID
 }
@@ -550,7 +550,11 @@ func (t *testQueryClient) Series(matchers []string, from, through time.Time, qui
 	panic("implement me")
 }
 
-func (t *testQueryClient) LiveTailQueryConn(queryStr string, delayFor time.Duration, limit int, start time.Time, quiet bool) (*websocket.Conn, error) {
+func (t *testQueryClient) LiveTailQueryConn(queryStr string, delayFor time.Duration, limit int, start time.Time, sample float64, rate int, quiet bool) (*websocket.Conn, error) {
+	panic("implement me")
+}
+
+func (t *testQueryClient) GetStats(queryStr string, start, end time.Time, quiet bool) (*logproto.IndexStatsResponse, error) {
 	panic("implement me")
 }
 
@@ -0,0 +1,43 @@
+package statsquery
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/dustin/go-humanize"
+
+	"github.com/grafana/loki/pkg/logcli/client"
+	"github.com/grafana/loki/pkg/logproto"
+)
+
+// StatsQuery contains all necessary fields to execute index stats queries and print out the results
+type StatsQuery struct {
+	QueryString string
+	Start       time.Time
+	End         time.Time
+	Quiet       bool
+}
+
+// DoStats prints out the index stats of the query
+func (q *StatsQuery) DoStats(c client.Client) {
+	stats := q.GetStats(c)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Streams:\t%d\n", stats.Streams)
+	fmt.Fprintf(w, "Chunks:\t%d\n", stats.Chunks)
+	fmt.Fprintf(w, "Entries:\t%d\n", stats.Entries)
+	fmt.Fprintf(w, "Bytes:\t%s\n", humanize.Bytes(stats.Bytes))
+	w.Flush()
+}
+
+// GetStats returns the index stats of the query
+func (q *StatsQuery) GetStats(c client.Client) *logproto.IndexStatsResponse {
+	stats, err := c.GetStats(q.QueryString, q.Start, q.End, q.Quiet)
+	if err != nil {
+		log.Fatalf("Error doing request: %+v", err)
+	}
+	return stats
+}
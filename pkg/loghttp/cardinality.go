@@ -0,0 +1,146 @@
+package loghttp
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/grafana/loki/pkg/logproto"
+)
+
+const (
+	defaultCardinalityLimit = 20
+	maxCardinalityLimit     = 500
+)
+
+var errMissingLabelNames = errors.New("at least one label name must be given with label_names[]")
+
+// CardinalityQuery is a request for the cardinality of the active streams of a tenant.
+type CardinalityQuery struct {
+	Selector   string
+	LabelNames []string
+	Limit      int
+}
+
+// ParseCardinalityLabelNamesQuery parses a label names cardinality request from an http request.
+func ParseCardinalityLabelNamesQuery(r *http.Request) (*CardinalityQuery, error) {
+	limit, err := cardinalityLimit(r)
+	if err != nil {
+		return nil, err
+	}
+	return &CardinalityQuery{
+		Selector: r.Form.Get("selector"),
+		Limit:    limit,
+	}, nil
+}
+
+// ParseCardinalityLabelValuesQuery parses a label values cardinality request from an http request.
+func ParseCardinalityLabelValuesQuery(r *http.Request) (*CardinalityQuery, error) {
+	limit, err := cardinalityLimit(r)
+	if err != nil {
+		return nil, err
+	}
+	names := r.Form["label_names[]"]
+	if len(names) == 0 {
+		return nil, errMissingLabelNames
+	}
+	return &CardinalityQuery{
+		Selector:   r.Form.Get("selector"),
+		LabelNames: names,
+		Limit:      limit,
+	}, nil
+}
+
+func cardinalityLimit(r *http.Request) (int, error) {
+	l, err := parseInt(r.Form.Get("limit"), defaultCardinalityLimit)
+	if err != nil {
+		return 0, err
+	}
+	if l <= 0 || l > maxCardinalityLimit {
+		return 0, fmt.Errorf("limit must be a positive value up to %d", maxCardinalityLimit)
+	}
+	return l, nil
+}
+
+// CardinalityLabelNamesResponse represents the http json response to a label names cardinality request.
+type CardinalityLabelNamesResponse struct {
+	Streams         uint64             `json:"streams"`
+	LabelNamesCount int                `json:"label_names_count"`
+	Labels          []LabelCardinality `json:"labels"`
+}
+
+// CardinalityLabelValuesResponse represents the http json response to a label values cardinality request.
+type CardinalityLabelValuesResponse struct {
+	Streams uint64             `json:"streams"`
+	Labels  []LabelCardinality `json:"labels"`
+}
+
+// LabelCardinality is the number of streams with a label name, and with each of its top values.
+type LabelCardinality struct {
+	Name        string                  `json:"label_name"`
+	Streams     uint64                  `json:"streams"`
+	ValuesCount int                     `json:"label_values_count"`
+	Values      []LabelValueCardinality `json:"values,omitempty"`
+}
+
+// LabelValueCardinality is the number of streams with a label value.
+type LabelValueCardinality struct {
+	Value   string `json:"label_value"`
+	Streams uint64 `json:"streams"`
+}
+
+// NewCardinalityLabelNamesResponse returns the limit label names with the most streams.
+func NewCardinalityLabelNamesResponse(resp *logproto.CardinalityResponse, limit int) CardinalityLabelNamesResponse {
+	res := CardinalityLabelNamesResponse{
+		Streams:         resp.Streams,
+		LabelNamesCount: len(resp.Labels),
+		Labels:          make([]LabelCardinality, 0, len(resp.Labels)),
+	}
+	for _, l := range resp.Labels {
+		res.Labels = append(res.Labels, LabelCardinality{Name: l.Name, Streams: l.Streams, ValuesCount: int(l.ValuesCount)})
+	}
+	res.Labels = topLabelCardinalities(res.Labels, limit)
+	return res
+}
+
+// NewCardinalityLabelValuesResponse returns the label names with the most streams first,
+// each with its limit values with the most streams.
+func NewCardinalityLabelValuesResponse(resp *logproto.CardinalityResponse, limit int) CardinalityLabelValuesResponse {
+	res := CardinalityLabelValuesResponse{
+		Streams: resp.Streams,
+		Labels:  make([]LabelCardinality, 0, len(resp.Labels)),
+	}
+	for _, l := range resp.Labels {
+		values := make([]LabelValueCardinality, 0, len(l.Values))
+		for _, v := range l.Values {
+			values = append(values, LabelValueCardinality{Value: v.Value, Streams: v.Streams})
+		}
+		sort.Slice(values, func(i, j int) bool {
+			if values[i].Streams != values[j].Streams {
+				return values[i].Streams > values[j].Streams
+			}
+			return values[i].Value < values[j].Value
+		})
+		if len(values) > limit {
+			values = values[:limit]
+		}
+		res.Labels = append(res.Labels, LabelCardinality{Name: l.Name, Streams: l.Streams, ValuesCount: int(l.ValuesCount), Values: values})
+	}
+	res.Labels = topLabelCardinalities(res.Labels, len(res.Labels))
+	return res
+}
+
+// topLabelCardinalities sorts the labels by descending number of streams and keeps the limit first ones.
+func topLabelCardinalities(labels []LabelCardinality, limit int) []LabelCardinality {
+	sort.Slice(labels, func(i, j int) bool {
+		if labels[i].Streams != labels[j].Streams {
+			return labels[i].Streams > labels[j].Streams
+		}
+		return labels[i].Name < labels[j].Name
+	})
+	if len(labels) > limit {
+		labels = labels[:limit]
+	}
+	return labels
+}
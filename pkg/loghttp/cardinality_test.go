@@ -0,0 +1,74 @@
+package loghttp
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/loki/pkg/logproto"
+)
+
+func TestParseCardinalityQuery(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		r       *http.Request
+		values  bool
+		want    *CardinalityQuery
+		wantErr bool
+	}{
+		{"label names", &http.Request{URL: mustParseURL(`?selector={job="loki"}`)}, false, &CardinalityQuery{Selector: `{job="loki"}`, Limit: 20}, false},
+		{"bad limit", &http.Request{URL: mustParseURL(`?limit=0`)}, false, nil, true},
+		{"too large limit", &http.Request{URL: mustParseURL(`?limit=501`)}, false, nil, true},
+		{"missing label names", &http.Request{URL: mustParseURL(`?selector={job="loki"}`)}, true, nil, true},
+		{"label values", &http.Request{URL: mustParseURL(`?label_names[]=pod&label_names[]=app&limit=5`)}, true, &CardinalityQuery{LabelNames: []string{"pod", "app"}, Limit: 5}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, tt.r.ParseForm())
+
+			parse := ParseCardinalityLabelNamesQuery
+			if tt.values {
+				parse = ParseCardinalityLabelValuesQuery
+			}
+			got, err := parse(tt.r)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCardinalityResponses(t *testing.T) {
+	resp := &logproto.CardinalityResponse{
+		Streams: 10,
+		Labels: []*logproto.LabelCardinality{
+			{Name: "app", Streams: 10, ValuesCount: 2, Values: []*logproto.LabelValueCardinality{{Value: "a", Streams: 3}, {Value: "b", Streams: 7}}},
+			{Name: "job", Streams: 10, ValuesCount: 1, Values: []*logproto.LabelValueCardinality{{Value: "loki", Streams: 10}}},
+			{Name: "pod", Streams: 3, ValuesCount: 3, Values: []*logproto.LabelValueCardinality{{Value: "x", Streams: 1}, {Value: "y", Streams: 1}, {Value: "z", Streams: 1}}},
+		},
+	}
+
+	require.Equal(t, CardinalityLabelNamesResponse{
+		Streams:         10,
+		LabelNamesCount: 3,
+		Labels: []LabelCardinality{
+			{Name: "app", Streams: 10, ValuesCount: 2},
+			{Name: "job", Streams: 10, ValuesCount: 1},
+		},
+	}, NewCardinalityLabelNamesResponse(resp, 2))
+
+	require.Equal(t, CardinalityLabelValuesResponse{
+		Streams: 10,
+		Labels: []LabelCardinality{
+			{Name: "app", Streams: 10, ValuesCount: 2, Values: []LabelValueCardinality{{Value: "b", Streams: 7}, {Value: "a", Streams: 3}}},
+			{Name: "job", Streams: 10, ValuesCount: 1, Values: []LabelValueCardinality{{Value: "loki", Streams: 10}}},
+			{Name: "pod", Streams: 3, ValuesCount: 3, Values: []LabelValueCardinality{{Value: "x", Streams: 1}, {Value: "y", Streams: 1}}},
+		},
+	}, NewCardinalityLabelValuesResponse(resp, 2))
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/buger/jsonparser"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/modern-go/reflect2"
+	"github.com/prometheus/prometheus/model/labels"
 )
 
 func init() {
@@ -15,9 +16,12 @@ func init() {
 }
 
 // Entry represents a log entry.  It includes a log message and the time it occurred at.
+// It can also include structured metadata, which is encoded as an optional third
+// element of the entry array: a JSON object of name/value pairs.
 type Entry struct {
-	Timestamp time.Time
-	Line      string
+	Timestamp          time.Time
+	Line               string
+	StructuredMetadata labels.Labels
 }
 
 func (e *Entry) UnmarshalJSON(data []byte) error {
@@ -26,7 +30,19 @@ func (e *Entry) UnmarshalJSON(data []byte) error {
 		parseError error
 	)
 	_, err := jsonparser.ArrayEach(data, func(value []byte, t jsonparser.ValueType, _ int, _ error) {
-		// assert that both items in array are of type string
+		if parseError != nil {
+			return
+		}
+		if i == 2 { // structured metadata
+			if t != jsonparser.Object {
+				parseError = jsonparser.MalformedObjectError
+				return
+			}
+			e.StructuredMetadata, parseError = parseStructuredMetadata(value)
+			i++
+			return
+		}
+		// assert that both the timestamp and the line are of type string
 		if t != jsonparser.String {
 			parseError = jsonparser.MalformedStringError
 			return
@@ -55,6 +71,25 @@ func (e *Entry) UnmarshalJSON(data []byte) error {
 	return err
 }
 
+func parseStructuredMetadata(data []byte) (labels.Labels, error) {
+	var lbs labels.Labels
+	err := jsonparser.ObjectEach(data, func(key []byte, value []byte, t jsonparser.ValueType, _ int) error {
+		if t != jsonparser.String {
+			return jsonparser.MalformedStringError
+		}
+		v, err := jsonparser.ParseString(value)
+		if err != nil {
+			return err
+		}
+		lbs = append(lbs, labels.Label{Name: string(key), Value: v})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return lbs, nil
+}
+
 type jsonExtension struct {
 	jsoniter.DummyExtension
 }
@@ -67,6 +102,7 @@ func (sliceEntryDecoder) Decode(ptr unsafe.Pointer, iter *jsoniter.Iterator) {
 		i := 0
 		var ts time.Time
 		var line string
+		var structuredMetadata labels.Labels
 		ok := iter.ReadArrayCB(func(iter *jsoniter.Iterator) bool {
 			var ok bool
 			switch i {
@@ -81,15 +117,23 @@ func (sliceEntryDecoder) Decode(ptr unsafe.Pointer, iter *jsoniter.Iterator) {
 					return false
 				}
 				return true
+			case 2:
+				iter.ReadMapCB(func(iter *jsoniter.Iterator, name string) bool {
+					structuredMetadata = append(structuredMetadata, labels.Label{Name: name, Value: iter.ReadString()})
+					return iter.Error == nil
+				})
+				i++
+				return iter.Error == nil
 			default:
-				iter.ReportError("error reading entry", "array must contains 2 values")
+				iter.ReportError("error reading entry", "array must contains 2 or 3 values")
 				return false
 			}
 		})
 		if ok {
 			*((*[]Entry)(ptr)) = append(*((*[]Entry)(ptr)), Entry{
-				Timestamp: ts,
-				Line:      line,
+				Timestamp:          ts,
+				Line:               line,
+				StructuredMetadata: structuredMetadata,
 			})
 			return true
 		}
@@ -126,6 +170,18 @@ func (entryEncoder) Encode(ptr unsafe.Pointer, stream *jsoniter.Stream) {
 	stream.WriteRaw(`"`)
 	stream.WriteMore()
 	stream.WriteStringWithHTMLEscaped(e.Line)
+	if len(e.StructuredMetadata) > 0 {
+		stream.WriteMore()
+		stream.WriteObjectStart()
+		for i, lbl := range e.StructuredMetadata {
+			if i > 0 {
+				stream.WriteMore()
+			}
+			stream.WriteObjectField(lbl.Name)
+			stream.WriteString(lbl.Value)
+		}
+		stream.WriteObjectEnd()
+	}
 	stream.WriteArrayEnd()
 }
 
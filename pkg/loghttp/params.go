@@ -46,6 +46,16 @@ func shards(r *http.Request) []string {
 	return r.Form["shards"]
 }
 
+// quantileSketch tells whether the quantile sketches of a quantile_over_time query are requested instead of its
+// quantiles, which the frontend does to merge the results of the shards of the query.
+func quantileSketch(r *http.Request) (bool, error) {
+	value := r.Form.Get("quantile_sketch")
+	if value == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}
+
 func bounds(r *http.Request) (time.Time, time.Time, error) {
 	now := time.Now()
 	start, err := parseTimestamp(r.Form.Get("start"), now.Add(-defaultSince))
@@ -89,6 +99,32 @@ func tailDelay(r *http.Request) (uint32, error) {
 	return uint32(l), nil
 }
 
+func tailSample(r *http.Request) (float64, error) {
+	value := r.Form.Get("sample")
+	if value == "" {
+		return 0, nil
+	}
+	sample, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, err
+	}
+	if sample <= 0 || sample > 1 {
+		return 0, errors.New("sample must be greater than 0 and lower than or equal to 1")
+	}
+	return sample, nil
+}
+
+func tailRate(r *http.Request) (uint32, error) {
+	l, err := parseInt(r.Form.Get("rate"), 0)
+	if err != nil {
+		return 0, err
+	}
+	if l < 0 {
+		return 0, errors.New("rate must be a positive value")
+	}
+	return uint32(l), nil
+}
+
 // parseInt parses an int from a string
 // if the value is empty it returns a default value passed as second parameter
 func parseInt(value string, def int) (int, error) {
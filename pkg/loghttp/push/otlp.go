@@ -0,0 +1,293 @@
+package push
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-logfmt/logfmt"
+	"github.com/grafana/dskit/flagext"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/util/strutil"
+
+	"github.com/grafana/loki/pkg/loghttp/push/otlp"
+	"github.com/grafana/loki/pkg/logproto"
+	"github.com/grafana/loki/pkg/util"
+)
+
+const (
+	applicationProtobuf = "application/x-protobuf"
+
+	// OTLPLineFormatLogfmt renders the body and the attributes of the log records as logfmt.
+	OTLPLineFormatLogfmt = "logfmt"
+	// OTLPLineFormatJSON renders the body and the attributes of the log records as a JSON object.
+	OTLPLineFormatJSON = "json"
+)
+
+// defaultOTLPAttributesAsLabels are the resource attributes from the OpenTelemetry semantic
+// conventions identifying where the logs come from.
+var defaultOTLPAttributesAsLabels = []string{
+	"service.name",
+	"service.namespace",
+	"service.instance.id",
+	"deployment.environment",
+	"cloud.region",
+	"cloud.availability_zone",
+	"k8s.cluster.name",
+	"k8s.namespace.name",
+	"k8s.pod.name",
+	"k8s.container.name",
+	"container.name",
+}
+
+// unknownServiceLabels are the labels of the log records without any attribute mapped to a label,
+// named after the OpenTelemetry default for a resource without service.name.
+var unknownServiceLabels = labels.Labels{{Name: "service_name", Value: "unknown_service"}}
+
+// OTLPConfig configures how the OTLP log records of a tenant are converted into streams.
+type OTLPConfig struct {
+	AttributesAsLabels []string `yaml:"attributes_as_labels,omitempty" json:"attributes_as_labels,omitempty"`
+	LineFormat         string   `yaml:"line_format" json:"line_format"`
+}
+
+func (cfg *OTLPConfig) RegisterFlagsWithPrefix(prefix string, f *flag.FlagSet) {
+	cfg.AttributesAsLabels = defaultOTLPAttributesAsLabels
+	f.Var((*flagext.StringSliceCSV)(&cfg.AttributesAsLabels), prefix+".attributes-as-labels", "Comma separated list of the resource and scope attributes of the OTLP log records mapped to stream labels. The dots and other characters invalid in label names are replaced by underscores. The other attributes are rendered into the log line.")
+	f.StringVar(&cfg.LineFormat, prefix+".line-format", OTLPLineFormatLogfmt, "Format of the log line rendered from the body, severity, trace and span IDs and attributes of the OTLP log records: logfmt or json.")
+}
+
+func (cfg *OTLPConfig) Validate() error {
+	switch cfg.LineFormat {
+	// An empty line format defaults to logfmt.
+	case "", OTLPLineFormatLogfmt, OTLPLineFormatJSON:
+		return nil
+	default:
+		return fmt.Errorf("invalid OTLP line format %q, must be one of %s or %s", cfg.LineFormat, OTLPLineFormatLogfmt, OTLPLineFormatJSON)
+	}
+}
+
+// ParseOTLPRequest reads an OTLP logs export request, encoded in protobuf or JSON, and converts its log records into streams.
+func ParseOTLPRequest(logger log.Logger, userID string, r *http.Request, tenantsRetention TenantsRetention, cfg OTLPConfig) (*logproto.PushRequest, error) {
+	// bodySize should always reflect the compressed size of the request body
+	bodySize := util.NewSizeReader(r.Body)
+	contentEncoding := r.Header.Get(contentEnc)
+	body, err := decompressBody(contentEncoding, bodySize)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	contentType, err := otlpContentType(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var otlpReq otlp.ExportLogsServiceRequest
+	switch contentType {
+	case applicationJSON:
+		buf, err := io.ReadAll(body)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(buf, &otlpReq); err != nil {
+			return nil, err
+		}
+	default:
+		if err := util.ParseProtoReader(r.Context(), body, int(r.ContentLength), math.MaxInt32, &otlpReq, util.NoCompression); err != nil {
+			return nil, err
+		}
+	}
+
+	req, err := otlpToPushRequest(&otlpReq, cfg, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := recordRequestStats(logger, userID, r, req, contentType, contentEncoding, bodySize, tenantsRetention); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// WriteOTLPResponse writes the response of a successful OTLP logs export request, in the encoding of the request.
+func WriteOTLPResponse(w http.ResponseWriter, r *http.Request) {
+	contentType, _ := otlpContentType(r)
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+
+	// All the log records are accepted, so the response has no partial success.
+	if contentType == applicationJSON {
+		_, _ = w.Write([]byte("{}"))
+	}
+}
+
+func otlpContentType(r *http.Request) (string, error) {
+	header := r.Header.Get(contentType)
+	if header == "" {
+		return applicationProtobuf, nil
+	}
+	contentType, _ /* params */, err := mime.ParseMediaType(header)
+	if err != nil {
+		return "", err
+	}
+	switch contentType {
+	case applicationJSON, applicationProtobuf:
+		return contentType, nil
+	default:
+		return "", fmt.Errorf("Content-Type %q not supported", contentType)
+	}
+}
+
+// otlpToPushRequest converts the log records of the request into streams.
+// The resource and scope attributes of the allow-list become the labels of the streams
+// and the other attributes are rendered into the log lines.
+func otlpToPushRequest(req *otlp.ExportLogsServiceRequest, cfg OTLPConfig, now time.Time) (*logproto.PushRequest, error) {
+	asLabels := make(map[string]struct{}, len(cfg.AttributesAsLabels))
+	for _, name := range cfg.AttributesAsLabels {
+		asLabels[name] = struct{}{}
+	}
+
+	var (
+		streams   []logproto.Stream
+		streamIdx = map[string]int{}
+	)
+	for _, rl := range req.ResourceLogs {
+		resourceLabels := labels.NewBuilder(nil)
+		resourceAttributes := otlpAttributes(rl.GetResource().GetAttributes(), asLabels, resourceLabels)
+
+		for _, sl := range rl.ScopeLogs {
+			scopeLabels := labels.NewBuilder(resourceLabels.Labels())
+			attributes := append(resourceAttributes[:len(resourceAttributes):len(resourceAttributes)], otlpAttributes(sl.GetScope().GetAttributes(), asLabels, scopeLabels)...)
+
+			lbs := scopeLabels.Labels()
+			if len(lbs) == 0 {
+				lbs = unknownServiceLabels
+			}
+			key := lbs.String()
+			idx, ok := streamIdx[key]
+			if !ok {
+				idx = len(streams)
+				streamIdx[key] = idx
+				streams = append(streams, logproto.Stream{Labels: key})
+			}
+
+			for _, lr := range sl.LogRecords {
+				line, err := otlpLine(cfg.LineFormat, lr, attributes)
+				if err != nil {
+					return nil, err
+				}
+				streams[idx].Entries = append(streams[idx].Entries, logproto.Entry{
+					Timestamp: otlpTimestamp(lr, now),
+					Line:      line,
+				})
+			}
+		}
+	}
+	return &logproto.PushRequest{Streams: streams}, nil
+}
+
+// otlpAttributes sets the attributes of the allow-list as labels and returns the other ones.
+func otlpAttributes(attributes []*otlp.KeyValue, asLabels map[string]struct{}, lbs *labels.Builder) []*otlp.KeyValue {
+	var rest []*otlp.KeyValue
+	for _, attr := range attributes {
+		if _, ok := asLabels[attr.Key]; ok {
+			lbs.Set(strutil.SanitizeLabelName(attr.Key), otlp.ValueString(attr.Value))
+			continue
+		}
+		rest = append(rest, attr)
+	}
+	return rest
+}
+
+func otlpTimestamp(lr *otlp.LogRecord, now time.Time) time.Time {
+	switch {
+	case lr.TimeUnixNano != 0:
+		return time.Unix(0, int64(lr.TimeUnixNano))
+	case lr.ObservedTimeUnixNano != 0:
+		return time.Unix(0, int64(lr.ObservedTimeUnixNano))
+	default:
+		return now
+	}
+}
+
+// otlpSeverity returns the severity text of the log record, or the short name of its severity number.
+func otlpSeverity(lr *otlp.LogRecord) string {
+	if lr.SeverityText != "" || lr.SeverityNumber == otlp.SEVERITY_NUMBER_UNSPECIFIED {
+		return lr.SeverityText
+	}
+	name, ok := otlp.SeverityNumber_name[int32(lr.SeverityNumber)]
+	if !ok {
+		return ""
+	}
+	return strings.TrimRight(strings.TrimPrefix(name, "SEVERITY_NUMBER_"), "234")
+}
+
+// otlpLine renders the body of the log record followed by its severity, trace and span IDs and attributes.
+func otlpLine(format string, lr *otlp.LogRecord, attributes []*otlp.KeyValue) (string, error) {
+	type field struct {
+		key   string
+		value *otlp.AnyValue
+	}
+	fields := make([]field, 0, 4+len(attributes)+len(lr.Attributes))
+	if lr.Body != nil {
+		fields = append(fields, field{key: "body", value: lr.Body})
+	}
+	if severity := otlpSeverity(lr); severity != "" {
+		fields = append(fields, field{key: "severity", value: otlpString(severity)})
+	}
+	if len(lr.TraceId) != 0 {
+		fields = append(fields, field{key: "trace_id", value: otlpString(hex.EncodeToString(lr.TraceId))})
+	}
+	if len(lr.SpanId) != 0 {
+		fields = append(fields, field{key: "span_id", value: otlpString(hex.EncodeToString(lr.SpanId))})
+	}
+	for _, attrs := range [][]*otlp.KeyValue{attributes, lr.Attributes} {
+		for _, attr := range attrs {
+			fields = append(fields, field{key: attr.Key, value: attr.Value})
+		}
+	}
+
+	var buf bytes.Buffer
+	switch format {
+	case OTLPLineFormatJSON:
+		buf.WriteByte('{')
+		for i, f := range fields {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			key, err := json.Marshal(f.key)
+			if err != nil {
+				return "", err
+			}
+			value, err := json.Marshal(otlp.ValueInterface(f.value))
+			if err != nil {
+				return "", err
+			}
+			buf.Write(key)
+			buf.WriteByte(':')
+			buf.Write(value)
+		}
+		buf.WriteByte('}')
+	default:
+		enc := logfmt.NewEncoder(&buf)
+		for _, f := range fields {
+			if err := enc.EncodeKeyval(f.key, otlp.ValueString(f.value)); err != nil {
+				return "", fmt.Errorf("invalid attribute %q: %w", f.key, err)
+			}
+		}
+	}
+	return buf.String(), nil
+}
+
+func otlpString(s string) *otlp.AnyValue {
+	return &otlp.AnyValue{Value: &otlp.AnyValue_StringValue{StringValue: s}}
+}
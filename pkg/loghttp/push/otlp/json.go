@@ -0,0 +1,194 @@
+package otlp
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// UnmarshalJSON decodes the OTLP/JSON encoding of the request, which differs from the JSON encoding of
+// the protobuf messages: the trace and span IDs are hex encoded, and the enums are always integers.
+func (m *ExportLogsServiceRequest) UnmarshalJSON(data []byte) error {
+	var req jsonExportLogsServiceRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		return err
+	}
+
+	m.ResourceLogs = make([]*ResourceLogs, 0, len(req.ResourceLogs))
+	for _, rl := range req.ResourceLogs {
+		resourceLogs := &ResourceLogs{SchemaUrl: rl.SchemaURL}
+		if rl.Resource != nil {
+			resourceLogs.Resource = &Resource{
+				Attributes:             toKeyValues(rl.Resource.Attributes),
+				DroppedAttributesCount: rl.Resource.DroppedAttributesCount,
+			}
+		}
+		for _, sl := range rl.ScopeLogs {
+			scopeLogs := &ScopeLogs{SchemaUrl: sl.SchemaURL}
+			if sl.Scope != nil {
+				scopeLogs.Scope = &InstrumentationScope{
+					Name:                   sl.Scope.Name,
+					Version:                sl.Scope.Version,
+					Attributes:             toKeyValues(sl.Scope.Attributes),
+					DroppedAttributesCount: sl.Scope.DroppedAttributesCount,
+				}
+			}
+			for _, lr := range sl.LogRecords {
+				scopeLogs.LogRecords = append(scopeLogs.LogRecords, &LogRecord{
+					TimeUnixNano:           uint64(lr.TimeUnixNano),
+					ObservedTimeUnixNano:   uint64(lr.ObservedTimeUnixNano),
+					SeverityNumber:         SeverityNumber(lr.SeverityNumber),
+					SeverityText:           lr.SeverityText,
+					Body:                   lr.Body.toAnyValue(),
+					Attributes:             toKeyValues(lr.Attributes),
+					DroppedAttributesCount: lr.DroppedAttributesCount,
+					Flags:                  lr.Flags,
+					TraceId:                lr.TraceID,
+					SpanId:                 lr.SpanID,
+				})
+			}
+			resourceLogs.ScopeLogs = append(resourceLogs.ScopeLogs, scopeLogs)
+		}
+		m.ResourceLogs = append(m.ResourceLogs, resourceLogs)
+	}
+	return nil
+}
+
+type jsonExportLogsServiceRequest struct {
+	ResourceLogs []jsonResourceLogs `json:"resourceLogs"`
+}
+
+type jsonResourceLogs struct {
+	Resource  *jsonResource   `json:"resource"`
+	ScopeLogs []jsonScopeLogs `json:"scopeLogs"`
+	SchemaURL string          `json:"schemaUrl"`
+}
+
+type jsonResource struct {
+	Attributes             []jsonKeyValue `json:"attributes"`
+	DroppedAttributesCount uint32         `json:"droppedAttributesCount"`
+}
+
+type jsonScopeLogs struct {
+	Scope      *jsonInstrumentationScope `json:"scope"`
+	LogRecords []jsonLogRecord           `json:"logRecords"`
+	SchemaURL  string                    `json:"schemaUrl"`
+}
+
+type jsonInstrumentationScope struct {
+	Name                   string         `json:"name"`
+	Version                string         `json:"version"`
+	Attributes             []jsonKeyValue `json:"attributes"`
+	DroppedAttributesCount uint32         `json:"droppedAttributesCount"`
+}
+
+type jsonLogRecord struct {
+	TimeUnixNano           jsonUint64     `json:"timeUnixNano"`
+	ObservedTimeUnixNano   jsonUint64     `json:"observedTimeUnixNano"`
+	SeverityNumber         int32          `json:"severityNumber"`
+	SeverityText           string         `json:"severityText"`
+	Body                   *jsonAnyValue  `json:"body"`
+	Attributes             []jsonKeyValue `json:"attributes"`
+	DroppedAttributesCount uint32         `json:"droppedAttributesCount"`
+	Flags                  uint32         `json:"flags"`
+	TraceID                jsonHexBytes   `json:"traceId"`
+	SpanID                 jsonHexBytes   `json:"spanId"`
+}
+
+type jsonKeyValue struct {
+	Key   string        `json:"key"`
+	Value *jsonAnyValue `json:"value"`
+}
+
+type jsonAnyValue struct {
+	StringValue *string    `json:"stringValue"`
+	BoolValue   *bool      `json:"boolValue"`
+	IntValue    *jsonInt64 `json:"intValue"`
+	DoubleValue *float64   `json:"doubleValue"`
+	ArrayValue  *struct {
+		Values []*jsonAnyValue `json:"values"`
+	} `json:"arrayValue"`
+	KvlistValue *struct {
+		Values []jsonKeyValue `json:"values"`
+	} `json:"kvlistValue"`
+	BytesValue []byte `json:"bytesValue"`
+}
+
+func (v *jsonAnyValue) toAnyValue() *AnyValue {
+	if v == nil {
+		return nil
+	}
+	switch {
+	case v.StringValue != nil:
+		return &AnyValue{Value: &AnyValue_StringValue{StringValue: *v.StringValue}}
+	case v.BoolValue != nil:
+		return &AnyValue{Value: &AnyValue_BoolValue{BoolValue: *v.BoolValue}}
+	case v.IntValue != nil:
+		return &AnyValue{Value: &AnyValue_IntValue{IntValue: int64(*v.IntValue)}}
+	case v.DoubleValue != nil:
+		return &AnyValue{Value: &AnyValue_DoubleValue{DoubleValue: *v.DoubleValue}}
+	case v.ArrayValue != nil:
+		values := make([]*AnyValue, 0, len(v.ArrayValue.Values))
+		for _, value := range v.ArrayValue.Values {
+			values = append(values, value.toAnyValue())
+		}
+		return &AnyValue{Value: &AnyValue_ArrayValue{ArrayValue: &ArrayValue{Values: values}}}
+	case v.KvlistValue != nil:
+		return &AnyValue{Value: &AnyValue_KvlistValue{KvlistValue: &KeyValueList{Values: toKeyValues(v.KvlistValue.Values)}}}
+	case v.BytesValue != nil:
+		return &AnyValue{Value: &AnyValue_BytesValue{BytesValue: v.BytesValue}}
+	}
+	return &AnyValue{}
+}
+
+func toKeyValues(kvs []jsonKeyValue) []*KeyValue {
+	if len(kvs) == 0 {
+		return nil
+	}
+	res := make([]*KeyValue, 0, len(kvs))
+	for _, kv := range kvs {
+		res = append(res, &KeyValue{Key: kv.Key, Value: kv.Value.toAnyValue()})
+	}
+	return res
+}
+
+// jsonUint64 is a 64 bits integer encoded either as a JSON number or string.
+type jsonUint64 uint64
+
+func (v *jsonUint64) UnmarshalJSON(data []byte) error {
+	n, err := strconv.ParseUint(strings.Trim(string(data), `"`), 10, 64)
+	if err != nil {
+		return err
+	}
+	*v = jsonUint64(n)
+	return nil
+}
+
+// jsonInt64 is a 64 bits integer encoded either as a JSON number or string.
+type jsonInt64 int64
+
+func (v *jsonInt64) UnmarshalJSON(data []byte) error {
+	n, err := strconv.ParseInt(strings.Trim(string(data), `"`), 10, 64)
+	if err != nil {
+		return err
+	}
+	*v = jsonInt64(n)
+	return nil
+}
+
+// jsonHexBytes are bytes encoded as an hex string.
+type jsonHexBytes []byte
+
+func (v *jsonHexBytes) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	*v = b
+	return nil
+}
@@ -0,0 +1,69 @@
+package otlp
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"math"
+	"strconv"
+)
+
+// ValueString renders the value as a string, the arrays and key value lists being rendered as JSON.
+func ValueString(v *AnyValue) string {
+	switch value := v.GetValue().(type) {
+	case nil:
+		return ""
+	case *AnyValue_StringValue:
+		return value.StringValue
+	case *AnyValue_BoolValue:
+		return strconv.FormatBool(value.BoolValue)
+	case *AnyValue_IntValue:
+		return strconv.FormatInt(value.IntValue, 10)
+	case *AnyValue_DoubleValue:
+		return strconv.FormatFloat(value.DoubleValue, 'g', -1, 64)
+	case *AnyValue_BytesValue:
+		return base64.StdEncoding.EncodeToString(value.BytesValue)
+	default:
+		b, _ := json.Marshal(ValueInterface(v))
+		return string(b)
+	}
+}
+
+// ValueInterface converts the value into a value that can be encoded in JSON.
+func ValueInterface(v *AnyValue) interface{} {
+	switch value := v.GetValue().(type) {
+	case nil:
+		return nil
+	case *AnyValue_StringValue:
+		return value.StringValue
+	case *AnyValue_BoolValue:
+		return value.BoolValue
+	case *AnyValue_IntValue:
+		return value.IntValue
+	case *AnyValue_DoubleValue:
+		if math.IsNaN(value.DoubleValue) || math.IsInf(value.DoubleValue, 0) {
+			return strconv.FormatFloat(value.DoubleValue, 'g', -1, 64)
+		}
+		return value.DoubleValue
+	case *AnyValue_BytesValue:
+		return value.BytesValue
+	case *AnyValue_ArrayValue:
+		res := make([]interface{}, 0, len(value.ArrayValue.GetValues()))
+		for _, v := range value.ArrayValue.GetValues() {
+			res = append(res, ValueInterface(v))
+		}
+		return res
+	case *AnyValue_KvlistValue:
+		return KeyValuesInterface(value.KvlistValue.GetValues())
+	default:
+		return nil
+	}
+}
+
+// KeyValuesInterface converts the key values, like attributes, into a map that can be encoded in JSON.
+func KeyValuesInterface(kvs []*KeyValue) map[string]interface{} {
+	res := make(map[string]interface{}, len(kvs))
+	for _, kv := range kvs {
+		res[kv.Key] = ValueInterface(kv.Value)
+	}
+	return res
+}
@@ -0,0 +1,164 @@
+package push
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/loki/pkg/loghttp/push/otlp"
+	"github.com/grafana/loki/pkg/logproto"
+	util_log "github.com/grafana/loki/pkg/util/log"
+)
+
+func otlpStringKeyValue(key, value string) *otlp.KeyValue {
+	return &otlp.KeyValue{Key: key, Value: otlpString(value)}
+}
+
+func TestParseOTLPRequest(t *testing.T) {
+	now := time.Unix(0, 1663718400000000000)
+
+	protoReq := &otlp.ExportLogsServiceRequest{
+		ResourceLogs: []*otlp.ResourceLogs{
+			{
+				Resource: &otlp.Resource{
+					Attributes: []*otlp.KeyValue{
+						otlpStringKeyValue("service.name", "api"),
+						otlpStringKeyValue("host.name", "host-1"),
+					},
+				},
+				ScopeLogs: []*otlp.ScopeLogs{
+					{
+						Scope: &otlp.InstrumentationScope{Name: "logger"},
+						LogRecords: []*otlp.LogRecord{
+							{
+								TimeUnixNano:   uint64(now.UnixNano()),
+								SeverityNumber: otlp.SEVERITY_NUMBER_WARN2,
+								Body:           otlpString("request failed"),
+								Attributes: []*otlp.KeyValue{
+									{Key: "http.status_code", Value: &otlp.AnyValue{Value: &otlp.AnyValue_IntValue{IntValue: 500}}},
+								},
+								TraceId: []byte{0x5b, 0x8e, 0xff, 0xf7, 0x98, 0x03, 0x81, 0x03, 0xd2, 0x69, 0xb6, 0x33, 0x81, 0x3f, 0xc6, 0x0c},
+								SpanId:  []byte{0xee, 0xe1, 0x9b, 0x7e, 0xc3, 0xc1, 0xb1, 0x74},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	protoBody, err := protoReq.Marshal()
+	require.NoError(t, err)
+
+	jsonBody := `{"resourceLogs": [{
+		"resource": {"attributes": [
+			{"key": "service.name", "value": {"stringValue": "api"}},
+			{"key": "host.name", "value": {"stringValue": "host-1"}}
+		]},
+		"scopeLogs": [{
+			"scope": {"name": "logger"},
+			"logRecords": [{
+				"timeUnixNano": "1663718400000000000",
+				"severityNumber": 14,
+				"body": {"stringValue": "request failed"},
+				"attributes": [{"key": "http.status_code", "value": {"intValue": "500"}}],
+				"traceId": "5b8efff798038103d269b633813fc60c",
+				"spanId": "eee19b7ec3c1b174"
+			}]
+		}]
+	}]}`
+
+	for _, tc := range []struct {
+		desc         string
+		contentType  string
+		body         []byte
+		lineFormat   string
+		expectedLine string
+	}{
+		{
+			desc:         "protobuf as logfmt",
+			contentType:  "application/x-protobuf",
+			body:         protoBody,
+			lineFormat:   OTLPLineFormatLogfmt,
+			expectedLine: `body="request failed" severity=WARN trace_id=5b8efff798038103d269b633813fc60c span_id=eee19b7ec3c1b174 host.name=host-1 http.status_code=500`,
+		},
+		{
+			desc:         "json as logfmt",
+			contentType:  "application/json",
+			body:         []byte(jsonBody),
+			lineFormat:   OTLPLineFormatLogfmt,
+			expectedLine: `body="request failed" severity=WARN trace_id=5b8efff798038103d269b633813fc60c span_id=eee19b7ec3c1b174 host.name=host-1 http.status_code=500`,
+		},
+		{
+			desc:         "json as json",
+			contentType:  "application/json",
+			body:         []byte(jsonBody),
+			lineFormat:   OTLPLineFormatJSON,
+			expectedLine: `{"body":"request failed","severity":"WARN","trace_id":"5b8efff798038103d269b633813fc60c","span_id":"eee19b7ec3c1b174","host.name":"host-1","http.status_code":500}`,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			request := httptest.NewRequest("POST", "/otlp/v1/logs", bytes.NewReader(tc.body))
+			request.Header.Add("Content-Type", tc.contentType)
+
+			cfg := OTLPConfig{AttributesAsLabels: []string{"service.name"}, LineFormat: tc.lineFormat}
+			req, err := ParseOTLPRequest(util_log.Logger, "fake", request, nil, cfg)
+			require.NoError(t, err)
+			require.Equal(t, []logproto.Stream{
+				{
+					Labels:  `{service_name="api"}`,
+					Entries: []logproto.Entry{{Timestamp: now, Line: tc.expectedLine}},
+				},
+			}, req.Streams)
+		})
+	}
+}
+
+func TestOTLPToPushRequest(t *testing.T) {
+	now := time.Now()
+	req := &otlp.ExportLogsServiceRequest{
+		ResourceLogs: []*otlp.ResourceLogs{
+			{
+				ScopeLogs: []*otlp.ScopeLogs{
+					{
+						Scope: &otlp.InstrumentationScope{
+							Attributes: []*otlp.KeyValue{otlpStringKeyValue("k8s.namespace.name", "prod")},
+						},
+						LogRecords: []*otlp.LogRecord{{Body: otlpString("a")}},
+					},
+					{
+						LogRecords: []*otlp.LogRecord{{ObservedTimeUnixNano: 1, Body: otlpString("b")}},
+					},
+				},
+			},
+			{
+				Resource: &otlp.Resource{
+					Attributes: []*otlp.KeyValue{otlpStringKeyValue("k8s.namespace.name", "prod")},
+				},
+				ScopeLogs: []*otlp.ScopeLogs{
+					{
+						LogRecords: []*otlp.LogRecord{{TimeUnixNano: 2, Body: otlpString("c")}},
+					},
+				},
+			},
+		},
+	}
+
+	res, err := otlpToPushRequest(req, OTLPConfig{AttributesAsLabels: defaultOTLPAttributesAsLabels}, now)
+	require.NoError(t, err)
+	require.Equal(t, []logproto.Stream{
+		{
+			Labels: `{k8s_namespace_name="prod"}`,
+			Entries: []logproto.Entry{
+				{Timestamp: now, Line: "body=a"},
+				{Timestamp: time.Unix(0, 2), Line: "body=c"},
+			},
+		},
+		{
+			Labels:  `{service_name="unknown_service"}`,
+			Entries: []logproto.Entry{{Timestamp: time.Unix(0, 1), Line: "body=b"}},
+		},
+	}, res.Streams)
+}
@@ -52,43 +52,18 @@ type TenantsRetention interface {
 }
 
 func ParseRequest(logger log.Logger, userID string, r *http.Request, tenantsRetention TenantsRetention) (*logproto.PushRequest, error) {
-	// Body
-	var body io.Reader
 	// bodySize should always reflect the compressed size of the request body
 	bodySize := loki_util.NewSizeReader(r.Body)
 	contentEncoding := r.Header.Get(contentEnc)
-	switch contentEncoding {
-	case "":
-		body = bodySize
-	case "snappy":
-		// Snappy-decoding is done by `util.ParseProtoReader(..., util.RawSnappy)` below.
-		// Pass on body bytes. Note: HTTP clients do not need to set this header,
-		// but they sometimes do. See #3407.
-		body = bodySize
-	case "gzip":
-		gzipReader, err := gzip.NewReader(bodySize)
-		if err != nil {
-			return nil, err
-		}
-		defer gzipReader.Close()
-		body = gzipReader
-	case "deflate":
-		flateReader := flate.NewReader(bodySize)
-		defer flateReader.Close()
-		body = flateReader
-	default:
-		return nil, fmt.Errorf("Content-Encoding %q not supported", contentEncoding)
+	body, err := decompressBody(contentEncoding, bodySize)
+	if err != nil {
+		return nil, err
 	}
+	defer body.Close()
 
-	contentType := r.Header.Get(contentType)
-	var (
-		entriesSize      int64
-		streamLabelsSize int64
-		totalEntries     int64
-		req              logproto.PushRequest
-	)
+	var req logproto.PushRequest
 
-	contentType, _ /* params */, err := mime.ParseMediaType(contentType)
+	contentType, _ /* params */, err := mime.ParseMediaType(r.Header.Get(contentType))
 	if err != nil {
 		return nil, err
 	}
@@ -118,6 +93,38 @@ func ParseRequest(logger log.Logger, userID string, r *http.Request, tenantsRete
 		}
 	}
 
+	if err := recordRequestStats(logger, userID, r, &req, contentType, contentEncoding, bodySize, tenantsRetention); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// decompressBody returns a reader of the body decompressed according to the Content-Encoding of the request.
+func decompressBody(contentEncoding string, body io.Reader) (io.ReadCloser, error) {
+	switch contentEncoding {
+	case "":
+		return io.NopCloser(body), nil
+	case "snappy":
+		// Snappy-decoding is done by `util.ParseProtoReader(..., util.RawSnappy)` below.
+		// Pass on body bytes. Note: HTTP clients do not need to set this header,
+		// but they sometimes do. See #3407.
+		return io.NopCloser(body), nil
+	case "gzip":
+		return gzip.NewReader(body)
+	case "deflate":
+		return flate.NewReader(body), nil
+	default:
+		return nil, fmt.Errorf("Content-Encoding %q not supported", contentEncoding)
+	}
+}
+
+// recordRequestStats updates the received bytes and lines metrics of the tenant with the parsed push request.
+func recordRequestStats(logger log.Logger, userID string, r *http.Request, req *logproto.PushRequest, contentType, contentEncoding string, bodySize loki_util.SizeReader, tenantsRetention TenantsRetention) error {
+	var (
+		entriesSize      int64
+		streamLabelsSize int64
+		totalEntries     int64
+	)
 	mostRecentEntry := time.Unix(0, 0)
 
 	for _, s := range req.Streams {
@@ -126,7 +133,7 @@ func ParseRequest(logger log.Logger, userID string, r *http.Request, tenantsRete
 		if tenantsRetention != nil {
 			lbs, err := syntax.ParseLabels(s.Labels)
 			if err != nil {
-				return nil, err
+				return err
 			}
 			retentionHours = fmt.Sprintf("%d", int64(math.Floor(tenantsRetention.RetentionPeriodFor(userID, lbs).Hours())))
 		}
@@ -160,5 +167,5 @@ func ParseRequest(logger log.Logger, userID string, r *http.Request, tenantsRete
 		"totalSize", humanize.Bytes(uint64(entriesSize+streamLabelsSize)),
 		"mostRecentLagMs", time.Since(mostRecentEntry).Milliseconds(),
 	)
-	return &req, nil
+	return nil
 }
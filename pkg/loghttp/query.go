@@ -67,6 +67,9 @@ const (
 	ResultTypeScalar = "scalar"
 	ResultTypeVector = "vector"
 	ResultTypeMatrix = "matrix"
+
+	// ResultTypeQuantileSketches is only returned by the queries of the shards of a quantile_over_time query.
+	ResultTypeQuantileSketches = "quantile_sketches"
 )
 
 // ResultValue interface mimics the promql.Value interface
@@ -93,6 +96,9 @@ func (Vector) Type() ResultType { return ResultTypeVector }
 // Type implements the promql.Value interface
 func (Matrix) Type() ResultType { return ResultTypeMatrix }
 
+// Type implements the promql.Value interface
+func (QuantileSketches) Type() ResultType { return ResultTypeQuantileSketches }
+
 // Streams is a slice of Stream
 type Streams []Stream
 
@@ -204,6 +210,12 @@ func (q *QueryResponseData) UnmarshalJSON(data []byte) error {
 					return err
 				}
 				q.Result = v
+			case ResultTypeQuantileSketches:
+				var s QuantileSketches
+				if err = json.Unmarshal(value, &s); err != nil {
+					return err
+				}
+				q.Result = s
 			default:
 				return fmt.Errorf("unknown type: %s", q.ResultType)
 			}
@@ -238,6 +250,9 @@ type Vector []model.Sample
 // Matrix is a slice of SampleStreams
 type Matrix []model.SampleStream
 
+// QuantileSketches is a slice of the quantile sketches of series
+type QuantileSketches []logproto.QuantileSketchSeries
+
 // InstantQuery defines a log instant query.
 type InstantQuery struct {
 	Query     string
@@ -245,6 +260,8 @@ type InstantQuery struct {
 	Limit     uint32
 	Direction logproto.Direction
 	Shards    []string
+	// QuantileSketch requests the quantile sketches of a quantile_over_time query instead of its quantiles.
+	QuantileSketch bool
 }
 
 // ParseInstantQuery parses an InstantQuery request from an http request.
@@ -264,6 +281,11 @@ func ParseInstantQuery(r *http.Request) (*InstantQuery, error) {
 	}
 	request.Shards = shards(r)
 
+	request.QuantileSketch, err = quantileSketch(r)
+	if err != nil {
+		return nil, err
+	}
+
 	request.Direction, err = direction(r)
 	if err != nil {
 		return nil, err
@@ -282,6 +304,8 @@ type RangeQuery struct {
 	Direction logproto.Direction
 	Limit     uint32
 	Shards    []string
+	// QuantileSketch requests the quantile sketches of a quantile_over_time query instead of its quantiles.
+	QuantileSketch bool
 }
 
 // ParseRangeQuery parses a RangeQuery request from an http request.
@@ -320,6 +344,11 @@ func ParseRangeQuery(r *http.Request) (*RangeQuery, error) {
 
 	result.Shards = shards(r)
 
+	result.QuantileSketch, err = quantileSketch(r)
+	if err != nil {
+		return nil, err
+	}
+
 	// For safety, limit the number of returned points per timeseries.
 	// This is sufficient for 60s resolution for a week or 1h resolution for a year.
 	if (result.End.Sub(result.Start) / result.Step) > 11000 {
@@ -337,3 +366,22 @@ func ParseRangeQuery(r *http.Request) (*RangeQuery, error) {
 
 	return &result, nil
 }
+
+// ParseIndexStatsQuery parses an index stats request from an http request.
+// Only the query selector and the time range of the range query are used.
+func ParseIndexStatsQuery(r *http.Request) (*RangeQuery, error) {
+	var result RangeQuery
+	var err error
+
+	result.Query = query(r)
+	result.Start, result.End, err = bounds(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.End.Before(result.Start) {
+		return nil, errEndBeforeStart
+	}
+
+	return &result, nil
+}
@@ -85,5 +85,13 @@ func ParseTailQuery(r *http.Request) (*logproto.TailRequest, error) {
 	if req.DelayFor > maxDelayForInTailing {
 		return nil, fmt.Errorf("delay_for can't be greater than %d", maxDelayForInTailing)
 	}
+	req.Sample, err = tailSample(r)
+	if err != nil {
+		return nil, err
+	}
+	req.Rate, err = tailRate(r)
+	if err != nil {
+		return nil, err
+	}
 	return &req, nil
 }
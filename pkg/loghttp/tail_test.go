@@ -39,6 +39,24 @@ func TestParseTailQuery(t *testing.T) {
 				Start:    time.Date(2017, 06, 10, 21, 42, 24, 760738998, time.UTC),
 				Limit:    1000,
 			}, false},
+		{"bad sample",
+			&http.Request{
+				URL: mustParseURL(`?query={foo="bar"}&start=2017-06-10T21:42:24.760738998Z&sample=1.5`),
+			}, nil, true},
+		{"bad rate",
+			&http.Request{
+				URL: mustParseURL(`?query={foo="bar"}&start=2017-06-10T21:42:24.760738998Z&rate=-1`),
+			}, nil, true},
+		{"sampled",
+			&http.Request{
+				URL: mustParseURL(`?query={foo="bar"}&start=2017-06-10T21:42:24.760738998Z&limit=1000&sample=0.1&rate=50`),
+			}, &logproto.TailRequest{
+				Query:  `{foo="bar"}`,
+				Start:  time.Date(2017, 06, 10, 21, 42, 24, 760738998, time.UTC),
+				Limit:  1000,
+				Sample: 0.1,
+				Rate:   50,
+			}, false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -265,3 +265,77 @@ func MergeSeriesResponses(responses []*SeriesResponse) (*SeriesResponse, error)
 
 	return result, nil
 }
+
+// MergeIndexStatsResponses merges the stats of multiple IndexStatsResponses of the same streams into a single
+// IndexStatsResponse, i.e. the stats of adjacent time ranges, or of the ingesters and the store.
+// The responses must account for distinct chunks, their chunks, bytes and entries are thus summed.
+// However the same streams can have chunks in each of them, so the streams are not summed but the
+// largest count is kept, which is a lower bound of the number of distinct streams.
+// Nil responses are ignored.
+func MergeIndexStatsResponses(responses []*IndexStatsResponse) *IndexStatsResponse {
+	result := &IndexStatsResponse{}
+	for _, r := range responses {
+		if r == nil {
+			continue
+		}
+		if r.Streams > result.Streams {
+			result.Streams = r.Streams
+		}
+		result.Chunks += r.Chunks
+		result.Bytes += r.Bytes
+		result.Entries += r.Entries
+	}
+	return result
+}
+
+// MergeCardinalityResponses sums the stream counts of multiple CardinalityResponses into a single
+// CardinalityResponse, with its labels sorted by name and their values sorted by value.
+// The values count of each label is the largest of the responses, or the number of merged values
+// if greater: when the responses do not return all the values, it is a lower bound.
+// Nil responses are ignored.
+func MergeCardinalityResponses(responses []*CardinalityResponse) *CardinalityResponse {
+	result := &CardinalityResponse{}
+	labels := map[string]*LabelCardinality{}
+	values := map[string]map[string]*LabelValueCardinality{}
+	for _, r := range responses {
+		if r == nil {
+			continue
+		}
+		result.Streams += r.Streams
+		for _, l := range r.Labels {
+			label, ok := labels[l.Name]
+			if !ok {
+				label = &LabelCardinality{Name: l.Name}
+				labels[l.Name] = label
+				values[l.Name] = map[string]*LabelValueCardinality{}
+				result.Labels = append(result.Labels, label)
+			}
+			label.Streams += l.Streams
+			if l.ValuesCount > label.ValuesCount {
+				label.ValuesCount = l.ValuesCount
+			}
+			for _, v := range l.Values {
+				value, ok := values[l.Name][v.Value]
+				if !ok {
+					value = &LabelValueCardinality{Value: v.Value}
+					values[l.Name][v.Value] = value
+					label.Values = append(label.Values, value)
+				}
+				value.Streams += v.Streams
+			}
+		}
+	}
+
+	sort.Slice(result.Labels, func(i, j int) bool {
+		return result.Labels[i].Name < result.Labels[j].Name
+	})
+	for _, l := range result.Labels {
+		sort.Slice(l.Values, func(i, j int) bool {
+			return l.Values[i].Value < l.Values[j].Value
+		})
+		if n := uint64(len(l.Values)); n > l.ValuesCount {
+			l.ValuesCount = n
+		}
+	}
+	return result
+}
@@ -301,6 +301,70 @@ func TestMergeSeriesResponses(t *testing.T) {
 	}
 }
 
+func TestMergeIndexStatsResponses(t *testing.T) {
+	for _, tc := range []struct {
+		desc      string
+		responses []*IndexStatsResponse
+		expected  *IndexStatsResponse
+	}{
+		{
+			desc:     "merge empty and expect empty",
+			expected: &IndexStatsResponse{},
+		},
+		{
+			desc: "merge one response",
+			responses: []*IndexStatsResponse{
+				{Streams: 1, Chunks: 2, Bytes: 3, Entries: 4},
+			},
+			expected: &IndexStatsResponse{Streams: 1, Chunks: 2, Bytes: 3, Entries: 4},
+		},
+		{
+			desc: "merge responses skipping nil ones",
+			responses: []*IndexStatsResponse{
+				{Streams: 1, Chunks: 2, Bytes: 3, Entries: 4},
+				nil,
+				{Streams: 10, Chunks: 20, Bytes: 30, Entries: 40},
+			},
+			expected: &IndexStatsResponse{Streams: 10, Chunks: 22, Bytes: 33, Entries: 44},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			require.Equal(t, tc.expected, MergeIndexStatsResponses(tc.responses))
+		})
+	}
+}
+
+func TestMergeCardinalityResponses(t *testing.T) {
+	responses := []*CardinalityResponse{
+		{
+			Streams: 3,
+			Labels: []*LabelCardinality{
+				{Name: "job", Streams: 3, Values: []*LabelValueCardinality{{Value: "b", Streams: 1}, {Value: "a", Streams: 2}}},
+				{Name: "app", Streams: 1, Values: []*LabelValueCardinality{{Value: "x", Streams: 1}}},
+				{Name: "pod", Streams: 3, ValuesCount: 3},
+			},
+		},
+		nil,
+		{
+			Streams: 2,
+			Labels: []*LabelCardinality{
+				{Name: "job", Streams: 2, Values: []*LabelValueCardinality{{Value: "c", Streams: 1}, {Value: "a", Streams: 1}}},
+				{Name: "pod", Streams: 2, ValuesCount: 2},
+			},
+		},
+	}
+
+	require.Equal(t, &CardinalityResponse{
+		Streams: 5,
+		Labels: []*LabelCardinality{
+			{Name: "app", Streams: 1, ValuesCount: 1, Values: []*LabelValueCardinality{{Value: "x", Streams: 1}}},
+			{Name: "job", Streams: 5, ValuesCount: 3, Values: []*LabelValueCardinality{{Value: "a", Streams: 3}, {Value: "b", Streams: 1}, {Value: "c", Streams: 1}}},
+			{Name: "pod", Streams: 5, ValuesCount: 3},
+		},
+	}, MergeCardinalityResponses(responses))
+	require.Equal(t, &CardinalityResponse{}, MergeCardinalityResponses(nil))
+}
+
 func benchmarkMergeLabelResponses(b *testing.B, responses []*LabelResponse) {
 	b.ReportAllocs()
 	for n := 0; n < b.N; n++ {
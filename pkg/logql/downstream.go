@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/go-kit/log"
@@ -116,6 +117,23 @@ func (c ConcatSampleExpr) Walk(f syntax.WalkFn) {
 	f(c.next)
 }
 
+// QuantileSketchEvalExpr evaluates a quantile from the quantile sketches returned by its
+// downstream quantile_over_time queries. The sketches of the same series are merged
+// before the quantile is computed.
+type QuantileSketchEvalExpr struct {
+	syntax.SampleExpr
+	quantile float64
+}
+
+func (e QuantileSketchEvalExpr) String() string {
+	return fmt.Sprintf("quantileSketchEval<%s, quantile=%s>", e.SampleExpr.String(), strconv.FormatFloat(e.quantile, 'f', -1, 64))
+}
+
+func (e *QuantileSketchEvalExpr) Walk(f syntax.WalkFn) {
+	f(e)
+	e.SampleExpr.Walk(f)
+}
+
 // ConcatLogSelectorExpr is an expr for concatenating multiple LogSelectorExpr
 type ConcatLogSelectorExpr struct {
 	DownstreamLogSelectorExpr
@@ -165,6 +183,8 @@ type DownstreamQuery struct {
 	Expr   syntax.Expr
 	Params Params
 	Shards Shards
+	// QuantileSketch requests the quantile sketches of the quantile_over_time query instead of its quantiles.
+	QuantileSketch bool
 }
 
 // Downstreamer is an interface for deferring responsibility for query execution.
@@ -271,6 +291,30 @@ func (ev *DownstreamEvaluator) StepEvaluator(
 
 		return ConcatEvaluator(xs)
 
+	case *QuantileSketchEvalExpr:
+		concat, ok := e.SampleExpr.(*ConcatSampleExpr)
+		if !ok {
+			return nil, EvaluatorUnsupportedType(e.SampleExpr, ev)
+		}
+		var queries []DownstreamQuery
+		for cur := concat; cur != nil; cur = cur.next {
+			qry := DownstreamQuery{
+				Expr:           cur.DownstreamSampleExpr.SampleExpr,
+				Params:         params,
+				QuantileSketch: true,
+			}
+			if shard := cur.DownstreamSampleExpr.shard; shard != nil {
+				qry.Shards = Shards{*shard}
+			}
+			queries = append(queries, qry)
+		}
+
+		results, err := ev.Downstream(ctx, queries)
+		if err != nil {
+			return nil, err
+		}
+		return newQuantileSketchMergeEvaluator(e.quantile, results, params)
+
 	default:
 		return ev.defaultEvaluator.StepEvaluator(ctx, nextEv, e, params)
 	}
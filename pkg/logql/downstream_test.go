@@ -78,7 +78,7 @@ func TestMappingEquivalence(t *testing.T) {
 			qry := regular.Query(params)
 			ctx := user.InjectOrgID(context.Background(), "fake")
 
-			mapper, err := NewShardMapper(shards, nilShardMetrics)
+			mapper, err := NewShardMapper(shards, nilShardMetrics, false)
 			require.Nil(t, err)
 			_, mapped, err := mapper.Parse(tc.query)
 			require.Nil(t, err)
@@ -100,6 +100,77 @@ func TestMappingEquivalence(t *testing.T) {
 	}
 }
 
+func TestQuantileSketchMappingEquivalence(t *testing.T) {
+	var (
+		shards   = 3
+		nStreams = 60
+		rounds   = 20
+		streams  = randomStreams(nStreams, rounds+1, shards, []string{"a", "b", "c", "d"})
+		start    = time.Unix(0, 0)
+		end      = time.Unix(0, int64(time.Second*time.Duration(rounds)))
+		step     = time.Second
+		interval = time.Duration(0)
+		limit    = 100
+	)
+
+	for _, query := range []string{
+		`quantile_over_time(0.99, {a=~".+"} | logfmt | unwrap line [1s])`,
+		`quantile_over_time(0.99, {a=~".+"} | logfmt | unwrap line [2s]) by (a)`,
+		`quantile_over_time(0.01, {a=~".+"} | logfmt | unwrap line [2s]) by (a)`,
+		`quantile_over_time(0.99, {a=~".+"} | logfmt | label_format a="{{.b}}" | unwrap line [2s]) by (a)`,
+		`max by (b) (quantile_over_time(0.99, {a=~".+"} | logfmt | unwrap line [2s]) by (a, b))`,
+	} {
+		q := NewMockQuerier(
+			shards,
+			streams,
+		)
+
+		opts := EngineOpts{}
+		regular := NewEngine(opts, q, NoLimits, log.NewNopLogger())
+		sharded := NewDownstreamEngine(opts, MockDownstreamer{regular}, NoLimits, log.NewNopLogger())
+
+		t.Run(query, func(t *testing.T) {
+			params := NewLiteralParams(
+				query,
+				start,
+				end,
+				step,
+				interval,
+				logproto.FORWARD,
+				uint32(limit),
+				nil,
+			)
+			qry := regular.Query(params)
+			ctx := user.InjectOrgID(context.Background(), "fake")
+
+			mapper, err := NewShardMapper(shards, nilShardMetrics, true)
+			require.Nil(t, err)
+			noop, mapped, err := mapper.Parse(query)
+			require.Nil(t, err)
+			require.False(t, noop)
+
+			shardedQry := sharded.Query(params, mapped)
+
+			res, err := qry.Exec(ctx)
+			require.Nil(t, err)
+
+			shardedRes, err := shardedQry.Exec(ctx)
+			require.Nil(t, err)
+
+			expected, actual := res.Data.(promql.Matrix), shardedRes.Data.(promql.Matrix)
+			require.Equal(t, len(expected), len(actual))
+			for i := range expected {
+				require.Equal(t, expected[i].Metric, actual[i].Metric)
+				require.Equal(t, len(expected[i].Points), len(actual[i].Points))
+				for j, p := range expected[i].Points {
+					require.Equal(t, p.T, actual[i].Points[j].T)
+					require.InDelta(t, p.V, actual[i].Points[j].V, p.V*quantileSketchRelativeAccuracy+1e-9)
+				}
+			}
+		})
+	}
+}
+
 func TestRangeMappingEquivalence(t *testing.T) {
 	var (
 		shards   = 3
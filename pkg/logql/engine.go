@@ -154,6 +154,14 @@ func (ng *Engine) Query(params Params) Query {
 	}
 }
 
+// QuantileSketchQuery creates a new LogQL query returning the quantile sketches of a quantile_over_time query
+// instead of its quantiles, so that the frontend can merge the sketches returned by each shard of the query.
+func (ng *Engine) QuantileSketchQuery(params Params) Query {
+	q := ng.Query(params).(*query)
+	q.quantileSketch = true
+	return q
+}
+
 // Query is a LogQL query to be executed.
 type Query interface {
 	// Exec processes the query.
@@ -168,6 +176,8 @@ type query struct {
 	limits    Limits
 	evaluator Evaluator
 	record    bool
+	// quantileSketch evaluates the quantile sketches of a quantile_over_time query instead of its quantiles.
+	quantileSketch bool
 }
 
 // Exec Implements `Query`. It handles instrumentation & defers to Eval.
@@ -222,6 +232,9 @@ func (q *query) Eval(ctx context.Context) (promql_parser.Value, error) {
 
 	switch e := expr.(type) {
 	case syntax.SampleExpr:
+		if q.quantileSketch {
+			return q.evalQuantileSketches(ctx, e)
+		}
 		value, err := q.evalSample(ctx, e)
 		return value, err
 
@@ -274,7 +287,10 @@ func (q *query) evalSample(ctx context.Context, expr syntax.SampleExpr) (promql_
 	}
 
 	if GetRangeType(q.params) == InstantType {
-		sort.Slice(vec, func(i, j int) bool { return labels.Compare(vec[i].Metric, vec[j].Metric) < 0 })
+		// sort and sort_desc order the samples by value, which must be preserved.
+		if !isSortedByValue(expr) {
+			sort.Slice(vec, func(i, j int) bool { return labels.Compare(vec[i].Metric, vec[j].Metric) < 0 })
+		}
 		return vec, nil
 	}
 
@@ -324,6 +340,31 @@ func (q *query) evalSample(ctx context.Context, expr syntax.SampleExpr) (promql_
 	return result, stepEvaluator.Error()
 }
 
+// isSortedByValue tells whether the result of the expression is ordered by the sort or sort_desc functions.
+func isSortedByValue(expr syntax.SampleExpr) bool {
+	vecExpr, ok := expr.(*syntax.VectorAggregationExpr)
+	return ok && (vecExpr.Operation == syntax.OpTypeSort || vecExpr.Operation == syntax.OpTypeSortDesc)
+}
+
+// evalQuantileSketches evaluates the quantile sketches of a quantile_over_time query.
+func (q *query) evalQuantileSketches(ctx context.Context, expr syntax.SampleExpr) (promql_parser.Value, error) {
+	rangeExpr, ok := expr.(*syntax.RangeAggregationExpr)
+	if !ok || rangeExpr.Operation != syntax.OpRangeTypeQuantile {
+		return nil, fmt.Errorf("quantile sketches can only be computed for %s, got %s", syntax.OpRangeTypeQuantile, expr)
+	}
+	ev, ok := q.evaluator.(*DefaultEvaluator)
+	if !ok {
+		return nil, fmt.Errorf("quantile sketches cannot be computed by %T", q.evaluator)
+	}
+
+	tenantIDs, err := tenant.TenantIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	maxSeries := validation.SmallestPositiveIntPerTenant(tenantIDs, q.limits.MaxQuerySeries)
+	return ev.quantileSketches(ctx, rangeExpr, q.params, maxSeries)
+}
+
 func (q *query) evalLiteral(_ context.Context, expr *syntax.LiteralExpr) (promql_parser.Value, error) {
 	s := promql.Scalar{
 		T: q.params.Start().UnixNano() / int64(time.Millisecond),
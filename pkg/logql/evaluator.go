@@ -207,11 +207,42 @@ func (ev *DefaultEvaluator) StepEvaluator(
 		return binOpStepEvaluator(ctx, nextEv, e, q)
 	case *syntax.LabelReplaceExpr:
 		return labelReplaceEvaluator(ctx, nextEv, e, q)
+	case *syntax.VectorExpr:
+		return vectorEvaluator(e, q)
 	default:
 		return nil, EvaluatorUnsupportedType(e, ev)
 	}
 }
 
+// quantileSketches returns the quantile sketches of the values of each series of a quantile_over_time
+// range aggregation at each step, instead of their quantile.
+func (ev *DefaultEvaluator) quantileSketches(
+	ctx context.Context,
+	expr *syntax.RangeAggregationExpr,
+	q Params,
+	maxSeries int,
+) (logqlmodel.QuantileSketches, error) {
+	it, err := ev.querier.SelectSamples(ctx, SelectSampleParams{
+		&logproto.SampleQueryRequest{
+			Start:    q.Start().Add(-expr.Left.Interval).Add(-expr.Left.Offset),
+			End:      q.End().Add(-expr.Left.Offset),
+			Selector: expr.String(),
+			Shards:   q.Shards(),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	iter := newRangeVectorIterator(
+		iter.NewPeekingSampleIterator(it),
+		expr.Left.Interval.Nanoseconds(),
+		q.Step().Nanoseconds(),
+		q.Start().UnixNano(), q.End().UnixNano(), expr.Left.Offset.Nanoseconds(),
+	)
+	defer util.LogErrorWithContext(ctx, "closing RangeAggregationExpr", iter.Close)
+	return evalQuantileSketches(iter, maxSeries)
+}
+
 func vectorAggEvaluator(
 	ctx context.Context,
 	ev SampleEvaluator,
@@ -234,6 +265,14 @@ func vectorAggEvaluator(
 		if !next {
 			return false, 0, promql.Vector{}
 		}
+		if expr.Operation == syntax.OpTypeSort {
+			sort.Sort(vectorByValueHeap(vec))
+			return next, ts, vec
+		}
+		if expr.Operation == syntax.OpTypeSortDesc {
+			sort.Sort(sort.Reverse(vectorByValueHeap(vec)))
+			return next, ts, vec
+		}
 		result := map[uint64]*groupedAggregation{}
 		if expr.Operation == syntax.OpTypeTopK || expr.Operation == syntax.OpTypeBottomK {
 			if expr.Params < 1 {
@@ -414,22 +453,23 @@ func rangeAggEvaluator(
 	q Params,
 	o time.Duration,
 ) (StepEvaluator, error) {
-	agg, err := aggregator(expr)
-	if err != nil {
-		return nil, err
-	}
 	iter := newRangeVectorIterator(
 		it,
 		expr.Left.Interval.Nanoseconds(),
 		q.Step().Nanoseconds(),
 		q.Start().UnixNano(), q.End().UnixNano(), o.Nanoseconds(),
 	)
-	if expr.Operation == syntax.OpRangeTypeAbsent {
+	switch expr.Operation {
+	case syntax.OpRangeTypeAbsent:
 		return &absentRangeVectorEvaluator{
 			iter: iter,
 			lbs:  absentLabels(expr),
 		}, nil
 	}
+	agg, err := aggregator(expr)
+	if err != nil {
+		return nil, err
+	}
 	return &rangeVectorEvaluator{
 		iter: iter,
 		agg:  agg,
@@ -875,6 +915,30 @@ func literalStepEvaluator(
 	)
 }
 
+// vectorEvaluator returns the value of the vector expression as a single sample without labels at each step.
+func vectorEvaluator(expr *syntax.VectorExpr, q Params) (StepEvaluator, error) {
+	start, end, step := q.Start().UnixNano(), q.End().UnixNano(), q.Step().Nanoseconds()
+	// forces at least one step.
+	if step == 0 {
+		step = 1
+	}
+	current := start - step
+	return newStepEvaluator(func() (bool, int64, promql.Vector) {
+		current += step
+		if current > end {
+			return false, 0, promql.Vector{}
+		}
+		// convert ts from nano to milli seconds as the other evaluators.
+		ts := current / int64(time.Millisecond)
+		return true, ts, promql.Vector{
+			promql.Sample{
+				Point:  promql.Point{T: ts, V: expr.Value()},
+				Metric: labels.Labels{},
+			},
+		}
+	}, func() error { return nil }, func() error { return nil })
+}
+
 func labelReplaceEvaluator(
 	ctx context.Context,
 	ev SampleEvaluator,
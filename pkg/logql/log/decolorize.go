@@ -0,0 +1,85 @@
+package log
+
+import (
+	"bytes"
+)
+
+const (
+	ansiEscape = 0x1b
+	ansiBell   = 0x07
+)
+
+// Decolorizer is a stage removing ANSI escape sequences, such as colors, from log lines.
+type Decolorizer struct{}
+
+// NewDecolorizer creates a new Decolorizer stage.
+func NewDecolorizer() (*Decolorizer, error) {
+	return &Decolorizer{}, nil
+}
+
+func (d *Decolorizer) Process(line []byte, _ *LabelsBuilder) ([]byte, bool) {
+	if bytes.IndexByte(line, ansiEscape) < 0 {
+		return line, true
+	}
+	return StripANSI(make([]byte, 0, len(line)), line), true
+}
+
+func (d *Decolorizer) RequiredLabelNames() []string { return []string{} }
+
+// StripANSI appends the line without its ANSI escape sequences to dst and returns the extended buffer.
+// Control sequences (CSI), string sequences (OSC, DCS, SOS, PM and APC) and two characters escape sequences
+// are removed, while incomplete sequences are removed up to the end of the line.
+func StripANSI(dst, line []byte) []byte {
+	for len(line) > 0 {
+		i := bytes.IndexByte(line, ansiEscape)
+		if i < 0 {
+			return append(dst, line...)
+		}
+		dst = append(dst, line[:i]...)
+		line = line[i+1+escapeSequenceLen(line[i+1:]):]
+	}
+	return dst
+}
+
+// escapeSequenceLen returns the length of the escape sequence following an escape character.
+func escapeSequenceLen(seq []byte) int {
+	if len(seq) == 0 {
+		return 0
+	}
+	switch seq[0] {
+	case '[':
+		// Control Sequence Introducer: parameter bytes, intermediate bytes and a final byte.
+		i := 1
+		for i < len(seq) && seq[i] >= 0x30 && seq[i] <= 0x3f {
+			i++
+		}
+		for i < len(seq) && seq[i] >= 0x20 && seq[i] <= 0x2f {
+			i++
+		}
+		if i < len(seq) && seq[i] >= 0x40 && seq[i] <= 0x7e {
+			i++
+		}
+		return i
+	case ']', 'P', 'X', '^', '_':
+		// String sequences terminated by a String Terminator (ESC \) or, for OSC, a bell.
+		for i := 1; i < len(seq); i++ {
+			if seq[i] == ansiBell {
+				return i + 1
+			}
+			if seq[i] == ansiEscape && i+1 < len(seq) && seq[i+1] == '\\' {
+				return i + 2
+			}
+		}
+		return len(seq)
+	default:
+		// Intermediate bytes followed by a final byte, such as ESC ( B or ESC =.
+		i := 0
+		for i < len(seq) && seq[i] >= 0x20 && seq[i] <= 0x2f {
+			i++
+		}
+		if i < len(seq) && seq[i] >= 0x30 && seq[i] <= 0x7e {
+			i++
+		}
+		return i
+	}
+}
@@ -0,0 +1,50 @@
+package log
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Decolorizer(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []byte
+		want []byte
+	}{
+		{"no escape", []byte("plain line"), []byte("plain line")},
+		{"empty", []byte(""), []byte("")},
+		{"colors", []byte("\033[0;32mlevel=info\033[0m msg=\"hello\""), []byte("level=info msg=\"hello\"")},
+		{"multiple parameters", []byte("\033[1;31;40mERROR\033[39;49m done"), []byte("ERROR done")},
+		{"cursor movement", []byte("\033[2K\033[1Gprogress 50%"), []byte("progress 50%")},
+		{"private mode", []byte("\033[?25lhidden cursor\033[?25h"), []byte("hidden cursor")},
+		{"title with bell", []byte("\033]0;my title\007line"), []byte("line")},
+		{"hyperlink with string terminator", []byte("\033]8;;http://example.com\033\\link\033]8;;\033\\"), []byte("link")},
+		{"character set", []byte("\033(Bline\033="), []byte("line")},
+		{"incomplete sequence", []byte("line\033[1;3"), []byte("line")},
+		{"trailing escape", []byte("line\033"), []byte("line")},
+		{"unicode", []byte("\033[33m⚠ warning\033[0m ✓"), []byte("⚠ warning ✓")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := NewDecolorizer()
+			require.NoError(t, err)
+
+			in := append([]byte{}, tt.in...)
+			line, ok := d.Process(in, NewBaseLabelsBuilder().ForLabels(nil, 0))
+			require.True(t, ok)
+			require.Equal(t, tt.want, line)
+			require.Equal(t, tt.in, in, "the input line must not be mutated")
+		})
+	}
+}
+
+func Benchmark_Decolorizer(b *testing.B) {
+	d, _ := NewDecolorizer()
+	line := []byte("\033[36m2022-06-01T10:23:45Z\033[0m \033[32mINFO\033[0m request handled method=GET path=/api/v1/push status=204 duration=1.2ms")
+	lbs := NewBaseLabelsBuilder().ForLabels(nil, 0)
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		_, _ = d.Process(line, lbs)
+	}
+}
@@ -0,0 +1,78 @@
+package log
+
+import (
+	"github.com/prometheus/prometheus/model/labels"
+
+	"github.com/grafana/loki/pkg/logqlmodel"
+)
+
+// DropLabel is a label to drop from the labels of a log line.
+// When a matcher is given the label is dropped only if its value matches,
+// otherwise the label named Name is always dropped.
+type DropLabel struct {
+	Matcher *labels.Matcher
+	Name    string
+}
+
+// NewDropLabel creates a new DropLabel, either from a matcher or from a label name.
+func NewDropLabel(matcher *labels.Matcher, name string) DropLabel {
+	return DropLabel{
+		Matcher: matcher,
+		Name:    name,
+	}
+}
+
+// DropLabels is a stage removing labels from the labels of a log line.
+type DropLabels struct {
+	dropLabels []DropLabel
+}
+
+// NewDropLabels creates a new DropLabels stage.
+func NewDropLabels(dl []DropLabel) *DropLabels {
+	return &DropLabels{dropLabels: dl}
+}
+
+func (dl *DropLabels) Process(line []byte, lbls *LabelsBuilder) ([]byte, bool) {
+	for _, dropLabel := range dl.dropLabels {
+		if dropLabel.Matcher != nil {
+			dropLabelMatches(dropLabel.Matcher, lbls)
+			continue
+		}
+		if dropLabel.Name == logqlmodel.ErrorLabel {
+			lbls.SetErr("")
+			continue
+		}
+		lbls.Del(dropLabel.Name)
+	}
+	return line, true
+}
+
+// RequiredLabelNames returns no label names: dropping a label which is not extracted is a no-op.
+func (dl *DropLabels) RequiredLabelNames() []string { return []string{} }
+
+// droppedLabelNames returns the names of the labels always dropped by the stage, regardless of their values.
+func (dl *DropLabels) droppedLabelNames() []string {
+	names := make([]string, 0, len(dl.dropLabels))
+	for _, dropLabel := range dl.dropLabels {
+		if dropLabel.Matcher == nil {
+			names = append(names, dropLabel.Name)
+		}
+	}
+	return names
+}
+
+func dropLabelMatches(matcher *labels.Matcher, lbls *LabelsBuilder) {
+	if matcher.Name == logqlmodel.ErrorLabel {
+		if matcher.Matches(lbls.GetErr()) {
+			lbls.SetErr("")
+		}
+		return
+	}
+	value, ok := lbls.Get(matcher.Name)
+	if !ok {
+		return
+	}
+	if matcher.Matches(value) {
+		lbls.Del(matcher.Name)
+	}
+}
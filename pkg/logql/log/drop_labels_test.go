@@ -0,0 +1,84 @@
+package log
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/loki/pkg/logqlmodel"
+)
+
+func Test_DropLabels(t *testing.T) {
+	tests := []struct {
+		name       string
+		dropLabels []DropLabel
+		err        string
+		lbs        labels.Labels
+		want       labels.Labels
+	}{
+		{
+			"drop by name",
+			[]DropLabel{
+				NewDropLabel(nil, "app"),
+				NewDropLabel(nil, "namespace"),
+			},
+			"",
+			labels.Labels{{Name: "app", Value: "foo"}, {Name: "namespace", Value: "prod"}, {Name: "pod", Value: "foo-1"}},
+			labels.Labels{{Name: "pod", Value: "foo-1"}},
+		},
+		{
+			"drop by matcher",
+			[]DropLabel{
+				NewDropLabel(labels.MustNewMatcher(labels.MatchEqual, "namespace", "prod"), ""),
+				NewDropLabel(labels.MustNewMatcher(labels.MatchRegexp, "pod", "bar-.*"), ""),
+			},
+			"",
+			labels.Labels{{Name: "app", Value: "foo"}, {Name: "namespace", Value: "prod"}, {Name: "pod", Value: "foo-1"}},
+			labels.Labels{{Name: "app", Value: "foo"}, {Name: "pod", Value: "foo-1"}},
+		},
+		{
+			"drop missing label",
+			[]DropLabel{
+				NewDropLabel(nil, "cluster"),
+				NewDropLabel(labels.MustNewMatcher(labels.MatchEqual, "level", ""), ""),
+			},
+			"",
+			labels.Labels{{Name: "app", Value: "foo"}},
+			labels.Labels{{Name: "app", Value: "foo"}},
+		},
+		{
+			"drop error by name",
+			[]DropLabel{
+				NewDropLabel(nil, logqlmodel.ErrorLabel),
+			},
+			errJSON,
+			labels.Labels{{Name: "app", Value: "foo"}},
+			labels.Labels{{Name: "app", Value: "foo"}},
+		},
+		{
+			"drop error by matcher",
+			[]DropLabel{
+				NewDropLabel(labels.MustNewMatcher(labels.MatchEqual, logqlmodel.ErrorLabel, errLogfmt), ""),
+			},
+			errJSON,
+			labels.Labels{{Name: "app", Value: "foo"}},
+			labels.Labels{{Name: "app", Value: "foo"}, {Name: logqlmodel.ErrorLabel, Value: errJSON}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dropLabels := NewDropLabels(tt.dropLabels)
+			lbls := NewBaseLabelsBuilder().ForLabels(tt.lbs, tt.lbs.Hash())
+			lbls.Reset()
+			lbls.SetErr(tt.err)
+
+			line, ok := dropLabels.Process([]byte("line"), lbls)
+			require.True(t, ok)
+			require.Equal(t, []byte("line"), line)
+			sort.Sort(tt.want)
+			require.Equal(t, tt.want, lbls.LabelsResult().Labels())
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package log
+
+import (
+	"github.com/prometheus/prometheus/model/labels"
+
+	"github.com/grafana/loki/pkg/logqlmodel"
+)
+
+// KeepLabel is a label to keep in the labels of a log line.
+// When a matcher is given the label is kept only if its value matches,
+// otherwise the label named Name is always kept.
+type KeepLabel struct {
+	Matcher *labels.Matcher
+	Name    string
+}
+
+// NewKeepLabel creates a new KeepLabel, either from a matcher or from a label name.
+func NewKeepLabel(matcher *labels.Matcher, name string) KeepLabel {
+	return KeepLabel{
+		Matcher: matcher,
+		Name:    name,
+	}
+}
+
+// KeepLabels is a stage removing all labels of a log line but the ones to keep.
+// The error label is never removed, it must be dropped explicitly.
+type KeepLabels struct {
+	keepLabels []KeepLabel
+}
+
+// NewKeepLabels creates a new KeepLabels stage.
+func NewKeepLabels(kl []KeepLabel) *KeepLabels {
+	return &KeepLabels{keepLabels: kl}
+}
+
+func (kl *KeepLabels) Process(line []byte, lbls *LabelsBuilder) ([]byte, bool) {
+	for _, lb := range lbls.Labels() {
+		if lb.Name == logqlmodel.ErrorLabel || kl.keep(lb) {
+			continue
+		}
+		lbls.Del(lb.Name)
+	}
+	return line, true
+}
+
+func (kl *KeepLabels) keep(lb labels.Label) bool {
+	for _, keepLabel := range kl.keepLabels {
+		if keepLabel.Matcher != nil {
+			if keepLabel.Matcher.Name == lb.Name && keepLabel.Matcher.Matches(lb.Value) {
+				return true
+			}
+			continue
+		}
+		if keepLabel.Name == lb.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// RequiredLabelNames returns no label names: labels are only extracted when they are kept,
+// which is hinted to the parsers using keptLabelNames.
+func (kl *KeepLabels) RequiredLabelNames() []string { return []string{} }
+
+// keptLabelNames returns the names of the labels that may be kept by the stage.
+func (kl *KeepLabels) keptLabelNames() []string {
+	names := make([]string, 0, len(kl.keepLabels))
+	for _, keepLabel := range kl.keepLabels {
+		if keepLabel.Matcher != nil {
+			names = append(names, keepLabel.Matcher.Name)
+			continue
+		}
+		names = append(names, keepLabel.Name)
+	}
+	return names
+}
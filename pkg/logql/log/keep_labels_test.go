@@ -0,0 +1,74 @@
+package log
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/loki/pkg/logqlmodel"
+)
+
+func Test_KeepLabels(t *testing.T) {
+	tests := []struct {
+		name       string
+		keepLabels []KeepLabel
+		err        string
+		lbs        labels.Labels
+		want       labels.Labels
+	}{
+		{
+			"keep by name",
+			[]KeepLabel{
+				NewKeepLabel(nil, "app"),
+				NewKeepLabel(nil, "namespace"),
+			},
+			"",
+			labels.Labels{{Name: "app", Value: "foo"}, {Name: "namespace", Value: "prod"}, {Name: "pod", Value: "foo-1"}},
+			labels.Labels{{Name: "app", Value: "foo"}, {Name: "namespace", Value: "prod"}},
+		},
+		{
+			"keep by matcher",
+			[]KeepLabel{
+				NewKeepLabel(labels.MustNewMatcher(labels.MatchEqual, "namespace", "prod"), ""),
+				NewKeepLabel(labels.MustNewMatcher(labels.MatchRegexp, "pod", "bar-.*"), ""),
+			},
+			"",
+			labels.Labels{{Name: "app", Value: "foo"}, {Name: "namespace", Value: "prod"}, {Name: "pod", Value: "foo-1"}},
+			labels.Labels{{Name: "namespace", Value: "prod"}},
+		},
+		{
+			"keep missing label",
+			[]KeepLabel{
+				NewKeepLabel(nil, "cluster"),
+			},
+			"",
+			labels.Labels{{Name: "app", Value: "foo"}},
+			labels.Labels{},
+		},
+		{
+			"error is kept",
+			[]KeepLabel{
+				NewKeepLabel(nil, "app"),
+			},
+			errJSON,
+			labels.Labels{{Name: "app", Value: "foo"}, {Name: "namespace", Value: "prod"}},
+			labels.Labels{{Name: "app", Value: "foo"}, {Name: logqlmodel.ErrorLabel, Value: errJSON}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keepLabels := NewKeepLabels(tt.keepLabels)
+			lbls := NewBaseLabelsBuilder().ForLabels(tt.lbs, tt.lbs.Hash())
+			lbls.Reset()
+			lbls.SetErr(tt.err)
+
+			line, ok := keepLabels.Process([]byte("line"), lbls)
+			require.True(t, ok)
+			require.Equal(t, []byte("line"), line)
+			sort.Sort(tt.want)
+			require.Equal(t, tt.want, lbls.LabelsResult().Labels())
+		})
+	}
+}
@@ -10,6 +10,10 @@ import (
 
 const MaxInternedStrings = 1024
 
+// StreamShardLabel is the label added by the distributor to each shard of a stream split because of its ingestion rate.
+// It is dropped from the base labels of pipelines and extractors so that the shards of a stream are seen as a single stream.
+const StreamShardLabel = "__stream_shard__"
+
 var EmptyLabelsResult = NewLabelsResult(labels.Labels{}, labels.Labels{}.Hash())
 
 // LabelsResult is a computed labels result that contains the labels set with associated string and hash.
@@ -83,6 +87,8 @@ type LabelsBuilder struct {
 	base          labels.Labels
 	currentResult LabelsResult
 	groupedResult LabelsResult
+	// parserKeyHints are the hints of the base builder for the base labels.
+	parserKeyHints ParserHint
 
 	*BaseLabelsBuilder
 }
@@ -109,10 +115,14 @@ func NewBaseLabelsBuilder() *BaseLabelsBuilder {
 // ForLabels creates a labels builder for a given labels set as base.
 // The labels cache is shared across all created LabelsBuilders.
 func (b *BaseLabelsBuilder) ForLabels(lbs labels.Labels, hash uint64) *LabelsBuilder {
+	if withoutShard := WithoutStreamShard(lbs); len(withoutShard) != len(lbs) {
+		lbs, hash = withoutShard, b.hasher.Hash(withoutShard)
+	}
 	if labelResult, ok := b.resultCache[hash]; ok {
 		res := &LabelsBuilder{
 			base:              lbs,
 			currentResult:     labelResult,
+			parserKeyHints:    b.parserHintsForLabels(lbs),
 			BaseLabelsBuilder: b,
 		}
 		return res
@@ -122,11 +132,32 @@ func (b *BaseLabelsBuilder) ForLabels(lbs labels.Labels, hash uint64) *LabelsBui
 	res := &LabelsBuilder{
 		base:              lbs,
 		currentResult:     labelResult,
+		parserKeyHints:    b.parserHintsForLabels(lbs),
 		BaseLabelsBuilder: b,
 	}
 	return res
 }
 
+// WithoutStreamShard returns the labels without the StreamShardLabel.
+// The labels are returned as is when they don't contain it.
+func WithoutStreamShard(lbs labels.Labels) labels.Labels {
+	for i, l := range lbs {
+		if l.Name == StreamShardLabel {
+			res := make(labels.Labels, 0, len(lbs)-1)
+			res = append(res, lbs[:i]...)
+			return append(res, lbs[i+1:]...)
+		}
+	}
+	return lbs
+}
+
+func (b *BaseLabelsBuilder) parserHintsForLabels(lbs labels.Labels) ParserHint {
+	if hints, ok := b.parserKeyHints.(*parserHint); ok {
+		return hints.forLabels(lbs)
+	}
+	return b.parserKeyHints
+}
+
 // Reset clears all current state for the builder.
 func (b *LabelsBuilder) Reset() {
 	b.del = b.del[:0]
@@ -140,6 +171,12 @@ func (b *BaseLabelsBuilder) ParserLabelHints() ParserHint {
 	return b.parserKeyHints
 }
 
+// ParserLabelHints returns a limited list of expected labels to extract from a log line of the base labels.
+// Returns nil when it's impossible to hint labels extractions.
+func (b *LabelsBuilder) ParserLabelHints() ParserHint {
+	return b.parserKeyHints
+}
+
 // SetErr sets the error label.
 func (b *LabelsBuilder) SetErr(err string) *LabelsBuilder {
 	b.err = err
@@ -195,6 +232,19 @@ func (b *LabelsBuilder) Del(ns ...string) *LabelsBuilder {
 	return b
 }
 
+// Add adds the structured metadata of an entry as labels.
+// Like parsed labels, a name colliding with a stream label gets the _extracted suffix.
+func (b *LabelsBuilder) Add(lbs ...labels.Label) *LabelsBuilder {
+	for _, l := range lbs {
+		name := l.Name
+		if b.BaseHas(name) {
+			name = name + duplicateSuffix
+		}
+		b.Set(name, l.Value)
+	}
+	return b
+}
+
 // Set the name/value pair as a label.
 func (b *LabelsBuilder) Set(n, v string) *LabelsBuilder {
 	for i, a := range b.add {
@@ -234,10 +234,56 @@ func (p *stubStreamExtractor) BaseLabels() LabelsResult {
 	return nil
 }
 
-func (p *stubStreamExtractor) Process(ts int64, line []byte) (float64, LabelsResult, bool) {
+func (p *stubStreamExtractor) Process(ts int64, line []byte, _ ...labels.Label) (float64, LabelsResult, bool) {
 	return 0, nil, true
 }
 
-func (p *stubStreamExtractor) ProcessString(ts int64, line string) (float64, LabelsResult, bool) {
+func (p *stubStreamExtractor) ProcessString(ts int64, line string, _ ...labels.Label) (float64, LabelsResult, bool) {
 	return 0, nil, true
 }
+
+func Test_labelsStagesParserHints(t *testing.T) {
+	stages := []Stage{
+		NewJSONParser(),
+		NewDropLabels([]DropLabel{
+			NewDropLabel(nil, "foo"),
+			NewDropLabel(nil, "bar"),
+			NewDropLabel(labels.MustNewMatcher(labels.MatchEqual, "buzz", "blip"), ""),
+		}),
+		NewStringLabelFilter(labels.MustNewMatcher(labels.MatchEqual, "bar", "blop")),
+	}
+	ex, err := NewLineSampleExtractor(CountExtractor, stages, nil, false, false)
+	require.NoError(t, err)
+
+	streamHints := ex.ForStream(labels.Labels{{Name: "app", Value: "foo"}}).(*streamLineSampleExtractor).builder.ParserLabelHints()
+	require.False(t, streamHints.ShouldExtract("foo"))
+	require.True(t, streamHints.ShouldExtract("bar"))
+	require.True(t, streamHints.ShouldExtract("buzz"))
+	require.True(t, streamHints.ShouldExtract("app"))
+
+	// a parsed label colliding with a stream label is renamed and therefore not dropped.
+	streamHints = ex.ForStream(labels.Labels{{Name: "foo", Value: "bar"}}).(*streamLineSampleExtractor).builder.ParserLabelHints()
+	require.True(t, streamHints.ShouldExtract("foo"))
+
+	stages = []Stage{
+		NewJSONParser(),
+		NewKeepLabels([]KeepLabel{
+			NewKeepLabel(nil, "foo"),
+			NewKeepLabel(labels.MustNewMatcher(labels.MatchEqual, "buzz", "blip"), ""),
+		}),
+	}
+	ex, err = NewLineSampleExtractor(CountExtractor, stages, nil, false, false)
+	require.NoError(t, err)
+
+	streamHints = ex.ForStream(labels.Labels{{Name: "app", Value: "foo"}}).(*streamLineSampleExtractor).builder.ParserLabelHints()
+	require.True(t, streamHints.ShouldExtract("foo"))
+	require.True(t, streamHints.ShouldExtract("buzz"))
+	require.False(t, streamHints.ShouldExtract("bar"))
+
+	// labels extracted after the keep stage are not hinted.
+	ex, err = NewLineSampleExtractor(CountExtractor, append(stages, NewLogfmtParser()), nil, false, false)
+	require.NoError(t, err)
+
+	streamHints = ex.ForStream(labels.Labels{{Name: "app", Value: "foo"}}).(*streamLineSampleExtractor).builder.ParserLabelHints()
+	require.True(t, streamHints.ShouldExtract("bar"))
+}
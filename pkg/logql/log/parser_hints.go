@@ -2,6 +2,8 @@ package log
 
 import (
 	"strings"
+
+	"github.com/prometheus/prometheus/model/labels"
 )
 
 var noParserHints = &parserHint{}
@@ -30,9 +32,16 @@ type ParserHint interface {
 type parserHint struct {
 	noLabels       bool
 	requiredLabels []string
+	// droppedLabels are labels dropped by a drop stage and not required by the query, they are never extracted.
+	droppedLabels []string
 }
 
 func (p *parserHint) ShouldExtract(key string) bool {
+	for _, l := range p.droppedLabels {
+		if l == key {
+			return false
+		}
+	}
 	if len(p.requiredLabels) == 0 {
 		return true
 	}
@@ -61,8 +70,32 @@ func (p *parserHint) NoLabels() bool {
 	return p.noLabels
 }
 
+// forLabels returns the hints to use for a stream with the given labels.
+// A parsed label colliding with a stream label gets the _extracted suffix and is therefore not removed
+// by a drop stage of its name, so it must be extracted when the stream has a label with the same name.
+func (p *parserHint) forLabels(lbs labels.Labels) *parserHint {
+	if len(p.droppedLabels) == 0 {
+		return p
+	}
+	dropped := make([]string, 0, len(p.droppedLabels))
+	for _, l := range p.droppedLabels {
+		if !lbs.Has(l) {
+			dropped = append(dropped, l)
+		}
+	}
+	if len(dropped) == len(p.droppedLabels) {
+		return p
+	}
+	return &parserHint{
+		noLabels:       p.noLabels,
+		requiredLabels: p.requiredLabels,
+		droppedLabels:  dropped,
+	}
+}
+
 // newParserHint creates a new parser hint using the list of labels that are seen and required in a query.
-func newParserHint(requiredLabelNames, groups []string, without, noLabels bool, metricLabelName string) *parserHint {
+// The drop and keep stages of the query are used to avoid extracting labels that would be removed anyway.
+func newParserHint(requiredLabelNames, groups []string, without, noLabels bool, metricLabelName string, stages []Stage) *parserHint {
 	hints := make([]string, 0, 2*(len(requiredLabelNames)+len(groups)+1))
 	hints = appendLabelHints(hints, requiredLabelNames...)
 	hints = appendLabelHints(hints, groups...)
@@ -77,12 +110,57 @@ func newParserHint(requiredLabelNames, groups []string, without, noLabels bool,
 	// we don't know what is required when a without clause is used.
 	// Same is true when there's no grouping.
 	// no hints available then.
+	// Unless the labels are limited by drop and keep stages.
 	if without || len(groups) == 0 {
+		dropped, kept := labelsStagesHints(stages)
+		if kept != nil {
+			hints = uniqueString(appendLabelHints(hints, kept...))
+			return &parserHint{requiredLabels: hints, droppedLabels: exceptStrings(dropped, hints)}
+		}
+		if dropped = exceptStrings(dropped, hints); len(dropped) > 0 {
+			return &parserHint{droppedLabels: dropped}
+		}
 		return noParserHints
 	}
 	return &parserHint{requiredLabels: hints}
 }
 
+// labelsStagesHints returns the names of the labels always dropped by the drop stages
+// and the names of the labels kept by the keep stages, or nil if there's no keep stage.
+// Only the stages following the last parser are used, since the labels extracted by a parser
+// placed after a drop or keep stage are not removed by it.
+func labelsStagesHints(stages []Stage) (dropped, kept []string) {
+	for i := len(stages) - 1; i >= 0; i-- {
+		switch stage := stages[i].(type) {
+		case *DropLabels:
+			dropped = append(dropped, stage.droppedLabelNames()...)
+		case *KeepLabels:
+			if kept == nil {
+				kept = []string{}
+			}
+			kept = append(kept, stage.keptLabelNames()...)
+		case *JSONParser, *LogfmtParser, *RegexpParser, *PatternParser, *JSONExpressionParser, *UnpackParser:
+			return dropped, kept
+		}
+	}
+	return dropped, kept
+}
+
+// exceptStrings returns the strings of src that are not in except.
+func exceptStrings(src, except []string) []string {
+	var res []string
+Outer:
+	for _, s := range src {
+		for _, e := range except {
+			if s == e {
+				continue Outer
+			}
+		}
+		res = append(res, s)
+	}
+	return res
+}
+
 // appendLabelHints Appends the label to the list of hints with and without the duplicate suffix.
 // If a parsed label collides with a stream label we add the `_extracted` suffix to it, however hints
 // are used by the parsers before we know they will collide with a stream label and hence before the
@@ -212,6 +212,48 @@ func Test_ParserHints(t *testing.T) {
 			0,
 			``,
 		},
+		{
+			`rate({app="nginx"} | json | drop request_host, request_method, request_size, request_time, request_uri, response_latency_seconds [1m])`,
+			jsonLine,
+			true,
+			1.0,
+			`{app="nginx", cluster="us-central-west", cluster_extracted="us-east-west", protocol="HTTP/2.0", remote_user="foo", response_status="204", upstream_addr="10.0.0.1:80"}`,
+		},
+		{
+			`rate({app="nginx"} | json | response_status = 204 | drop response_status, protocol="HTTP/1.1", cluster [1m])`,
+			jsonLine,
+			true,
+			1.0,
+			`{app="nginx", cluster_extracted="us-east-west", protocol="HTTP/2.0", remote_user="foo", request_host="foo.grafana.net", request_method="POST", request_size="101", request_time="30.001", request_uri="/rpc/v2/stage", response_latency_seconds="30.001", upstream_addr="10.0.0.1:80"}`,
+		},
+		{
+			`rate({app="nginx"} | json | keep app, request_host, protocol="HTTP/2.0", remote_user="bar" [1m])`,
+			jsonLine,
+			true,
+			1.0,
+			`{app="nginx", protocol="HTTP/2.0", request_host="foo.grafana.net"}`,
+		},
+		{
+			`sum without (app) (rate({app="nginx"} | json | response_status = 204 | keep app, cluster_extracted, remote_user [1m]))`,
+			jsonLine,
+			true,
+			1.0,
+			`{cluster_extracted="us-east-west", remote_user="foo"}`,
+		},
+		{
+			`rate({app="nginx"} | keep app, cluster | logfmt | drop ts, caller [1m])`,
+			logfmtLine,
+			true,
+			1.0,
+			`{Ingester_TotalBatches="0", Ingester_TotalChunksMatched="0", Ingester_TotalReached="15", app="nginx", cluster="us-central-west", org_id="3677", traceID="2e5c7234b8640997"}`,
+		},
+		{
+			`sum by (org_id) (rate({app="nginx"} | logfmt | drop org_id [1m]))`,
+			logfmtLine,
+			true,
+			1.0,
+			`{}`,
+		},
 	} {
 		tt := tt
 		t.Run(tt.expr, func(t *testing.T) {
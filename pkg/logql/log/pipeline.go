@@ -17,10 +17,11 @@ type Pipeline interface {
 
 // StreamPipeline transform and filter log lines and labels.
 // A StreamPipeline never mutate the received line.
+// The structured metadata of the entry, if any, is added to the labels of the stream before processing the line.
 type StreamPipeline interface {
 	BaseLabels() LabelsResult
-	Process(ts int64, line []byte) (resultLine []byte, resultLabels LabelsResult, skip bool)
-	ProcessString(ts int64, line string) (resultLine string, resultLabels LabelsResult, skip bool)
+	Process(ts int64, line []byte, structuredMetadata ...labels.Label) (resultLine []byte, resultLabels LabelsResult, skip bool)
+	ProcessString(ts int64, line string, structuredMetadata ...labels.Label) (resultLine string, resultLabels LabelsResult, skip bool)
 }
 
 // Stage is a single step of a Pipeline.
@@ -34,12 +35,14 @@ type Stage interface {
 // NewNoopPipeline creates a pipelines that does not process anything and returns log streams as is.
 func NewNoopPipeline() Pipeline {
 	return &noopPipeline{
-		cache: map[uint64]*noopStreamPipeline{},
+		cache:       map[uint64]*noopStreamPipeline{},
+		baseBuilder: NewBaseLabelsBuilder(),
 	}
 }
 
 type noopPipeline struct {
-	cache map[uint64]*noopStreamPipeline
+	cache       map[uint64]*noopStreamPipeline
+	baseBuilder *BaseLabelsBuilder
 }
 
 // IsNoopPipeline tells if a pipeline is a Noop.
@@ -50,24 +53,36 @@ func IsNoopPipeline(p Pipeline) bool {
 
 type noopStreamPipeline struct {
 	LabelsResult
+	builder *LabelsBuilder
 }
 
-func (n noopStreamPipeline) Process(_ int64, line []byte) ([]byte, LabelsResult, bool) {
-	return line, n.LabelsResult, true
+func (n noopStreamPipeline) Process(_ int64, line []byte, structuredMetadata ...labels.Label) ([]byte, LabelsResult, bool) {
+	if len(structuredMetadata) == 0 {
+		return line, n.LabelsResult, true
+	}
+	n.builder.Reset()
+	n.builder.Add(structuredMetadata...)
+	return line, n.builder.LabelsResult(), true
 }
 
-func (n noopStreamPipeline) ProcessString(_ int64, line string) (string, LabelsResult, bool) {
-	return line, n.LabelsResult, true
+func (n noopStreamPipeline) ProcessString(ts int64, line string, structuredMetadata ...labels.Label) (string, LabelsResult, bool) {
+	if len(structuredMetadata) == 0 {
+		return line, n.LabelsResult, true
+	}
+	_, lr, ok := n.Process(ts, unsafeGetBytes(line), structuredMetadata...)
+	return line, lr, ok
 }
 
 func (n noopStreamPipeline) BaseLabels() LabelsResult { return n.LabelsResult }
 
 func (n *noopPipeline) ForStream(labels labels.Labels) StreamPipeline {
+	labels = WithoutStreamShard(labels)
 	h := labels.Hash()
 	if cached, ok := n.cache[h]; ok {
 		return cached
 	}
-	sp := &noopStreamPipeline{LabelsResult: NewLabelsResult(labels, h)}
+	builder := n.baseBuilder.ForLabels(labels, h)
+	sp := &noopStreamPipeline{LabelsResult: builder.currentResult, builder: builder}
 	n.cache[h] = sp
 	return sp
 }
@@ -135,9 +150,10 @@ func (p *pipeline) ForStream(labels labels.Labels) StreamPipeline {
 	return res
 }
 
-func (p *streamPipeline) Process(_ int64, line []byte) ([]byte, LabelsResult, bool) {
+func (p *streamPipeline) Process(_ int64, line []byte, structuredMetadata ...labels.Label) ([]byte, LabelsResult, bool) {
 	var ok bool
 	p.builder.Reset()
+	p.builder.Add(structuredMetadata...)
 	for _, s := range p.stages {
 		line, ok = s.Process(line, p.builder)
 		if !ok {
@@ -147,10 +163,10 @@ func (p *streamPipeline) Process(_ int64, line []byte) ([]byte, LabelsResult, bo
 	return line, p.builder.LabelsResult(), true
 }
 
-func (p *streamPipeline) ProcessString(ts int64, line string) (string, LabelsResult, bool) {
+func (p *streamPipeline) ProcessString(ts int64, line string, structuredMetadata ...labels.Label) (string, LabelsResult, bool) {
 	// Stages only read from the line.
 	lb := unsafeGetBytes(line)
-	lb, lr, ok := p.Process(ts, lb)
+	lb, lr, ok := p.Process(ts, lb, structuredMetadata...)
 	// either the line is unchanged and we can just send back the same string.
 	// or we created a new buffer for it in which case it is still safe to avoid the string(byte) copy.
 	return unsafeGetString(lb), lr, ok
@@ -226,34 +242,34 @@ func (sp *filteringStreamPipeline) BaseLabels() LabelsResult {
 	return sp.pipeline.BaseLabels()
 }
 
-func (sp *filteringStreamPipeline) Process(ts int64, line []byte) ([]byte, LabelsResult, bool) {
+func (sp *filteringStreamPipeline) Process(ts int64, line []byte, structuredMetadata ...labels.Label) ([]byte, LabelsResult, bool) {
 	for _, filter := range sp.filters {
 		if ts < filter.start || ts > filter.end {
 			continue
 		}
 
-		_, _, skip := filter.pipeline.Process(ts, line)
+		_, _, skip := filter.pipeline.Process(ts, line, structuredMetadata...)
 		if skip { // When the filter matches, don't run the next step
 			return nil, nil, false
 		}
 	}
 
-	return sp.pipeline.Process(ts, line)
+	return sp.pipeline.Process(ts, line, structuredMetadata...)
 }
 
-func (sp *filteringStreamPipeline) ProcessString(ts int64, line string) (string, LabelsResult, bool) {
+func (sp *filteringStreamPipeline) ProcessString(ts int64, line string, structuredMetadata ...labels.Label) (string, LabelsResult, bool) {
 	for _, filter := range sp.filters {
 		if ts < filter.start || ts > filter.end {
 			continue
 		}
 
-		_, _, skip := filter.pipeline.ProcessString(ts, line)
+		_, _, skip := filter.pipeline.ProcessString(ts, line, structuredMetadata...)
 		if skip { // When the filter matches, don't run the next step
 			return "", nil, false
 		}
 	}
 
-	return sp.pipeline.ProcessString(ts, line)
+	return sp.pipeline.ProcessString(ts, line, structuredMetadata...)
 }
 
 // ReduceStages reduces multiple stages into one.
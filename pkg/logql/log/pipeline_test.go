@@ -50,6 +50,69 @@ func TestPipeline(t *testing.T) {
 	require.Equal(t, false, ok)
 }
 
+func TestPipeline_StreamShard(t *testing.T) {
+	lbs := labels.Labels{{Name: "foo", Value: "bar"}}
+	shards := []labels.Labels{
+		{{Name: StreamShardLabel, Value: "0"}, {Name: "foo", Value: "bar"}},
+		{{Name: StreamShardLabel, Value: "1"}, {Name: "foo", Value: "bar"}},
+	}
+	expected := NewLabelsResult(lbs, lbs.Hash())
+
+	for _, p := range []Pipeline{
+		NewNoopPipeline(),
+		NewPipeline([]Stage{NewStringLabelFilter(labels.MustNewMatcher(labels.MatchEqual, "foo", "bar"))}),
+	} {
+		for _, shard := range shards {
+			sp := p.ForStream(shard)
+			require.Equal(t, expected, sp.BaseLabels())
+			_, lbr, ok := sp.Process(0, []byte("line"))
+			require.True(t, ok)
+			require.Equal(t, expected, lbr)
+		}
+	}
+
+	ex, err := NewLineSampleExtractor(CountExtractor, nil, nil, false, false)
+	require.NoError(t, err)
+	for _, shard := range shards {
+		_, lbr, ok := ex.ForStream(shard).Process(0, []byte("line"))
+		require.True(t, ok)
+		require.Equal(t, expected, lbr)
+	}
+}
+
+func TestPipeline_StructuredMetadata(t *testing.T) {
+	lbs := labels.Labels{{Name: "foo", Value: "bar"}}
+	structuredMetadata := labels.Labels{{Name: "traceID", Value: "123"}, {Name: "foo", Value: "baz"}}
+	expected := labels.Labels{{Name: "foo", Value: "bar"}, {Name: "foo_extracted", Value: "baz"}, {Name: "traceID", Value: "123"}}
+
+	for _, p := range []Pipeline{
+		NewNoopPipeline(),
+		NewPipeline([]Stage{NewStringLabelFilter(labels.MustNewMatcher(labels.MatchEqual, "traceID", "123"))}),
+	} {
+		sp := p.ForStream(lbs)
+		_, lbr, ok := sp.Process(0, []byte("line"), structuredMetadata...)
+		require.True(t, ok)
+		require.Equal(t, expected, lbr.Labels())
+
+		_, lbr, ok = sp.ProcessString(0, "line", structuredMetadata...)
+		require.True(t, ok)
+		require.Equal(t, expected, lbr.Labels())
+
+		// the structured metadata of an entry is not kept for the next ones.
+		_, lbr, ok = sp.Process(0, []byte("line"))
+		require.Equal(t, IsNoopPipeline(p), ok)
+		if ok {
+			require.Equal(t, lbs, lbr.Labels())
+		}
+	}
+
+	ex, err := NewLineSampleExtractor(CountExtractor, nil, []string{"traceID"}, false, false)
+	require.NoError(t, err)
+	_, lbr, ok := ex.ForStream(lbs).Process(0, []byte("line"), structuredMetadata...)
+	require.True(t, ok)
+	require.Equal(t, labels.Labels{{Name: "traceID", Value: "123"}}, lbr.Labels())
+}
+
 func TestFilteringPipeline(t *testing.T) {
 	p := NewFilteringPipeline([]PipelineFilter{
 		newPipelineFilter(2, 4, labels.Labels{{Name: "foo", Value: "bar"}, {Name: "bar", Value: "baz"}}, "e"),
@@ -118,11 +181,11 @@ func (p *stubStreamPipeline) BaseLabels() LabelsResult {
 	return nil
 }
 
-func (p *stubStreamPipeline) Process(ts int64, line []byte) ([]byte, LabelsResult, bool) {
+func (p *stubStreamPipeline) Process(ts int64, line []byte, _ ...labels.Label) ([]byte, LabelsResult, bool) {
 	return nil, nil, true
 }
 
-func (p *stubStreamPipeline) ProcessString(ts int64, line string) (string, LabelsResult, bool) {
+func (p *stubStreamPipeline) ProcessString(ts int64, line string, _ ...labels.Label) (string, LabelsResult, bool) {
 	return "", nil, true
 }
 
@@ -0,0 +1,164 @@
+package logql
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/DataDog/sketches-go/ddsketch"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql"
+
+	"github.com/grafana/loki/pkg/logproto"
+	"github.com/grafana/loki/pkg/logql/syntax"
+	"github.com/grafana/loki/pkg/logqlmodel"
+)
+
+// quantileSketchRelativeAccuracy is the maximum relative error of the quantiles computed from the sketches.
+const quantileSketchRelativeAccuracy = 0.01
+
+// evalQuantileSketches computes a DDSketch of the values of each series within the range at each step,
+// instead of their quantile. The sketches of the same series can then be merged,
+// which is what allows quantile_over_time to be sharded.
+func evalQuantileSketches(iter *rangeVectorIterator, maxSeries int) (logqlmodel.QuantileSketches, error) {
+	sketch, err := ddsketch.NewDefaultDDSketch(quantileSketchRelativeAccuracy)
+	if err != nil {
+		return nil, err
+	}
+
+	seriesIndex := map[string]*logproto.QuantileSketchSeries{}
+	for iter.Next() {
+		ts := iter.timestamp()
+		for lbs, series := range iter.window {
+			// Errors are not allowed in metrics.
+			if series.Metric.Has(logqlmodel.ErrorLabel) {
+				return nil, logqlmodel.NewPipelineErr(series.Metric)
+			}
+
+			sketch.Clear()
+			for _, p := range series.Points {
+				if err := sketch.Add(p.V); err != nil {
+					return nil, fmt.Errorf("cannot add value %f of series %s to quantile sketch: %w", p.V, series.Metric, err)
+				}
+			}
+			var encoded []byte
+			sketch.Encode(&encoded, false)
+
+			s, ok := seriesIndex[lbs]
+			if !ok {
+				if len(seriesIndex) >= maxSeries {
+					return nil, logqlmodel.NewSeriesLimitError(maxSeries)
+				}
+				s = &logproto.QuantileSketchSeries{Labels: series.Metric.String()}
+				seriesIndex[lbs] = s
+			}
+			s.Points = append(s.Points, logproto.QuantileSketchPoint{Timestamp: ts, Sketch: encoded})
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return nil, err
+	}
+
+	result := make(logqlmodel.QuantileSketches, 0, len(seriesIndex))
+	for _, s := range seriesIndex {
+		result = append(result, *s)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Labels < result[j].Labels })
+	return result, nil
+}
+
+// mergedQuantileSketches is the merged sketches of a series at each step.
+type mergedQuantileSketches struct {
+	metric   labels.Labels
+	sketches map[int64]*ddsketch.DDSketch
+}
+
+// newQuantileSketchMergeEvaluator merges the sketches of each series returned by the queries of the shards
+// of a quantile_over_time query, and returns their quantile q at each step.
+func newQuantileSketchMergeEvaluator(q float64, results []logqlmodel.Result, params Params) (StepEvaluator, error) {
+	index := map[string]*mergedQuantileSketches{}
+	for _, res := range results {
+		data, ok := res.Data.(logqlmodel.QuantileSketches)
+		if !ok {
+			return nil, fmt.Errorf("unexpected type (%s) for quantile sketches; expected %s", res.Data.Type(), logqlmodel.ValueTypeQuantileSketches)
+		}
+		for _, series := range data {
+			merged, ok := index[series.Labels]
+			if !ok {
+				metric, err := syntax.ParseLabels(series.Labels)
+				if err != nil {
+					return nil, err
+				}
+				merged = &mergedQuantileSketches{metric: metric, sketches: map[int64]*ddsketch.DDSketch{}}
+				index[series.Labels] = merged
+			}
+			for _, p := range series.Points {
+				sketch, ok := merged.sketches[p.Timestamp]
+				if !ok {
+					var err error
+					sketch, err = ddsketch.NewDefaultDDSketch(quantileSketchRelativeAccuracy)
+					if err != nil {
+						return nil, err
+					}
+					merged.sketches[p.Timestamp] = sketch
+				}
+				if err := sketch.DecodeAndMergeWith(p.Sketch); err != nil {
+					return nil, fmt.Errorf("cannot merge quantile sketch of series %s: %w", series.Labels, err)
+				}
+			}
+		}
+	}
+
+	series := make([]*mergedQuantileSketches, 0, len(index))
+	for _, s := range index {
+		series = append(series, s)
+	}
+	sort.Slice(series, func(i, j int) bool { return labels.Compare(series[i].metric, series[j].metric) < 0 })
+
+	var (
+		end  = params.End().UnixNano() / int64(time.Millisecond)
+		step = params.Step().Nanoseconds() / int64(time.Millisecond)
+		ts   = params.Start().UnixNano() / int64(time.Millisecond)
+	)
+	return newStepEvaluator(func() (bool, int64, promql.Vector) {
+		if ts > end {
+			return false, 0, nil
+		}
+		cur := ts
+		if step > 0 {
+			ts += step
+		} else {
+			// instant queries have a single step.
+			ts = end + 1
+		}
+
+		vec := make(promql.Vector, 0, len(series))
+		for _, s := range series {
+			if sketch, ok := s.sketches[cur]; ok {
+				vec = append(vec, promql.Sample{
+					Metric: s.metric,
+					Point:  promql.Point{T: cur, V: sketchQuantile(q, sketch)},
+				})
+			}
+		}
+		return true, cur, vec
+	}, nil, nil)
+}
+
+// sketchQuantile returns the quantile q of the sketch, following the same conventions as quantile.
+func sketchQuantile(q float64, sketch *ddsketch.DDSketch) float64 {
+	switch {
+	case sketch.IsEmpty(), math.IsNaN(q):
+		return math.NaN()
+	case q < 0:
+		return math.Inf(-1)
+	case q > 1:
+		return math.Inf(+1)
+	}
+	v, err := sketch.GetValueAtQuantile(q)
+	if err != nil {
+		return math.NaN()
+	}
+	return v
+}
@@ -154,8 +154,7 @@ func (r *rangeVectorIterator) At(aggregator RangeVectorAggregator) (int64, promq
 		r.at = make([]promql.Sample, 0, len(r.window))
 	}
 	r.at = r.at[:0]
-	// convert ts from nano to milli seconds as the iterator work with nanoseconds
-	ts := r.current/1e+6 + r.offset/1e+6
+	ts := r.timestamp()
 	for _, series := range r.window {
 		r.at = append(r.at, promql.Sample{
 			Point: promql.Point{
@@ -168,6 +167,12 @@ func (r *rangeVectorIterator) At(aggregator RangeVectorAggregator) (int64, promq
 	return ts, r.at
 }
 
+// timestamp returns the timestamp in milliseconds of the current window.
+func (r *rangeVectorIterator) timestamp() int64 {
+	// convert ts from nano to milli seconds as the iterator work with nanoseconds
+	return r.current/1e+6 + r.offset/1e+6
+}
+
 var seriesPool sync.Pool
 
 func getSeries() *promql.Series {
@@ -15,15 +15,19 @@ import (
 type ShardMapper struct {
 	shards  int
 	metrics *MapperMetrics
+	// quantileOverTimeSharding enables the sharding of quantile_over_time using quantile sketches,
+	// which trades the exact quantiles for approximated ones.
+	quantileOverTimeSharding bool
 }
 
-func NewShardMapper(shards int, metrics *MapperMetrics) (ShardMapper, error) {
+func NewShardMapper(shards int, metrics *MapperMetrics, quantileOverTimeSharding bool) (ShardMapper, error) {
 	if shards < 2 {
 		return ShardMapper{}, fmt.Errorf("cannot create ShardMapper with <2 shards. Received %d", shards)
 	}
 	return ShardMapper{
-		shards:  shards,
-		metrics: metrics,
+		shards:                   shards,
+		metrics:                  metrics,
+		quantileOverTimeSharding: quantileOverTimeSharding,
 	}, nil
 }
 
@@ -67,7 +71,7 @@ func (m ShardMapper) Map(expr syntax.Expr, r *downstreamRecorder) (syntax.Expr,
 	}
 
 	switch e := expr.(type) {
-	case *syntax.LiteralExpr:
+	case *syntax.LiteralExpr, *syntax.VectorExpr:
 		return e, nil
 	case *syntax.MatchersExpr, *syntax.PipelineExpr:
 		return m.mapLogSelectorExpr(e.(syntax.LogSelectorExpr), r), nil
@@ -229,6 +233,15 @@ func (m ShardMapper) mapLabelReplaceExpr(expr *syntax.LabelReplaceExpr, r *downs
 }
 
 func (m ShardMapper) mapRangeAggregationExpr(expr *syntax.RangeAggregationExpr, r *downstreamRecorder) syntax.SampleExpr {
+	if expr.Operation == syntax.OpRangeTypeQuantile && m.quantileOverTimeSharding {
+		// quantile_over_time(q, x) -> quantileSketchEval(quantile_over_time(q, x, shard=1) ++ quantile_over_time(q, x, shard=2)..., q)
+		// The downstream queries return the quantile sketches of their series, and the sketches of the same
+		// series are merged, so unlike the concatenation this is also valid when multiple shards return the same labelset.
+		return &QuantileSketchEvalExpr{
+			SampleExpr: m.mapSampleExpr(expr, r),
+			quantile:   *expr.Params,
+		}
+	}
 	if hasLabelModifier(expr) {
 		// if an expr can modify labels this means multiple shards can return the same labelset.
 		// When this happens the merge strategy needs to be different from a simple concatenation.
@@ -255,7 +268,8 @@ func hasLabelModifier(expr *syntax.RangeAggregationExpr) bool {
 		return false
 	case *syntax.PipelineExpr:
 		for _, p := range ex.MultiStages {
-			if _, ok := p.(*syntax.LabelFmtExpr); ok {
+			switch p.(type) {
+			case *syntax.LabelFmtExpr, *syntax.DropLabelsExpr, *syntax.KeepLabelsExpr:
 				return true
 			}
 		}
@@ -51,7 +51,7 @@ func TestShardedStringer(t *testing.T) {
 }
 
 func TestMapSampleExpr(t *testing.T) {
-	m, err := NewShardMapper(2, nilShardMetrics)
+	m, err := NewShardMapper(2, nilShardMetrics, false)
 	require.Nil(t, err)
 
 	for _, tc := range []struct {
@@ -112,7 +112,7 @@ func TestMapSampleExpr(t *testing.T) {
 }
 
 func TestMappingStrings(t *testing.T) {
-	m, err := NewShardMapper(2, nilShardMetrics)
+	m, err := NewShardMapper(2, nilShardMetrics, false)
 	require.Nil(t, err)
 	for _, tc := range []struct {
 		in  string
@@ -151,6 +151,20 @@ func TestMappingStrings(t *testing.T) {
 				++ downstream<rate({foo="bar"}[5m]), shard=1_of_2>
 			)`,
 		},
+		{
+			in: `sort(rate({foo="bar"}[5m]))`,
+			out: `sort(
+				downstream<rate({foo="bar"}[5m]), shard=0_of_2> 
+				++ downstream<rate({foo="bar"}[5m]), shard=1_of_2>
+			)`,
+		},
+		{
+			in: `sum(rate({foo="bar"}[1m])) or vector(0)`,
+			out: `(sum(
+				downstream<sum(rate({foo="bar"}[1m])), shard=0_of_2> 
+				++ downstream<sum(rate({foo="bar"}[1m])), shard=1_of_2>
+			) or vector(0))`,
+		},
 		{
 			in: `sum(max(rate({foo="bar"}[5m])))`,
 			out: `sum(max(
@@ -166,6 +180,14 @@ func TestMappingStrings(t *testing.T) {
 			in:  `rate({foo="bar"} | json | label_format foo=bar [5m])`,
 			out: `rate({foo="bar"} | json | label_format foo=bar [5m])`,
 		},
+		{
+			in:  `rate({foo="bar"} | json | drop foo [5m])`,
+			out: `rate({foo="bar"} | json | drop foo [5m])`,
+		},
+		{
+			in:  `rate({foo="bar"} | json | keep foo [5m])`,
+			out: `rate({foo="bar"} | json | keep foo [5m])`,
+		},
 		{
 			in: `count(rate({foo="bar"} | json [5m]))`,
 			out: `count(
@@ -268,8 +290,50 @@ func TestMappingStrings(t *testing.T) {
 	}
 }
 
+func TestMappingStrings_QuantileOverTime(t *testing.T) {
+	for _, tc := range []struct {
+		in       string
+		sharding bool
+		out      string
+	}{
+		{
+			in:  `quantile_over_time(0.99, {foo="bar"} | unwrap latency [5m]) by (cluster)`,
+			out: `quantile_over_time(0.99,{foo="bar"} | unwrap latency [5m]) by (cluster)`,
+		},
+		{
+			in:       `quantile_over_time(0.99, {foo="bar"} | unwrap latency [5m]) by (cluster)`,
+			sharding: true,
+			out: `quantileSketchEval<
+				downstream<quantile_over_time(0.99, {foo="bar"} | unwrap latency [5m]) by (cluster), shard=0_of_2>
+				++ downstream<quantile_over_time(0.99, {foo="bar"} | unwrap latency [5m]) by (cluster), shard=1_of_2>,
+				quantile=0.99>`,
+		},
+		{
+			in:       `max by (cluster) (quantile_over_time(0.5, {foo="bar"} | label_format foo="buzz" | unwrap latency [5m]))`,
+			sharding: true,
+			out: `max by (cluster) (quantileSketchEval<
+				downstream<quantile_over_time(0.5, {foo="bar"} | label_format foo="buzz" | unwrap latency [5m]), shard=0_of_2>
+				++ downstream<quantile_over_time(0.5, {foo="bar"} | label_format foo="buzz" | unwrap latency [5m]), shard=1_of_2>,
+				quantile=0.5>)`,
+		},
+	} {
+		t.Run(tc.in, func(t *testing.T) {
+			m, err := NewShardMapper(2, nilShardMetrics, tc.sharding)
+			require.Nil(t, err)
+
+			ast, err := syntax.ParseExpr(tc.in)
+			require.Nil(t, err)
+
+			mapped, err := m.Map(ast, nilShardMetrics.downstreamRecorder())
+			require.Nil(t, err)
+
+			require.Equal(t, removeWhiteSpace(tc.out), removeWhiteSpace(mapped.String()))
+		})
+	}
+}
+
 func TestMapping(t *testing.T) {
-	m, err := NewShardMapper(2, nilShardMetrics)
+	m, err := NewShardMapper(2, nilShardMetrics, false)
 	require.Nil(t, err)
 
 	for _, tc := range []struct {
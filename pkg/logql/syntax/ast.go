@@ -207,6 +207,37 @@ func (e *PipelineExpr) HasFilter() bool {
 	return false
 }
 
+// RequiredLineSubstrings returns the substrings that all the lines selected by the expression contain,
+// taken from its `|=` line filters. The line filters following a stage which may modify the line,
+// such as line_format, decolorize or unpack, are ignored.
+func RequiredLineSubstrings(expr LogSelectorExpr) []string {
+	p, ok := expr.(*PipelineExpr)
+	if !ok {
+		return nil
+	}
+	var res []string
+	for _, stage := range p.MultiStages {
+		switch e := stage.(type) {
+		case *LineFilterExpr:
+			for f := e; f != nil; f = f.Left {
+				if f.Ty == labels.MatchEqual && f.Op == "" && f.Match != "" {
+					res = append(res, f.Match)
+				}
+			}
+		case *LabelParserExpr:
+			// unpack replaces the line with the packed _entry label.
+			if e.Op == OpParserTypeUnpack {
+				return res
+			}
+		case *LabelFilterExpr, *LabelFmtExpr, *DropLabelsExpr, *KeepLabelsExpr, *JSONExpressionParser:
+			// these stages only extract, filter or modify the labels.
+		default:
+			return res
+		}
+	}
+	return res
+}
+
 type LineFilterExpr struct {
 	Left  *LineFilterExpr
 	Ty    labels.MatchType
@@ -459,6 +490,94 @@ func (e *LabelFmtExpr) String() string {
 	return sb.String()
 }
 
+type DecolorizeExpr struct {
+	implicit
+}
+
+func newDecolorizeExpr() *DecolorizeExpr {
+	return &DecolorizeExpr{}
+}
+
+func (e *DecolorizeExpr) Shardable() bool { return true }
+
+func (e *DecolorizeExpr) Walk(f WalkFn) { f(e) }
+
+func (e *DecolorizeExpr) Stage() (log.Stage, error) {
+	return log.NewDecolorizer()
+}
+
+func (e *DecolorizeExpr) String() string {
+	return fmt.Sprintf("%s %s", OpPipe, OpDecolorize)
+}
+
+type DropLabelsExpr struct {
+	dropLabels []log.DropLabel
+
+	implicit
+}
+
+func newDropLabelsExpr(dropLabels []log.DropLabel) *DropLabelsExpr {
+	return &DropLabelsExpr{dropLabels: dropLabels}
+}
+
+func (e *DropLabelsExpr) Shardable() bool { return false }
+
+func (e *DropLabelsExpr) Walk(f WalkFn) { f(e) }
+
+func (e *DropLabelsExpr) Stage() (log.Stage, error) {
+	return log.NewDropLabels(e.dropLabels), nil
+}
+
+func (e *DropLabelsExpr) String() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%s %s ", OpPipe, OpDrop))
+	for i, dropLabel := range e.dropLabels {
+		if dropLabel.Matcher != nil {
+			sb.WriteString(dropLabel.Matcher.String())
+		} else {
+			sb.WriteString(dropLabel.Name)
+		}
+		if i+1 != len(e.dropLabels) {
+			sb.WriteString(",")
+		}
+	}
+	return sb.String()
+}
+
+type KeepLabelsExpr struct {
+	keepLabels []log.KeepLabel
+
+	implicit
+}
+
+func newKeepLabelsExpr(keepLabels []log.KeepLabel) *KeepLabelsExpr {
+	return &KeepLabelsExpr{keepLabels: keepLabels}
+}
+
+func (e *KeepLabelsExpr) Shardable() bool { return false }
+
+func (e *KeepLabelsExpr) Walk(f WalkFn) { f(e) }
+
+func (e *KeepLabelsExpr) Stage() (log.Stage, error) {
+	return log.NewKeepLabels(e.keepLabels), nil
+}
+
+func (e *KeepLabelsExpr) String() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%s %s ", OpPipe, OpKeep))
+	for i, keepLabel := range e.keepLabels {
+		if keepLabel.Matcher != nil {
+			sb.WriteString(keepLabel.Matcher.String())
+		} else {
+			sb.WriteString(keepLabel.Name)
+		}
+		if i+1 != len(e.keepLabels) {
+			sb.WriteString(",")
+		}
+	}
+	return sb.String()
+}
+
 type JSONExpressionParser struct {
 	Expressions []log.JSONExpression
 
@@ -605,15 +724,17 @@ func newOffsetExpr(offset time.Duration) *OffsetExpr {
 
 const (
 	// vector ops
-	OpTypeSum     = "sum"
-	OpTypeAvg     = "avg"
-	OpTypeMax     = "max"
-	OpTypeMin     = "min"
-	OpTypeCount   = "count"
-	OpTypeStddev  = "stddev"
-	OpTypeStdvar  = "stdvar"
-	OpTypeBottomK = "bottomk"
-	OpTypeTopK    = "topk"
+	OpTypeSum      = "sum"
+	OpTypeAvg      = "avg"
+	OpTypeMax      = "max"
+	OpTypeMin      = "min"
+	OpTypeCount    = "count"
+	OpTypeStddev   = "stddev"
+	OpTypeStdvar   = "stdvar"
+	OpTypeBottomK  = "bottomk"
+	OpTypeTopK     = "topk"
+	OpTypeSort     = "sort"
+	OpTypeSortDesc = "sort_desc"
 
 	// range vector ops
 	OpRangeTypeCount     = "count_over_time"
@@ -662,6 +783,11 @@ const (
 	OpFmtLine  = "line_format"
 	OpFmtLabel = "label_format"
 
+	OpDecolorize = "decolorize"
+
+	OpDrop = "drop"
+	OpKeep = "keep"
+
 	OpPipe   = "|"
 	OpUnwrap = "unwrap"
 	OpOffset = "offset"
@@ -678,6 +804,7 @@ const (
 	OpConvDurationSeconds = "duration_seconds"
 
 	OpLabelReplace = "label_replace"
+	OpTypeVector   = "vector"
 
 	// function filters
 	OpFilterIP = "ip"
@@ -850,6 +977,13 @@ func mustNewVectorAggregationExpr(left SampleExpr, operation string, gr *Groupin
 		if p, err = strconv.Atoi(*params); err != nil {
 			panic(logqlmodel.NewParseError(fmt.Sprintf("invalid parameter %s(%s,", operation, *params), 0, 0))
 		}
+	case OpTypeSort, OpTypeSortDesc:
+		if params != nil {
+			panic(logqlmodel.NewParseError(fmt.Sprintf("unsupported parameter for operation %s(%s,", operation, *params), 0, 0))
+		}
+		if gr != nil {
+			panic(logqlmodel.NewParseError(fmt.Sprintf("grouping not allowed for %s aggregation", operation), 0, 0))
+		}
 
 	default:
 		if params != nil {
@@ -1366,6 +1500,38 @@ func (e *LiteralExpr) Matchers() []*labels.Matcher             { return nil }
 func (e *LiteralExpr) Extractor() (log.SampleExtractor, error) { return nil, nil }
 func (e *LiteralExpr) Value() float64                          { return e.Val }
 
+// VectorExpr is the vector(scalar) function, returning the scalar as a sample without labels at each step.
+type VectorExpr struct {
+	Val float64
+	implicit
+}
+
+func mustNewVectorExpr(s string) *VectorExpr {
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		panic(logqlmodel.NewParseError(fmt.Sprintf("unable to parse vector argument as a float: %s", err.Error()), 0, 0))
+	}
+
+	return &VectorExpr{
+		Val: n,
+	}
+}
+
+func (e *VectorExpr) String() string {
+	return fmt.Sprintf("%s(%s)", OpTypeVector, strconv.FormatFloat(e.Val, 'f', -1, 64))
+}
+
+// vectorExpr impls SampleExpr & LogSelectorExpr for the same reasons as the literalExpr.
+// It does not select any logs so it is never sharded.
+func (e *VectorExpr) Selector() LogSelectorExpr               { return e }
+func (e *VectorExpr) HasFilter() bool                         { return false }
+func (e *VectorExpr) Shardable() bool                         { return false }
+func (e *VectorExpr) Walk(f WalkFn)                           { f(e) }
+func (e *VectorExpr) Pipeline() (log.Pipeline, error)         { return log.NewNoopPipeline(), nil }
+func (e *VectorExpr) Matchers() []*labels.Matcher             { return nil }
+func (e *VectorExpr) Extractor() (log.SampleExtractor, error) { return nil, nil }
+func (e *VectorExpr) Value() float64                          { return e.Val }
+
 // helper used to impl Stringer for vector and range aggregations
 // nolint:interfacer
 func formatOperation(op string, grouping *Grouping, params ...string) string {
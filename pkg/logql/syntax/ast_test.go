@@ -40,6 +40,9 @@ func Test_logSelectorExpr_String(t *testing.T) {
 		{`{foo="bar"} |= "baz" |~ "blip" != "flip" !~ "flap" | logfmt | b=ip("127.0.0.1") | level="error" | c=ip("::1")`, true}, // chain inside label filters.
 		{`{foo="bar"} |= "baz" |~ "blip" != "flip" !~ "flap" | regexp "(?P<foo>foo|bar)"`, true},
 		{`{foo="bar"} |= "baz" |~ "blip" != "flip" !~ "flap" | regexp "(?P<foo>foo|bar)" | ( ( foo<5.01 , bar>20ms ) or foo="bar" ) | line_format "blip{{.boop}}bap" | label_format foo=bar,bar="blip{{.blop}}"`, true},
+		{`{foo="bar"} |= "baz" | json | drop foo,bar="buzz",__error__=~"JSON.*"`, true},
+		{`{foo="bar"} |= "baz" | logfmt | keep foo,bar!="buzz"`, true},
+		{`{foo="bar"} | decolorize |= "baz" | pattern "<level> <msg>"`, true},
 	}
 
 	for _, tt := range tests {
@@ -73,6 +76,9 @@ func Test_SampleExpr_String(t *testing.T) {
 		`sum without(a) ( rate ( ( {job="mysql"} |="error" !="timeout" ) [10s] ) )`,
 		`sum by(a) (rate( ( {job="mysql"} |="error" !="timeout" ) [10s] ) )`,
 		`sum(count_over_time({job="mysql"}[5m]))`,
+		`sort(sum by(a) (count_over_time({job="mysql"}[5m])))`,
+		`sort_desc(sum by(a) (count_over_time({job="mysql"}[5m])))`,
+		`sum(count_over_time({job="mysql"}[5m])) or vector(0)`,
 		`sum(count_over_time({job="mysql"}[5m] offset 10m))`,
 		`sum(count_over_time({job="mysql"} | json [5m]))`,
 		`sum(count_over_time({job="mysql"} | json [5m] offset 10m))`,
@@ -399,6 +405,29 @@ func BenchmarkContainsFilter(b *testing.B) {
 	}
 }
 
+func Test_RequiredLineSubstrings(t *testing.T) {
+	for _, tc := range []struct {
+		in       string
+		expected []string
+	}{
+		{`{app="foo"}`, nil},
+		{`{app="foo"} |= "bar"`, []string{"bar"}},
+		{`{app="foo"} |= "bar" != "baz" |~ "b.z" |= ip("127.0.0.1") |= "buzz"`, []string{"buzz", "bar"}},
+		{`{app="foo"} |= "bar" | logfmt | level="error" |= "buzz"`, []string{"bar", "buzz"}},
+		{`{app="foo"} |= "" |= "bar" | line_format "{{.foo}}" |= "buzz"`, []string{"bar"}},
+		{`{app="foo"} | decolorize |= "bar"`, nil},
+		{`{app="foo"} |= "bar" | json | label_format foo=bar | drop foo | keep bar |= "buzz"`, []string{"bar", "buzz"}},
+		{`{app="x"} | unpack |= "say \"hi\""`, nil},
+		{`{app="x"} |= "packed" | unpack |= "say \"hi\""`, []string{"packed"}},
+	} {
+		t.Run(tc.in, func(t *testing.T) {
+			expr, err := ParseLogSelector(tc.in, true)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, RequiredLineSubstrings(expr))
+		})
+	}
+}
+
 func Test_parserExpr_Parser(t *testing.T) {
 	tests := []struct {
 		name    string
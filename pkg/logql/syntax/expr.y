@@ -28,6 +28,7 @@ import (
   FilterOp                string
   BinOpExpr               SampleExpr
   LabelReplaceExpr        SampleExpr
+  VectorExpr              *VectorExpr
   binOp                   string
   bytes                   uint64
   str                     string
@@ -51,6 +52,13 @@ import (
   LabelFormatExpr         *LabelFmtExpr
   LabelFormat             log.LabelFmt
   LabelsFormat            []log.LabelFmt
+  DecolorizeExpr          *DecolorizeExpr
+  DropLabelsExpr          *DropLabelsExpr
+  DropLabels              []log.DropLabel
+  DropLabel               log.DropLabel
+  KeepLabelsExpr          *KeepLabelsExpr
+  KeepLabels              []log.KeepLabel
+  KeepLabel               log.KeepLabel
   JSONExpressionParser    *JSONExpressionParser
   JSONExpression          log.JSONExpression
   JSONExpressionList      []log.JSONExpression
@@ -79,6 +87,7 @@ import (
 %type <BinOpExpr>             binOpExpr
 %type <LiteralExpr>           literalExpr
 %type <LabelReplaceExpr>      labelReplaceExpr
+%type <VectorExpr>            vectorExpr
 %type <BinOpModifier>         binOpModifier
 %type <BoolModifier>          boolModifier
 %type <OnOrIgnoringModifier>  onOrIgnoringModifier
@@ -95,6 +104,13 @@ import (
 %type <LabelFormatExpr>       labelFormatExpr
 %type <LabelFormat>           labelFormat
 %type <LabelsFormat>          labelsFormat
+%type <DecolorizeExpr>        decolorizeExpr
+%type <DropLabelsExpr>        dropLabelsExpr
+%type <DropLabels>            dropLabels
+%type <DropLabel>             dropLabel
+%type <KeepLabelsExpr>        keepLabelsExpr
+%type <KeepLabels>            keepLabels
+%type <KeepLabel>             keepLabel
 %type <JSONExpressionParser>  jsonExpressionParser
 %type <JSONExpression>        jsonExpression
 %type <JSONExpressionList>    jsonExpressionList
@@ -110,7 +126,8 @@ import (
                   OPEN_PARENTHESIS CLOSE_PARENTHESIS BY WITHOUT COUNT_OVER_TIME RATE SUM AVG MAX MIN COUNT STDDEV STDVAR BOTTOMK TOPK
                   BYTES_OVER_TIME BYTES_RATE BOOL JSON REGEXP LOGFMT PIPE LINE_FMT LABEL_FMT UNWRAP AVG_OVER_TIME SUM_OVER_TIME MIN_OVER_TIME
                   MAX_OVER_TIME STDVAR_OVER_TIME STDDEV_OVER_TIME QUANTILE_OVER_TIME BYTES_CONV DURATION_CONV DURATION_SECONDS_CONV
-                  FIRST_OVER_TIME LAST_OVER_TIME ABSENT_OVER_TIME LABEL_REPLACE UNPACK OFFSET PATTERN IP ON IGNORING GROUP_LEFT GROUP_RIGHT
+                  FIRST_OVER_TIME LAST_OVER_TIME ABSENT_OVER_TIME VECTOR LABEL_REPLACE UNPACK OFFSET PATTERN IP ON IGNORING GROUP_LEFT GROUP_RIGHT
+                  SORT SORT_DESC DROP KEEP DECOLORIZE
 
 // Operators are listed with increasing precedence.
 %left <binOp> OR
@@ -135,6 +152,7 @@ metricExpr:
     | binOpExpr                                     { $$ = $1 }
     | literalExpr                                   { $$ = $1 }
     | labelReplaceExpr                              { $$ = $1 }
+    | vectorExpr                                    { $$ = $1 }
     | OPEN_PARENTHESIS metricExpr CLOSE_PARENTHESIS { $$ = $2 }
     ;
 
@@ -208,6 +226,10 @@ labelReplaceExpr:
       { $$ = mustNewLabelReplaceExpr($3, $5, $7, $9, $11)}
     ;
 
+vectorExpr:
+    VECTOR OPEN_PARENTHESIS NUMBER CLOSE_PARENTHESIS   { $$ = mustNewVectorExpr($3) }
+    ;
+
 filter:
       PIPE_MATCH                       { $$ = labels.MatchRegexp }
     | PIPE_EXACT                       { $$ = labels.MatchEqual }
@@ -245,6 +267,9 @@ pipelineStage:
   | PIPE labelFilter             { $$ = &LabelFilterExpr{LabelFilterer: $2 }}
   | PIPE lineFormatExpr          { $$ = $2 }
   | PIPE labelFormatExpr         { $$ = $2 }
+  | PIPE decolorizeExpr          { $$ = $2 }
+  | PIPE dropLabelsExpr          { $$ = $2 }
+  | PIPE keepLabelsExpr          { $$ = $2 }
   ;
 
 filterOp:
@@ -287,6 +312,32 @@ labelsFormat:
 
 labelFormatExpr: LABEL_FMT labelsFormat { $$ = newLabelFmtExpr($2) };
 
+decolorizeExpr: DECOLORIZE { $$ = newDecolorizeExpr() };
+
+dropLabel:
+      IDENTIFIER { $$ = log.NewDropLabel(nil, $1) }
+    | matcher    { $$ = log.NewDropLabel($1, "") }
+    ;
+
+dropLabels:
+      dropLabel                  { $$ = []log.DropLabel{ $1 } }
+    | dropLabels COMMA dropLabel { $$ = append($1, $3) }
+    ;
+
+dropLabelsExpr: DROP dropLabels { $$ = newDropLabelsExpr($2) };
+
+keepLabel:
+      IDENTIFIER { $$ = log.NewKeepLabel(nil, $1) }
+    | matcher    { $$ = log.NewKeepLabel($1, "") }
+    ;
+
+keepLabels:
+      keepLabel                  { $$ = []log.KeepLabel{ $1 } }
+    | keepLabels COMMA keepLabel { $$ = append($1, $3) }
+    ;
+
+keepLabelsExpr: KEEP keepLabels { $$ = newKeepLabelsExpr($2) };
+
 labelFilter:
       matcher                                        { $$ = log.NewStringLabelFilter($1) }
     | ipLabelFilter                                       { $$ = $1 }
@@ -451,6 +502,8 @@ vectorOp:
       | STDVAR  { $$ = OpTypeStdvar }
       | BOTTOMK { $$ = OpTypeBottomK }
       | TOPK    { $$ = OpTypeTopK }
+      | SORT      { $$ = OpTypeSort }
+      | SORT_DESC { $$ = OpTypeSortDesc }
       ;
 
 rangeOp:
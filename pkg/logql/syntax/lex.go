@@ -69,6 +69,12 @@ var tokens = map[string]int{
 	OpFmtLabel: LABEL_FMT,
 	OpFmtLine:  LINE_FMT,
 
+	OpDecolorize: DECOLORIZE,
+
+	// labels
+	OpDrop: DROP,
+	OpKeep: KEEP,
+
 	// filter functions
 	OpFilterIP: IP,
 }
@@ -101,7 +107,10 @@ var functionTokens = map[string]int{
 	OpTypeStdvar:   STDVAR,
 	OpTypeBottomK:  BOTTOMK,
 	OpTypeTopK:     TOPK,
+	OpTypeSort:     SORT,
+	OpTypeSortDesc: SORT_DESC,
 	OpLabelReplace: LABEL_REPLACE,
+	OpTypeVector:   VECTOR,
 
 	// conversion Op
 	OpConvBytes:           BYTES_CONV,
@@ -602,6 +602,35 @@ func TestParse(t *testing.T) {
 			in:  `topk(count_over_time({ foo = "bar" }[5h]))`,
 			err: logqlmodel.NewParseError("parameter required for operation topk", 0, 0),
 		},
+		{
+			in:  `sort(3, count_over_time({ foo = "bar" }[5h]))`,
+			err: logqlmodel.NewParseError("unsupported parameter for operation sort(3,", 0, 0),
+		},
+		{
+			in:  `sort_desc(count_over_time({ foo = "bar" }[5h])) by (foo)`,
+			err: logqlmodel.NewParseError("grouping not allowed for sort_desc aggregation", 0, 0),
+		},
+		{
+			in: `sort(count_over_time({ foo = "bar" }[5h]))`,
+			exp: mustNewVectorAggregationExpr(newRangeAggregationExpr(
+				&LogRange{Left: &MatchersExpr{Mts: []*labels.Matcher{mustNewMatcher(labels.MatchEqual, "foo", "bar")}}, Interval: 5 * time.Hour},
+				OpRangeTypeCount, nil, nil),
+				OpTypeSort, nil, nil),
+		},
+		{
+			in: `sum(count_over_time({ foo = "bar" }[5h])) or vector(1.5)`,
+			exp: mustNewBinOpExpr(OpTypeOr, &BinOpOptions{VectorMatching: &VectorMatching{Card: CardOneToOne}},
+				mustNewVectorAggregationExpr(newRangeAggregationExpr(
+					&LogRange{Left: &MatchersExpr{Mts: []*labels.Matcher{mustNewMatcher(labels.MatchEqual, "foo", "bar")}}, Interval: 5 * time.Hour},
+					OpRangeTypeCount, nil, nil),
+					OpTypeSum, nil, nil),
+				&VectorExpr{Val: 1.5},
+			),
+		},
+		{
+			in:  `vector(foo)`,
+			err: logqlmodel.NewParseError("syntax error: unexpected IDENTIFIER, expecting NUMBER", 1, 8),
+		},
 		{
 			in:  `bottomk(he,count_over_time({ foo = "bar" }[5h]))`,
 			err: logqlmodel.NewParseError("syntax error: unexpected IDENTIFIER", 1, 9),
@@ -2943,6 +2972,70 @@ func TestParse(t *testing.T) {
 				},
 			},
 		},
+		{
+			in: `{app="foo"} | json | drop foo, bar="buzz", __error__=~"JSON.*"`,
+			exp: &PipelineExpr{
+				Left: newMatcherExpr([]*labels.Matcher{{Type: labels.MatchEqual, Name: "app", Value: "foo"}}),
+				MultiStages: MultiStageExpr{
+					newLabelParserExpr(OpParserTypeJSON, ""),
+					newDropLabelsExpr([]log.DropLabel{
+						log.NewDropLabel(nil, "foo"),
+						log.NewDropLabel(mustNewMatcher(labels.MatchEqual, "bar", "buzz"), ""),
+						log.NewDropLabel(mustNewMatcher(labels.MatchRegexp, logqlmodel.ErrorLabel, "JSON.*"), ""),
+					}),
+				},
+			},
+		},
+		{
+			in: `{app="foo"} | logfmt | keep foo, bar!="buzz"`,
+			exp: &PipelineExpr{
+				Left: newMatcherExpr([]*labels.Matcher{{Type: labels.MatchEqual, Name: "app", Value: "foo"}}),
+				MultiStages: MultiStageExpr{
+					newLabelParserExpr(OpParserTypeLogfmt, ""),
+					newKeepLabelsExpr([]log.KeepLabel{
+						log.NewKeepLabel(nil, "foo"),
+						log.NewKeepLabel(mustNewMatcher(labels.MatchNotEqual, "bar", "buzz"), ""),
+					}),
+				},
+			},
+		},
+		{
+			in: `sum by (foo) (count_over_time({app="foo"} | json | drop bar, buzz [5m]))`,
+			exp: mustNewVectorAggregationExpr(
+				newRangeAggregationExpr(
+					newLogRange(&PipelineExpr{
+						Left: newMatcherExpr([]*labels.Matcher{{Type: labels.MatchEqual, Name: "app", Value: "foo"}}),
+						MultiStages: MultiStageExpr{
+							newLabelParserExpr(OpParserTypeJSON, ""),
+							newDropLabelsExpr([]log.DropLabel{
+								log.NewDropLabel(nil, "bar"),
+								log.NewDropLabel(nil, "buzz"),
+							}),
+						},
+					}, 5*time.Minute, nil, nil),
+					OpRangeTypeCount, nil, nil,
+				),
+				OpTypeSum,
+				&Grouping{Groups: []string{"foo"}},
+				nil,
+			),
+		},
+		{
+			in: `{app="foo"} | decolorize |= "bar" | logfmt`,
+			exp: &PipelineExpr{
+				Left: newMatcherExpr([]*labels.Matcher{{Type: labels.MatchEqual, Name: "app", Value: "foo"}}),
+				MultiStages: MultiStageExpr{
+					newDecolorizeExpr(),
+					newLineFilterExpr(labels.MatchEqual, "", "bar"),
+					newLabelParserExpr(OpParserTypeLogfmt, ""),
+				},
+			},
+		},
+		{
+			in:  `{app="foo"} | drop`,
+			exp: nil,
+			err: logqlmodel.NewParseError("syntax error: unexpected $end, expecting IDENTIFIER", 1, 19),
+		},
 	} {
 		t.Run(tc.in, func(t *testing.T) {
 			ast, err := ParseExpr(tc.in)
@@ -227,7 +227,11 @@ func (m MockDownstreamer) Downstream(ctx context.Context, queries []DownstreamQu
 			query.Params.Limit(),
 			query.Shards.Encode(),
 		)
-		res, err := m.Query(params).Exec(ctx)
+		qry := m.Query(params)
+		if query.QuantileSketch {
+			qry = m.QuantileSketchQuery(params)
+		}
+		res, err := qry.Exec(ctx)
 		if err != nil {
 			return nil, err
 		}
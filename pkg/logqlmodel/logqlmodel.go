@@ -10,6 +10,9 @@ import (
 // ValueTypeStreams promql.ValueType for log streams
 const ValueTypeStreams = "streams"
 
+// ValueTypeQuantileSketches promql.ValueType for the quantile sketches of series
+const ValueTypeQuantileSketches = "quantile_sketches"
+
 // PackedEntryKey is a special JSON key used by the pack promtail stage and unpack parser
 const PackedEntryKey = "_entry"
 
@@ -43,3 +46,14 @@ func (streams Streams) Lines() int64 {
 	}
 	return res
 }
+
+// QuantileSketches is promql.Value
+type QuantileSketches []logproto.QuantileSketchSeries
+
+// Type implements `promql.Value`
+func (QuantileSketches) Type() parser.ValueType { return ValueTypeQuantileSketches }
+
+// String implements `promql.Value`
+func (QuantileSketches) String() string {
+	return ""
+}
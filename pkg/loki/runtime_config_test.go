@@ -83,6 +83,47 @@ overrides:
 	require.Equal(t, "invalid override for tenant 29: retention period must be >= 24h was 5h", err.Error())
 }
 
+func Test_LoadLabelPolicy(t *testing.T) {
+	cfg, err := loadRuntimeConfig(strings.NewReader(
+		`
+overrides:
+    "29":
+        label_policy:
+            required_labels: [namespace]
+            forbidden_labels: [pod_ip]
+            forbidden_labels_fallback_value: other
+            label_value_patterns:
+                env: prod|dev
+`))
+	require.NoError(t, err)
+	policy := cfg.(*runtimeConfigValues).TenantLimits["29"].LabelPolicy
+	require.Equal(t, []string{"namespace"}, policy.RequiredLabels)
+	require.Equal(t, []string{"pod_ip"}, policy.ForbiddenLabels)
+	require.Equal(t, "other", policy.ForbiddenLabelsFallback)
+	require.True(t, policy.ValueRegexps["env"].MatchString("dev"))
+	require.False(t, policy.ValueRegexps["env"].MatchString("development"))
+
+	_, err = loadRuntimeConfig(strings.NewReader(
+		`
+overrides:
+    "29":
+        label_policy:
+            label_value_patterns:
+                env: prod|(dev
+`))
+	require.EqualError(t, err, "invalid override for tenant 29: invalid label value pattern \"prod|(dev\" for label \"env\": error parsing regexp: missing closing ): `^(?:prod|(dev)$`")
+
+	_, err = loadRuntimeConfig(strings.NewReader(
+		`
+overrides:
+    "29":
+        label_policy:
+            required_labels: [namespace]
+            forbidden_labels: [namespace]
+`))
+	require.EqualError(t, err, "invalid override for tenant 29: label \"namespace\" of the label policy can't be both required and forbidden")
+}
+
 func newTestOverrides(t *testing.T, yaml string) *validation.Overrides {
 	t.Helper()
 	f, err := ioutil.TempFile(t.TempDir(), "bar")
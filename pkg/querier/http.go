@@ -19,6 +19,7 @@ import (
 	"github.com/grafana/loki/pkg/logql"
 	"github.com/grafana/loki/pkg/logql/syntax"
 	"github.com/grafana/loki/pkg/logqlmodel"
+	"github.com/grafana/loki/pkg/util"
 	util_log "github.com/grafana/loki/pkg/util/log"
 	"github.com/grafana/loki/pkg/util/marshal"
 	marshal_legacy "github.com/grafana/loki/pkg/util/marshal/legacy"
@@ -82,7 +83,12 @@ func (q *QuerierAPI) RangeQueryHandler(w http.ResponseWriter, r *http.Request) {
 		request.Limit,
 		request.Shards,
 	)
-	query := q.engine.Query(params)
+	var query logql.Query
+	if request.QuantileSketch {
+		query = q.engine.QuantileSketchQuery(params)
+	} else {
+		query = q.engine.Query(params)
+	}
 	result, err := query.Exec(ctx)
 	if err != nil {
 		serverutil.WriteError(err, w)
@@ -121,7 +127,12 @@ func (q *QuerierAPI) InstantQueryHandler(w http.ResponseWriter, r *http.Request)
 		request.Limit,
 		request.Shards,
 	)
-	query := q.engine.Query(params)
+	var query logql.Query
+	if request.QuantileSketch {
+		query = q.engine.QuantileSketchQuery(params)
+	} else {
+		query = q.engine.Query(params)
+	}
 	result, err := query.Exec(ctx)
 	if err != nil {
 		serverutil.WriteError(err, w)
@@ -363,6 +374,63 @@ func (q *QuerierAPI) SeriesHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// IndexStatsHandler returns the number of streams, chunks, entries and bytes matching a selector over a time range.
+func (q *QuerierAPI) IndexStatsHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := loghttp.ParseIndexStatsQuery(r)
+	if err != nil {
+		serverutil.WriteError(httpgrpc.Errorf(http.StatusBadRequest, err.Error()), w)
+		return
+	}
+
+	resp, err := q.querier.IndexStats(r.Context(), req)
+	if err != nil {
+		serverutil.WriteError(err, w)
+		return
+	}
+
+	err = marshal.WriteIndexStatsResponseJSON(resp, w)
+	if err != nil {
+		serverutil.WriteError(err, w)
+		return
+	}
+}
+
+// CardinalityLabelNamesHandler returns the number of active streams per label name, for the label names
+// of the streams matching an optional selector with the most streams.
+func (q *QuerierAPI) CardinalityLabelNamesHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := loghttp.ParseCardinalityLabelNamesQuery(r)
+	if err != nil {
+		serverutil.WriteError(httpgrpc.Errorf(http.StatusBadRequest, err.Error()), w)
+		return
+	}
+
+	resp, err := q.querier.Cardinality(r.Context(), req)
+	if err != nil {
+		serverutil.WriteError(err, w)
+		return
+	}
+
+	util.WriteJSONResponse(w, loghttp.NewCardinalityLabelNamesResponse(resp, req.Limit))
+}
+
+// CardinalityLabelValuesHandler returns the number of active streams per value of the given label names,
+// for the values of the streams matching an optional selector with the most streams.
+func (q *QuerierAPI) CardinalityLabelValuesHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := loghttp.ParseCardinalityLabelValuesQuery(r)
+	if err != nil {
+		serverutil.WriteError(httpgrpc.Errorf(http.StatusBadRequest, err.Error()), w)
+		return
+	}
+
+	resp, err := q.querier.Cardinality(r.Context(), req)
+	if err != nil {
+		serverutil.WriteError(err, w)
+		return
+	}
+
+	util.WriteJSONResponse(w, loghttp.NewCardinalityLabelValuesResponse(resp, req.Limit))
+}
+
 // parseRegexQuery parses regex and query querystring from httpRequest and returns the combined LogQL query.
 // This is used only to keep regexp query string support until it gets fully deprecated.
 func parseRegexQuery(httpRequest *http.Request) (string, error) {
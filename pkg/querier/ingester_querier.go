@@ -227,6 +227,82 @@ func (q *IngesterQuerier) Series(ctx context.Context, req *logproto.SeriesReques
 	return acc, nil
 }
 
+// Stats returns the stats of the in-memory chunks of the ingesters.
+// Each chunk being replicated to several ingesters, the stats are divided by the replication
+// factor and are approximate when ingesters are unhealthy.
+func (q *IngesterQuerier) Stats(ctx context.Context, req *logproto.IndexStatsRequest) (*logproto.IndexStatsResponse, error) {
+	resps, err := q.forAllIngesters(ctx, func(querierClient logproto.QuerierClient) (interface{}, error) {
+		return querierClient.GetStats(ctx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	casted := make([]*logproto.IndexStatsResponse, 0, len(resps))
+	for _, resp := range resps {
+		casted = append(casted, resp.response.(*logproto.IndexStatsResponse))
+	}
+
+	// the ingesters hold distinct replicas of the chunks, which are summed up before being deduplicated.
+	res := &logproto.IndexStatsResponse{}
+	for _, r := range casted {
+		res.Streams += r.Streams
+		res.Chunks += r.Chunks
+		res.Bytes += r.Bytes
+		res.Entries += r.Entries
+	}
+	replicationFactor := uint64(q.ring.ReplicationFactor())
+	res.Streams = deduplicateStreamsCount(res.Streams, replicationFactor)
+	res.Chunks = deduplicateStreamsCount(res.Chunks, replicationFactor)
+	res.Bytes = deduplicateStreamsCount(res.Bytes, replicationFactor)
+	res.Entries = deduplicateStreamsCount(res.Entries, replicationFactor)
+	return res, nil
+}
+
+// Cardinality returns the number of active streams of the ingesters per label name and value.
+// Each stream being replicated to several ingesters, the counts are divided by the replication
+// factor and are approximate when ingesters are unhealthy.
+// Each ingester only returns its top values, so more values than the limit are requested from each of
+// them for the values ranked just below the limit on some of them to still be accounted for once merged.
+func (q *IngesterQuerier) Cardinality(ctx context.Context, req *logproto.CardinalityRequest) (*logproto.CardinalityResponse, error) {
+	replicationFactor := uint64(q.ring.ReplicationFactor())
+
+	ingesterReq := *req
+	if replicationFactor > 1 {
+		ingesterReq.Limit *= uint32(replicationFactor)
+	}
+	resps, err := q.forAllIngesters(ctx, func(querierClient logproto.QuerierClient) (interface{}, error) {
+		return querierClient.GetCardinality(ctx, &ingesterReq)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	casted := make([]*logproto.CardinalityResponse, 0, len(resps))
+	for _, resp := range resps {
+		casted = append(casted, resp.response.(*logproto.CardinalityResponse))
+	}
+
+	res := logproto.MergeCardinalityResponses(casted)
+	res.Streams = deduplicateStreamsCount(res.Streams, replicationFactor)
+	for _, l := range res.Labels {
+		l.Streams = deduplicateStreamsCount(l.Streams, replicationFactor)
+		for _, v := range l.Values {
+			v.Streams = deduplicateStreamsCount(v.Streams, replicationFactor)
+		}
+	}
+	return res, nil
+}
+
+// deduplicateStreamsCount returns the number of distinct streams, or chunks, from their count summed over
+// their replicas, rounded up so that streams with less replicas are still accounted for.
+func deduplicateStreamsCount(n, replicationFactor uint64) uint64 {
+	if replicationFactor <= 1 {
+		return n
+	}
+	return (n + replicationFactor - 1) / replicationFactor
+}
+
 func (q *IngesterQuerier) TailersCount(ctx context.Context) ([]uint32, error) {
 	replicationSet, err := q.ring.GetAllHealthy(ring.Read)
 	if err != nil {
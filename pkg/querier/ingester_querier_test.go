@@ -129,3 +129,79 @@ func TestConvertMatchersToString(t *testing.T) {
 		})
 	}
 }
+
+func TestIngesterQuerier_Stats(t *testing.T) {
+	ingesterClient := newQuerierClientMock()
+	ingesterClient.On("GetStats", mock.Anything, mock.Anything).Return(&logproto.IndexStatsResponse{Streams: 2, Chunks: 3, Bytes: 300, Entries: 30}, nil)
+
+	readRing := newReadRingMock([]ring.InstanceDesc{
+		mockInstanceDesc("1.1.1.1", ring.ACTIVE),
+		mockInstanceDesc("2.2.2.2", ring.ACTIVE),
+		mockInstanceDesc("3.3.3.3", ring.ACTIVE),
+	})
+	readRing.replicationFactor = 3
+
+	ingesterQuerier, err := newIngesterQuerier(
+		mockIngesterClientConfig(),
+		readRing,
+		mockQuerierConfig().ExtraQueryDelay,
+		newIngesterClientMockFactory(ingesterClient),
+	)
+	require.NoError(t, err)
+
+	// each of the 3 ingesters holds a replica of the same streams and chunks.
+	res, err := ingesterQuerier.Stats(context.Background(), &logproto.IndexStatsRequest{Matchers: `{a="1"}`})
+	require.NoError(t, err)
+	require.Equal(t, &logproto.IndexStatsResponse{Streams: 2, Chunks: 3, Bytes: 300, Entries: 30}, res)
+}
+
+func TestIngesterQuerier_Cardinality(t *testing.T) {
+	ingesterClient := newQuerierClientMock()
+	// the values are over-fetched from each ingester by the replication factor.
+	ingesterClient.On("GetCardinality", mock.Anything, &logproto.CardinalityRequest{Matchers: `{a="1"}`, LabelNames: []string{"a"}, Limit: 6}).Return(&logproto.CardinalityResponse{
+		Streams: 2,
+		Labels: []*logproto.LabelCardinality{
+			{Name: "a", Streams: 2, ValuesCount: 1, Values: []*logproto.LabelValueCardinality{{Value: "1", Streams: 2}}},
+		},
+	}, nil)
+
+	readRing := newReadRingMock([]ring.InstanceDesc{
+		mockInstanceDesc("1.1.1.1", ring.ACTIVE),
+		mockInstanceDesc("2.2.2.2", ring.ACTIVE),
+		mockInstanceDesc("3.3.3.3", ring.ACTIVE),
+	})
+	readRing.replicationFactor = 3
+
+	ingesterQuerier, err := newIngesterQuerier(
+		mockIngesterClientConfig(),
+		readRing,
+		mockQuerierConfig().ExtraQueryDelay,
+		newIngesterClientMockFactory(ingesterClient),
+	)
+	require.NoError(t, err)
+
+	req := &logproto.CardinalityRequest{Matchers: `{a="1"}`, LabelNames: []string{"a"}, Limit: 2}
+	res, err := ingesterQuerier.Cardinality(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, &logproto.CardinalityResponse{
+		Streams: 2,
+		Labels: []*logproto.LabelCardinality{
+			{Name: "a", Streams: 2, ValuesCount: 1, Values: []*logproto.LabelValueCardinality{{Value: "1", Streams: 2}}},
+		},
+	}, res)
+	require.Equal(t, uint32(2), req.Limit)
+}
+
+func TestDeduplicateStreamsCount(t *testing.T) {
+	for _, tc := range []struct {
+		n, replicationFactor, expected uint64
+	}{
+		{n: 0, replicationFactor: 3, expected: 0},
+		{n: 9, replicationFactor: 3, expected: 3},
+		{n: 8, replicationFactor: 3, expected: 3},
+		{n: 1, replicationFactor: 3, expected: 1},
+		{n: 5, replicationFactor: 1, expected: 5},
+	} {
+		require.Equal(t, tc.expected, deduplicateStreamsCount(tc.n, tc.replicationFactor))
+	}
+}
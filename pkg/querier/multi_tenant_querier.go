@@ -10,6 +10,7 @@ import (
 	"github.com/grafana/dskit/tenant"
 
 	"github.com/grafana/loki/pkg/iter"
+	"github.com/grafana/loki/pkg/loghttp"
 	"github.com/grafana/loki/pkg/logproto"
 	"github.com/grafana/loki/pkg/logql"
 	"github.com/grafana/loki/pkg/logql/syntax"
@@ -158,6 +159,58 @@ func (q *MultiTenantQuerier) Series(ctx context.Context, req *logproto.SeriesReq
 	return logproto.MergeSeriesResponses(responses)
 }
 
+func (q *MultiTenantQuerier) IndexStats(ctx context.Context, req *loghttp.RangeQuery) (*logproto.IndexStatsResponse, error) {
+	tenantIDs, err := tenant.TenantIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tenantIDs) == 1 {
+		return q.Querier.IndexStats(ctx, req)
+	}
+
+	// the streams of the tenants are distinct, so all of the stats are summed.
+	result := &logproto.IndexStatsResponse{}
+	for _, id := range tenantIDs {
+		singleContext := user.InjectOrgID(ctx, id)
+		resp, err := q.Querier.IndexStats(singleContext, req)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Streams += resp.Streams
+		result.Chunks += resp.Chunks
+		result.Bytes += resp.Bytes
+		result.Entries += resp.Entries
+	}
+
+	return result, nil
+}
+
+func (q *MultiTenantQuerier) Cardinality(ctx context.Context, req *loghttp.CardinalityQuery) (*logproto.CardinalityResponse, error) {
+	tenantIDs, err := tenant.TenantIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tenantIDs) == 1 {
+		return q.Querier.Cardinality(ctx, req)
+	}
+
+	responses := make([]*logproto.CardinalityResponse, len(tenantIDs))
+	for i, id := range tenantIDs {
+		singleContext := user.InjectOrgID(ctx, id)
+		resp, err := q.Querier.Cardinality(singleContext, req)
+		if err != nil {
+			return nil, err
+		}
+
+		responses[i] = resp
+	}
+
+	return logproto.MergeCardinalityResponses(responses), nil
+}
+
 // removeTenantSelector filters the given tenant IDs based on any tenant ID filter the in passed selector.
 func removeTenantSelector(params logql.SelectSampleParams, tenantIDs []string) (map[string]struct{}, syntax.Expr, error) {
 	expr, err := params.Expr()
@@ -22,6 +22,8 @@ import (
 	"github.com/grafana/loki/pkg/loghttp"
 	"github.com/grafana/loki/pkg/logproto"
 	"github.com/grafana/loki/pkg/logql"
+	"github.com/grafana/loki/pkg/logql/log"
+	"github.com/grafana/loki/pkg/logql/syntax"
 	"github.com/grafana/loki/pkg/storage"
 	listutil "github.com/grafana/loki/pkg/util"
 	"github.com/grafana/loki/pkg/util/spanlogger"
@@ -83,6 +85,8 @@ type Querier interface {
 	Label(ctx context.Context, req *logproto.LabelRequest) (*logproto.LabelResponse, error)
 	Series(ctx context.Context, req *logproto.SeriesRequest) (*logproto.SeriesResponse, error)
 	Tail(ctx context.Context, req *logproto.TailRequest) (*Tailer, error)
+	IndexStats(ctx context.Context, req *loghttp.RangeQuery) (*logproto.IndexStatsResponse, error)
+	Cardinality(ctx context.Context, req *loghttp.CardinalityQuery) (*logproto.CardinalityResponse, error)
 }
 
 // SingleTenantQuerier handles single tenant queries.
@@ -353,6 +357,11 @@ func (q *SingleTenantQuerier) Label(ctx context.Context, req *logproto.LabelRequ
 		return nil, err
 	}
 
+	// The label added to the shards of a stream is hidden from the results.
+	if req.Values && req.Name == log.StreamShardLabel {
+		return &logproto.LabelResponse{}, nil
+	}
+
 	// Enforce the query timeout while querying backends
 	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(q.cfg.QueryTimeout))
 	defer cancel()
@@ -390,11 +399,24 @@ func (q *SingleTenantQuerier) Label(ctx context.Context, req *logproto.LabelRequ
 	}
 
 	results := append(ingesterValues, storeValues)
+	values := listutil.MergeStringLists(results...)
+	if !req.Values {
+		values = withoutStreamShardName(values)
+	}
 	return &logproto.LabelResponse{
-		Values: listutil.MergeStringLists(results...),
+		Values: values,
 	}, nil
 }
 
+func withoutStreamShardName(names []string) []string {
+	for i, name := range names {
+		if name == log.StreamShardLabel {
+			return append(names[:i:i], names[i+1:]...)
+		}
+	}
+	return names
+}
+
 // Check implements the grpc healthcheck
 func (*SingleTenantQuerier) Check(_ context.Context, _ *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
 	return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING}, nil
@@ -536,6 +558,10 @@ func (q *SingleTenantQuerier) awaitSeries(ctx context.Context, req *logproto.Ser
 	deduped := make(map[string]logproto.SeriesIdentifier)
 	for _, set := range sets {
 		for _, s := range set {
+			// The shards of a stream are returned as a single series.
+			if _, ok := s.Labels[log.StreamShardLabel]; ok {
+				s = withoutStreamShard(s)
+			}
 			key := loghttp.LabelSet(s.Labels).String()
 			if _, exists := deduped[key]; !exists {
 				deduped[key] = s
@@ -554,6 +580,95 @@ func (q *SingleTenantQuerier) awaitSeries(ctx context.Context, req *logproto.Ser
 	return response, nil
 }
 
+func withoutStreamShard(s logproto.SeriesIdentifier) logproto.SeriesIdentifier {
+	lbs := make(map[string]string, len(s.Labels)-1)
+	for name, value := range s.Labels {
+		if name != log.StreamShardLabel {
+			lbs[name] = value
+		}
+	}
+	return logproto.SeriesIdentifier{Labels: lbs}
+}
+
+// IndexStats returns the stats of the streams and chunks matching the query selector,
+// accounting for both the in-memory chunks of the ingesters and the chunks in the store.
+func (q *SingleTenantQuerier) IndexStats(ctx context.Context, req *loghttp.RangeQuery) (*logproto.IndexStatsResponse, error) {
+	userID, err := tenant.TenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end, err := validateQueryTimeRangeLimits(ctx, userID, q.limits, req.Start, req.End)
+	if err != nil {
+		return nil, err
+	}
+
+	matchers, err := syntax.ParseMatchers(req.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	// Enforce the query timeout while querying backends
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(q.cfg.QueryTimeout))
+	defer cancel()
+
+	ingesterQueryInterval, storeQueryInterval := q.buildQueryIntervals(start, end)
+
+	var responses []*logproto.IndexStatsResponse
+	if !q.cfg.QueryStoreOnly && ingesterQueryInterval != nil {
+		resp, err := q.ingesterQuerier.Stats(ctx, &logproto.IndexStatsRequest{
+			From:     model.TimeFromUnixNano(ingesterQueryInterval.start.UnixNano()),
+			Through:  model.TimeFromUnixNano(ingesterQueryInterval.end.UnixNano()),
+			Matchers: req.Query,
+		})
+		if err != nil {
+			return nil, err
+		}
+		responses = append(responses, resp)
+	}
+
+	if !q.cfg.QueryIngesterOnly && storeQueryInterval != nil {
+		resp, err := q.store.Stats(
+			ctx,
+			userID,
+			model.TimeFromUnixNano(storeQueryInterval.start.UnixNano()),
+			model.TimeFromUnixNano(storeQueryInterval.end.UnixNano()),
+			matchers...,
+		)
+		if err != nil {
+			return nil, err
+		}
+		responses = append(responses, resp)
+	}
+
+	return logproto.MergeIndexStatsResponses(responses), nil
+}
+
+// Cardinality returns the number of active streams matching the query selector per label name and value,
+// from the in-memory index of the ingesters.
+func (q *SingleTenantQuerier) Cardinality(ctx context.Context, req *loghttp.CardinalityQuery) (*logproto.CardinalityResponse, error) {
+	if req.Selector != "" {
+		if _, err := syntax.ParseMatchers(req.Selector); err != nil {
+			return nil, err
+		}
+	}
+
+	// Only the ingesters know about the active streams.
+	if q.cfg.QueryStoreOnly {
+		return &logproto.CardinalityResponse{}, nil
+	}
+
+	// Enforce the query timeout while querying backends
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(q.cfg.QueryTimeout))
+	defer cancel()
+
+	return q.ingesterQuerier.Cardinality(ctx, &logproto.CardinalityRequest{
+		Matchers:   req.Selector,
+		LabelNames: req.LabelNames,
+		Limit:      uint32(req.Limit),
+	})
+}
+
 // seriesForMatchers fetches series from the store for each matcher set
 // TODO: make efficient if/when the index supports labels so we don't have to read chunks
 func (q *SingleTenantQuerier) seriesForMatchers(
@@ -19,6 +19,7 @@ import (
 	"github.com/grafana/loki/pkg/distributor/clientpool"
 	"github.com/grafana/loki/pkg/ingester/client"
 	"github.com/grafana/loki/pkg/iter"
+	"github.com/grafana/loki/pkg/loghttp"
 	"github.com/grafana/loki/pkg/logproto"
 	"github.com/grafana/loki/pkg/logql"
 	"github.com/grafana/loki/pkg/storage/chunk"
@@ -68,6 +69,24 @@ func (c *querierClientMock) Series(ctx context.Context, in *logproto.SeriesReque
 	return res.(*logproto.SeriesResponse), args.Error(1)
 }
 
+func (c *querierClientMock) GetStats(ctx context.Context, in *logproto.IndexStatsRequest, opts ...grpc.CallOption) (*logproto.IndexStatsResponse, error) {
+	args := c.Called(ctx, in)
+	res := args.Get(0)
+	if res == nil {
+		return (*logproto.IndexStatsResponse)(nil), args.Error(1)
+	}
+	return res.(*logproto.IndexStatsResponse), args.Error(1)
+}
+
+func (c *querierClientMock) GetCardinality(ctx context.Context, in *logproto.CardinalityRequest, opts ...grpc.CallOption) (*logproto.CardinalityResponse, error) {
+	args := c.Called(ctx, in)
+	res := args.Get(0)
+	if res == nil {
+		return (*logproto.CardinalityResponse)(nil), args.Error(1)
+	}
+	return res.(*logproto.CardinalityResponse), args.Error(1)
+}
+
 func (c *querierClientMock) TailersCount(ctx context.Context, in *logproto.TailersCountRequest, opts ...grpc.CallOption) (*logproto.TailersCountResponse, error) {
 	args := c.Called(ctx, in, opts)
 	return args.Get(0).(*logproto.TailersCountResponse), args.Error(1)
@@ -320,13 +339,23 @@ func (s *storeMock) GetSeries(ctx context.Context, userID string, from, through
 	panic("don't call me please")
 }
 
+func (s *storeMock) Stats(ctx context.Context, userID string, from, through model.Time, matchers ...*labels.Matcher) (*logproto.IndexStatsResponse, error) {
+	args := s.Called(ctx, userID, from, through)
+	res := args.Get(0)
+	if res == nil {
+		return (*logproto.IndexStatsResponse)(nil), args.Error(1)
+	}
+	return res.(*logproto.IndexStatsResponse), args.Error(1)
+}
+
 func (s *storeMock) Stop() {
 }
 
 // readRingMock is a mocked version of a ReadRing, used in querier unit tests
 // to control the pool of ingesters available
 type readRingMock struct {
-	replicationSet ring.ReplicationSet
+	replicationSet    ring.ReplicationSet
+	replicationFactor int
 }
 
 func newReadRingMock(ingesters []ring.InstanceDesc) *readRingMock {
@@ -369,6 +398,9 @@ func (r *readRingMock) GetReplicationSetForOperation(op ring.Operation) (ring.Re
 }
 
 func (r *readRingMock) ReplicationFactor() int {
+	if r.replicationFactor > 0 {
+		return r.replicationFactor
+	}
 	return 1
 }
 
@@ -478,6 +510,16 @@ func (q *querierMock) Series(ctx context.Context, req *logproto.SeriesRequest) (
 	return args.Get(0).(func() *logproto.SeriesResponse)(), args.Error(1)
 }
 
+func (q *querierMock) IndexStats(ctx context.Context, req *loghttp.RangeQuery) (*logproto.IndexStatsResponse, error) {
+	args := q.Called(ctx, req)
+	return args.Get(0).(*logproto.IndexStatsResponse), args.Error(1)
+}
+
+func (q *querierMock) Cardinality(ctx context.Context, req *loghttp.CardinalityQuery) (*logproto.CardinalityResponse, error) {
+	args := q.Called(ctx, req)
+	return args.Get(0).(*logproto.CardinalityResponse), args.Error(1)
+}
+
 func (q *querierMock) Tail(ctx context.Context, req *logproto.TailRequest) (*Tailer, error) {
 	return nil, errors.New("querierMock.Tail() has not been mocked")
 }
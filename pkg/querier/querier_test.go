@@ -21,6 +21,7 @@ import (
 	"github.com/weaveworks/common/user"
 
 	"github.com/grafana/loki/pkg/ingester/client"
+	"github.com/grafana/loki/pkg/loghttp"
 	"github.com/grafana/loki/pkg/logproto"
 	"github.com/grafana/loki/pkg/logql"
 	"github.com/grafana/loki/pkg/storage"
@@ -329,6 +330,30 @@ func TestQuerier_SeriesAPI(t *testing.T) {
 				}, resp.GetSeries())
 			},
 		},
+		{
+			"merges stream shards",
+			mkReq([]string{`{a="1"}`}),
+			func(store *storeMock, querier *queryClientMock, ingester *querierClientMock, limits validation.Limits, req *logproto.SeriesRequest) {
+				ingester.On("Series", mock.Anything, req, mock.Anything).Return(mockSeriesResponse([]map[string]string{
+					{"a": "1", "b": "2", "__stream_shard__": "0"},
+					{"a": "1", "b": "2", "__stream_shard__": "1"},
+				}), nil)
+
+				store.On("Series", mock.Anything, mock.Anything).Return([]logproto.SeriesIdentifier{
+					{Labels: map[string]string{"a": "1", "b": "2"}},
+					{Labels: map[string]string{"a": "1", "b": "3", "__stream_shard__": "0"}},
+				}, nil)
+			},
+			func(t *testing.T, q *SingleTenantQuerier, req *logproto.SeriesRequest) {
+				ctx := user.InjectOrgID(context.Background(), "test")
+				resp, err := q.Series(ctx, req)
+				require.Nil(t, err)
+				require.ElementsMatch(t, []logproto.SeriesIdentifier{
+					{Labels: map[string]string{"a": "1", "b": "2"}},
+					{Labels: map[string]string{"a": "1", "b": "3"}},
+				}, resp.GetSeries())
+			},
+		},
 	} {
 		t.Run(tc.desc, func(t *testing.T) {
 			store := newStoreMock()
@@ -356,6 +381,142 @@ func TestQuerier_SeriesAPI(t *testing.T) {
 	}
 }
 
+func TestQuerier_IndexStats(t *testing.T) {
+	req := &loghttp.RangeQuery{
+		Start: time.Unix(0, 0),
+		End:   time.Unix(10, 0),
+		Query: `{a="1"}`,
+	}
+
+	for _, tc := range []struct {
+		desc     string
+		setup    func(*storeMock, *querierClientMock)
+		expected *logproto.IndexStatsResponse
+		err      bool
+	}{
+		{
+			desc: "ingester error",
+			setup: func(store *storeMock, ingester *querierClientMock) {
+				ingester.On("GetStats", mock.Anything, mock.Anything).Return(nil, errors.New("tst-err"))
+				store.On("Stats", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&logproto.IndexStatsResponse{}, nil)
+			},
+			err: true,
+		},
+		{
+			desc: "store error",
+			setup: func(store *storeMock, ingester *querierClientMock) {
+				ingester.On("GetStats", mock.Anything, mock.Anything).Return(&logproto.IndexStatsResponse{}, nil)
+				store.On("Stats", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, context.DeadlineExceeded)
+			},
+			err: true,
+		},
+		{
+			desc: "merges ingester and store stats",
+			setup: func(store *storeMock, ingester *querierClientMock) {
+				ingester.On("GetStats", mock.Anything, mock.Anything).Return(&logproto.IndexStatsResponse{Streams: 1, Chunks: 1, Bytes: 10, Entries: 2}, nil)
+				store.On("Stats", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&logproto.IndexStatsResponse{Streams: 2, Chunks: 3, Bytes: 100, Entries: 20}, nil)
+			},
+			// the streams of the ingesters and of the store mostly overlap, they are not summed.
+			expected: &logproto.IndexStatsResponse{Streams: 2, Chunks: 4, Bytes: 110, Entries: 22},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			store := newStoreMock()
+			ingesterClient := newQuerierClientMock()
+			tc.setup(store, ingesterClient)
+
+			limits, err := validation.NewOverrides(defaultLimitsTestConfig(), nil)
+			require.NoError(t, err)
+
+			q, err := newQuerier(
+				mockQuerierConfig(),
+				mockIngesterClientConfig(),
+				newIngesterClientMockFactory(ingesterClient),
+				mockReadRingWithOneActiveIngester(),
+				&mockDeleteGettter{},
+				store, limits)
+			require.NoError(t, err)
+
+			ctx := user.InjectOrgID(context.Background(), "test")
+			resp, err := q.IndexStats(ctx, req)
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, resp)
+		})
+	}
+}
+
+func TestQuerier_Cardinality(t *testing.T) {
+	resp := &logproto.CardinalityResponse{
+		Streams: 2,
+		Labels: []*logproto.LabelCardinality{
+			{Name: "a", Streams: 2, ValuesCount: 1, Values: []*logproto.LabelValueCardinality{{Value: "1", Streams: 2}}},
+		},
+	}
+
+	for _, tc := range []struct {
+		desc     string
+		req      *loghttp.CardinalityQuery
+		setup    func(*querierClientMock)
+		expected *logproto.CardinalityResponse
+		err      bool
+	}{
+		{
+			desc: "invalid selector",
+			req:  &loghttp.CardinalityQuery{Selector: `{a="1"`},
+			setup: func(ingester *querierClientMock) {
+				ingester.On("GetCardinality", mock.Anything, mock.Anything).Return(resp, nil)
+			},
+			err: true,
+		},
+		{
+			desc: "ingester error",
+			req:  &loghttp.CardinalityQuery{Selector: `{a="1"}`},
+			setup: func(ingester *querierClientMock) {
+				ingester.On("GetCardinality", mock.Anything, mock.Anything).Return(nil, errors.New("tst-err"))
+			},
+			err: true,
+		},
+		{
+			desc: "ingester cardinality",
+			req:  &loghttp.CardinalityQuery{Selector: `{a="1"}`, LabelNames: []string{"a"}, Limit: 5},
+			setup: func(ingester *querierClientMock) {
+				ingester.On("GetCardinality", mock.Anything, &logproto.CardinalityRequest{Matchers: `{a="1"}`, LabelNames: []string{"a"}, Limit: 5}).Return(resp, nil)
+			},
+			expected: resp,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			ingesterClient := newQuerierClientMock()
+			tc.setup(ingesterClient)
+
+			limits, err := validation.NewOverrides(defaultLimitsTestConfig(), nil)
+			require.NoError(t, err)
+
+			q, err := newQuerier(
+				mockQuerierConfig(),
+				mockIngesterClientConfig(),
+				newIngesterClientMockFactory(ingesterClient),
+				mockReadRingWithOneActiveIngester(),
+				&mockDeleteGettter{},
+				newStoreMock(), limits)
+			require.NoError(t, err)
+
+			ctx := user.InjectOrgID(context.Background(), "test")
+			res, err := q.Cardinality(ctx, tc.req)
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, res)
+		})
+	}
+}
+
 func TestQuerier_IngesterMaxQueryLookback(t *testing.T) {
 	limits, err := validation.NewOverrides(defaultLimitsTestConfig(), nil)
 	require.NoError(t, err)
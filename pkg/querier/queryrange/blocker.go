@@ -0,0 +1,135 @@
+package queryrange
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/weaveworks/common/httpgrpc"
+
+	"github.com/grafana/loki/pkg/logql/syntax"
+	"github.com/grafana/loki/pkg/util"
+	"github.com/grafana/loki/pkg/validation"
+)
+
+// ErrQueryBlocked is returned when a query is blocked by the blocked_queries limit of a tenant.
+var ErrQueryBlocked = httpgrpc.Errorf(http.StatusBadRequest, "query blocked by policy, please contact your Loki operator")
+
+type QueryBlockerMetrics struct {
+	blockedQueries *prometheus.CounterVec
+}
+
+func NewQueryBlockerMetrics(r prometheus.Registerer) *QueryBlockerMetrics {
+	return &QueryBlockerMetrics{
+		blockedQueries: promauto.With(r).NewCounterVec(prometheus.CounterOpts{
+			Namespace: "loki",
+			Name:      "query_frontend_blocked_queries_total",
+			Help:      "Total number of queries blocked by the blocked_queries limit of the tenant.",
+		}, []string{"tenant", "type"}),
+	}
+}
+
+// queryBlocker rejects the queries matching the blocked queries of a tenant.
+type queryBlocker struct {
+	logger  log.Logger
+	limits  Limits
+	metrics *QueryBlockerMetrics
+}
+
+func newQueryBlocker(logger log.Logger, limits Limits, metrics *QueryBlockerMetrics) *queryBlocker {
+	return &queryBlocker{
+		logger:  logger,
+		limits:  limits,
+		metrics: metrics,
+	}
+}
+
+// isBlocked tells if the query is blocked for any of the given tenants.
+func (b *queryBlocker) isBlocked(tenantIDs []string, query string, expr syntax.Expr) bool {
+	queryType := blockedQueryType(expr)
+	hash := util.HashedQuery(query)
+
+	for _, tenantID := range tenantIDs {
+		for _, blocked := range b.limits.BlockedQueries(tenantID) {
+			if !blockedQueryTypeMatches(blocked.Types, queryType) {
+				continue
+			}
+			if !b.matches(blocked, query, hash, expr) {
+				continue
+			}
+			level.Warn(b.logger).Log(
+				"msg", "query blocked",
+				"tenant", tenantID,
+				"query", query,
+				"query_hash", hash,
+				"type", queryType,
+				"pattern", blocked.Pattern,
+				"regex", blocked.Regex,
+				"hash", blocked.Hash,
+			)
+			b.metrics.blockedQueries.WithLabelValues(tenantID, queryType).Inc()
+			return true
+		}
+	}
+	return false
+}
+
+func (b *queryBlocker) matches(blocked *validation.BlockedQuery, query string, hash uint32, expr syntax.Expr) bool {
+	if blocked.Hash != 0 {
+		return blocked.Hash == hash
+	}
+	pattern := strings.TrimSpace(blocked.Pattern)
+	if pattern == "" {
+		return true
+	}
+	if blocked.Regex {
+		// the regex is compiled when the limits are validated.
+		if blocked.Regexp == nil {
+			return false
+		}
+		return blocked.Regexp.MatchString(query) || blocked.Regexp.MatchString(expr.String())
+	}
+	if pattern == strings.TrimSpace(query) {
+		return true
+	}
+	// compare the formatted queries, so that whitespaces or comments don't allow to bypass the rule.
+	patternExpr, err := syntax.ParseExpr(pattern)
+	if err != nil {
+		return false
+	}
+	return patternExpr.String() == expr.String()
+}
+
+// blockedQueryType returns the type of the query, as used by the blocked queries limits.
+func blockedQueryType(expr syntax.Expr) string {
+	switch e := expr.(type) {
+	case syntax.SampleExpr:
+		return validation.BlockedQueryTypeMetric
+	case syntax.LogSelectorExpr:
+		if e.HasFilter() {
+			return validation.BlockedQueryTypeFilter
+		}
+		return validation.BlockedQueryTypeLog
+	default:
+		return ""
+	}
+}
+
+func blockedQueryTypeMatches(types []string, queryType string) bool {
+	if len(types) == 0 {
+		return true
+	}
+	for _, t := range types {
+		if t == queryType {
+			return true
+		}
+		// filter queries are log queries too.
+		if t == validation.BlockedQueryTypeLog && queryType == validation.BlockedQueryTypeFilter {
+			return true
+		}
+	}
+	return false
+}
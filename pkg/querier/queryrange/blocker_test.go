@@ -0,0 +1,122 @@
+package queryrange
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/loki/pkg/logql/syntax"
+	"github.com/grafana/loki/pkg/util"
+	util_log "github.com/grafana/loki/pkg/util/log"
+	"github.com/grafana/loki/pkg/validation"
+)
+
+func Test_queryBlocker(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		blocked []*validation.BlockedQuery
+		query   string
+		want    bool
+	}{
+		{
+			name:  "no blocked queries",
+			query: `{app="foo"}`,
+		},
+		{
+			name:    "exact match",
+			blocked: []*validation.BlockedQuery{{Pattern: `{app="foo"} |= "bar"`}},
+			query:   `{app="foo"} |= "bar"`,
+			want:    true,
+		},
+		{
+			name:    "exact match ignores formatting",
+			blocked: []*validation.BlockedQuery{{Pattern: `sum by (app) (rate({app="foo"}[5m]))`}},
+			query: `sum by (app) (
+				rate({app="foo"} [5m]) # comment
+			)`,
+			want: true,
+		},
+		{
+			name:    "exact mismatch",
+			blocked: []*validation.BlockedQuery{{Pattern: `{app="foo"} |= "bar"`}},
+			query:   `{app="foo"} |= "buzz"`,
+		},
+		{
+			name:    "regex match",
+			blocked: []*validation.BlockedQuery{{Pattern: `.*\|~ "\.\*.*`, Regex: true}},
+			query:   `count_over_time({app="foo"} |~ ".*error" [1h])`,
+			want:    true,
+		},
+		{
+			name:    "regex mismatch",
+			blocked: []*validation.BlockedQuery{{Pattern: `.*\|~ "\.\*.*`, Regex: true}},
+			query:   `count_over_time({app="foo"} |~ "error" [1h])`,
+		},
+		{
+			name:    "invalid regex",
+			blocked: []*validation.BlockedQuery{{Pattern: `(`, Regex: true}},
+			query:   `{app="foo"}`,
+		},
+		{
+			name:    "hash match",
+			blocked: []*validation.BlockedQuery{{Hash: util.HashedQuery(`{app="foo"}`), Pattern: `{app="bar"}`}},
+			query:   `{app="foo"}`,
+			want:    true,
+		},
+		{
+			name:    "hash mismatch",
+			blocked: []*validation.BlockedQuery{{Hash: util.HashedQuery(`{app="foo"}`)}},
+			query:   `{app="bar"}`,
+		},
+		{
+			name:    "metric type",
+			blocked: []*validation.BlockedQuery{{Types: []string{validation.BlockedQueryTypeMetric}}},
+			query:   `rate({app="foo"}[1m])`,
+			want:    true,
+		},
+		{
+			name:    "metric type mismatch",
+			blocked: []*validation.BlockedQuery{{Types: []string{validation.BlockedQueryTypeMetric}}},
+			query:   `{app="foo"}`,
+		},
+		{
+			name:    "log type matches filter queries",
+			blocked: []*validation.BlockedQuery{{Types: []string{validation.BlockedQueryTypeLog}}},
+			query:   `{app="foo"} |= "bar"`,
+			want:    true,
+		},
+		{
+			name:    "filter type mismatch",
+			blocked: []*validation.BlockedQuery{{Types: []string{validation.BlockedQueryTypeFilter}}},
+			query:   `{app="foo"}`,
+		},
+		{
+			name: "pattern and type",
+			blocked: []*validation.BlockedQuery{
+				{Pattern: `.*app="foo".*`, Regex: true, Types: []string{validation.BlockedQueryTypeFilter}},
+			},
+			query: `{app="foo"} != "bar"`,
+			want:  true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, blocked := range tc.blocked {
+				// the limits are validated when loaded, which compiles the regexes; invalid ones are left uncompiled.
+				_ = blocked.Validate()
+			}
+			metrics := NewQueryBlockerMetrics(nil)
+			b := newQueryBlocker(util_log.Logger, fakeLimits{blockedQueries: tc.blocked}, metrics)
+
+			expr, err := syntax.ParseExpr(tc.query)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, b.isBlocked([]string{"fake"}, tc.query, expr))
+
+			var want float64
+			if tc.want {
+				want = 1
+			}
+			require.Equal(t, want, testutil.ToFloat64(metrics.blockedQueries.WithLabelValues("fake", blockedQueryType(expr))))
+		})
+	}
+}
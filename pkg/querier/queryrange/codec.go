@@ -202,6 +202,41 @@ func (r *LokiLabelNamesRequest) LogToSpan(sp opentracing.Span) {
 
 func (*LokiLabelNamesRequest) GetCachingOptions() (res queryrangebase.CachingOptions) { return }
 
+func (r *LokiIndexStatsRequest) GetEnd() int64 {
+	return r.EndTs.UnixNano() / (int64(time.Millisecond) / int64(time.Nanosecond))
+}
+
+func (r *LokiIndexStatsRequest) GetStart() int64 {
+	return r.StartTs.UnixNano() / (int64(time.Millisecond) / int64(time.Nanosecond))
+}
+
+func (r *LokiIndexStatsRequest) WithStartEnd(s int64, e int64) queryrangebase.Request {
+	new := *r
+	new.StartTs = time.Unix(0, s*int64(time.Millisecond))
+	new.EndTs = time.Unix(0, e*int64(time.Millisecond))
+	return &new
+}
+
+func (r *LokiIndexStatsRequest) WithQuery(query string) queryrangebase.Request {
+	new := *r
+	new.Query = query
+	return &new
+}
+
+func (r *LokiIndexStatsRequest) GetStep() int64 {
+	return 0
+}
+
+func (r *LokiIndexStatsRequest) LogToSpan(sp opentracing.Span) {
+	sp.LogFields(
+		otlog.String("query", r.GetQuery()),
+		otlog.String("start", timestamp.Time(r.GetStart()).String()),
+		otlog.String("end", timestamp.Time(r.GetEnd()).String()),
+	)
+}
+
+func (*LokiIndexStatsRequest) GetCachingOptions() (res queryrangebase.CachingOptions) { return }
+
 func (Codec) DecodeRequest(_ context.Context, r *http.Request, forwardHeaders []string) (queryrangebase.Request, error) {
 	if err := r.ParseForm(); err != nil {
 		return nil, httpgrpc.Errorf(http.StatusBadRequest, err.Error())
@@ -259,6 +294,17 @@ func (Codec) DecodeRequest(_ context.Context, r *http.Request, forwardHeaders []
 			EndTs:   *req.End,
 			Path:    r.URL.Path,
 		}, nil
+	case IndexStatsOp:
+		req, err := loghttp.ParseIndexStatsQuery(r)
+		if err != nil {
+			return nil, httpgrpc.Errorf(http.StatusBadRequest, err.Error())
+		}
+		return &LokiIndexStatsRequest{
+			Query:   req.Query,
+			StartTs: req.Start.UTC(),
+			EndTs:   req.End.UTC(),
+			Path:    r.URL.Path,
+		}, nil
 	default:
 		return nil, httpgrpc.Errorf(http.StatusBadRequest, fmt.Sprintf("unknown request path: %s", r.URL.Path))
 	}
@@ -283,6 +329,9 @@ func (Codec) EncodeRequest(ctx context.Context, r queryrangebase.Request) (*http
 		if len(request.Shards) > 0 {
 			params["shards"] = request.Shards
 		}
+		if request.QuantileSketch {
+			params["quantile_sketch"] = []string{"true"}
+		}
 		if request.Step != 0 {
 			params["step"] = []string{fmt.Sprintf("%f", float64(request.Step)/float64(1e3))}
 		}
@@ -352,6 +401,9 @@ func (Codec) EncodeRequest(ctx context.Context, r queryrangebase.Request) (*http
 		if len(request.Shards) > 0 {
 			params["shards"] = request.Shards
 		}
+		if request.QuantileSketch {
+			params["quantile_sketch"] = []string{"true"}
+		}
 		u := &url.URL{
 			// the request could come /api/prom/query but we want to only use the new api.
 			Path:     "/loki/api/v1/query",
@@ -365,6 +417,24 @@ func (Codec) EncodeRequest(ctx context.Context, r queryrangebase.Request) (*http
 			Header:     header,
 		}
 
+		return req.WithContext(ctx), nil
+	case *LokiIndexStatsRequest:
+		params := url.Values{
+			"start": []string{fmt.Sprintf("%d", request.StartTs.UnixNano())},
+			"end":   []string{fmt.Sprintf("%d", request.EndTs.UnixNano())},
+			"query": []string{request.Query},
+		}
+		u := &url.URL{
+			Path:     "/loki/api/v1/index/stats",
+			RawQuery: params.Encode(),
+		}
+		req := &http.Request{
+			Method:     "GET",
+			RequestURI: u.String(), // This is what the httpgrpc code looks at.
+			URL:        u,
+			Body:       http.NoBody,
+			Header:     header,
+		}
 		return req.WithContext(ctx), nil
 	default:
 		return nil, httpgrpc.Errorf(http.StatusInternalServerError, "invalid request format")
@@ -424,6 +494,15 @@ func (Codec) DecodeResponse(ctx context.Context, r *http.Response, req queryrang
 			Data:    resp.Data,
 			Headers: httpResponseHeadersToPromResponseHeaders(r.Header),
 		}, nil
+	case *LokiIndexStatsRequest:
+		var resp logproto.IndexStatsResponse
+		if err := json.Unmarshal(buf, &resp); err != nil {
+			return nil, httpgrpc.Errorf(http.StatusInternalServerError, "error decoding response: %v", err)
+		}
+		return &LokiIndexStatsResponse{
+			Response: &resp,
+			Headers:  httpResponseHeadersToPromResponseHeaders(r.Header),
+		}, nil
 	default:
 		var resp loghttp.QueryResponse
 		if err := resp.UnmarshalJSON(buf); err != nil {
@@ -482,6 +561,13 @@ func (Codec) DecodeResponse(ctx context.Context, r *http.Response, req queryrang
 				},
 				Statistics: resp.Data.Statistics,
 			}, nil
+		case loghttp.ResultTypeQuantileSketches:
+			return &QuantileSketchResponse{
+				Status:     resp.Status,
+				Data:       resp.Data.Result.(loghttp.QuantileSketches),
+				Statistics: resp.Data.Statistics,
+				Headers:    httpResponseHeadersToPromResponseHeaders(r.Header),
+			}, nil
 		default:
 			return nil, httpgrpc.Errorf(http.StatusInternalServerError, "unsupported response type, got (%s)", string(resp.Data.ResultType))
 		}
@@ -536,6 +622,10 @@ func (Codec) EncodeResponse(ctx context.Context, res queryrangebase.Response) (*
 				return nil, err
 			}
 		}
+	case *LokiIndexStatsResponse:
+		if err := marshal.WriteIndexStatsResponseJSON(response.Response, &buf); err != nil {
+			return nil, err
+		}
 	default:
 		return nil, httpgrpc.Errorf(http.StatusInternalServerError, "invalid response format")
 	}
@@ -620,6 +710,16 @@ func (Codec) MergeResponse(responses ...queryrangebase.Response) (queryrangebase
 			Version: labelNameRes.Version,
 			Data:    names,
 		}, nil
+	case *LokiIndexStatsResponse:
+		// the responses must account for distinct chunks, see MergeIndexStatsResponses.
+		statsResponses := make([]*logproto.IndexStatsResponse, 0, len(responses))
+		for _, res := range responses {
+			statsResponses = append(statsResponses, res.(*LokiIndexStatsResponse).Response)
+		}
+
+		return &LokiIndexStatsResponse{
+			Response: logproto.MergeIndexStatsResponses(statsResponses),
+		}, nil
 	default:
 		return nil, errors.New("unknown response in merging responses")
 	}
@@ -868,6 +968,10 @@ func NewEmptyResponse(r queryrangebase.Request) (queryrangebase.Response, error)
 			Status:  loghttp.QueryStatusSuccess,
 			Version: uint32(loghttp.GetVersion(req.Path)),
 		}, nil
+	case *LokiIndexStatsRequest:
+		return &LokiIndexStatsResponse{
+			Response: &logproto.IndexStatsResponse{},
+		}, nil
 	case *LokiInstantRequest:
 		// instant queries in the frontend are always metrics queries.
 		return &LokiPromResponse{
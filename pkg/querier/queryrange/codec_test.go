@@ -79,6 +79,15 @@ func Test_codec_DecodeRequest(t *testing.T) {
 			StartTs: start,
 			EndTs:   end,
 		}, false},
+		{"index_stats", func() (*http.Request, error) {
+			return http.NewRequest(http.MethodGet,
+				fmt.Sprintf(`/loki/api/v1/index/stats?start=%d&end=%d&query={foo="bar"}`, start.UnixNano(), end.UnixNano()), nil)
+		}, &LokiIndexStatsRequest{
+			Query:   `{foo="bar"}`,
+			Path:    "/loki/api/v1/index/stats",
+			StartTs: start,
+			EndTs:   end,
+		}, false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -152,6 +161,20 @@ func Test_codec_DecodeResponse(t *testing.T) {
 				Statistics: statsResult,
 			}, false,
 		},
+		{
+			"quantile sketches", &http.Response{StatusCode: 200, Body: ioutil.NopCloser(strings.NewReader(quantileSketchesString))},
+			&LokiRequest{Direction: logproto.FORWARD, Limit: 100, Path: "/loki/api/v1/query_range", QuantileSketch: true},
+			&QuantileSketchResponse{
+				Status: loghttp.QueryStatusSuccess,
+				Data: []logproto.QuantileSketchSeries{
+					{
+						Labels: `{job="varlogs"}`,
+						Points: []logproto.QuantileSketchPoint{{Timestamp: 1568404331324, Sketch: []byte{2, 1, 2, 3}}},
+					},
+				},
+				Statistics: statsResult,
+			}, false,
+		},
 		{
 			"streams v1", &http.Response{StatusCode: 200, Body: ioutil.NopCloser(strings.NewReader(streamsString))},
 			&LokiRequest{Direction: logproto.FORWARD, Limit: 100, Path: "/loki/api/v1/query_range"},
@@ -241,6 +264,7 @@ func Test_codec_EncodeRequest(t *testing.T) {
 	require.Equal(t, `FORWARD`, got.URL.Query().Get("direction"))
 	require.Equal(t, "86400.000000", got.URL.Query().Get("step"))
 	require.Equal(t, "10000.000000", got.URL.Query().Get("interval"))
+	require.Equal(t, "", got.URL.Query().Get("quantile_sketch"))
 
 	// testing a full roundtrip
 	req, err := LokiCodec.DecodeRequest(context.TODO(), got, nil)
@@ -253,6 +277,11 @@ func Test_codec_EncodeRequest(t *testing.T) {
 	require.Equal(t, toEncode.Direction, req.(*LokiRequest).Direction)
 	require.Equal(t, toEncode.Limit, req.(*LokiRequest).Limit)
 	require.Equal(t, "/loki/api/v1/query_range", req.(*LokiRequest).Path)
+
+	toEncode.QuantileSketch = true
+	got, err = LokiCodec.EncodeRequest(ctx, toEncode)
+	require.NoError(t, err)
+	require.Equal(t, "true", got.URL.Query().Get("quantile_sketch"))
 }
 
 func Test_codec_series_EncodeRequest(t *testing.T) {
@@ -961,6 +990,19 @@ var (
 	  "result": []
 	},
 	"status": "success"
+  }`
+	quantileSketchesString = `{
+	"data": {
+	  ` + statsResultString + `
+	  "resultType": "quantile_sketches",
+	  "result": [
+		{
+		  "metric": "{job=\"varlogs\"}",
+		  "values": [{"timestamp": 1568404331324, "sketch": "AgECAw=="}]
+		}
+	  ]
+	},
+	"status": "success"
   }`
 	vectorStringEmptyResult = `{
 	"data": {
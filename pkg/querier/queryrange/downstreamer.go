@@ -48,6 +48,17 @@ func ParamsToLokiRequest(params logql.Params, shards logql.Shards) queryrangebas
 	}
 }
 
+// withQuantileSketch requests the quantile sketches of a quantile_over_time query instead of its quantiles.
+func withQuantileSketch(req queryrangebase.Request) queryrangebase.Request {
+	switch r := req.(type) {
+	case *LokiRequest:
+		r.QuantileSketch = true
+	case *LokiInstantRequest:
+		r.QuantileSketch = true
+	}
+	return req
+}
+
 // Note: After the introduction of the LimitedRoundTripper,
 // bounding concurrency in the downstreamer is mostly redundant
 // The reason we don't remove it is to prevent malicious queries
@@ -77,6 +88,9 @@ type instance struct {
 func (in instance) Downstream(ctx context.Context, queries []logql.DownstreamQuery) ([]logqlmodel.Result, error) {
 	return in.For(ctx, queries, func(qry logql.DownstreamQuery) (logqlmodel.Result, error) {
 		req := ParamsToLokiRequest(qry.Params, qry.Shards).WithQuery(qry.Expr.String())
+		if qry.QuantileSketch {
+			req = withQuantileSketch(req)
+		}
 		logger, ctx := spanlogger.New(ctx, "DownstreamHandler.instance")
 		defer logger.Finish()
 		level.Debug(logger).Log("shards", fmt.Sprintf("%+v", qry.Shards), "query", req.GetQuery(), "step", req.GetStep())
@@ -225,6 +239,12 @@ func ResponseToResult(resp queryrangebase.Response) (logqlmodel.Result, error) {
 			Data:       sampleStreamToMatrix(r.Response.Data.Result),
 		}, nil
 
+	case *QuantileSketchResponse:
+		return logqlmodel.Result{
+			Statistics: r.Statistics,
+			Data:       logqlmodel.QuantileSketches(r.Data),
+		}, nil
+
 	default:
 		return logqlmodel.Result{}, fmt.Errorf("cannot decode (%T)", resp)
 	}
@@ -169,6 +169,27 @@ func TestResponseToResult(t *testing.T) {
 			},
 			err: true,
 		},
+		{
+			desc: "QuantileSketchResponse",
+			input: &QuantileSketchResponse{
+				Data: []logproto.QuantileSketchSeries{{
+					Labels: `{foo="bar"}`,
+					Points: []logproto.QuantileSketchPoint{{Timestamp: 1, Sketch: []byte{2, 1, 2, 3}}},
+				}},
+				Statistics: stats.Result{
+					Summary: stats.Summary{QueueTime: 1, ExecTime: 2},
+				},
+			},
+			expected: logqlmodel.Result{
+				Statistics: stats.Result{
+					Summary: stats.Summary{QueueTime: 1, ExecTime: 2},
+				},
+				Data: logqlmodel.QuantileSketches{{
+					Labels: `{foo="bar"}`,
+					Points: []logproto.QuantileSketchPoint{{Timestamp: 1, Sketch: []byte{2, 1, 2, 3}}},
+				}},
+			},
+		},
 		{
 			desc:  "UnexpectedTypeError",
 			input: nil,
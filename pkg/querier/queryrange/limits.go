@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/dustin/go-humanize"
 	"github.com/go-kit/log/level"
 	"github.com/opentracing/opentracing-go"
 	"github.com/prometheus/prometheus/model/timestamp"
@@ -17,14 +18,19 @@ import (
 
 	"github.com/grafana/loki/pkg/logproto"
 	"github.com/grafana/loki/pkg/logql"
+	"github.com/grafana/loki/pkg/logql/syntax"
 	"github.com/grafana/loki/pkg/querier/queryrange/queryrangebase"
 	"github.com/grafana/loki/pkg/util"
 	"github.com/grafana/loki/pkg/util/spanlogger"
-	"github.com/grafana/loki/pkg/util/validation"
+	util_validation "github.com/grafana/loki/pkg/util/validation"
+	"github.com/grafana/loki/pkg/validation"
 )
 
 const (
 	limitErrTmpl = "maximum of series (%d) reached for a single query"
+
+	limErrQueryTooManyBytesTmpl   = "the query would read too many bytes (query: %s, limit: %s). Consider adding more specific stream selectors or reduce the time range of the query"
+	limErrQuerierTooManyBytesTmpl = "query too large to execute on a single querier: (query: %s, limit: %s). Consider adding more specific stream selectors, reduce the time range of the query, or increase parallelization"
 )
 
 var (
@@ -39,6 +45,10 @@ type Limits interface {
 	MaxQuerySeries(string) int
 	MaxEntriesLimitPerQuery(string) int
 	MinShardingLookback(string) time.Duration
+	ShardQuantileOverTime(string) bool
+	BlockedQueries(string) []*validation.BlockedQuery
+	MaxQueryBytesRead(string) int
+	MaxQuerierBytesRead(string) int
 }
 
 type limits struct {
@@ -102,7 +112,7 @@ func (l limitsMiddleware) Do(ctx context.Context, r queryrangebase.Request) (que
 
 	// Clamp the time range based on the max query lookback.
 
-	if maxQueryLookback := validation.SmallestPositiveNonZeroDurationPerTenant(tenantIDs, l.MaxQueryLookback); maxQueryLookback > 0 {
+	if maxQueryLookback := util_validation.SmallestPositiveNonZeroDurationPerTenant(tenantIDs, l.MaxQueryLookback); maxQueryLookback > 0 {
 		minStartTime := util.TimeToMillis(time.Now().Add(-maxQueryLookback))
 
 		if r.GetEnd() < minStartTime {
@@ -129,16 +139,145 @@ func (l limitsMiddleware) Do(ctx context.Context, r queryrangebase.Request) (que
 	}
 
 	// Enforce the max query length.
-	if maxQueryLength := validation.SmallestPositiveNonZeroDurationPerTenant(tenantIDs, l.MaxQueryLength); maxQueryLength > 0 {
+	if maxQueryLength := util_validation.SmallestPositiveNonZeroDurationPerTenant(tenantIDs, l.MaxQueryLength); maxQueryLength > 0 {
 		queryLen := timestamp.Time(r.GetEnd()).Sub(timestamp.Time(r.GetStart()))
 		if queryLen > maxQueryLength {
-			return nil, httpgrpc.Errorf(http.StatusBadRequest, validation.ErrQueryTooLong, queryLen, maxQueryLength)
+			return nil, httpgrpc.Errorf(http.StatusBadRequest, util_validation.ErrQueryTooLong, queryLen, maxQueryLength)
 		}
 	}
 
 	return l.next.Do(ctx, r)
 }
 
+type querySizeLimiter struct {
+	next         queryrangebase.Handler
+	statsHandler queryrangebase.Handler
+	limitFunc    func(string) int
+	limitErrTmpl string
+	// shards returns the number of shards the request is going to be split into by the sharding middleware.
+	shards func(queryrangebase.Request) int
+}
+
+// NewQuerySizeLimiterMiddleware creates a new Middleware that rejects the queries estimated to read more bytes
+// than the max_query_bytes_read limit. The estimate is computed from the index stats of the stream selectors of the query,
+// which are requested through the statsHandler.
+func NewQuerySizeLimiterMiddleware(limits Limits, statsHandler queryrangebase.Handler) queryrangebase.Middleware {
+	return queryrangebase.MiddlewareFunc(func(next queryrangebase.Handler) queryrangebase.Handler {
+		return &querySizeLimiter{
+			next:         next,
+			statsHandler: statsHandler,
+			limitFunc:    limits.MaxQueryBytesRead,
+			limitErrTmpl: limErrQueryTooManyBytesTmpl,
+			shards:       func(queryrangebase.Request) int { return 1 },
+		}
+	})
+}
+
+// NewQuerierSizeLimiterMiddleware creates a new Middleware that rejects the queries estimated to read more bytes
+// than the max_querier_bytes_read limit in a single querier. It is meant to be placed after the split by interval middleware,
+// and takes into account the sharding of the request if shardedQueries is enabled.
+func NewQuerierSizeLimiterMiddleware(limits Limits, statsHandler queryrangebase.Handler, confs ShardingConfigs, shardedQueries bool) queryrangebase.Middleware {
+	return queryrangebase.MiddlewareFunc(func(next queryrangebase.Handler) queryrangebase.Handler {
+		return &querySizeLimiter{
+			next:         next,
+			statsHandler: statsHandler,
+			limitFunc:    limits.MaxQuerierBytesRead,
+			limitErrTmpl: limErrQuerierTooManyBytesTmpl,
+			shards: func(r queryrangebase.Request) int {
+				if !shardedQueries {
+					return 1
+				}
+				conf, err := confs.GetConf(r)
+				if err != nil {
+					return 1
+				}
+				expr, err := syntax.ParseExpr(r.GetQuery())
+				if err != nil || !expr.Shardable() {
+					return 1
+				}
+				return int(conf.RowShards)
+			},
+		}
+	})
+}
+
+func (q *querySizeLimiter) Do(ctx context.Context, r queryrangebase.Request) (queryrangebase.Response, error) {
+	log, ctx := spanlogger.New(ctx, "query_size_limits")
+	defer log.Finish()
+
+	tenantIDs, err := tenant.TenantIDs(ctx)
+	if err != nil {
+		return nil, httpgrpc.Errorf(http.StatusBadRequest, err.Error())
+	}
+
+	maxBytesRead := util_validation.SmallestPositiveNonZeroIntPerTenant(tenantIDs, q.limitFunc)
+	if maxBytesRead == 0 {
+		return q.next.Do(ctx, r)
+	}
+
+	bytesRead, err := q.estimateBytesRead(ctx, r)
+	if err != nil {
+		level.Warn(log).Log("msg", "failed to estimate the bytes read by the query, skipping the query size limit", "err", err)
+		return q.next.Do(ctx, r)
+	}
+
+	level.Debug(log).Log("msg", "estimated the bytes read by the query", "bytes", humanize.Bytes(bytesRead), "limit", humanize.Bytes(uint64(maxBytesRead)))
+	if bytesRead > uint64(maxBytesRead) {
+		return nil, httpgrpc.Errorf(http.StatusBadRequest, q.limitErrTmpl, humanize.Bytes(bytesRead), humanize.Bytes(uint64(maxBytesRead)))
+	}
+
+	return q.next.Do(ctx, r)
+}
+
+// estimateBytesRead returns the bytes of the chunks matched by the stream selectors of the request, divided by its number of shards.
+func (q *querySizeLimiter) estimateBytesRead(ctx context.Context, r queryrangebase.Request) (uint64, error) {
+	expr, err := syntax.ParseExpr(r.GetQuery())
+	if err != nil {
+		return 0, err
+	}
+
+	var bytesRead uint64
+	for _, req := range indexStatsRequests(expr, r) {
+		resp, err := q.statsHandler.Do(ctx, req)
+		if err != nil {
+			return 0, err
+		}
+		if statsResp, ok := resp.(*LokiIndexStatsResponse); ok && statsResp.Response != nil {
+			bytesRead += statsResp.Response.Bytes
+		}
+	}
+
+	return bytesRead / uint64(q.shards(r)), nil
+}
+
+// indexStatsRequests returns the index stats requests of the stream selectors of the expression.
+// The start of the requests accounts for the range and offset of the range aggregations.
+func indexStatsRequests(expr syntax.Expr, r queryrangebase.Request) []queryrangebase.Request {
+	start, end := timestamp.Time(r.GetStart()), timestamp.Time(r.GetEnd())
+
+	if logSelector, ok := expr.(syntax.LogSelectorExpr); ok {
+		return []queryrangebase.Request{&LokiIndexStatsRequest{
+			Query:   (&syntax.MatchersExpr{Mts: logSelector.Matchers()}).String(),
+			StartTs: start,
+			EndTs:   end,
+		}}
+	}
+
+	var reqs []queryrangebase.Request
+	expr.Walk(func(e interface{}) {
+		logRange, ok := e.(*syntax.LogRange)
+		if !ok {
+			return
+		}
+		reqs = append(reqs, &LokiIndexStatsRequest{
+			Query:   (&syntax.MatchersExpr{Mts: logRange.Left.Matchers()}).String(),
+			StartTs: start.Add(-logRange.Interval - logRange.Offset),
+			EndTs:   end.Add(-logRange.Offset),
+		})
+	})
+	return reqs
+}
+
 type seriesLimiter struct {
 	hashes map[uint64]struct{}
 	rw     sync.RWMutex
@@ -265,7 +404,7 @@ func (rt limitedRoundTripper) RoundTrip(r *http.Request) (*http.Response, error)
 		return nil, httpgrpc.Errorf(http.StatusBadRequest, err.Error())
 	}
 
-	parallelism := validation.SmallestPositiveIntPerTenant(tenantIDs, rt.limits.MaxQueryParallelism)
+	parallelism := util_validation.SmallestPositiveIntPerTenant(tenantIDs, rt.limits.MaxQueryParallelism)
 	if parallelism < 1 {
 		return nil, httpgrpc.Errorf(http.StatusTooManyRequests, ErrMaxQueryParalellism.Error())
 	}
@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/promql"
 	"github.com/stretchr/testify/require"
@@ -267,6 +268,142 @@ func Test_MaxQueryLookBack(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func Test_MaxQuerySize(t *testing.T) {
+	now := time.Now()
+	schemaConfigs := []config.PeriodConfig{
+		{
+			From:      config.DayTime{Time: model.TimeFromUnix(now.Add(-48 * time.Hour).Unix())},
+			RowShards: 2,
+		},
+	}
+
+	for _, tc := range []struct {
+		desc           string
+		query          string
+		queryLimit     int
+		querierLimit   int
+		shardedQueries bool
+		statsBytes     uint64
+
+		expectedStatsRequests []*LokiIndexStatsRequest
+		expectedErr           string
+	}{
+		{
+			desc:       "no limits",
+			query:      `{app="foo"} |= "foo"`,
+			statsBytes: 2 << 10,
+		},
+		{
+			desc:       "log query under the query limit",
+			query:      `{app="foo"} |= "foo"`,
+			queryLimit: 3 << 10,
+			statsBytes: 2 << 10,
+			expectedStatsRequests: []*LokiIndexStatsRequest{
+				{Query: `{app="foo"}`, StartTs: now.Add(-time.Hour), EndTs: now},
+			},
+		},
+		{
+			desc:       "log query over the query limit",
+			query:      `{app="foo"} |= "foo"`,
+			queryLimit: 1 << 10,
+			statsBytes: 2 << 10,
+			expectedStatsRequests: []*LokiIndexStatsRequest{
+				{Query: `{app="foo"}`, StartTs: now.Add(-time.Hour), EndTs: now},
+			},
+			expectedErr: "the query would read too many bytes (query: 2.0 kB, limit: 1.0 kB)",
+		},
+		{
+			desc:       "metric query with multiple selectors over the query limit",
+			query:      `sum(rate({app="foo"}[5m])) / sum(rate({app="bar"}[10m] offset 1h))`,
+			queryLimit: 3 << 10,
+			statsBytes: 2 << 10,
+			expectedStatsRequests: []*LokiIndexStatsRequest{
+				{Query: `{app="foo"}`, StartTs: now.Add(-time.Hour - 5*time.Minute), EndTs: now},
+				{Query: `{app="bar"}`, StartTs: now.Add(-2*time.Hour - 10*time.Minute), EndTs: now.Add(-time.Hour)},
+			},
+			expectedErr: "the query would read too many bytes (query: 4.1 kB, limit: 3.1 kB)",
+		},
+		{
+			desc:         "over the querier limit",
+			query:        `sum(rate({app="foo"}[5m]))`,
+			querierLimit: 1 << 10,
+			statsBytes:   2 << 10,
+			expectedStatsRequests: []*LokiIndexStatsRequest{
+				{Query: `{app="foo"}`, StartTs: now.Add(-time.Hour - 5*time.Minute), EndTs: now},
+			},
+			expectedErr: "query too large to execute on a single querier: (query: 2.0 kB, limit: 1.0 kB)",
+		},
+		{
+			desc:           "sharded query under the querier limit",
+			query:          `sum(rate({app="foo"}[5m]))`,
+			querierLimit:   1 << 10,
+			shardedQueries: true,
+			statsBytes:     2 << 10,
+			expectedStatsRequests: []*LokiIndexStatsRequest{
+				{Query: `{app="foo"}`, StartTs: now.Add(-time.Hour - 5*time.Minute), EndTs: now},
+			},
+		},
+		{
+			desc:           "non shardable query over the querier limit",
+			query:          `sum(rate({app="foo"} | label_format foo=bar [5m]))`,
+			querierLimit:   1 << 10,
+			shardedQueries: true,
+			statsBytes:     2 << 10,
+			expectedStatsRequests: []*LokiIndexStatsRequest{
+				{Query: `{app="foo"}`, StartTs: now.Add(-time.Hour - 5*time.Minute), EndTs: now},
+			},
+			expectedErr: "query too large to execute on a single querier: (query: 2.0 kB, limit: 1.0 kB)",
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			var statsRequests []*LokiIndexStatsRequest
+			statsHandler := queryrangebase.HandlerFunc(func(_ context.Context, r queryrangebase.Request) (queryrangebase.Response, error) {
+				req := r.(*LokiIndexStatsRequest)
+				statsRequests = append(statsRequests, req)
+				return &LokiIndexStatsResponse{Response: &logproto.IndexStatsResponse{Bytes: tc.statsBytes}}, nil
+			})
+
+			var called bool
+			next := queryrangebase.HandlerFunc(func(_ context.Context, r queryrangebase.Request) (queryrangebase.Response, error) {
+				called = true
+				return &LokiResponse{}, nil
+			})
+
+			limits := fakeLimits{
+				maxQueryBytesRead:   tc.queryLimit,
+				maxQuerierBytesRead: tc.querierLimit,
+			}
+			handler := queryrangebase.MergeMiddlewares(
+				NewQuerySizeLimiterMiddleware(limits, statsHandler),
+				NewQuerierSizeLimiterMiddleware(limits, statsHandler, schemaConfigs, tc.shardedQueries),
+			).Wrap(next)
+
+			ctx := user.InjectOrgID(context.Background(), "foo")
+			_, err := handler.Do(ctx, &LokiRequest{
+				Query:   tc.query,
+				StartTs: now.Add(-time.Hour),
+				EndTs:   now,
+			})
+
+			require.Len(t, statsRequests, len(tc.expectedStatsRequests))
+			for i, expected := range tc.expectedStatsRequests {
+				require.Equal(t, expected.Query, statsRequests[i].Query)
+				require.Equal(t, expected.StartTs.UnixMilli(), statsRequests[i].StartTs.UnixMilli())
+				require.Equal(t, expected.EndTs.UnixMilli(), statsRequests[i].EndTs.UnixMilli())
+			}
+
+			if tc.expectedErr != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.expectedErr)
+				require.False(t, called)
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, called)
+		})
+	}
+}
+
 func Test_GenerateCacheKey_NoDivideZero(t *testing.T) {
 	l := cacheKeyLimits{WithSplitByLimits(nil, 0)}
 	start := time.Now()
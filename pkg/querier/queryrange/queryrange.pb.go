@@ -44,6 +44,8 @@ type LokiRequest struct {
 	Direction logproto.Direction `protobuf:"varint,6,opt,name=direction,proto3,enum=logproto.Direction" json:"direction,omitempty"`
 	Path      string             `protobuf:"bytes,7,opt,name=path,proto3" json:"path,omitempty"`
 	Shards    []string           `protobuf:"bytes,8,rep,name=shards,proto3" json:"shards"`
+	// quantileSketch requests the quantile sketches of a sharded quantile_over_time query instead of its quantiles.
+	QuantileSketch bool `protobuf:"varint,10,opt,name=quantileSketch,proto3" json:"quantileSketch,omitempty"`
 }
 
 func (m *LokiRequest) Reset()      { *m = LokiRequest{} }
@@ -141,6 +143,13 @@ func (m *LokiRequest) GetShards() []string {
 	return nil
 }
 
+func (m *LokiRequest) GetQuantileSketch() bool {
+	if m != nil {
+		return m.QuantileSketch
+	}
+	return false
+}
+
 type LokiInstantRequest struct {
 	Query     string             `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
 	Limit     uint32             `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
@@ -148,6 +157,8 @@ type LokiInstantRequest struct {
 	Direction logproto.Direction `protobuf:"varint,4,opt,name=direction,proto3,enum=logproto.Direction" json:"direction,omitempty"`
 	Path      string             `protobuf:"bytes,5,opt,name=path,proto3" json:"path,omitempty"`
 	Shards    []string           `protobuf:"bytes,6,rep,name=shards,proto3" json:"shards"`
+	// quantileSketch requests the quantile sketches of a sharded quantile_over_time query instead of its quantiles.
+	QuantileSketch bool `protobuf:"varint,7,opt,name=quantileSketch,proto3" json:"quantileSketch,omitempty"`
 }
 
 func (m *LokiInstantRequest) Reset()      { *m = LokiInstantRequest{} }
@@ -224,6 +235,13 @@ func (m *LokiInstantRequest) GetShards() []string {
 	return nil
 }
 
+func (m *LokiInstantRequest) GetQuantileSketch() bool {
+	if m != nil {
+		return m.QuantileSketch
+	}
+	return false
+}
+
 type LokiResponse struct {
 	Status     string                                                                                   `protobuf:"bytes,1,opt,name=Status,proto3" json:"status"`
 	Data       LokiData                                                                                 `protobuf:"bytes,2,opt,name=Data,proto3" json:"data,omitempty"`
@@ -578,6 +596,117 @@ func (m *LokiLabelNamesResponse) GetVersion() uint32 {
 	return 0
 }
 
+type LokiIndexStatsRequest struct {
+	Query   string    `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	StartTs time.Time `protobuf:"bytes,2,opt,name=startTs,proto3,stdtime" json:"startTs"`
+	EndTs   time.Time `protobuf:"bytes,3,opt,name=endTs,proto3,stdtime" json:"endTs"`
+	Path    string    `protobuf:"bytes,4,opt,name=path,proto3" json:"path,omitempty"`
+}
+
+func (m *LokiIndexStatsRequest) Reset()      { *m = LokiIndexStatsRequest{} }
+func (*LokiIndexStatsRequest) ProtoMessage() {}
+func (*LokiIndexStatsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51b9d53b40d11902, []int{7}
+}
+func (m *LokiIndexStatsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LokiIndexStatsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LokiIndexStatsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *LokiIndexStatsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LokiIndexStatsRequest.Merge(m, src)
+}
+func (m *LokiIndexStatsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *LokiIndexStatsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LokiIndexStatsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LokiIndexStatsRequest proto.InternalMessageInfo
+
+func (m *LokiIndexStatsRequest) GetQuery() string {
+	if m != nil {
+		return m.Query
+	}
+	return ""
+}
+
+func (m *LokiIndexStatsRequest) GetStartTs() time.Time {
+	if m != nil {
+		return m.StartTs
+	}
+	return time.Time{}
+}
+
+func (m *LokiIndexStatsRequest) GetEndTs() time.Time {
+	if m != nil {
+		return m.EndTs
+	}
+	return time.Time{}
+}
+
+func (m *LokiIndexStatsRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+type LokiIndexStatsResponse struct {
+	Response *logproto.IndexStatsResponse                                                             `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Headers  []github_com_grafana_loki_pkg_querier_queryrange_queryrangebase.PrometheusResponseHeader `protobuf:"bytes,2,rep,name=Headers,proto3,customtype=github.com/grafana/loki/pkg/querier/queryrange/queryrangebase.PrometheusResponseHeader" json:"-"`
+}
+
+func (m *LokiIndexStatsResponse) Reset()      { *m = LokiIndexStatsResponse{} }
+func (*LokiIndexStatsResponse) ProtoMessage() {}
+func (*LokiIndexStatsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51b9d53b40d11902, []int{8}
+}
+func (m *LokiIndexStatsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LokiIndexStatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LokiIndexStatsResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *LokiIndexStatsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LokiIndexStatsResponse.Merge(m, src)
+}
+func (m *LokiIndexStatsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *LokiIndexStatsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LokiIndexStatsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LokiIndexStatsResponse proto.InternalMessageInfo
+
+func (m *LokiIndexStatsResponse) GetResponse() *logproto.IndexStatsResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
 type LokiData struct {
 	ResultType string                                        `protobuf:"bytes,1,opt,name=ResultType,proto3" json:"resultType"`
 	Result     []github_com_grafana_loki_pkg_logproto.Stream `protobuf:"bytes,2,rep,name=Result,proto3,customtype=github.com/grafana/loki/pkg/logproto.Stream" json:"result"`
@@ -586,7 +715,7 @@ type LokiData struct {
 func (m *LokiData) Reset()      { *m = LokiData{} }
 func (*LokiData) ProtoMessage() {}
 func (*LokiData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51b9d53b40d11902, []int{7}
+	return fileDescriptor_51b9d53b40d11902, []int{9}
 }
 func (m *LokiData) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -631,7 +760,7 @@ type LokiPromResponse struct {
 func (m *LokiPromResponse) Reset()      { *m = LokiPromResponse{} }
 func (*LokiPromResponse) ProtoMessage() {}
 func (*LokiPromResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51b9d53b40d11902, []int{8}
+	return fileDescriptor_51b9d53b40d11902, []int{10}
 }
 func (m *LokiPromResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -674,6 +803,67 @@ func (m *LokiPromResponse) GetStatistics() stats.Result {
 	return stats.Result{}
 }
 
+// QuantileSketchResponse holds the quantile sketches returned by the query of a shard of a quantile_over_time query.
+type QuantileSketchResponse struct {
+	Status     string                                                                                   `protobuf:"bytes,1,opt,name=Status,proto3" json:"status"`
+	Data       []logproto.QuantileSketchSeries                                                          `protobuf:"bytes,2,rep,name=Data,proto3" json:"data,omitempty"`
+	Statistics stats.Result                                                                             `protobuf:"bytes,3,opt,name=statistics,proto3" json:"statistics"`
+	Headers    []github_com_grafana_loki_pkg_querier_queryrange_queryrangebase.PrometheusResponseHeader `protobuf:"bytes,4,rep,name=Headers,proto3,customtype=github.com/grafana/loki/pkg/querier/queryrange/queryrangebase.PrometheusResponseHeader" json:"-"`
+}
+
+func (m *QuantileSketchResponse) Reset()      { *m = QuantileSketchResponse{} }
+func (*QuantileSketchResponse) ProtoMessage() {}
+func (*QuantileSketchResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51b9d53b40d11902, []int{11}
+}
+func (m *QuantileSketchResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QuantileSketchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QuantileSketchResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QuantileSketchResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QuantileSketchResponse.Merge(m, src)
+}
+func (m *QuantileSketchResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *QuantileSketchResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_QuantileSketchResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QuantileSketchResponse proto.InternalMessageInfo
+
+func (m *QuantileSketchResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *QuantileSketchResponse) GetData() []logproto.QuantileSketchSeries {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *QuantileSketchResponse) GetStatistics() stats.Result {
+	if m != nil {
+		return m.Statistics
+	}
+	return stats.Result{}
+}
+
 func init() {
 	proto.RegisterType((*LokiRequest)(nil), "queryrange.LokiRequest")
 	proto.RegisterType((*LokiInstantRequest)(nil), "queryrange.LokiInstantRequest")
@@ -682,8 +872,11 @@ func init() {
 	proto.RegisterType((*LokiSeriesResponse)(nil), "queryrange.LokiSeriesResponse")
 	proto.RegisterType((*LokiLabelNamesRequest)(nil), "queryrange.LokiLabelNamesRequest")
 	proto.RegisterType((*LokiLabelNamesResponse)(nil), "queryrange.LokiLabelNamesResponse")
+	proto.RegisterType((*LokiIndexStatsRequest)(nil), "queryrange.LokiIndexStatsRequest")
+	proto.RegisterType((*LokiIndexStatsResponse)(nil), "queryrange.LokiIndexStatsResponse")
 	proto.RegisterType((*LokiData)(nil), "queryrange.LokiData")
 	proto.RegisterType((*LokiPromResponse)(nil), "queryrange.LokiPromResponse")
+	proto.RegisterType((*QuantileSketchResponse)(nil), "queryrange.QuantileSketchResponse")
 }
 
 func init() {
@@ -691,65 +884,71 @@ func init() {
 }
 
 var fileDescriptor_51b9d53b40d11902 = []byte{
-	// 915 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x54, 0x4f, 0x6f, 0x23, 0x35,
-	0x14, 0x8f, 0xf3, 0x7f, 0x5c, 0xb6, 0x80, 0xbb, 0xec, 0x8e, 0x8a, 0x34, 0x13, 0xe5, 0x00, 0x41,
-	0xb0, 0x13, 0xd1, 0x05, 0x0e, 0x08, 0x10, 0x3b, 0x2a, 0x88, 0x95, 0x56, 0x08, 0x79, 0x23, 0xae,
-	0xc8, 0x69, 0xdc, 0x64, 0xd4, 0x99, 0xf1, 0xd4, 0x76, 0x56, 0xea, 0x8d, 0x2f, 0x80, 0xb4, 0x9f,
-	0x01, 0x90, 0x40, 0x7c, 0x0a, 0x8e, 0x3d, 0xf6, 0xb8, 0x5a, 0x89, 0x81, 0xa6, 0x17, 0xc8, 0x69,
-	0x3f, 0x02, 0xb2, 0x3d, 0x93, 0x38, 0x4b, 0x4b, 0x9b, 0xed, 0x05, 0x71, 0x49, 0xfc, 0xec, 0xf7,
-	0xf3, 0xf8, 0xf7, 0x7b, 0xbf, 0xf7, 0xe0, 0x9b, 0xd9, 0xc1, 0xb8, 0x7f, 0x38, 0xa5, 0x3c, 0xa2,
-	0x5c, 0xff, 0x1f, 0x71, 0x92, 0x8e, 0xa9, 0xb5, 0x0c, 0x32, 0xce, 0x24, 0x43, 0x70, 0xb9, 0xb3,
-	0x7d, 0x67, 0x1c, 0xc9, 0xc9, 0x74, 0x18, 0xec, 0xb1, 0xa4, 0x3f, 0x66, 0x63, 0xd6, 0xd7, 0x29,
-	0xc3, 0xe9, 0xbe, 0x8e, 0x74, 0xa0, 0x57, 0x06, 0xba, 0xed, 0x8f, 0x19, 0x1b, 0xc7, 0x74, 0x99,
-	0x25, 0xa3, 0x84, 0x0a, 0x49, 0x92, 0xac, 0x48, 0x78, 0x5d, 0x3d, 0x22, 0x66, 0x63, 0x83, 0x2c,
-	0x17, 0xc5, 0x61, 0xa7, 0x38, 0x3c, 0x8c, 0x13, 0x36, 0xa2, 0x71, 0x5f, 0x48, 0x22, 0x85, 0xf9,
-	0x2d, 0x32, 0x3e, 0xb8, 0x94, 0xc3, 0x90, 0x88, 0x7f, 0x52, 0xea, 0x9e, 0x54, 0xe1, 0xc6, 0x03,
-	0x76, 0x10, 0x61, 0x7a, 0x38, 0xa5, 0x42, 0xa2, 0x9b, 0xb0, 0xa1, 0x73, 0x5c, 0xd0, 0x01, 0x3d,
-	0x07, 0x9b, 0x40, 0xed, 0xc6, 0x51, 0x12, 0x49, 0xb7, 0xda, 0x01, 0xbd, 0x1b, 0xd8, 0x04, 0x08,
-	0xc1, 0xba, 0x90, 0x34, 0x73, 0x6b, 0x1d, 0xd0, 0xab, 0x61, 0xbd, 0x46, 0xdb, 0xb0, 0x1d, 0xa5,
-	0x92, 0xf2, 0x47, 0x24, 0x76, 0x1d, 0xbd, 0xbf, 0x88, 0xd1, 0x27, 0xb0, 0x25, 0x24, 0xe1, 0x72,
-	0x20, 0xdc, 0x7a, 0x07, 0xf4, 0x36, 0x76, 0xb6, 0x03, 0xa3, 0x4a, 0x50, 0xaa, 0x12, 0x0c, 0x4a,
-	0x55, 0xc2, 0xf6, 0x71, 0xee, 0x57, 0x1e, 0xff, 0xee, 0x03, 0x5c, 0x82, 0xd0, 0x87, 0xb0, 0x41,
-	0xd3, 0xd1, 0x40, 0xb8, 0x8d, 0x35, 0xd0, 0x06, 0x82, 0xde, 0x85, 0xce, 0x28, 0xe2, 0x74, 0x4f,
-	0x46, 0x2c, 0x75, 0x9b, 0x1d, 0xd0, 0xdb, 0xdc, 0xd9, 0x0a, 0x16, 0x2a, 0xef, 0x96, 0x47, 0x78,
-	0x99, 0xa5, 0xe8, 0x65, 0x44, 0x4e, 0xdc, 0x96, 0x56, 0x42, 0xaf, 0x51, 0x17, 0x36, 0xc5, 0x84,
-	0xf0, 0x91, 0x70, 0xdb, 0x9d, 0x5a, 0xcf, 0x09, 0xe1, 0x3c, 0xf7, 0x8b, 0x1d, 0x5c, 0xfc, 0x77,
-	0xff, 0x02, 0x10, 0x29, 0x49, 0xef, 0xa7, 0x42, 0x92, 0x54, 0xbe, 0x88, 0xb2, 0x1f, 0xc1, 0xa6,
-	0xf2, 0xc7, 0x40, 0x68, 0x6d, 0xaf, 0x4a, 0xb5, 0xc0, 0xac, 0x72, 0xad, 0xaf, 0xc5, 0xb5, 0x71,
-	0x2e, 0xd7, 0xe6, 0x85, 0x5c, 0xbf, 0xaf, 0xc3, 0x97, 0x8c, 0x7d, 0x44, 0xc6, 0x52, 0x41, 0x15,
-	0xe8, 0xa1, 0x24, 0x72, 0x2a, 0x0c, 0xcd, 0x02, 0xa4, 0x77, 0x70, 0x71, 0x82, 0x3e, 0x85, 0xf5,
-	0x5d, 0x22, 0x89, 0xa6, 0xbc, 0xb1, 0x73, 0x33, 0xb0, 0x4c, 0xa9, 0xee, 0x52, 0x67, 0xe1, 0x2d,
-	0xc5, 0x6a, 0x9e, 0xfb, 0x9b, 0x23, 0x22, 0xc9, 0x3b, 0x2c, 0x89, 0x24, 0x4d, 0x32, 0x79, 0x84,
-	0x35, 0x12, 0xbd, 0x0f, 0x9d, 0xcf, 0x38, 0x67, 0x7c, 0x70, 0x94, 0x51, 0x2d, 0x91, 0x13, 0xde,
-	0x9e, 0xe7, 0xfe, 0x16, 0x2d, 0x37, 0x2d, 0xc4, 0x32, 0x13, 0xbd, 0x05, 0x1b, 0x3a, 0xd0, 0xa2,
-	0x38, 0xe1, 0xd6, 0x3c, 0xf7, 0x5f, 0xd6, 0x10, 0x2b, 0xdd, 0x64, 0xac, 0x6a, 0xd8, 0xb8, 0x92,
-	0x86, 0x8b, 0x52, 0x36, 0xed, 0x52, 0xba, 0xb0, 0xf5, 0x88, 0x72, 0xa1, 0xae, 0x69, 0xe9, 0xfd,
-	0x32, 0x44, 0xf7, 0x20, 0x54, 0xc2, 0x44, 0x42, 0x46, 0x7b, 0xca, 0x4f, 0x4a, 0x8c, 0x1b, 0x81,
-	0x69, 0x6a, 0x4c, 0xc5, 0x34, 0x96, 0x21, 0x2a, 0x54, 0xb0, 0x12, 0xb1, 0xb5, 0x46, 0x3f, 0x00,
-	0xd8, 0xfa, 0x82, 0x92, 0x11, 0xe5, 0xc2, 0x75, 0x3a, 0xb5, 0xde, 0xc6, 0x4e, 0x2f, 0x58, 0xed,
-	0xf8, 0xe0, 0x2b, 0xce, 0x12, 0x2a, 0x27, 0x74, 0x2a, 0xca, 0x1a, 0x19, 0x40, 0xf8, 0xcd, 0xd3,
-	0xdc, 0xff, 0xda, 0x1e, 0x62, 0x9c, 0xec, 0x93, 0x94, 0xf4, 0x63, 0x76, 0x10, 0xf5, 0xaf, 0x34,
-	0x4d, 0x2e, 0xbc, 0x7b, 0x9e, 0xfb, 0xe0, 0x0e, 0x2e, 0x5f, 0xd6, 0xfd, 0x0d, 0xc0, 0x57, 0x55,
-	0x61, 0x1f, 0xaa, 0xfb, 0x84, 0xd5, 0x0f, 0x09, 0x91, 0x7b, 0x13, 0x17, 0x28, 0x77, 0x61, 0x13,
-	0xd8, 0x33, 0xa2, 0x7a, 0xad, 0x19, 0x51, 0x5b, 0x7f, 0x46, 0x94, 0x4d, 0x50, 0x3f, 0xb7, 0x09,
-	0x1a, 0x17, 0x36, 0xc1, 0xaf, 0x55, 0xd3, 0xf0, 0x25, 0xbf, 0x35, 0x5a, 0xe1, 0xf3, 0x45, 0x2b,
-	0xd4, 0xf4, 0x6b, 0x17, 0x0e, 0x33, 0x77, 0xdd, 0x1f, 0xd1, 0x54, 0x46, 0xfb, 0x11, 0xe5, 0x97,
-	0x34, 0x84, 0xe5, 0xb2, 0xda, 0xaa, 0xcb, 0x6c, 0x8b, 0xd4, 0xff, 0xb3, 0x16, 0xf9, 0x09, 0xc0,
-	0xd7, 0x94, 0x84, 0x0f, 0xc8, 0x90, 0xc6, 0x5f, 0x92, 0x64, 0x69, 0x13, 0xcb, 0x10, 0xe0, 0x5a,
-	0x86, 0xa8, 0xbe, 0xb8, 0x21, 0x6a, 0x4b, 0x43, 0x74, 0x7f, 0xac, 0xc2, 0x5b, 0xcf, 0xbf, 0x74,
-	0x8d, 0x82, 0xbf, 0x61, 0x15, 0xdc, 0x09, 0xd1, 0xff, 0xb6, 0xa0, 0xbf, 0x00, 0xd8, 0x2e, 0x87,
-	0x39, 0x0a, 0x20, 0x34, 0x03, 0x4d, 0xcf, 0x6b, 0x23, 0xce, 0xa6, 0x1a, 0x6b, 0x7c, 0xb1, 0x8b,
-	0xad, 0x0c, 0x94, 0xc2, 0xa6, 0x89, 0x8a, 0xbe, 0xb8, 0x6d, 0xf5, 0x85, 0xe4, 0x94, 0x24, 0xf7,
-	0x46, 0x24, 0x93, 0x94, 0x87, 0x1f, 0xab, 0x8a, 0x3d, 0xcd, 0xfd, 0xb7, 0xff, 0x8d, 0xd3, 0x73,
-	0x58, 0x55, 0x14, 0xf3, 0x5d, 0x5c, 0x7c, 0xa5, 0xfb, 0x1d, 0x80, 0xaf, 0xa8, 0xc7, 0x2a, 0x6e,
-	0x8b, 0x6a, 0xee, 0xc2, 0x36, 0x2f, 0xd6, 0x85, 0xf3, 0xba, 0x97, 0xeb, 0x1c, 0xd6, 0x8f, 0x73,
-	0x1f, 0xe0, 0x05, 0x12, 0xdd, 0x5d, 0x19, 0xf2, 0xd5, 0xf3, 0x86, 0xbc, 0x82, 0x54, 0xec, 0xb1,
-	0x1e, 0xbe, 0x77, 0x72, 0xea, 0x55, 0x9e, 0x9c, 0x7a, 0x95, 0x67, 0xa7, 0x1e, 0xf8, 0x76, 0xe6,
-	0x81, 0x9f, 0x67, 0x1e, 0x38, 0x9e, 0x79, 0xe0, 0x64, 0xe6, 0x81, 0x3f, 0x66, 0x1e, 0xf8, 0x73,
-	0xe6, 0x55, 0x9e, 0xcd, 0x3c, 0xf0, 0xf8, 0xcc, 0xab, 0x9c, 0x9c, 0x79, 0x95, 0x27, 0x67, 0x5e,
-	0x65, 0xd8, 0xd4, 0x2c, 0xef, 0xfe, 0x1d, 0x00, 0x00, 0xff, 0xff, 0xa6, 0x19, 0xca, 0xfc, 0xcf,
-	0x0a, 0x00, 0x00,
+	// 1022 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x56, 0x4f, 0x6f, 0x1b, 0x45,
+	0x14, 0xf7, 0x78, 0xfd, 0x77, 0x42, 0x03, 0x4c, 0x4a, 0xba, 0x0a, 0x68, 0xd7, 0xda, 0x43, 0x31,
+	0x82, 0xae, 0x45, 0x0a, 0x1c, 0x10, 0x54, 0x74, 0x15, 0x10, 0x41, 0x15, 0x82, 0x8d, 0xc5, 0x15,
+	0x8d, 0xb3, 0x13, 0x7b, 0x95, 0xfd, 0x97, 0x99, 0x71, 0x45, 0x6e, 0x7c, 0x01, 0xa4, 0xf2, 0x15,
+	0x00, 0x09, 0xc4, 0x77, 0x40, 0xe2, 0x98, 0x63, 0x8e, 0x55, 0x25, 0x16, 0xe2, 0x5c, 0x90, 0x4f,
+	0x3d, 0x72, 0x42, 0x68, 0x66, 0x76, 0xed, 0xdd, 0x24, 0x6d, 0xe2, 0xe6, 0x12, 0x7a, 0x89, 0xe7,
+	0xbd, 0x79, 0x6f, 0xf6, 0xbd, 0xdf, 0xfb, 0xbd, 0xf7, 0x02, 0x5f, 0x4f, 0x76, 0x87, 0xbd, 0xbd,
+	0x31, 0xa1, 0x3e, 0xa1, 0xf2, 0x77, 0x9f, 0xe2, 0x68, 0x48, 0x0a, 0x47, 0x3b, 0xa1, 0x31, 0x8f,
+	0x11, 0x9c, 0x6b, 0xd6, 0x6e, 0x0d, 0x7d, 0x3e, 0x1a, 0x0f, 0xec, 0xed, 0x38, 0xec, 0x0d, 0xe3,
+	0x61, 0xdc, 0x93, 0x26, 0x83, 0xf1, 0x8e, 0x94, 0xa4, 0x20, 0x4f, 0xca, 0x75, 0xcd, 0x1c, 0xc6,
+	0xf1, 0x30, 0x20, 0x73, 0x2b, 0xee, 0x87, 0x84, 0x71, 0x1c, 0x26, 0x99, 0xc1, 0xab, 0x22, 0x88,
+	0x20, 0x1e, 0x2a, 0xcf, 0xfc, 0x90, 0x5d, 0x76, 0xb2, 0xcb, 0xbd, 0x20, 0x8c, 0x3d, 0x12, 0xf4,
+	0x18, 0xc7, 0x9c, 0xa9, 0xbf, 0x99, 0xc5, 0x7b, 0xe7, 0xe6, 0x30, 0xc0, 0xec, 0x74, 0x4a, 0xd6,
+	0x3f, 0x55, 0xb8, 0x74, 0x2f, 0xde, 0xf5, 0x5d, 0xb2, 0x37, 0x26, 0x8c, 0xa3, 0xeb, 0xb0, 0x2e,
+	0x6d, 0x74, 0xd0, 0x01, 0xdd, 0xb6, 0xab, 0x04, 0xa1, 0x0d, 0xfc, 0xd0, 0xe7, 0x7a, 0xb5, 0x03,
+	0xba, 0xd7, 0x5c, 0x25, 0x20, 0x04, 0x6b, 0x8c, 0x93, 0x44, 0xd7, 0x3a, 0xa0, 0xab, 0xb9, 0xf2,
+	0x8c, 0xd6, 0x60, 0xcb, 0x8f, 0x38, 0xa1, 0xf7, 0x71, 0xa0, 0xb7, 0xa5, 0x7e, 0x26, 0xa3, 0x3b,
+	0xb0, 0xc9, 0x38, 0xa6, 0xbc, 0xcf, 0xf4, 0x5a, 0x07, 0x74, 0x97, 0xd6, 0xd7, 0x6c, 0x85, 0x8a,
+	0x9d, 0xa3, 0x62, 0xf7, 0x73, 0x54, 0x9c, 0xd6, 0x41, 0x6a, 0x56, 0x1e, 0xfc, 0x69, 0x02, 0x37,
+	0x77, 0x42, 0xef, 0xc3, 0x3a, 0x89, 0xbc, 0x3e, 0xd3, 0xeb, 0x0b, 0x78, 0x2b, 0x17, 0xf4, 0x36,
+	0x6c, 0x7b, 0x3e, 0x25, 0xdb, 0xdc, 0x8f, 0x23, 0xbd, 0xd1, 0x01, 0xdd, 0xe5, 0xf5, 0x15, 0x7b,
+	0x86, 0xf2, 0x46, 0x7e, 0xe5, 0xce, 0xad, 0x44, 0x7a, 0x09, 0xe6, 0x23, 0xbd, 0x29, 0x91, 0x90,
+	0x67, 0x64, 0xc1, 0x06, 0x1b, 0x61, 0xea, 0x31, 0xbd, 0xd5, 0xd1, 0xba, 0x6d, 0x07, 0x4e, 0x53,
+	0x33, 0xd3, 0xb8, 0xd9, 0x2f, 0xba, 0x09, 0x97, 0xf7, 0xc6, 0x38, 0xe2, 0x7e, 0x40, 0xb6, 0x76,
+	0x09, 0xdf, 0x1e, 0xe9, 0xb0, 0x03, 0xba, 0x2d, 0xf7, 0x84, 0xd6, 0xfa, 0xbe, 0x0a, 0x91, 0x80,
+	0x7e, 0x33, 0x62, 0x1c, 0x47, 0xfc, 0x59, 0x2a, 0xf0, 0x01, 0x6c, 0x08, 0x1e, 0xf5, 0x99, 0xae,
+	0x2d, 0x00, 0x49, 0xe6, 0x53, 0xc6, 0xa4, 0xb6, 0x10, 0x26, 0xf5, 0x33, 0x31, 0x69, 0x2c, 0x80,
+	0x49, 0xf3, 0x4c, 0x4c, 0x7e, 0xa8, 0xc1, 0x17, 0x14, 0x1d, 0x59, 0x12, 0x47, 0x8c, 0x88, 0xc7,
+	0xb7, 0x38, 0xe6, 0x63, 0xa6, 0xe0, 0xc8, 0x1e, 0x97, 0x1a, 0x37, 0xbb, 0x41, 0x1f, 0xc1, 0xda,
+	0x06, 0xe6, 0x58, 0x42, 0xb3, 0xb4, 0x7e, 0xdd, 0x2e, 0x90, 0x5c, 0xbc, 0x25, 0xee, 0x9c, 0x55,
+	0x91, 0xfd, 0x34, 0x35, 0x97, 0x3d, 0xcc, 0xf1, 0x5b, 0x71, 0xe8, 0x73, 0x12, 0x26, 0x7c, 0xdf,
+	0x95, 0x9e, 0xe8, 0x5d, 0xd8, 0xfe, 0x98, 0xd2, 0x98, 0xf6, 0xf7, 0x13, 0x22, 0xa1, 0x6c, 0x3b,
+	0x37, 0xa6, 0xa9, 0xb9, 0x42, 0x72, 0x65, 0xc1, 0x63, 0x6e, 0x89, 0xde, 0x80, 0x75, 0x29, 0x48,
+	0xf0, 0xda, 0xce, 0xca, 0x34, 0x35, 0x5f, 0x94, 0x2e, 0x05, 0x73, 0x65, 0x51, 0xc6, 0xba, 0x7e,
+	0x21, 0xac, 0x67, 0x25, 0x6f, 0x14, 0x4b, 0xae, 0xc3, 0xe6, 0x7d, 0x42, 0x99, 0x78, 0xa6, 0x29,
+	0xf5, 0xb9, 0x88, 0xee, 0x42, 0x28, 0x80, 0xf1, 0x19, 0xf7, 0xb7, 0x05, 0x3f, 0x05, 0x18, 0xd7,
+	0x6c, 0x35, 0x24, 0x5c, 0xc2, 0xc6, 0x01, 0x77, 0x50, 0x86, 0x42, 0xc1, 0xd0, 0x2d, 0x9c, 0xd1,
+	0x8f, 0x00, 0x36, 0x3f, 0x25, 0xd8, 0x23, 0x94, 0xe9, 0xed, 0x8e, 0xd6, 0x5d, 0x5a, 0xef, 0xda,
+	0xe5, 0x09, 0x62, 0x7f, 0x41, 0xe3, 0x90, 0xf0, 0x11, 0x19, 0xb3, 0xbc, 0x46, 0xca, 0xc1, 0xf9,
+	0xfa, 0x51, 0x6a, 0x7e, 0x55, 0x1c, 0x8a, 0x14, 0xef, 0xe0, 0x08, 0xf7, 0x82, 0x78, 0xd7, 0xef,
+	0x5d, 0x68, 0x3a, 0x3d, 0xf1, 0xed, 0x69, 0x6a, 0x82, 0x5b, 0x6e, 0x1e, 0x99, 0xf5, 0x07, 0x80,
+	0x2f, 0x8b, 0xc2, 0x6e, 0x89, 0xf7, 0x58, 0xa1, 0x6f, 0x42, 0x2c, 0x98, 0x05, 0x04, 0x0b, 0x5d,
+	0x25, 0x14, 0x67, 0x4e, 0xf5, 0x52, 0x33, 0x47, 0x5b, 0x7c, 0xe6, 0xe4, 0xcd, 0x52, 0x3b, 0xb3,
+	0x59, 0xea, 0x4f, 0x6a, 0x16, 0xeb, 0xf7, 0x6c, 0x30, 0xe4, 0xf9, 0x2d, 0xd0, 0x0a, 0x9f, 0xcc,
+	0x5a, 0x41, 0x93, 0xd1, 0xce, 0x18, 0xa6, 0xde, 0xda, 0xf4, 0x48, 0xc4, 0xfd, 0x1d, 0x9f, 0xd0,
+	0x73, 0x1a, 0xa2, 0xc0, 0x32, 0xad, 0xcc, 0xb2, 0x22, 0x45, 0x6a, 0x57, 0x96, 0x22, 0x3f, 0x03,
+	0xf8, 0x8a, 0x80, 0xf0, 0x1e, 0x1e, 0x90, 0xe0, 0x73, 0x1c, 0xce, 0x69, 0x52, 0x20, 0x04, 0xb8,
+	0x14, 0x21, 0xaa, 0xcf, 0x4e, 0x08, 0x6d, 0x4e, 0x08, 0xeb, 0xa7, 0x2a, 0x5c, 0x3d, 0x19, 0xe9,
+	0x02, 0x05, 0xbf, 0x59, 0x28, 0x78, 0xdb, 0x41, 0xcf, 0x6d, 0x41, 0x7f, 0xcb, 0x0a, 0xba, 0x19,
+	0x79, 0xe4, 0x1b, 0x91, 0x3b, 0x7b, 0xfa, 0xbe, 0xbc, 0x62, 0x7d, 0x6f, 0xfd, 0x0b, 0xe0, 0xea,
+	0xc9, 0xf8, 0xb3, 0x32, 0xdf, 0x81, 0x2d, 0x9a, 0x9d, 0x33, 0x4a, 0xbe, 0x36, 0xef, 0xdb, 0xd3,
+	0xf6, 0x4e, 0xed, 0x20, 0x35, 0x81, 0x3b, 0xf3, 0x29, 0x15, 0xb0, 0x7a, 0x65, 0x0b, 0xf8, 0x2b,
+	0x80, 0xad, 0x7c, 0x1b, 0x23, 0x1b, 0x42, 0xb5, 0x91, 0xe4, 0xc2, 0x55, 0xec, 0x5e, 0x16, 0x7b,
+	0x89, 0xce, 0xb4, 0x6e, 0xc1, 0x02, 0x45, 0xb0, 0xa1, 0xa4, 0x2c, 0xc1, 0x1b, 0x85, 0xc1, 0xc6,
+	0x29, 0xc1, 0xe1, 0x5d, 0x0f, 0x27, 0x9c, 0x50, 0xe7, 0x43, 0x51, 0x8b, 0x47, 0xa9, 0xf9, 0xe6,
+	0xd3, 0x72, 0x3a, 0xe1, 0x2b, 0xba, 0x4a, 0x7d, 0xd7, 0xcd, 0xbe, 0x62, 0x7d, 0x07, 0xe0, 0x4b,
+	0x22, 0x58, 0x91, 0xdb, 0xac, 0x4e, 0x1b, 0xa7, 0xea, 0x64, 0x9d, 0x8f, 0xf3, 0xa9, 0x6a, 0xdd,
+	0x2e, 0x6d, 0xe9, 0xea, 0x59, 0x5b, 0x5a, 0xb8, 0x54, 0x8a, 0x7b, 0xd9, 0x3a, 0xae, 0xc2, 0xd5,
+	0x2f, 0x4b, 0xff, 0x29, 0x2d, 0x34, 0x24, 0x3e, 0x2b, 0x6d, 0x05, 0x63, 0x0e, 0x5e, 0xf9, 0x4d,
+	0xb5, 0x23, 0xce, 0xd9, 0x0c, 0xe5, 0xf8, 0xb5, 0x0b, 0xc5, 0xff, 0xff, 0x98, 0x31, 0xce, 0x3b,
+	0x87, 0x47, 0x46, 0xe5, 0xe1, 0x91, 0x51, 0x79, 0x7c, 0x64, 0x80, 0x6f, 0x27, 0x06, 0xf8, 0x65,
+	0x62, 0x80, 0x83, 0x89, 0x01, 0x0e, 0x27, 0x06, 0xf8, 0x6b, 0x62, 0x80, 0xbf, 0x27, 0x46, 0xe5,
+	0xf1, 0xc4, 0x00, 0x0f, 0x8e, 0x8d, 0xca, 0xe1, 0xb1, 0x51, 0x79, 0x78, 0x6c, 0x54, 0x06, 0x0d,
+	0x09, 0xe5, 0xed, 0xff, 0x06, 0x00, 0xe2, 0xda, 0x59, 0x2c, 0x46, 0x0e, 0x00, 0x00,
 }
 
 func (this *LokiRequest) Equal(that interface{}) bool {
@@ -803,6 +1002,9 @@ func (this *LokiRequest) Equal(that interface{}) bool {
 			return false
 		}
 	}
+	if this.QuantileSketch != that1.QuantileSketch {
+		return false
+	}
 	return true
 }
 func (this *LokiInstantRequest) Equal(that interface{}) bool {
@@ -847,6 +1049,9 @@ func (this *LokiInstantRequest) Equal(that interface{}) bool {
 			return false
 		}
 	}
+	if this.QuantileSketch != that1.QuantileSketch {
+		return false
+	}
 	return true
 }
 func (this *LokiResponse) Equal(that interface{}) bool {
@@ -1064,6 +1269,71 @@ func (this *LokiLabelNamesResponse) Equal(that interface{}) bool {
 	}
 	return true
 }
+func (this *LokiIndexStatsRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*LokiIndexStatsRequest)
+	if !ok {
+		that2, ok := that.(LokiIndexStatsRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Query != that1.Query {
+		return false
+	}
+	if !this.StartTs.Equal(that1.StartTs) {
+		return false
+	}
+	if !this.EndTs.Equal(that1.EndTs) {
+		return false
+	}
+	if this.Path != that1.Path {
+		return false
+	}
+	return true
+}
+func (this *LokiIndexStatsResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*LokiIndexStatsResponse)
+	if !ok {
+		that2, ok := that.(LokiIndexStatsResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.Response.Equal(that1.Response) {
+		return false
+	}
+	if len(this.Headers) != len(that1.Headers) {
+		return false
+	}
+	for i := range this.Headers {
+		if !this.Headers[i].Equal(that1.Headers[i]) {
+			return false
+		}
+	}
+	return true
+}
 func (this *LokiData) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
@@ -1123,11 +1393,54 @@ func (this *LokiPromResponse) Equal(that interface{}) bool {
 	}
 	return true
 }
+func (this *QuantileSketchResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*QuantileSketchResponse)
+	if !ok {
+		that2, ok := that.(QuantileSketchResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Status != that1.Status {
+		return false
+	}
+	if len(this.Data) != len(that1.Data) {
+		return false
+	}
+	for i := range this.Data {
+		if !this.Data[i].Equal(&that1.Data[i]) {
+			return false
+		}
+	}
+	if !this.Statistics.Equal(&that1.Statistics) {
+		return false
+	}
+	if len(this.Headers) != len(that1.Headers) {
+		return false
+	}
+	for i := range this.Headers {
+		if !this.Headers[i].Equal(that1.Headers[i]) {
+			return false
+		}
+	}
+	return true
+}
 func (this *LokiRequest) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 13)
+	s := make([]string, 0, 14)
 	s = append(s, "&queryrange.LokiRequest{")
 	s = append(s, "Query: "+fmt.Sprintf("%#v", this.Query)+",\n")
 	s = append(s, "Limit: "+fmt.Sprintf("%#v", this.Limit)+",\n")
@@ -1138,6 +1451,7 @@ func (this *LokiRequest) GoString() string {
 	s = append(s, "Direction: "+fmt.Sprintf("%#v", this.Direction)+",\n")
 	s = append(s, "Path: "+fmt.Sprintf("%#v", this.Path)+",\n")
 	s = append(s, "Shards: "+fmt.Sprintf("%#v", this.Shards)+",\n")
+	s = append(s, "QuantileSketch: "+fmt.Sprintf("%#v", this.QuantileSketch)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -1145,7 +1459,7 @@ func (this *LokiInstantRequest) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 10)
+	s := make([]string, 0, 11)
 	s = append(s, "&queryrange.LokiInstantRequest{")
 	s = append(s, "Query: "+fmt.Sprintf("%#v", this.Query)+",\n")
 	s = append(s, "Limit: "+fmt.Sprintf("%#v", this.Limit)+",\n")
@@ -1153,6 +1467,7 @@ func (this *LokiInstantRequest) GoString() string {
 	s = append(s, "Direction: "+fmt.Sprintf("%#v", this.Direction)+",\n")
 	s = append(s, "Path: "+fmt.Sprintf("%#v", this.Path)+",\n")
 	s = append(s, "Shards: "+fmt.Sprintf("%#v", this.Shards)+",\n")
+	s = append(s, "QuantileSketch: "+fmt.Sprintf("%#v", this.QuantileSketch)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -1196,9 +1511,9 @@ func (this *LokiSeriesResponse) GoString() string {
 	s = append(s, "&queryrange.LokiSeriesResponse{")
 	s = append(s, "Status: "+fmt.Sprintf("%#v", this.Status)+",\n")
 	if this.Data != nil {
-		vs := make([]*logproto.SeriesIdentifier, len(this.Data))
+		vs := make([]logproto.SeriesIdentifier, len(this.Data))
 		for i := range vs {
-			vs[i] = &this.Data[i]
+			vs[i] = this.Data[i]
 		}
 		s = append(s, "Data: "+fmt.Sprintf("%#v", vs)+",\n")
 	}
@@ -1232,6 +1547,32 @@ func (this *LokiLabelNamesResponse) GoString() string {
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
+func (this *LokiIndexStatsRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 8)
+	s = append(s, "&queryrange.LokiIndexStatsRequest{")
+	s = append(s, "Query: "+fmt.Sprintf("%#v", this.Query)+",\n")
+	s = append(s, "StartTs: "+fmt.Sprintf("%#v", this.StartTs)+",\n")
+	s = append(s, "EndTs: "+fmt.Sprintf("%#v", this.EndTs)+",\n")
+	s = append(s, "Path: "+fmt.Sprintf("%#v", this.Path)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *LokiIndexStatsResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&queryrange.LokiIndexStatsResponse{")
+	if this.Response != nil {
+		s = append(s, "Response: "+fmt.Sprintf("%#v", this.Response)+",\n")
+	}
+	s = append(s, "Headers: "+fmt.Sprintf("%#v", this.Headers)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
 func (this *LokiData) GoString() string {
 	if this == nil {
 		return "nil"
@@ -1256,9 +1597,28 @@ func (this *LokiPromResponse) GoString() string {
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
-func valueToGoStringQueryrange(v interface{}, typ string) string {
-	rv := reflect.ValueOf(v)
-	if rv.IsNil() {
+func (this *QuantileSketchResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 8)
+	s = append(s, "&queryrange.QuantileSketchResponse{")
+	s = append(s, "Status: "+fmt.Sprintf("%#v", this.Status)+",\n")
+	if this.Data != nil {
+		vs := make([]logproto.QuantileSketchSeries, len(this.Data))
+		for i := range vs {
+			vs[i] = this.Data[i]
+		}
+		s = append(s, "Data: "+fmt.Sprintf("%#v", vs)+",\n")
+	}
+	s = append(s, "Statistics: "+strings.Replace(this.Statistics.GoString(), `&`, ``, 1)+",\n")
+	s = append(s, "Headers: "+fmt.Sprintf("%#v", this.Headers)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func valueToGoStringQueryrange(v interface{}, typ string) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
 		return "nil"
 	}
 	pv := reflect.Indirect(rv).Interface()
@@ -1284,6 +1644,16 @@ func (m *LokiRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.QuantileSketch {
+		i--
+		if m.QuantileSketch {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x50
+	}
 	if m.Interval != 0 {
 		i = encodeVarintQueryrange(dAtA, i, uint64(m.Interval))
 		i--
@@ -1366,6 +1736,16 @@ func (m *LokiInstantRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.QuantileSketch {
+		i--
+		if m.QuantileSketch {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x38
+	}
 	if len(m.Shards) > 0 {
 		for iNdEx := len(m.Shards) - 1; iNdEx >= 0; iNdEx-- {
 			i -= len(m.Shards[iNdEx])
@@ -1734,6 +2114,108 @@ func (m *LokiLabelNamesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error)
 	return len(dAtA) - i, nil
 }
 
+func (m *LokiIndexStatsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LokiIndexStatsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LokiIndexStatsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Path) > 0 {
+		i -= len(m.Path)
+		copy(dAtA[i:], m.Path)
+		i = encodeVarintQueryrange(dAtA, i, uint64(len(m.Path)))
+		i--
+		dAtA[i] = 0x22
+	}
+	n10, err10 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.EndTs, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.EndTs):])
+	if err10 != nil {
+		return 0, err10
+	}
+	i -= n10
+	i = encodeVarintQueryrange(dAtA, i, uint64(n10))
+	i--
+	dAtA[i] = 0x1a
+	n11, err11 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.StartTs, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.StartTs):])
+	if err11 != nil {
+		return 0, err11
+	}
+	i -= n11
+	i = encodeVarintQueryrange(dAtA, i, uint64(n11))
+	i--
+	dAtA[i] = 0x12
+	if len(m.Query) > 0 {
+		i -= len(m.Query)
+		copy(dAtA[i:], m.Query)
+		i = encodeVarintQueryrange(dAtA, i, uint64(len(m.Query)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LokiIndexStatsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LokiIndexStatsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LokiIndexStatsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Headers) > 0 {
+		for iNdEx := len(m.Headers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size := m.Headers[iNdEx].Size()
+				i -= size
+				if _, err := m.Headers[iNdEx].MarshalTo(dAtA[i:]); err != nil {
+					return 0, err
+				}
+				i = encodeVarintQueryrange(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Response != nil {
+		{
+			size, err := m.Response.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintQueryrange(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *LokiData) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1823,6 +2305,74 @@ func (m *LokiPromResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *QuantileSketchResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QuantileSketchResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QuantileSketchResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Headers) > 0 {
+		for iNdEx := len(m.Headers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size := m.Headers[iNdEx].Size()
+				i -= size
+				if _, err := m.Headers[iNdEx].MarshalTo(dAtA[i:]); err != nil {
+					return 0, err
+				}
+				i = encodeVarintQueryrange(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	{
+		size, err := m.Statistics.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintQueryrange(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x1a
+	if len(m.Data) > 0 {
+		for iNdEx := len(m.Data) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Data[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintQueryrange(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Status) > 0 {
+		i -= len(m.Status)
+		copy(dAtA[i:], m.Status)
+		i = encodeVarintQueryrange(dAtA, i, uint64(len(m.Status)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintQueryrange(dAtA []byte, offset int, v uint64) int {
 	offset -= sovQueryrange(v)
 	base := offset
@@ -1870,6 +2420,9 @@ func (m *LokiRequest) Size() (n int) {
 	if m.Interval != 0 {
 		n += 1 + sovQueryrange(uint64(m.Interval))
 	}
+	if m.QuantileSketch {
+		n += 2
+	}
 	return n
 }
 
@@ -1901,6 +2454,9 @@ func (m *LokiInstantRequest) Size() (n int) {
 			n += 1 + l + sovQueryrange(uint64(l))
 		}
 	}
+	if m.QuantileSketch {
+		n += 2
+	}
 	return n
 }
 
@@ -2046,6 +2602,46 @@ func (m *LokiLabelNamesResponse) Size() (n int) {
 	return n
 }
 
+func (m *LokiIndexStatsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Query)
+	if l > 0 {
+		n += 1 + l + sovQueryrange(uint64(l))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.StartTs)
+	n += 1 + l + sovQueryrange(uint64(l))
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.EndTs)
+	n += 1 + l + sovQueryrange(uint64(l))
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovQueryrange(uint64(l))
+	}
+	return n
+}
+
+func (m *LokiIndexStatsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Response != nil {
+		l = m.Response.Size()
+		n += 1 + l + sovQueryrange(uint64(l))
+	}
+	if len(m.Headers) > 0 {
+		for _, e := range m.Headers {
+			l = e.Size()
+			n += 1 + l + sovQueryrange(uint64(l))
+		}
+	}
+	return n
+}
+
 func (m *LokiData) Size() (n int) {
 	if m == nil {
 		return 0
@@ -2080,6 +2676,33 @@ func (m *LokiPromResponse) Size() (n int) {
 	return n
 }
 
+func (m *QuantileSketchResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Status)
+	if l > 0 {
+		n += 1 + l + sovQueryrange(uint64(l))
+	}
+	if len(m.Data) > 0 {
+		for _, e := range m.Data {
+			l = e.Size()
+			n += 1 + l + sovQueryrange(uint64(l))
+		}
+	}
+	l = m.Statistics.Size()
+	n += 1 + l + sovQueryrange(uint64(l))
+	if len(m.Headers) > 0 {
+		for _, e := range m.Headers {
+			l = e.Size()
+			n += 1 + l + sovQueryrange(uint64(l))
+		}
+	}
+	return n
+}
+
 func sovQueryrange(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -2100,6 +2723,7 @@ func (this *LokiRequest) String() string {
 		`Path:` + fmt.Sprintf("%v", this.Path) + `,`,
 		`Shards:` + fmt.Sprintf("%v", this.Shards) + `,`,
 		`Interval:` + fmt.Sprintf("%v", this.Interval) + `,`,
+		`QuantileSketch:` + fmt.Sprintf("%v", this.QuantileSketch) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -2115,6 +2739,7 @@ func (this *LokiInstantRequest) String() string {
 		`Direction:` + fmt.Sprintf("%v", this.Direction) + `,`,
 		`Path:` + fmt.Sprintf("%v", this.Path) + `,`,
 		`Shards:` + fmt.Sprintf("%v", this.Shards) + `,`,
+		`QuantileSketch:` + fmt.Sprintf("%v", this.QuantileSketch) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -2194,28 +2819,70 @@ func (this *LokiLabelNamesResponse) String() string {
 	}, "")
 	return s
 }
-func (this *LokiData) String() string {
+func (this *LokiIndexStatsRequest) String() string {
 	if this == nil {
 		return "nil"
 	}
-	s := strings.Join([]string{`&LokiData{`,
-		`ResultType:` + fmt.Sprintf("%v", this.ResultType) + `,`,
-		`Result:` + fmt.Sprintf("%v", this.Result) + `,`,
+	s := strings.Join([]string{`&LokiIndexStatsRequest{`,
+		`Query:` + fmt.Sprintf("%v", this.Query) + `,`,
+		`StartTs:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.StartTs), "Timestamp", "types.Timestamp", 1), `&`, ``, 1) + `,`,
+		`EndTs:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.EndTs), "Timestamp", "types.Timestamp", 1), `&`, ``, 1) + `,`,
+		`Path:` + fmt.Sprintf("%v", this.Path) + `,`,
 		`}`,
 	}, "")
 	return s
 }
-func (this *LokiPromResponse) String() string {
+func (this *LokiIndexStatsResponse) String() string {
 	if this == nil {
 		return "nil"
 	}
-	s := strings.Join([]string{`&LokiPromResponse{`,
+	s := strings.Join([]string{`&LokiIndexStatsResponse{`,
+		`Response:` + strings.Replace(fmt.Sprintf("%v", this.Response), "IndexStatsResponse", "logproto.IndexStatsResponse", 1) + `,`,
+		`Headers:` + fmt.Sprintf("%v", this.Headers) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LokiData) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LokiData{`,
+		`ResultType:` + fmt.Sprintf("%v", this.ResultType) + `,`,
+		`Result:` + fmt.Sprintf("%v", this.Result) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LokiPromResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LokiPromResponse{`,
 		`Response:` + strings.Replace(fmt.Sprintf("%v", this.Response), "PrometheusResponse", "queryrangebase.PrometheusResponse", 1) + `,`,
 		`Statistics:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.Statistics), "Result", "stats.Result", 1), `&`, ``, 1) + `,`,
 		`}`,
 	}, "")
 	return s
 }
+func (this *QuantileSketchResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForData := "[]QuantileSketchSeries{"
+	for _, f := range this.Data {
+		repeatedStringForData += fmt.Sprintf("%v", f) + ","
+	}
+	repeatedStringForData += "}"
+	s := strings.Join([]string{`&QuantileSketchResponse{`,
+		`Status:` + fmt.Sprintf("%v", this.Status) + `,`,
+		`Data:` + repeatedStringForData + `,`,
+		`Statistics:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.Statistics), "Result", "stats.Result", 1), `&`, ``, 1) + `,`,
+		`Headers:` + fmt.Sprintf("%v", this.Headers) + `,`,
+		`}`,
+	}, "")
+	return s
+}
 func valueToStringQueryrange(v interface{}) string {
 	rv := reflect.ValueOf(v)
 	if rv.IsNil() {
@@ -2491,6 +3158,26 @@ func (m *LokiRequest) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field QuantileSketch", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQueryrange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.QuantileSketch = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipQueryrange(dAtA[iNdEx:])
@@ -2711,6 +3398,26 @@ func (m *LokiInstantRequest) Unmarshal(dAtA []byte) error {
 			}
 			m.Shards = append(m.Shards, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field QuantileSketch", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQueryrange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.QuantileSketch = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipQueryrange(dAtA[iNdEx:])
@@ -3749,7 +4456,7 @@ func (m *LokiLabelNamesResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *LokiData) Unmarshal(dAtA []byte) error {
+func (m *LokiIndexStatsRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3772,15 +4479,15 @@ func (m *LokiData) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: LokiData: wiretype end group for non-group")
+			return fmt.Errorf("proto: LokiIndexStatsRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LokiData: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LokiIndexStatsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ResultType", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Query", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3808,11 +4515,11 @@ func (m *LokiData) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ResultType = string(dAtA[iNdEx:postIndex])
+			m.Query = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field StartTs", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3839,11 +4546,75 @@ func (m *LokiData) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Result = append(m.Result, github_com_grafana_loki_pkg_logproto.Stream{})
-			if err := m.Result[len(m.Result)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.StartTs, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndTs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQueryrange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.EndTs, dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQueryrange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Path = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipQueryrange(dAtA[iNdEx:])
@@ -3868,7 +4639,7 @@ func (m *LokiData) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *LokiPromResponse) Unmarshal(dAtA []byte) error {
+func (m *LokiIndexStatsResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3891,10 +4662,10 @@ func (m *LokiPromResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: LokiPromResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: LokiIndexStatsResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LokiPromResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LokiIndexStatsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -3927,7 +4698,7 @@ func (m *LokiPromResponse) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			if m.Response == nil {
-				m.Response = &queryrangebase.PrometheusResponse{}
+				m.Response = &logproto.IndexStatsResponse{}
 			}
 			if err := m.Response.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
@@ -3935,7 +4706,7 @@ func (m *LokiPromResponse) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Statistics", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Headers", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3962,7 +4733,8 @@ func (m *LokiPromResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Statistics.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Headers = append(m.Headers, github_com_grafana_loki_pkg_querier_queryrange_queryrangebase.PrometheusResponseHeader{})
+			if err := m.Headers[len(m.Headers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -3990,113 +4762,514 @@ func (m *LokiPromResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func skipQueryrange(dAtA []byte) (n int, err error) {
+func (m *LokiData) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
+		preIndex := iNdEx
 		var wire uint64
 		for shift := uint(0); ; shift += 7 {
 			if shift >= 64 {
-				return 0, ErrIntOverflowQueryrange
+				return ErrIntOverflowQueryrange
 			}
 			if iNdEx >= l {
-				return 0, io.ErrUnexpectedEOF
+				return io.ErrUnexpectedEOF
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
 		}
+		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
-		switch wireType {
-		case 0:
+		if wireType == 4 {
+			return fmt.Errorf("proto: LokiData: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LokiData: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResultType", wireType)
+			}
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
-					return 0, ErrIntOverflowQueryrange
+					return ErrIntOverflowQueryrange
 				}
 				if iNdEx >= l {
-					return 0, io.ErrUnexpectedEOF
+					return io.ErrUnexpectedEOF
 				}
+				b := dAtA[iNdEx]
 				iNdEx++
-				if dAtA[iNdEx-1] < 0x80 {
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
 					break
 				}
 			}
-			return iNdEx, nil
-		case 1:
-			iNdEx += 8
-			return iNdEx, nil
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ResultType = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 2:
-			var length int
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
+			}
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
-					return 0, ErrIntOverflowQueryrange
+					return ErrIntOverflowQueryrange
 				}
 				if iNdEx >= l {
-					return 0, io.ErrUnexpectedEOF
+					return io.ErrUnexpectedEOF
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				length |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if length < 0 {
-				return 0, ErrInvalidLengthQueryrange
+			if msglen < 0 {
+				return ErrInvalidLengthQueryrange
 			}
-			iNdEx += length
-			if iNdEx < 0 {
-				return 0, ErrInvalidLengthQueryrange
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQueryrange
 			}
-			return iNdEx, nil
-		case 3:
-			for {
-				var innerWire uint64
-				var start int = iNdEx
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return 0, ErrIntOverflowQueryrange
-					}
-					if iNdEx >= l {
-						return 0, io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					innerWire |= (uint64(b) & 0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				innerWireType := int(innerWire & 0x7)
-				if innerWireType == 4 {
-					break
-				}
-				next, err := skipQueryrange(dAtA[start:])
-				if err != nil {
-					return 0, err
-				}
-				iNdEx = start + next
-				if iNdEx < 0 {
-					return 0, ErrInvalidLengthQueryrange
-				}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
-			return iNdEx, nil
-		case 4:
-			return iNdEx, nil
-		case 5:
-			iNdEx += 4
-			return iNdEx, nil
+			m.Result = append(m.Result, github_com_grafana_loki_pkg_logproto.Stream{})
+			if err := m.Result[len(m.Result)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
-			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+			iNdEx = preIndex
+			skippy, err := skipQueryrange(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LokiPromResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQueryrange
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LokiPromResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LokiPromResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Response", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQueryrange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Response == nil {
+				m.Response = &queryrangebase.PrometheusResponse{}
+			}
+			if err := m.Response.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Statistics", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQueryrange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Statistics.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQueryrange(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QuantileSketchResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQueryrange
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QuantileSketchResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QuantileSketchResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQueryrange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Status = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQueryrange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append(m.Data, logproto.QuantileSketchSeries{})
+			if err := m.Data[len(m.Data)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Statistics", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQueryrange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Statistics.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Headers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQueryrange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Headers = append(m.Headers, github_com_grafana_loki_pkg_querier_queryrange_queryrangebase.PrometheusResponseHeader{})
+			if err := m.Headers[len(m.Headers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQueryrange(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipQueryrange(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowQueryrange
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowQueryrange
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowQueryrange
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthQueryrange
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupQueryrange
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthQueryrange
+		}
+		if depth == 0 {
+			return iNdEx, nil
 		}
 	}
-	panic("unreachable")
+	return 0, io.ErrUnexpectedEOF
 }
 
 var (
-	ErrInvalidLengthQueryrange = fmt.Errorf("proto: negative length found during unmarshaling")
-	ErrIntOverflowQueryrange   = fmt.Errorf("proto: integer overflow")
+	ErrInvalidLengthQueryrange        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowQueryrange          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupQueryrange = fmt.Errorf("proto: unexpected end of group")
 )
@@ -141,6 +141,15 @@ func NewRoundTripper(next http.RoundTripper, codec Codec, headers []string, midd
 	return transport
 }
 
+// NewRoundTripperHandler returns a handler sending the requests to the `next` roundtripper
+// using the codec to translate requests and responses.
+func NewRoundTripperHandler(next http.RoundTripper, codec Codec) Handler {
+	return roundTripper{
+		next:  next,
+		codec: codec,
+	}
+}
+
 func (q roundTripper) RoundTrip(r *http.Request) (*http.Response, error) {
 	// include the headers specified in the roundTripper during decoding the request.
 	request, err := q.codec.DecodeRequest(r.Context(), r, q.headers)
@@ -69,7 +69,7 @@ func newASTMapperware(
 	next queryrangebase.Handler,
 	logger log.Logger,
 	metrics *logql.MapperMetrics,
-	limits logql.Limits,
+	limits Limits,
 ) *astMapperware {
 	return &astMapperware{
 		confs:   confs,
@@ -77,6 +77,7 @@ func newASTMapperware(
 		next:    next,
 		ng:      logql.NewDownstreamEngine(logql.EngineOpts{}, DownstreamHandler{next}, limits, logger),
 		metrics: metrics,
+		limits:  limits,
 	}
 }
 
@@ -86,6 +87,7 @@ type astMapperware struct {
 	next    queryrangebase.Handler
 	ng      *logql.DownstreamEngine
 	metrics *logql.MapperMetrics
+	limits  Limits
 }
 
 func (ast *astMapperware) Do(ctx context.Context, r queryrangebase.Request) (queryrangebase.Response, error) {
@@ -97,7 +99,12 @@ func (ast *astMapperware) Do(ctx context.Context, r queryrangebase.Request) (que
 		return ast.next.Do(ctx, r)
 	}
 
-	mapper, err := logql.NewShardMapper(int(conf.RowShards), ast.metrics)
+	tenantIDs, err := tenant.TenantIDs(ctx)
+	if err != nil {
+		return nil, httpgrpc.Errorf(http.StatusBadRequest, err.Error())
+	}
+
+	mapper, err := logql.NewShardMapper(int(conf.RowShards), ast.metrics, shardQuantileOverTime(tenantIDs, ast.limits))
 	if err != nil {
 		return nil, err
 	}
@@ -209,6 +216,17 @@ func (splitter *shardSplitter) Do(ctx context.Context, r queryrangebase.Request)
 	return splitter.next.Do(ctx, r)
 }
 
+// shardQuantileOverTime tells whether quantile_over_time can be sharded, which requires all the tenants to allow it
+// since the sharded queries return approximated results.
+func shardQuantileOverTime(tenantIDs []string, limits Limits) bool {
+	for _, tenantID := range tenantIDs {
+		if !limits.ShardQuantileOverTime(tenantID) {
+			return false
+		}
+	}
+	return len(tenantIDs) > 0
+}
+
 func hasShards(confs ShardingConfigs) bool {
 	for _, conf := range confs {
 		if conf.RowShards > 0 {
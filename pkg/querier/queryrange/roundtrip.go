@@ -61,15 +61,20 @@ func NewTripperware(
 		}
 	}
 
+	indexStatsTripperware, err := NewIndexStatsTripperware(cfg, log, limits, LokiCodec, metrics)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	metricsTripperware, err := NewMetricTripperware(cfg, log, limits, schema, LokiCodec, c,
-		cacheGenNumLoader, PrometheusExtractor{}, metrics, registerer)
+		cacheGenNumLoader, PrometheusExtractor{}, metrics, registerer, indexStatsTripperware)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	// NOTE: When we would start caching response from non-metric queries we would have to consider cache gen headers as well in
 	// MergeResponse implementation for Loki codecs same as it is done in Cortex at https://github.com/cortexproject/cortex/blob/21bad57b346c730d684d6d0205efef133422ab28/pkg/querier/queryrange/query_range.go#L170
-	logFilterTripperware, err := NewLogFilterTripperware(cfg, log, limits, schema, LokiCodec, c, metrics)
+	logFilterTripperware, err := NewLogFilterTripperware(cfg, log, limits, schema, LokiCodec, c, metrics, indexStatsTripperware)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -84,35 +89,41 @@ func NewTripperware(
 		return nil, nil, err
 	}
 
-	instantMetricTripperware, err := NewInstantMetricTripperware(cfg, log, limits, schema, LokiCodec, metrics)
+	instantMetricTripperware, err := NewInstantMetricTripperware(cfg, log, limits, schema, LokiCodec, metrics, indexStatsTripperware)
 	if err != nil {
 		return nil, nil, err
 	}
+
+	blocker := newQueryBlocker(log, limits, metrics.QueryBlockerMetrics)
 	return func(next http.RoundTripper) http.RoundTripper {
 		metricRT := metricsTripperware(next)
 		logFilterRT := logFilterTripperware(next)
 		seriesRT := seriesTripperware(next)
 		labelsRT := labelsTripperware(next)
 		instantRT := instantMetricTripperware(next)
-		return newRoundTripper(next, logFilterRT, metricRT, seriesRT, labelsRT, instantRT, limits)
+		indexStatsRT := indexStatsTripperware(next)
+		return newRoundTripper(next, logFilterRT, metricRT, seriesRT, labelsRT, instantRT, indexStatsRT, limits, blocker)
 	}, c, nil
 }
 
 type roundTripper struct {
-	next, log, metric, series, labels, instantMetric http.RoundTripper
+	next, log, metric, series, labels, instantMetric, indexStats http.RoundTripper
 
-	limits Limits
+	limits  Limits
+	blocker *queryBlocker
 }
 
 // newRoundTripper creates a new queryrange roundtripper
-func newRoundTripper(next, log, metric, series, labels, instantMetric http.RoundTripper, limits Limits) roundTripper {
+func newRoundTripper(next, log, metric, series, labels, instantMetric, indexStats http.RoundTripper, limits Limits, blocker *queryBlocker) roundTripper {
 	return roundTripper{
 		log:           log,
 		limits:        limits,
+		blocker:       blocker,
 		metric:        metric,
 		series:        series,
 		labels:        labels,
 		instantMetric: instantMetric,
+		indexStats:    indexStats,
 		next:          next,
 	}
 }
@@ -133,6 +144,9 @@ func (r roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
 		if err != nil {
 			return nil, httpgrpc.Errorf(http.StatusBadRequest, err.Error())
 		}
+		if err := r.checkBlocked(req, rangeQuery.Query, expr); err != nil {
+			return nil, err
+		}
 		switch e := expr.(type) {
 		case syntax.SampleExpr:
 			return r.metric.RoundTrip(req)
@@ -174,17 +188,45 @@ func (r roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
 		if err != nil {
 			return nil, httpgrpc.Errorf(http.StatusBadRequest, err.Error())
 		}
+		if err := r.checkBlocked(req, instantQuery.Query, expr); err != nil {
+			return nil, err
+		}
 		switch expr.(type) {
 		case syntax.SampleExpr:
 			return r.instantMetric.RoundTrip(req)
 		default:
 			return r.next.RoundTrip(req)
 		}
+	case IndexStatsOp:
+		statsQuery, err := loghttp.ParseIndexStatsQuery(req)
+		if err != nil {
+			return nil, httpgrpc.Errorf(http.StatusBadRequest, err.Error())
+		}
+		if _, err := syntax.ParseMatchers(statsQuery.Query); err != nil {
+			return nil, httpgrpc.Errorf(http.StatusBadRequest, err.Error())
+		}
+		return r.indexStats.RoundTrip(req)
 	default:
 		return r.next.RoundTrip(req)
 	}
 }
 
+// checkBlocked rejects the query if it's blocked by the limits of one of the tenants of the request.
+// This is done before splitting and sharding so that a blocked query doesn't reach the queriers.
+func (r roundTripper) checkBlocked(req *http.Request, query string, expr syntax.Expr) error {
+	if r.blocker == nil {
+		return nil
+	}
+	tenantIDs, err := tenant.TenantIDs(req.Context())
+	if err != nil {
+		return httpgrpc.Errorf(http.StatusBadRequest, err.Error())
+	}
+	if r.blocker.isBlocked(tenantIDs, query, expr) {
+		return ErrQueryBlocked
+	}
+	return nil
+}
+
 // transformRegexQuery backport the old regexp params into the v1 query format
 func transformRegexQuery(req *http.Request, expr syntax.LogSelectorExpr) (syntax.LogSelectorExpr, error) {
 	regexp := req.Form.Get("regexp")
@@ -224,6 +266,7 @@ const (
 	QueryRangeOp   = "query_range"
 	SeriesOp       = "series"
 	LabelNamesOp   = "labels"
+	IndexStatsOp   = "index_stats"
 )
 
 func getOperation(path string) string {
@@ -236,6 +279,8 @@ func getOperation(path string) string {
 		return LabelNamesOp
 	case strings.HasSuffix(path, "/v1/query"):
 		return InstantQueryOp
+	case strings.HasSuffix(path, "/index/stats"):
+		return IndexStatsOp
 	default:
 		return ""
 	}
@@ -250,55 +295,60 @@ func NewLogFilterTripperware(
 	codec queryrangebase.Codec,
 	c cache.Cache,
 	metrics *Metrics,
+	indexStatsTripperware queryrangebase.Tripperware,
 ) (queryrangebase.Tripperware, error) {
-	queryRangeMiddleware := []queryrangebase.Middleware{
-		StatsCollectorMiddleware(),
-		NewLimitsMiddleware(limits),
-		queryrangebase.InstrumentMiddleware("split_by_interval", metrics.InstrumentMiddlewareMetrics),
-		SplitByIntervalMiddleware(limits, codec, splitByTime, metrics.SplitByMetrics),
-	}
-
-	if cfg.CacheResults {
-		queryCacheMiddleware := NewLogResultCache(
-			log,
-			limits,
-			c,
-			func(r queryrangebase.Request) bool {
-				return !r.GetCachingOptions().Disabled
-			},
-			metrics.LogResultCacheMetrics,
-		)
-		queryRangeMiddleware = append(
-			queryRangeMiddleware,
-			queryrangebase.InstrumentMiddleware("log_results_cache", metrics.InstrumentMiddlewareMetrics),
-			queryCacheMiddleware,
-		)
-	}
+	return func(next http.RoundTripper) http.RoundTripper {
+		statsHandler := queryrangebase.NewRoundTripperHandler(indexStatsTripperware(next), codec)
+
+		queryRangeMiddleware := []queryrangebase.Middleware{
+			StatsCollectorMiddleware(),
+			NewLimitsMiddleware(limits),
+			NewQuerySizeLimiterMiddleware(limits, statsHandler),
+			queryrangebase.InstrumentMiddleware("split_by_interval", metrics.InstrumentMiddlewareMetrics),
+			SplitByIntervalMiddleware(limits, codec, splitByTime, metrics.SplitByMetrics),
+		}
 
-	if cfg.ShardedQueries {
-		queryRangeMiddleware = append(queryRangeMiddleware,
-			NewQueryShardMiddleware(
+		if cfg.CacheResults {
+			queryCacheMiddleware := NewLogResultCache(
 				log,
-				schema.Configs,
-				metrics.InstrumentMiddlewareMetrics, // instrumentation is included in the sharding middleware
-				metrics.MiddlewareMapperMetrics.shardMapper,
 				limits,
-			),
-		)
-	}
+				c,
+				func(r queryrangebase.Request) bool {
+					return !r.GetCachingOptions().Disabled
+				},
+				metrics.LogResultCacheMetrics,
+			)
+			queryRangeMiddleware = append(
+				queryRangeMiddleware,
+				queryrangebase.InstrumentMiddleware("log_results_cache", metrics.InstrumentMiddlewareMetrics),
+				queryCacheMiddleware,
+			)
+		}
 
-	if cfg.MaxRetries > 0 {
-		queryRangeMiddleware = append(
-			queryRangeMiddleware, queryrangebase.InstrumentMiddleware("retry", metrics.InstrumentMiddlewareMetrics),
-			queryrangebase.NewRetryMiddleware(log, cfg.MaxRetries, metrics.RetryMiddlewareMetrics),
+		queryRangeMiddleware = append(queryRangeMiddleware,
+			NewQuerierSizeLimiterMiddleware(limits, statsHandler, schema.Configs, cfg.ShardedQueries),
 		)
-	}
 
-	return func(next http.RoundTripper) http.RoundTripper {
-		if len(queryRangeMiddleware) > 0 {
-			return NewLimitedRoundTripper(next, codec, limits, queryRangeMiddleware...)
+		if cfg.ShardedQueries {
+			queryRangeMiddleware = append(queryRangeMiddleware,
+				NewQueryShardMiddleware(
+					log,
+					schema.Configs,
+					metrics.InstrumentMiddlewareMetrics, // instrumentation is included in the sharding middleware
+					metrics.MiddlewareMapperMetrics.shardMapper,
+					limits,
+				),
+			)
 		}
-		return next
+
+		if cfg.MaxRetries > 0 {
+			queryRangeMiddleware = append(
+				queryRangeMiddleware, queryrangebase.InstrumentMiddleware("retry", metrics.InstrumentMiddlewareMetrics),
+				queryrangebase.NewRetryMiddleware(log, cfg.MaxRetries, metrics.RetryMiddlewareMetrics),
+			)
+		}
+
+		return NewLimitedRoundTripper(next, codec, limits, queryRangeMiddleware...)
 	}, nil
 }
 
@@ -380,6 +430,37 @@ func NewLabelsTripperware(
 	}, nil
 }
 
+// NewIndexStatsTripperware creates a new frontend tripperware responsible for handling index stats requests.
+func NewIndexStatsTripperware(
+	cfg Config,
+	log log.Logger,
+	limits Limits,
+	codec queryrangebase.Codec,
+	metrics *Metrics,
+) (queryrangebase.Tripperware, error) {
+	queryRangeMiddleware := []queryrangebase.Middleware{
+		NewLimitsMiddleware(limits),
+		// Index stats requests are not split by interval: the stats of the splits can't be merged without
+		// accounting for the streams and the chunks spanning over several splits once per split, while
+		// the querier deduplicates them across the daily index tables of the whole time range.
+	}
+
+	if cfg.MaxRetries > 0 {
+		queryRangeMiddleware = append(queryRangeMiddleware,
+			queryrangebase.InstrumentMiddleware("retry", metrics.InstrumentMiddlewareMetrics),
+			queryrangebase.NewRetryMiddleware(log, cfg.MaxRetries, metrics.RetryMiddlewareMetrics),
+		)
+	}
+
+	return func(next http.RoundTripper) http.RoundTripper {
+		if len(queryRangeMiddleware) > 0 {
+			// Do not forward any request header.
+			return queryrangebase.NewRoundTripper(next, codec, nil, queryRangeMiddleware...)
+		}
+		return next
+	}, nil
+}
+
 // NewMetricTripperware creates a new frontend tripperware responsible for handling metric queries
 func NewMetricTripperware(
 	cfg Config,
@@ -392,24 +473,12 @@ func NewMetricTripperware(
 	extractor queryrangebase.Extractor,
 	metrics *Metrics,
 	registerer prometheus.Registerer,
+	indexStatsTripperware queryrangebase.Tripperware,
 ) (queryrangebase.Tripperware, error) {
-	queryRangeMiddleware := []queryrangebase.Middleware{StatsCollectorMiddleware(), NewLimitsMiddleware(limits)}
-	if cfg.AlignQueriesWithStep {
-		queryRangeMiddleware = append(
-			queryRangeMiddleware,
-			queryrangebase.InstrumentMiddleware("step_align", metrics.InstrumentMiddlewareMetrics),
-			queryrangebase.StepAlignMiddleware,
-		)
-	}
-
-	queryRangeMiddleware = append(
-		queryRangeMiddleware,
-		queryrangebase.InstrumentMiddleware("split_by_interval", metrics.InstrumentMiddlewareMetrics),
-		SplitByIntervalMiddleware(limits, codec, splitMetricByTime, metrics.SplitByMetrics),
-	)
-
+	var queryCacheMiddleware queryrangebase.Middleware
 	if cfg.CacheResults {
-		queryCacheMiddleware, err := queryrangebase.NewResultsCacheMiddleware(
+		var err error
+		queryCacheMiddleware, err = queryrangebase.NewResultsCacheMiddleware(
 			log,
 			c,
 			cacheKeyLimits{limits},
@@ -425,45 +494,70 @@ func NewMetricTripperware(
 		if err != nil {
 			return nil, err
 		}
+	}
+
+	return func(next http.RoundTripper) http.RoundTripper {
+		statsHandler := queryrangebase.NewRoundTripperHandler(indexStatsTripperware(next), codec)
+
+		queryRangeMiddleware := []queryrangebase.Middleware{
+			StatsCollectorMiddleware(),
+			NewLimitsMiddleware(limits),
+			NewQuerySizeLimiterMiddleware(limits, statsHandler),
+		}
+		if cfg.AlignQueriesWithStep {
+			queryRangeMiddleware = append(
+				queryRangeMiddleware,
+				queryrangebase.InstrumentMiddleware("step_align", metrics.InstrumentMiddlewareMetrics),
+				queryrangebase.StepAlignMiddleware,
+			)
+		}
+
 		queryRangeMiddleware = append(
 			queryRangeMiddleware,
-			queryrangebase.InstrumentMiddleware("results_cache", metrics.InstrumentMiddlewareMetrics),
-			queryCacheMiddleware,
+			queryrangebase.InstrumentMiddleware("split_by_interval", metrics.InstrumentMiddlewareMetrics),
+			SplitByIntervalMiddleware(limits, codec, splitMetricByTime, metrics.SplitByMetrics),
 		)
-	}
 
-	if cfg.ShardedQueries {
+		if cfg.CacheResults {
+			queryRangeMiddleware = append(
+				queryRangeMiddleware,
+				queryrangebase.InstrumentMiddleware("results_cache", metrics.InstrumentMiddlewareMetrics),
+				queryCacheMiddleware,
+			)
+		}
+
 		queryRangeMiddleware = append(queryRangeMiddleware,
-			NewQueryShardMiddleware(
-				log,
-				schema.Configs,
-				metrics.InstrumentMiddlewareMetrics, // instrumentation is included in the sharding middleware
-				metrics.MiddlewareMapperMetrics.shardMapper,
-				limits,
-			),
+			NewQuerierSizeLimiterMiddleware(limits, statsHandler, schema.Configs, cfg.ShardedQueries),
 		)
-	}
 
-	if cfg.MaxRetries > 0 {
-		queryRangeMiddleware = append(
-			queryRangeMiddleware,
-			queryrangebase.InstrumentMiddleware("retry", metrics.InstrumentMiddlewareMetrics),
-			queryrangebase.NewRetryMiddleware(log, cfg.MaxRetries, metrics.RetryMiddlewareMetrics),
-		)
-	}
+		if cfg.ShardedQueries {
+			queryRangeMiddleware = append(queryRangeMiddleware,
+				NewQueryShardMiddleware(
+					log,
+					schema.Configs,
+					metrics.InstrumentMiddlewareMetrics, // instrumentation is included in the sharding middleware
+					metrics.MiddlewareMapperMetrics.shardMapper,
+					limits,
+				),
+			)
+		}
 
-	return func(next http.RoundTripper) http.RoundTripper {
-		// Finally, if the user selected any query range middleware, stitch it in.
-		if len(queryRangeMiddleware) > 0 {
-			rt := NewLimitedRoundTripper(next, codec, limits, queryRangeMiddleware...)
-			return queryrangebase.RoundTripFunc(func(r *http.Request) (*http.Response, error) {
-				if !strings.HasSuffix(r.URL.Path, "/query_range") {
-					return next.RoundTrip(r)
-				}
-				return rt.RoundTrip(r)
-			})
+		if cfg.MaxRetries > 0 {
+			queryRangeMiddleware = append(
+				queryRangeMiddleware,
+				queryrangebase.InstrumentMiddleware("retry", metrics.InstrumentMiddlewareMetrics),
+				queryrangebase.NewRetryMiddleware(log, cfg.MaxRetries, metrics.RetryMiddlewareMetrics),
+			)
 		}
-		return next
+
+		// Finally, stitch in the query range middlewares.
+		rt := NewLimitedRoundTripper(next, codec, limits, queryRangeMiddleware...)
+		return queryrangebase.RoundTripFunc(func(r *http.Request) (*http.Response, error) {
+			if !strings.HasSuffix(r.URL.Path, "/query_range") {
+				return next.RoundTrip(r)
+			}
+			return rt.RoundTrip(r)
+		})
 	}, nil
 }
 
@@ -475,34 +569,39 @@ func NewInstantMetricTripperware(
 	schema config.SchemaConfig,
 	codec queryrangebase.Codec,
 	metrics *Metrics,
+	indexStatsTripperware queryrangebase.Tripperware,
 ) (queryrangebase.Tripperware, error) {
-	queryRangeMiddleware := []queryrangebase.Middleware{StatsCollectorMiddleware(), NewLimitsMiddleware(limits)}
+	return func(next http.RoundTripper) http.RoundTripper {
+		statsHandler := queryrangebase.NewRoundTripperHandler(indexStatsTripperware(next), codec)
 
-	if cfg.ShardedQueries {
-		queryRangeMiddleware = append(queryRangeMiddleware,
-			NewSplitByRangeMiddleware(log, limits, metrics.MiddlewareMapperMetrics.rangeMapper),
-			NewQueryShardMiddleware(
-				log,
-				schema.Configs,
-				metrics.InstrumentMiddlewareMetrics, // instrumentation is included in the sharding middleware
-				metrics.MiddlewareMapperMetrics.shardMapper,
-				limits,
-			),
-		)
-	}
+		queryRangeMiddleware := []queryrangebase.Middleware{
+			StatsCollectorMiddleware(),
+			NewLimitsMiddleware(limits),
+			NewQuerySizeLimiterMiddleware(limits, statsHandler),
+			NewQuerierSizeLimiterMiddleware(limits, statsHandler, schema.Configs, cfg.ShardedQueries),
+		}
 
-	if cfg.MaxRetries > 0 {
-		queryRangeMiddleware = append(
-			queryRangeMiddleware,
-			queryrangebase.InstrumentMiddleware("retry", metrics.InstrumentMiddlewareMetrics),
-			queryrangebase.NewRetryMiddleware(log, cfg.MaxRetries, metrics.RetryMiddlewareMetrics),
-		)
-	}
+		if cfg.ShardedQueries {
+			queryRangeMiddleware = append(queryRangeMiddleware,
+				NewSplitByRangeMiddleware(log, limits, metrics.MiddlewareMapperMetrics.rangeMapper),
+				NewQueryShardMiddleware(
+					log,
+					schema.Configs,
+					metrics.InstrumentMiddlewareMetrics, // instrumentation is included in the sharding middleware
+					metrics.MiddlewareMapperMetrics.shardMapper,
+					limits,
+				),
+			)
+		}
 
-	return func(next http.RoundTripper) http.RoundTripper {
-		if len(queryRangeMiddleware) > 0 {
-			return NewLimitedRoundTripper(next, codec, limits, queryRangeMiddleware...)
+		if cfg.MaxRetries > 0 {
+			queryRangeMiddleware = append(
+				queryRangeMiddleware,
+				queryrangebase.InstrumentMiddleware("retry", metrics.InstrumentMiddlewareMetrics),
+				queryrangebase.NewRetryMiddleware(log, cfg.MaxRetries, metrics.RetryMiddlewareMetrics),
+			)
 		}
-		return next
+
+		return NewLimitedRoundTripper(next, codec, limits, queryRangeMiddleware...)
 	}, nil
 }
@@ -28,6 +28,7 @@ import (
 	"github.com/grafana/loki/pkg/storage/config"
 	util_log "github.com/grafana/loki/pkg/util/log"
 	"github.com/grafana/loki/pkg/util/marshal"
+	"github.com/grafana/loki/pkg/validation"
 )
 
 var (
@@ -343,6 +344,58 @@ func TestLabelsTripperware(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestIndexStatsTripperware(t *testing.T) {
+	tpw, stopper, err := NewTripperware(testConfig, util_log.Logger, fakeLimits{maxQueryLength: 48 * time.Hour, maxQueryParallelism: 1}, config.SchemaConfig{}, nil, nil)
+	if stopper != nil {
+		defer stopper.Stop()
+	}
+	require.NoError(t, err)
+	rt, err := newfakeRoundTripper()
+	require.NoError(t, err)
+	defer rt.Close()
+
+	sreq := &LokiIndexStatsRequest{
+		Query:   `{job="varlogs"}`,
+		StartTs: testTime.Add(-25 * time.Hour), // bigger than the split of the other requests
+		EndTs:   testTime,
+		Path:    "/loki/api/v1/index/stats",
+	}
+
+	ctx := user.InjectOrgID(context.Background(), "1")
+	req, err := LokiCodec.EncodeRequest(ctx, sreq)
+	require.NoError(t, err)
+
+	req = req.WithContext(ctx)
+	err = user.InjectOrgIDIntoHTTPRequest(ctx, req)
+	require.NoError(t, err)
+
+	handler := newFakeHandler(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, marshal.WriteIndexStatsResponseJSON(&logproto.IndexStatsResponse{Streams: 1, Chunks: 2, Bytes: 1 << 10, Entries: 10}, w))
+		}),
+	)
+	rt.setHandler(handler)
+	resp, err := tpw(rt).RoundTrip(req)
+	// verify the request is not split, the streams and chunks spanning over the splits would be accounted for twice.
+	require.Equal(t, 1, handler.count)
+	require.NoError(t, err)
+	statsResponse, err := LokiCodec.DecodeResponse(ctx, resp, sreq)
+	require.NoError(t, err)
+	res, ok := statsResponse.(*LokiIndexStatsResponse)
+	require.Equal(t, true, ok)
+	require.Equal(t, &logproto.IndexStatsResponse{Streams: 1, Chunks: 2, Bytes: 1 << 10, Entries: 10}, res.Response)
+
+	// the query length limit also applies to index stats requests.
+	sreq.StartTs = testTime.Add(-49 * time.Hour)
+	req, err = LokiCodec.EncodeRequest(ctx, sreq)
+	require.NoError(t, err)
+	req = req.WithContext(ctx)
+	err = user.InjectOrgIDIntoHTTPRequest(ctx, req)
+	require.NoError(t, err)
+	_, err = tpw(rt).RoundTrip(req)
+	require.Error(t, err)
+}
+
 func TestLogNoRegex(t *testing.T) {
 	tpw, stopper, err := NewTripperware(testConfig, util_log.Logger, fakeLimits{}, config.SchemaConfig{}, nil, nil)
 	if stopper != nil {
@@ -480,7 +533,12 @@ func TestPostQueries(t *testing.T) {
 			t.Error("unexpected instant roundtripper called")
 			return nil, nil
 		}),
+		queryrangebase.RoundTripFunc(func(*http.Request) (*http.Response, error) {
+			t.Error("unexpected index stats roundtripper called")
+			return nil, nil
+		}),
 		fakeLimits{},
+		newQueryBlocker(util_log.Logger, fakeLimits{}, NewQueryBlockerMetrics(nil)),
 	).RoundTrip(req)
 	require.NoError(t, err)
 }
@@ -547,6 +605,70 @@ func TestEntriesLimitWithZeroTripperware(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestBlockedQueriesTripperware(t *testing.T) {
+	limits := fakeLimits{
+		maxQueryParallelism: 1,
+		blockedQueries: []*validation.BlockedQuery{
+			{Pattern: `.*foo.*`, Regex: true, Types: []string{validation.BlockedQueryTypeFilter}},
+			{Pattern: `sum(rate({app="bar"}[1m]))`},
+		},
+	}
+	for _, blocked := range limits.blockedQueries {
+		require.NoError(t, blocked.Validate())
+	}
+	tpw, stopper, err := NewTripperware(testConfig, util_log.Logger, limits, config.SchemaConfig{}, nil, nil)
+	if stopper != nil {
+		defer stopper.Stop()
+	}
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		query   string
+		blocked bool
+	}{
+		{`{app="foo"} |= "error"`, true},
+		{`{app="foo"}`, false},
+		{`sum by (app) (rate({app="bar"}[1m]))`, false},
+		{`sum(rate({app="bar"}[1m]))`, true},
+		{`sum(  rate({app="bar"}  [1m]))`, true},
+	} {
+		t.Run(tc.query, func(t *testing.T) {
+			count, h := counter()
+			rt, err := newfakeRoundTripper()
+			require.NoError(t, err)
+			defer rt.Close()
+			rt.setHandler(h)
+
+			lreq := &LokiRequest{
+				Query:     tc.query,
+				Limit:     1000,
+				Step:      30000, // 30sec
+				StartTs:   testTime.Add(-6 * time.Hour),
+				EndTs:     testTime,
+				Direction: logproto.FORWARD,
+				Path:      "/loki/api/v1/query_range",
+			}
+
+			ctx := user.InjectOrgID(context.Background(), "1")
+			req, err := LokiCodec.EncodeRequest(ctx, lreq)
+			require.NoError(t, err)
+
+			req = req.WithContext(ctx)
+			err = user.InjectOrgIDIntoHTTPRequest(ctx, req)
+			require.NoError(t, err)
+
+			_, err = tpw(rt).RoundTrip(req)
+			if tc.blocked {
+				require.Equal(t, ErrQueryBlocked, err)
+				require.Equal(t, 0, *count)
+				return
+			}
+			require.NotEqual(t, ErrQueryBlocked, err)
+			require.NotZero(t, *count)
+		})
+	}
+}
+
 type fakeLimits struct {
 	maxQueryLength          time.Duration
 	maxQueryParallelism     int
@@ -555,6 +677,10 @@ type fakeLimits struct {
 	maxSeries               int
 	splits                  map[string]time.Duration
 	minShardingLookback     time.Duration
+	shardQuantileOverTime   bool
+	blockedQueries          []*validation.BlockedQuery
+	maxQueryBytesRead       int
+	maxQuerierBytesRead     int
 }
 
 func (f fakeLimits) QuerySplitDuration(key string) time.Duration {
@@ -579,6 +705,14 @@ func (f fakeLimits) MaxEntriesLimitPerQuery(string) int {
 	return f.maxEntriesLimitPerQuery
 }
 
+func (f fakeLimits) MaxQueryBytesRead(string) int {
+	return f.maxQueryBytesRead
+}
+
+func (f fakeLimits) MaxQuerierBytesRead(string) int {
+	return f.maxQuerierBytesRead
+}
+
 func (f fakeLimits) MaxQuerySeries(string) int {
 	return f.maxSeries
 }
@@ -595,6 +729,14 @@ func (f fakeLimits) MinShardingLookback(string) time.Duration {
 	return f.minShardingLookback
 }
 
+func (f fakeLimits) ShardQuantileOverTime(string) bool {
+	return f.shardQuantileOverTime
+}
+
+func (f fakeLimits) BlockedQueries(string) []*validation.BlockedQuery {
+	return f.blockedQueries
+}
+
 func counter() (*int, http.Handler) {
 	count := 0
 	var lock sync.Mutex
@@ -27,10 +27,11 @@ import (
 )
 
 type ChunkMetrics struct {
-	refs    *prometheus.CounterVec
-	series  *prometheus.CounterVec
-	chunks  *prometheus.CounterVec
-	batches *prometheus.HistogramVec
+	refs          *prometheus.CounterVec
+	series        *prometheus.CounterVec
+	chunks        *prometheus.CounterVec
+	batches       *prometheus.HistogramVec
+	bloomFiltered prometheus.Counter
 }
 
 const (
@@ -72,6 +73,12 @@ func NewChunkMetrics(r prometheus.Registerer, maxBatchSize int) *ChunkMetrics {
 			// split buckets evenly across 0->maxBatchSize
 			Buckets: prometheus.LinearBuckets(0, float64(maxBatchSize/buckets), buckets+1), // increment buckets by one to ensure upper bound bucket exists.
 		}, []string{"status"}),
+		bloomFiltered: promauto.With(r).NewCounter(prometheus.CounterOpts{
+			Namespace: "loki",
+			Subsystem: "store",
+			Name:      "chunks_bloom_filtered_total",
+			Help:      "Number of downloaded chunks skipped because their bloom filters tell they can't match the line filters of the query.",
+		}),
 	}
 }
 
@@ -310,9 +317,10 @@ type logBatchIterator struct {
 	curr iter.EntryIterator
 	err  error
 
-	ctx      context.Context
-	cancel   context.CancelFunc
-	pipeline syntax.Pipeline
+	ctx         context.Context
+	cancel      context.CancelFunc
+	pipeline    syntax.Pipeline
+	lineFilters []string
 }
 
 func newLogBatchIterator(
@@ -323,6 +331,7 @@ func newLogBatchIterator(
 	batchSize int,
 	matchers []*labels.Matcher,
 	pipeline syntax.Pipeline,
+	lineFilters []string,
 	direction logproto.Direction,
 	start, end time.Time,
 	chunkFilterer chunk.Filterer,
@@ -330,6 +339,7 @@ func newLogBatchIterator(
 	ctx, cancel := context.WithCancel(ctx)
 	return &logBatchIterator{
 		pipeline:           pipeline,
+		lineFilters:        lineFilters,
 		ctx:                ctx,
 		cancel:             cancel,
 		batchChunkIterator: newBatchChunkIterator(ctx, schemas, chunks, batchSize, direction, start, end, metrics, matchers, chunkFilterer),
@@ -434,7 +444,11 @@ func (it *logBatchIterator) buildHeapIterator(chks [][]*LazyChunk, from, through
 			if !chks[i][j].IsValid {
 				continue
 			}
-			iterator, err := chks[i][j].Iterator(it.ctx, from, through, it.direction, streamPipeline, nextChunk)
+			if !chks[i][j].MayContain(from, through, it.lineFilters) {
+				it.metrics.bloomFiltered.Inc()
+				continue
+			}
+			iterator, err := chks[i][j].Iterator(it.ctx, from, through, it.direction, streamPipeline, it.lineFilters, nextChunk)
 			if err != nil {
 				return nil, err
 			}
@@ -456,9 +470,10 @@ type sampleBatchIterator struct {
 	curr iter.SampleIterator
 	err  error
 
-	ctx       context.Context
-	cancel    context.CancelFunc
-	extractor syntax.SampleExtractor
+	ctx         context.Context
+	cancel      context.CancelFunc
+	extractor   syntax.SampleExtractor
+	lineFilters []string
 }
 
 func newSampleBatchIterator(
@@ -469,12 +484,14 @@ func newSampleBatchIterator(
 	batchSize int,
 	matchers []*labels.Matcher,
 	extractor syntax.SampleExtractor,
+	lineFilters []string,
 	start, end time.Time,
 	chunkFilterer chunk.Filterer,
 ) (iter.SampleIterator, error) {
 	ctx, cancel := context.WithCancel(ctx)
 	return &sampleBatchIterator{
 		extractor:          extractor,
+		lineFilters:        lineFilters,
 		ctx:                ctx,
 		cancel:             cancel,
 		batchChunkIterator: newBatchChunkIterator(ctx, schemas, chunks, batchSize, logproto.FORWARD, start, end, metrics, matchers, chunkFilterer),
@@ -577,7 +594,11 @@ func (it *sampleBatchIterator) buildHeapIterator(chks [][]*LazyChunk, from, thro
 			if !chks[i][j].IsValid {
 				continue
 			}
-			iterator, err := chks[i][j].SampleIterator(it.ctx, from, through, streamExtractor, nextChunk)
+			if !chks[i][j].MayContain(from, through, it.lineFilters) {
+				it.metrics.bloomFiltered.Inc()
+				continue
+			}
+			iterator, err := chks[i][j].SampleIterator(it.ctx, from, through, streamExtractor, it.lineFilters, nextChunk)
 			if err != nil {
 				return nil, err
 			}
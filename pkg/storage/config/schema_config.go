@@ -39,6 +39,8 @@ const (
 	StorageTypeSwift          = "swift"
 	// BoltDBShipperType holds the index type for using boltdb with shipper which keeps flushing them to a shared storage
 	BoltDBShipperType = "boltdb-shipper"
+	// TSDBType holds the index type for using tsdb with shipper which keeps flushing them to a shared storage
+	TSDBType = "tsdb"
 )
 
 var (
@@ -50,6 +52,8 @@ var (
 
 	errCurrentBoltdbShipperNon24Hours  = errors.New("boltdb-shipper works best with 24h periodic index config. Either add a new config with future date set to 24h to retain the existing index or change the existing config to use 24h period")
 	errUpcomingBoltdbShipperNon24Hours = errors.New("boltdb-shipper with future date must always have periodic config for index set to 24h")
+	errCurrentTSDBNon24Hours           = errors.New("tsdb must always have periodic config for index set to 24h. Either add a new config with future date set to 24h to retain the existing index or change the existing config to use 24h period")
+	errUpcomingTSDBNon24Hours          = errors.New("tsdb with future date must always have periodic config for index set to 24h")
 	errZeroLengthConfig                = errors.New("must specify at least one schema configuration")
 )
 
@@ -155,6 +159,15 @@ func (cfg *SchemaConfig) Validate() error {
 		return errUpcomingBoltdbShipperNon24Hours
 	}
 
+	// same as above, tsdb index files are built per 24h table.
+	if cfg.Configs[activePCIndex].IndexType == TSDBType && cfg.Configs[activePCIndex].IndexTables.Period != 24*time.Hour && len(cfg.Configs)-1 == activePCIndex {
+		return errCurrentTSDBNon24Hours
+	}
+
+	if len(cfg.Configs)-1 > activePCIndex && (cfg.Configs[activePCIndex+1].IndexType == TSDBType && cfg.Configs[activePCIndex+1].IndexTables.Period != 24*time.Hour) {
+		return errUpcomingTSDBNon24Hours
+	}
+
 	for i := range cfg.Configs {
 		periodCfg := &cfg.Configs[i]
 		periodCfg.applyDefaults()
@@ -195,6 +208,23 @@ func UsingBoltdbShipper(configs []PeriodConfig) bool {
 	return false
 }
 
+// UsingTSDB checks whether current or the next index type is tsdb, returns true if yes.
+func UsingTSDB(configs []PeriodConfig) bool {
+	activePCIndex := ActivePeriodConfig(configs)
+	if configs[activePCIndex].IndexType == TSDBType ||
+		(len(configs)-1 > activePCIndex && configs[activePCIndex+1].IndexType == TSDBType) {
+		return true
+	}
+
+	return false
+}
+
+// UsingObjectStorageIndex checks whether current or the next index type is one of the index types
+// which ship the index files to the object store i.e boltdb-shipper or tsdb, returns true if yes.
+func UsingObjectStorageIndex(configs []PeriodConfig) bool {
+	return UsingBoltdbShipper(configs) || UsingTSDB(configs)
+}
+
 func defaultRowShards(schema string) uint32 {
 	switch schema {
 	case "v1", "v2", "v3", "v4", "v5", "v6", "v9":
@@ -752,3 +752,65 @@ func TestSchemaConfig_ValidateBoltdb(t *testing.T) {
 		})
 	}
 }
+
+func TestSchemaConfig_ValidateTSDB(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		configs []PeriodConfig
+		err     error
+	}{
+		{
+			name: "current config tsdb with 7 days periodic config, without future index type changes",
+			configs: []PeriodConfig{{
+				From:      DayTime{Time: model.Now().Add(-24 * time.Hour)},
+				IndexType: TSDBType,
+				Schema:    "v11",
+				IndexTables: PeriodicTableConfig{
+					Period: 7 * 24 * time.Hour,
+				},
+			}},
+			err: errCurrentTSDBNon24Hours,
+		},
+		{
+			name: "current config tsdb with 1 day periodic config, without future index type changes",
+			configs: []PeriodConfig{{
+				From:      DayTime{Time: model.Now().Add(-24 * time.Hour)},
+				IndexType: TSDBType,
+				Schema:    "v11",
+				IndexTables: PeriodicTableConfig{
+					Period: 24 * time.Hour,
+				},
+			}},
+		},
+		{
+			name: "current config boltdb-shipper, upcoming config tsdb with 7 days periodic config",
+			configs: []PeriodConfig{{
+				From:      DayTime{Time: model.Now().Add(-24 * time.Hour)},
+				IndexType: BoltDBShipperType,
+				Schema:    "v11",
+				IndexTables: PeriodicTableConfig{
+					Period: 24 * time.Hour,
+				},
+			}, {
+				From:      DayTime{Time: model.Now().Add(time.Hour)},
+				IndexType: TSDBType,
+				Schema:    "v11",
+				IndexTables: PeriodicTableConfig{
+					Period: 7 * 24 * time.Hour,
+				},
+			}},
+			err: errUpcomingTSDBNon24Hours,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := SchemaConfig{Configs: tc.configs}
+			err := cfg.Validate()
+			if tc.err == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tc.err.Error())
+			}
+			require.True(t, UsingObjectStorageIndex(tc.configs))
+		})
+	}
+}
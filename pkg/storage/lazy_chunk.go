@@ -36,6 +36,7 @@ func (c *LazyChunk) Iterator(
 	from, through time.Time,
 	direction logproto.Direction,
 	pipeline log.StreamPipeline,
+	lineFilters []string,
 	nextChunk *LazyChunk,
 ) (iter.EntryIterator, error) {
 	// If the chunk is not already loaded, then error out.
@@ -51,6 +52,10 @@ func (c *LazyChunk) Iterator(
 	its := make([]iter.EntryIterator, 0, len(blocks))
 
 	for _, b := range blocks {
+		// skip the blocks which can't match the line filters.
+		if !b.MayContain(lineFilters) {
+			continue
+		}
 		// if we have already processed and cache block let's use it.
 		if cache, ok := c.overlappingBlocks[b.Offset()]; ok {
 			cache.Reset()
@@ -116,6 +121,7 @@ func (c *LazyChunk) SampleIterator(
 	ctx context.Context,
 	from, through time.Time,
 	extractor log.StreamSampleExtractor,
+	lineFilters []string,
 	nextChunk *LazyChunk,
 ) (iter.SampleIterator, error) {
 	// If the chunk is not already loaded, then error out.
@@ -131,6 +137,10 @@ func (c *LazyChunk) SampleIterator(
 	its := make([]iter.SampleIterator, 0, len(blocks))
 
 	for _, b := range blocks {
+		// skip the blocks which can't match the line filters.
+		if !b.MayContain(lineFilters) {
+			continue
+		}
 		// if we have already processed and cache block let's use it.
 		if cache, ok := c.overlappingSampleBlocks[b.Offset()]; ok {
 			cache.Reset()
@@ -171,6 +181,22 @@ func (c *LazyChunk) SampleIterator(
 	), nil
 }
 
+// MayContain returns false if the bloom filters of the blocks overlapping the time range
+// tell that none of them can contain lines matching the line filters.
+func (c *LazyChunk) MayContain(from, through time.Time, lineFilters []string) bool {
+	if len(lineFilters) == 0 || c.Chunk.Data == nil {
+		return true
+	}
+
+	blocks := c.Chunk.Data.(*chunkenc.Facade).LokiChunk().Blocks(from, through)
+	for _, b := range blocks {
+		if b.MayContain(lineFilters) {
+			return true
+		}
+	}
+	return len(blocks) == 0
+}
+
 func IsBlockOverlapping(b chunkenc.Block, with *LazyChunk, direction logproto.Direction) bool {
 	if direction == logproto.BACKWARD {
 		through := int64(with.Chunk.Through) * int64(time.Millisecond)
@@ -48,7 +48,7 @@ func TestLazyChunkIterator(t *testing.T) {
 		},
 	} {
 		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
-			it, err := tc.chunk.Iterator(context.Background(), time.Unix(0, 0), time.Unix(1000, 0), logproto.FORWARD, log.NewNoopPipeline().ForStream(labels.Labels{labels.Label{Name: "foo", Value: "bar"}}), nil)
+			it, err := tc.chunk.Iterator(context.Background(), time.Unix(0, 0), time.Unix(1000, 0), logproto.FORWARD, log.NewNoopPipeline().ForStream(labels.Labels{labels.Label{Name: "foo", Value: "bar"}}), nil, nil)
 			require.Nil(t, err)
 			streams, _, err := iter.ReadBatch(it, 1000)
 			require.Nil(t, err)
@@ -58,6 +58,40 @@ func TestLazyChunkIterator(t *testing.T) {
 	}
 }
 
+func TestLazyChunkBloomFilters(t *testing.T) {
+	chk := chunkenc.NewMemChunk(chunkenc.EncGZIP, chunkenc.UnorderedHeadBlockFmt, 256*1024, 0)
+	chk.EnableBloomFilters()
+	require.NoError(t, chk.Append(&logproto.Entry{Timestamp: from, Line: "level=error trace_id=abc123"}))
+	require.NoError(t, chk.Append(&logproto.Entry{Timestamp: from.Add(time.Second), Line: "level=info trace_id=def456"}))
+	require.NoError(t, chk.Close())
+	c := &LazyChunk{IsValid: true, Chunk: chunk.Chunk{Data: chunkenc.NewFacade(chk, 0, 0)}}
+
+	for _, tc := range []struct {
+		lineFilters []string
+		expected    int
+	}{
+		{nil, 2},
+		{[]string{"trace_id=abc123"}, 2},
+		{[]string{"level=error", "trace_id=def456"}, 2},
+		{[]string{"trace_id=xyz789"}, 0},
+	} {
+		t.Run(fmt.Sprintf("%v", tc.lineFilters), func(t *testing.T) {
+			require.Equal(t, tc.expected > 0, c.MayContain(from, from.Add(time.Minute), tc.lineFilters))
+
+			it, err := c.Iterator(context.Background(), from, from.Add(time.Minute), logproto.FORWARD, log.NewNoopPipeline().ForStream(labels.Labels{}), tc.lineFilters, nil)
+			require.NoError(t, err)
+			streams, _, err := iter.ReadBatch(it, 1000)
+			require.NoError(t, err)
+			_ = it.Close()
+			entries := 0
+			for _, s := range streams.Streams {
+				entries += len(s.Entries)
+			}
+			require.Equal(t, tc.expected, entries)
+		})
+	}
+}
+
 func TestLazyChunksPop(t *testing.T) {
 	for i, tc := range []struct {
 		initial    int
@@ -173,10 +207,11 @@ type fakeBlock struct {
 	mint, maxt int64
 }
 
-func (fakeBlock) Entries() int     { return 0 }
-func (fakeBlock) Offset() int      { return 0 }
-func (f fakeBlock) MinTime() int64 { return f.mint }
-func (f fakeBlock) MaxTime() int64 { return f.maxt }
+func (fakeBlock) Entries() int             { return 0 }
+func (fakeBlock) Offset() int              { return 0 }
+func (fakeBlock) MayContain([]string) bool { return true }
+func (f fakeBlock) MinTime() int64         { return f.mint }
+func (f fakeBlock) MaxTime() int64         { return f.maxt }
 func (fakeBlock) Iterator(context.Context, log.StreamPipeline) iter.EntryIterator {
 	return nil
 }
@@ -15,6 +15,7 @@ import (
 	"github.com/grafana/loki/pkg/iter"
 	"github.com/grafana/loki/pkg/logproto"
 	"github.com/grafana/loki/pkg/logql"
+	"github.com/grafana/loki/pkg/logql/syntax"
 	"github.com/grafana/loki/pkg/logqlmodel/stats"
 	"github.com/grafana/loki/pkg/querier/astmapper"
 	"github.com/grafana/loki/pkg/storage/chunk"
@@ -23,10 +24,12 @@ import (
 	"github.com/grafana/loki/pkg/storage/chunk/fetcher"
 	"github.com/grafana/loki/pkg/storage/config"
 	"github.com/grafana/loki/pkg/storage/stores"
+	"github.com/grafana/loki/pkg/storage/stores/indexshipper"
 	"github.com/grafana/loki/pkg/storage/stores/series"
 	"github.com/grafana/loki/pkg/storage/stores/series/index"
 	"github.com/grafana/loki/pkg/storage/stores/shipper"
 	"github.com/grafana/loki/pkg/storage/stores/shipper/indexgateway"
+	"github.com/grafana/loki/pkg/storage/stores/tsdb"
 	"github.com/grafana/loki/pkg/usagestats"
 	"github.com/grafana/loki/pkg/util"
 	"github.com/grafana/loki/pkg/util/deletion"
@@ -69,6 +72,9 @@ type store struct {
 	logger log.Logger
 
 	chunkFilterer chunk.RequestChunkFilterer
+
+	// tsdbStore is shared by all the periods using TSDB, since they share the same shipper and heads.
+	tsdbStore *tsdb.Store
 }
 
 // NewStore creates a new Loki Store using configuration supplied.
@@ -197,12 +203,29 @@ func shouldUseIndexGatewayClient(cfg Config) bool {
 	return true
 }
 
-func (s *store) storeForPeriod(p config.PeriodConfig, chunkClient client.Client, f *fetcher.Fetcher) (stores.ChunkWriter, stores.Index, func(), error) {
-	// todo switch tsdb.
+// shouldUseTSDBIndexGatewayClient tells whether the read only tsdb store should query the index-gateway.
+// It shares the index-gateway client config of the boltdb-shipper since a gateway serves both index types.
+func shouldUseTSDBIndexGatewayClient(cfg Config) bool {
+	if cfg.TSDBShipperConfig.Mode != indexshipper.ModeReadOnly || cfg.BoltDBShipperConfig.IndexGatewayClientConfig.Disabled {
+		return false
+	}
+
+	gatewayCfg := cfg.BoltDBShipperConfig.IndexGatewayClientConfig
+	if gatewayCfg.Mode == indexgateway.SimpleMode && gatewayCfg.Address == "" {
+		return false
+	}
+
+	return true
+}
 
+func (s *store) storeForPeriod(p config.PeriodConfig, chunkClient client.Client, f *fetcher.Fetcher) (stores.ChunkWriter, stores.Index, func(), error) {
 	indexClientReg := prometheus.WrapRegistererWith(
 		prometheus.Labels{"component": "index-store-" + p.From.String()}, s.registerer)
 
+	if p.IndexType == config.TSDBType {
+		return s.tsdbStoreForPeriod(p, chunkClient, f, indexClientReg)
+	}
+
 	idx, err := NewIndexClient(p.IndexType, s.cfg, s.schemaCfg, s.limits, s.clientMetrics, indexClientReg)
 	if err != nil {
 		return nil, nil, nil, errors.Wrap(err, "error creating index client")
@@ -241,6 +264,48 @@ func (s *store) storeForPeriod(p config.PeriodConfig, chunkClient client.Client,
 		nil
 }
 
+func (s *store) tsdbStoreForPeriod(p config.PeriodConfig, chunkClient client.Client, f *fetcher.Fetcher, indexClientReg prometheus.Registerer) (stores.ChunkWriter, stores.Index, func(), error) {
+	if shouldUseTSDBIndexGatewayClient(s.cfg) {
+		// the index-gateway serves the TSDB files, the read only store does not need to download them.
+		gw, err := shipper.NewGatewayClient(s.cfg.BoltDBShipperConfig.IndexGatewayClientConfig, indexClientReg, s.logger)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		return tsdb.NewChunkWriter(f, s.schemaCfg, p, nil),
+			series.NewIndexGatewayClientStore(gw, nil),
+			func() {
+				chunkClient.Stop()
+				f.Stop()
+				gw.Stop()
+			},
+			nil
+	}
+
+	if s.tsdbStore == nil {
+		objectClient, err := NewObjectClient(s.cfg.TSDBShipperConfig.SharedStoreType, s.cfg, s.clientMetrics)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		s.tsdbStore, err = tsdb.NewStore(s.cfg.TSDBShipperConfig, objectClient, s.limits, s.registerer)
+		if err != nil {
+			return nil, nil, nil, errors.Wrap(err, "error creating tsdb store")
+		}
+	}
+
+	tsdbStore := s.tsdbStore
+	writer, idx := tsdbStore.ForPeriod(f, s.schemaCfg, p)
+	return writer,
+		idx,
+		func() {
+			chunkClient.Stop()
+			f.Stop()
+			tsdbStore.Stop()
+		},
+		nil
+}
+
 // decodeReq sanitizes an incoming request, rounds bounds, appends the __name__ matcher,
 // and adds the "__cortex_shard__" label if this is a sharded query.
 // todo(cyriltovena) refactor this.
@@ -289,6 +354,18 @@ func injectShardLabel(shards []string, matchers []*labels.Matcher) ([]*labels.Ma
 	return matchers, nil
 }
 
+// Stats returns the stats of the streams matching the matchers within the time range, adding the __name__ matcher
+// used by the index to the matchers.
+func (s *store) Stats(ctx context.Context, userID string, from, through model.Time, matchers ...*labels.Matcher) (*logproto.IndexStatsResponse, error) {
+	nameLabelMatcher, err := labels.NewMatcher(labels.MatchEqual, labels.MetricName, "logs")
+	if err != nil {
+		return nil, err
+	}
+	matchers = append(matchers[:len(matchers):len(matchers)], nameLabelMatcher)
+
+	return s.Store.Stats(ctx, userID, from, through, matchers...)
+}
+
 func (s *store) SetChunkFilterer(chunkFilterer chunk.RequestChunkFilterer) {
 	s.chunkFilterer = chunkFilterer
 	s.Store.SetChunkFilterer(chunkFilterer)
@@ -408,7 +485,7 @@ func (s *store) SelectLogs(ctx context.Context, req logql.SelectLogParams) (iter
 		chunkFilterer = s.chunkFilterer.ForRequest(ctx)
 	}
 
-	return newLogBatchIterator(ctx, s.schemaCfg, s.chunkMetrics, lazyChunks, s.cfg.MaxChunkBatchSize, matchers, pipeline, req.Direction, req.Start, req.End, chunkFilterer)
+	return newLogBatchIterator(ctx, s.schemaCfg, s.chunkMetrics, lazyChunks, s.cfg.MaxChunkBatchSize, matchers, pipeline, syntax.RequiredLineSubstrings(expr), req.Direction, req.Start, req.End, chunkFilterer)
 }
 
 func (s *store) SelectSamples(ctx context.Context, req logql.SelectSampleParams) (iter.SampleIterator, error) {
@@ -446,7 +523,7 @@ func (s *store) SelectSamples(ctx context.Context, req logql.SelectSampleParams)
 		chunkFilterer = s.chunkFilterer.ForRequest(ctx)
 	}
 
-	return newSampleBatchIterator(ctx, s.schemaCfg, s.chunkMetrics, lazyChunks, s.cfg.MaxChunkBatchSize, matchers, extractor, req.Start, req.End, chunkFilterer)
+	return newSampleBatchIterator(ctx, s.schemaCfg, s.chunkMetrics, lazyChunks, s.cfg.MaxChunkBatchSize, matchers, extractor, syntax.RequiredLineSubstrings(expr.Selector()), req.Start, req.End, chunkFilterer)
 }
 
 func (s *store) GetSchemaConfigs() []config.PeriodConfig {
@@ -2,6 +2,7 @@ package storage
 
 import (
 	"context"
+	"flag"
 	"log"
 	"net/http"
 	_ "net/http/pprof"
@@ -25,6 +26,7 @@ import (
 	"github.com/grafana/loki/pkg/storage/chunk"
 	"github.com/grafana/loki/pkg/storage/chunk/client/local"
 	"github.com/grafana/loki/pkg/storage/config"
+	"github.com/grafana/loki/pkg/storage/stores/indexshipper"
 	"github.com/grafana/loki/pkg/storage/stores/shipper"
 	util_log "github.com/grafana/loki/pkg/util/log"
 	"github.com/grafana/loki/pkg/util/marshal"
@@ -1096,6 +1098,63 @@ func TestStore_MultipleBoltDBShippersInConfig(t *testing.T) {
 	}
 }
 
+func TestStore_TSDBRecreated(t *testing.T) {
+	tempDir := t.TempDir()
+
+	limits, err := validation.NewOverrides(validation.Limits{}, nil)
+	require.NoError(t, err)
+
+	tsdbShipperConfig := indexshipper.Config{}
+	tsdbShipperConfig.RegisterFlagsWithPrefix("tsdb", flag.NewFlagSet("tsdb", flag.PanicOnError))
+	tsdbShipperConfig.ActiveIndexDirectory = path.Join(tempDir, "tsdb-index")
+	tsdbShipperConfig.SharedStoreType = "filesystem"
+	tsdbShipperConfig.CacheLocation = path.Join(tempDir, "tsdb-shipper-cache")
+	tsdbShipperConfig.Mode = indexshipper.ModeReadWrite
+
+	cfg := Config{
+		FSConfig:          local.FSConfig{Directory: path.Join(tempDir, "chunks")},
+		TSDBShipperConfig: tsdbShipperConfig,
+	}
+
+	storeDate := parseDate("2019-01-01")
+	schemaConfig := config.SchemaConfig{
+		Configs: []config.PeriodConfig{
+			{
+				From:       config.DayTime{Time: timeToModelTime(storeDate)},
+				IndexType:  config.TSDBType,
+				ObjectType: "filesystem",
+				Schema:     "v12",
+				IndexTables: config.PeriodicTableConfig{
+					Prefix: "index_",
+					Period: time.Hour * 24,
+				},
+			},
+		},
+	}
+
+	// The TSDB store of a stopped store must not be reused by the stores created after it.
+	for i := 0; i < 2; i++ {
+		store, err := NewStore(cfg, config.ChunkStoreConfig{}, schemaConfig, limits, cm, nil, util_log.Logger)
+		require.NoError(t, err)
+
+		tr := timeRange{storeDate.Add(time.Duration(i) * time.Hour), storeDate.Add(time.Duration(i)*time.Hour + time.Minute)}
+		chk := newChunk(buildTestStreams(fooLabelsWithName, tr))
+		require.NoError(t, store.PutOne(ctx, chk.From, chk.Through, chk))
+
+		chunks, _, err := store.GetChunkRefs(ctx, "fake", chk.From, chk.Through, newMatchers(fooLabelsWithName.String())...)
+		require.NoError(t, err)
+		var found bool
+		for _, chks := range chunks {
+			for _, c := range chks {
+				found = found || (c.From == chk.From && c.Through == chk.Through)
+			}
+		}
+		require.True(t, found)
+
+		store.Stop()
+	}
+}
+
 func mustParseLabels(s string) map[string]string {
 	l, err := marshal.NewLabelSet(s)
 	if err != nil {
@@ -7,6 +7,7 @@ import (
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/model/labels"
 
+	"github.com/grafana/loki/pkg/logproto"
 	"github.com/grafana/loki/pkg/storage/chunk"
 	"github.com/grafana/loki/pkg/storage/chunk/fetcher"
 	"github.com/grafana/loki/pkg/util"
@@ -22,6 +23,8 @@ type Store interface {
 	GetSeries(ctx context.Context, userID string, from, through model.Time, matchers ...*labels.Matcher) ([]labels.Labels, error)
 	LabelValuesForMetricName(ctx context.Context, userID string, from, through model.Time, metricName string, labelName string, matchers ...*labels.Matcher) ([]string, error)
 	LabelNamesForMetricName(ctx context.Context, userID string, from, through model.Time, metricName string) ([]string, error)
+	// Stats returns the number of streams, chunks, entries and bytes of the chunks matching the matchers within the time range.
+	Stats(ctx context.Context, userID string, from, through model.Time, matchers ...*labels.Matcher) (*logproto.IndexStatsResponse, error)
 	GetChunkFetcher(tm model.Time) *fetcher.Fetcher
 	SetChunkFilterer(chunkFilter chunk.RequestChunkFilterer)
 	Stop()
@@ -139,6 +142,25 @@ func (c compositeStore) LabelNamesForMetricName(ctx context.Context, userID stri
 	return result.Strings(), err
 }
 
+// Stats returns the stats of the streams matching the matchers within the time range from the stores of each period.
+// The bytes and entries of the chunks spanning over two periods are prorated by each of them, however their
+// chunk count is accounted for in both periods since each of their indices references them.
+func (c compositeStore) Stats(ctx context.Context, userID string, from, through model.Time, matchers ...*labels.Matcher) (*logproto.IndexStatsResponse, error) {
+	var xs []*logproto.IndexStatsResponse
+	err := c.forStores(ctx, from, through, func(innerCtx context.Context, from, through model.Time, store Store) error {
+		x, err := store.Stats(innerCtx, userID, from, through, matchers...)
+		if err != nil {
+			return err
+		}
+		xs = append(xs, x)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return logproto.MergeIndexStatsResponses(xs), nil
+}
+
 func (c compositeStore) GetChunkRefs(ctx context.Context, userID string, from, through model.Time, matchers ...*labels.Matcher) ([][]chunk.Chunk, []*fetcher.Fetcher, error) {
 	chunkIDs := [][]chunk.Chunk{}
 	fetchers := []*fetcher.Fetcher{}
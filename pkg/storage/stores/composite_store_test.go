@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/weaveworks/common/test"
 
+	"github.com/grafana/loki/pkg/logproto"
 	"github.com/grafana/loki/pkg/storage/chunk"
 	"github.com/grafana/loki/pkg/storage/chunk/fetcher"
 )
@@ -43,6 +44,10 @@ func (m mockStore) LabelNamesForMetricName(ctx context.Context, userID string, f
 	return nil, nil
 }
 
+func (m mockStore) Stats(ctx context.Context, userID string, from, through model.Time, matchers ...*labels.Matcher) (*logproto.IndexStatsResponse, error) {
+	return nil, nil
+}
+
 func (m mockStore) GetChunkFetcher(tm model.Time) *fetcher.Fetcher {
 	return nil
 }
@@ -233,6 +238,52 @@ func TestCompositeStoreLabels(t *testing.T) {
 	}
 }
 
+type mockStoreStats struct {
+	mockStore
+	stats *logproto.IndexStatsResponse
+}
+
+func (m mockStoreStats) Stats(ctx context.Context, userID string, from, through model.Time, matchers ...*labels.Matcher) (*logproto.IndexStatsResponse, error) {
+	return m.stats, nil
+}
+
+func TestCompositeStoreStats(t *testing.T) {
+	t.Parallel()
+
+	cs := compositeStore{
+		stores: []compositeStoreEntry{
+			{model.TimeFromUnix(0), mockStore(1)},
+			{model.TimeFromUnix(20), mockStoreStats{mockStore(1), &logproto.IndexStatsResponse{Streams: 1, Chunks: 2, Bytes: 3, Entries: 4}}},
+			{model.TimeFromUnix(40), mockStoreStats{mockStore(1), &logproto.IndexStatsResponse{Streams: 10, Chunks: 20, Bytes: 30, Entries: 40}}},
+		},
+	}
+
+	for i, tc := range []struct {
+		from, through int64
+		want          *logproto.IndexStatsResponse
+	}{
+		{
+			0, 10,
+			&logproto.IndexStatsResponse{},
+		},
+		{
+			0, 30,
+			&logproto.IndexStatsResponse{Streams: 1, Chunks: 2, Bytes: 3, Entries: 4},
+		},
+		{
+			0, 40,
+			// the streams of the periods are not summed.
+			&logproto.IndexStatsResponse{Streams: 10, Chunks: 22, Bytes: 33, Entries: 44},
+		},
+	} {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			have, err := cs.Stats(context.Background(), "", model.TimeFromUnix(tc.from), model.TimeFromUnix(tc.through))
+			require.NoError(t, err)
+			require.Equal(t, tc.want, have)
+		})
+	}
+}
+
 type mockStoreGetChunkFetcher struct {
 	mockStore
 	chunkFetcher *fetcher.Fetcher
@@ -14,6 +14,7 @@ import (
 	"github.com/grafana/loki/pkg/storage/stores/indexshipper/index"
 	"github.com/grafana/loki/pkg/storage/stores/indexshipper/uploads"
 	"github.com/grafana/loki/pkg/storage/stores/shipper/storage"
+	shipper_util "github.com/grafana/loki/pkg/storage/stores/shipper/util"
 	util_log "github.com/grafana/loki/pkg/util/log"
 )
 
@@ -72,6 +73,10 @@ func (cfg *Config) RegisterFlagsWithPrefix(prefix string, f *flag.FlagSet) {
 	f.IntVar(&cfg.QueryReadyNumDays, prefix+".shipper.query-ready-num-days", 0, "Number of days of common index to be kept downloaded for queries. For per tenant index query readiness, use limits overrides config.")
 }
 
+func (cfg *Config) Validate() error {
+	return shipper_util.ValidateSharedStoreKeyPrefix(cfg.SharedStoreKeyPrefix)
+}
+
 type indexShipper struct {
 	cfg               Config
 	openIndexFileFunc index.OpenIndexFileFunc
@@ -83,9 +88,10 @@ type indexShipper struct {
 
 // NewIndexShipper creates a shipper for providing index store functionality using index files and object storage.
 // It manages the whole life cycle of uploading the index and downloading the index at query time.
-func NewIndexShipper(cfg Config, storageClient client.ObjectClient, limits downloads.Limits) (IndexShipper, error) {
+func NewIndexShipper(cfg Config, storageClient client.ObjectClient, limits downloads.Limits, open index.OpenIndexFileFunc) (IndexShipper, error) {
 	shipper := indexShipper{
-		cfg: cfg,
+		cfg:               cfg,
+		openIndexFileFunc: open,
 	}
 
 	err := shipper.init(storageClient, limits)
@@ -140,11 +146,15 @@ func (s *indexShipper) AddIndex(tableName, userID string, index index.Index) err
 
 func (s *indexShipper) ForEach(ctx context.Context, tableName, userID string, callback func(index index.Index) error) error {
 	if s.downloadsManager != nil {
-		return s.downloadsManager.ForEach(ctx, tableName, userID, callback)
+		if err := s.downloadsManager.ForEach(ctx, tableName, userID, callback); err != nil {
+			return err
+		}
 	}
 
 	if s.uploadsManager != nil {
-		return s.uploadsManager.ForEach(tableName, userID, callback)
+		if err := s.uploadsManager.ForEach(tableName, userID, callback); err != nil {
+			return err
+		}
 	}
 
 	return nil
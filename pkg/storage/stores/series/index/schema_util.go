@@ -52,6 +52,13 @@ func labelsSeriesID(ls labels.Labels) []byte {
 	return encodeBase64Bytes(h[:])
 }
 
+// RowShardForLabels returns the row shard the series with the given labels is written to
+// by schemas v10 and above when they are configured with the given number of row shards.
+// The labels are expected to include the metric name, as they are stored in the chunk.
+func RowShardForLabels(ls labels.Labels, rowShards uint32) uint32 {
+	return binary.BigEndian.Uint32(labelsSeriesID(ls)) % rowShards
+}
+
 func sha256bytes(s string) []byte {
 	h := sha256.Sum256([]byte(s))
 	return encodeBase64Bytes(h[:])
@@ -8,9 +8,14 @@ import (
 	"google.golang.org/grpc"
 
 	"github.com/grafana/loki/pkg/logproto"
+	"github.com/grafana/loki/pkg/logql/syntax"
+	"github.com/grafana/loki/pkg/storage/chunk"
 	"github.com/grafana/loki/pkg/storage/stores/shipper/indexgateway/indexgatewaypb"
 )
 
+// IndexGatewayClientStore serves index queries from the index gateway.
+// When an IndexStore is given, queries are resolved locally using the index gateway as the index client(boltdb-shipper),
+// otherwise each query is delegated to the index gateway RPCs (tsdb).
 type IndexGatewayClientStore struct {
 	client IndexGatewayClient
 	*IndexStore
@@ -18,8 +23,10 @@ type IndexGatewayClientStore struct {
 
 type IndexGatewayClient interface {
 	GetChunkRef(ctx context.Context, in *indexgatewaypb.GetChunkRefRequest, opts ...grpc.CallOption) (*indexgatewaypb.GetChunkRefResponse, error)
+	GetSeries(ctx context.Context, in *indexgatewaypb.GetSeriesRequest, opts ...grpc.CallOption) (*indexgatewaypb.GetSeriesResponse, error)
 	LabelNamesForMetricName(ctx context.Context, in *indexgatewaypb.LabelNamesForMetricNameRequest, opts ...grpc.CallOption) (*indexgatewaypb.LabelResponse, error)
 	LabelValuesForMetricName(ctx context.Context, in *indexgatewaypb.LabelValuesForMetricNameRequest, opts ...grpc.CallOption) (*indexgatewaypb.LabelResponse, error)
+	GetStats(ctx context.Context, in *logproto.IndexStatsRequest, opts ...grpc.CallOption) (*logproto.IndexStatsResponse, error)
 }
 
 func NewIndexGatewayClientStore(client IndexGatewayClient, index *IndexStore) *IndexGatewayClientStore {
@@ -30,18 +37,99 @@ func NewIndexGatewayClientStore(client IndexGatewayClient, index *IndexStore) *I
 }
 
 func (c *IndexGatewayClientStore) GetChunkRefs(ctx context.Context, userID string, from, through model.Time, allMatchers ...*labels.Matcher) ([]logproto.ChunkRef, error) {
-	return c.IndexStore.GetChunkRefs(ctx, userID, from, through, allMatchers...)
+	if c.IndexStore != nil {
+		return c.IndexStore.GetChunkRefs(ctx, userID, from, through, allMatchers...)
+	}
+
+	response, err := c.client.GetChunkRef(ctx, &indexgatewaypb.GetChunkRefRequest{
+		From:     from,
+		Through:  through,
+		Matchers: (&syntax.MatchersExpr{Mts: allMatchers}).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]logproto.ChunkRef, len(response.Refs))
+	for i, ref := range response.Refs {
+		result[i] = *ref
+	}
+
+	return result, nil
 }
 
 func (c *IndexGatewayClientStore) GetSeries(ctx context.Context, userID string, from, through model.Time, matchers ...*labels.Matcher) ([]labels.Labels, error) {
-	return c.IndexStore.GetSeries(ctx, userID, from, through, matchers...)
+	if c.IndexStore != nil {
+		return c.IndexStore.GetSeries(ctx, userID, from, through, matchers...)
+	}
+
+	response, err := c.client.GetSeries(ctx, &indexgatewaypb.GetSeriesRequest{
+		From:     from,
+		Through:  through,
+		Matchers: (&syntax.MatchersExpr{Mts: matchers}).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]labels.Labels, len(response.Series))
+	for i, s := range response.Series {
+		result[i] = labels.FromMap(s.Labels)
+	}
+
+	return result, nil
 }
 
 // LabelNamesForMetricName retrieves all label names for a metric name.
 func (c *IndexGatewayClientStore) LabelNamesForMetricName(ctx context.Context, userID string, from, through model.Time, metricName string) ([]string, error) {
-	return c.IndexStore.LabelNamesForMetricName(ctx, userID, from, through, metricName)
+	if c.IndexStore != nil {
+		return c.IndexStore.LabelNamesForMetricName(ctx, userID, from, through, metricName)
+	}
+
+	resp, err := c.client.LabelNamesForMetricName(ctx, &indexgatewaypb.LabelNamesForMetricNameRequest{
+		MetricName: metricName,
+		From:       from,
+		Through:    through,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Values, nil
 }
 
 func (c *IndexGatewayClientStore) LabelValuesForMetricName(ctx context.Context, userID string, from, through model.Time, metricName string, labelName string, matchers ...*labels.Matcher) ([]string, error) {
-	return c.IndexStore.LabelValuesForMetricName(ctx, userID, from, through, metricName, labelName)
+	if c.IndexStore != nil {
+		return c.IndexStore.LabelValuesForMetricName(ctx, userID, from, through, metricName, labelName, matchers...)
+	}
+
+	resp, err := c.client.LabelValuesForMetricName(ctx, &indexgatewaypb.LabelValuesForMetricNameRequest{
+		MetricName: metricName,
+		LabelName:  labelName,
+		From:       from,
+		Through:    through,
+		Matchers:   (&syntax.MatchersExpr{Mts: matchers}).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Values, nil
+}
+
+func (c *IndexGatewayClientStore) Stats(ctx context.Context, userID string, from, through model.Time, matchers ...*labels.Matcher) (*logproto.IndexStatsResponse, error) {
+	if c.IndexStore != nil {
+		return c.IndexStore.Stats(ctx, userID, from, through, matchers...)
+	}
+
+	return c.client.GetStats(ctx, &logproto.IndexStatsRequest{
+		From:     from,
+		Through:  through,
+		Matchers: (&syntax.MatchersExpr{Mts: matchers}).String(),
+	})
+}
+
+func (c *IndexGatewayClientStore) SetChunkFilterer(chunkFilter chunk.RequestChunkFilterer) {
+	// if there is no IndexStore, the chunk filtering is done by the index gateway.
+	if c.IndexStore != nil {
+		c.IndexStore.SetChunkFilterer(chunkFilter)
+	}
 }
@@ -232,6 +232,25 @@ func (c *IndexStore) LabelNamesForMetricName(ctx context.Context, userID string,
 	return labelNames, nil
 }
 
+// Stats returns the number of streams and chunks matching the matchers within the time range.
+// The series index doesn't hold the size of the chunks, so the entries and bytes are not accounted for.
+func (c *IndexStore) Stats(ctx context.Context, userID string, from, through model.Time, matchers ...*labels.Matcher) (*logproto.IndexStatsResponse, error) {
+	refs, err := c.GetChunkRefs(ctx, userID, from, through, matchers...)
+	if err != nil {
+		return nil, err
+	}
+
+	streams := make(map[uint64]struct{})
+	for _, ref := range refs {
+		streams[ref.Fingerprint] = struct{}{}
+	}
+
+	return &logproto.IndexStatsResponse{
+		Streams: uint64(len(streams)),
+		Chunks:  uint64(len(refs)),
+	}, nil
+}
+
 func (c *IndexStore) LabelValuesForMetricName(ctx context.Context, userID string, from, through model.Time, metricName string, labelName string, matchers ...*labels.Matcher) ([]string, error) {
 	log, ctx := spanlogger.New(ctx, "SeriesStore.LabelValuesForMetricName")
 	defer log.Span.Finish()
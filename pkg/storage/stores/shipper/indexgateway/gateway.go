@@ -44,8 +44,10 @@ const (
 
 type IndexQuerier interface {
 	GetChunkRefs(ctx context.Context, userID string, from, through model.Time, matchers ...*labels.Matcher) ([][]chunk.Chunk, []*fetcher.Fetcher, error)
+	GetSeries(ctx context.Context, userID string, from, through model.Time, matchers ...*labels.Matcher) ([]labels.Labels, error)
 	LabelValuesForMetricName(ctx context.Context, userID string, from, through model.Time, metricName string, labelName string, matchers ...*labels.Matcher) ([]string, error)
 	LabelNamesForMetricName(ctx context.Context, userID string, from, through model.Time, metricName string) ([]string, error)
+	Stats(ctx context.Context, userID string, from, through model.Time, matchers ...*labels.Matcher) (*logproto.IndexStatsResponse, error)
 	Stop()
 }
 
@@ -127,8 +129,7 @@ func NewIndexGateway(cfg Config, log log.Logger, registerer prometheus.Registere
 		g.Service = services.NewBasicService(g.starting, g.running, g.stopping)
 	} else {
 		g.Service = services.NewIdleService(nil, func(failureCase error) error {
-			g.indexQuerier.Stop()
-			g.indexClient.Stop()
+			g.stopDependencies()
 			return nil
 		})
 	}
@@ -209,14 +210,23 @@ func (g *Gateway) running(ctx context.Context) error {
 // Only invoked if the Index Gateway is in ring mode.
 func (g *Gateway) stopping(_ error) error {
 	level.Debug(util_log.Logger).Log("msg", "stopping index gateway")
-	defer func() {
-		g.indexQuerier.Stop()
-		g.indexClient.Stop()
-	}()
+	defer g.stopDependencies()
 	return services.StopManagerAndAwaitStopped(context.Background(), g.subservices)
 }
 
+func (g *Gateway) stopDependencies() {
+	g.indexQuerier.Stop()
+	// indexClient is only set when one of the period configs uses boltdb-shipper.
+	if g.indexClient != nil {
+		g.indexClient.Stop()
+	}
+}
+
 func (g *Gateway) QueryIndex(request *indexgatewaypb.QueryIndexRequest, server indexgatewaypb.IndexGateway_QueryIndexServer) error {
+	if g.indexClient == nil {
+		return errors.New("index gateway is not serving boltdb-shipper index")
+	}
+
 	var outerErr error
 	var innerErr error
 
@@ -314,6 +324,30 @@ func (g *Gateway) GetChunkRef(ctx context.Context, req *indexgatewaypb.GetChunkR
 	return result, nil
 }
 
+func (g *Gateway) GetSeries(ctx context.Context, req *indexgatewaypb.GetSeriesRequest) (*indexgatewaypb.GetSeriesResponse, error) {
+	instanceID, err := tenant.TenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	matchers, err := syntax.ParseMatchers(req.Matchers)
+	if err != nil {
+		return nil, err
+	}
+	series, err := g.indexQuerier.GetSeries(ctx, instanceID, req.From, req.Through, matchers...)
+	if err != nil {
+		return nil, err
+	}
+	result := &indexgatewaypb.GetSeriesResponse{
+		Series: make([]logproto.SeriesIdentifier, 0, len(series)),
+	}
+	for _, s := range series {
+		result.Series = append(result.Series, logproto.SeriesIdentifier{
+			Labels: s.Map(),
+		})
+	}
+	return result, nil
+}
+
 func (g *Gateway) LabelNamesForMetricName(ctx context.Context, req *indexgatewaypb.LabelNamesForMetricNameRequest) (*indexgatewaypb.LabelResponse, error) {
 	instanceID, err := tenant.TenantID(ctx)
 	if err != nil {
@@ -351,6 +385,18 @@ func (g *Gateway) LabelValuesForMetricName(ctx context.Context, req *indexgatewa
 	}, nil
 }
 
+func (g *Gateway) GetStats(ctx context.Context, req *logproto.IndexStatsRequest) (*logproto.IndexStatsResponse, error) {
+	instanceID, err := tenant.TenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	matchers, err := syntax.ParseMatchers(req.Matchers)
+	if err != nil {
+		return nil, err
+	}
+	return g.indexQuerier.Stats(ctx, instanceID, req.From, req.Through, matchers...)
+}
+
 // ServeHTTP serves the HTTP route /indexgateway/ring.
 func (g *Gateway) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	if g.cfg.Mode == RingMode {
@@ -269,6 +269,94 @@ func (m *GetChunkRefResponse) GetRefs() []*logproto.ChunkRef {
 	return nil
 }
 
+type GetSeriesRequest struct {
+	From     github_com_prometheus_common_model.Time `protobuf:"varint,1,opt,name=from,proto3,customtype=github.com/prometheus/common/model.Time" json:"from"`
+	Through  github_com_prometheus_common_model.Time `protobuf:"varint,2,opt,name=through,proto3,customtype=github.com/prometheus/common/model.Time" json:"through"`
+	Matchers string                                  `protobuf:"bytes,3,opt,name=matchers,proto3" json:"matchers,omitempty"`
+}
+
+func (m *GetSeriesRequest) Reset()      { *m = GetSeriesRequest{} }
+func (*GetSeriesRequest) ProtoMessage() {}
+func (*GetSeriesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33a7bd4603d312b2, []int{5}
+}
+func (m *GetSeriesRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetSeriesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetSeriesRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetSeriesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSeriesRequest.Merge(m, src)
+}
+func (m *GetSeriesRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetSeriesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSeriesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSeriesRequest proto.InternalMessageInfo
+
+func (m *GetSeriesRequest) GetMatchers() string {
+	if m != nil {
+		return m.Matchers
+	}
+	return ""
+}
+
+type GetSeriesResponse struct {
+	Series []logproto.SeriesIdentifier `protobuf:"bytes,1,rep,name=series,proto3" json:"series"`
+}
+
+func (m *GetSeriesResponse) Reset()      { *m = GetSeriesResponse{} }
+func (*GetSeriesResponse) ProtoMessage() {}
+func (*GetSeriesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33a7bd4603d312b2, []int{6}
+}
+func (m *GetSeriesResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetSeriesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetSeriesResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetSeriesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSeriesResponse.Merge(m, src)
+}
+func (m *GetSeriesResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetSeriesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSeriesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSeriesResponse proto.InternalMessageInfo
+
+func (m *GetSeriesResponse) GetSeries() []logproto.SeriesIdentifier {
+	if m != nil {
+		return m.Series
+	}
+	return nil
+}
+
 type QueryIndexResponse struct {
 	QueryKey string `protobuf:"bytes,1,opt,name=QueryKey,proto3" json:"QueryKey,omitempty"`
 	Rows     []*Row `protobuf:"bytes,2,rep,name=rows,proto3" json:"rows,omitempty"`
@@ -277,7 +365,7 @@ type QueryIndexResponse struct {
 func (m *QueryIndexResponse) Reset()      { *m = QueryIndexResponse{} }
 func (*QueryIndexResponse) ProtoMessage() {}
 func (*QueryIndexResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_33a7bd4603d312b2, []int{5}
+	return fileDescriptor_33a7bd4603d312b2, []int{7}
 }
 func (m *QueryIndexResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -328,7 +416,7 @@ type Row struct {
 func (m *Row) Reset()      { *m = Row{} }
 func (*Row) ProtoMessage() {}
 func (*Row) Descriptor() ([]byte, []int) {
-	return fileDescriptor_33a7bd4603d312b2, []int{6}
+	return fileDescriptor_33a7bd4603d312b2, []int{8}
 }
 func (m *Row) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -378,7 +466,7 @@ type QueryIndexRequest struct {
 func (m *QueryIndexRequest) Reset()      { *m = QueryIndexRequest{} }
 func (*QueryIndexRequest) ProtoMessage() {}
 func (*QueryIndexRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_33a7bd4603d312b2, []int{7}
+	return fileDescriptor_33a7bd4603d312b2, []int{9}
 }
 func (m *QueryIndexRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -425,7 +513,7 @@ type IndexQuery struct {
 func (m *IndexQuery) Reset()      { *m = IndexQuery{} }
 func (*IndexQuery) ProtoMessage() {}
 func (*IndexQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_33a7bd4603d312b2, []int{8}
+	return fileDescriptor_33a7bd4603d312b2, []int{10}
 }
 func (m *IndexQuery) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -495,6 +583,8 @@ func init() {
 	proto.RegisterType((*LabelResponse)(nil), "indexgatewaypb.LabelResponse")
 	proto.RegisterType((*GetChunkRefRequest)(nil), "indexgatewaypb.GetChunkRefRequest")
 	proto.RegisterType((*GetChunkRefResponse)(nil), "indexgatewaypb.GetChunkRefResponse")
+	proto.RegisterType((*GetSeriesRequest)(nil), "indexgatewaypb.GetSeriesRequest")
+	proto.RegisterType((*GetSeriesResponse)(nil), "indexgatewaypb.GetSeriesResponse")
 	proto.RegisterType((*QueryIndexResponse)(nil), "indexgatewaypb.QueryIndexResponse")
 	proto.RegisterType((*Row)(nil), "indexgatewaypb.Row")
 	proto.RegisterType((*QueryIndexRequest)(nil), "indexgatewaypb.QueryIndexRequest")
@@ -506,51 +596,57 @@ func init() {
 }
 
 var fileDescriptor_33a7bd4603d312b2 = []byte{
-	// 704 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x55, 0xcd, 0x6e, 0xd3, 0x4e,
-	0x10, 0xf7, 0x26, 0xe9, 0x47, 0xa6, 0xf9, 0x7f, 0xb0, 0x45, 0x10, 0x19, 0xba, 0x29, 0x46, 0xa2,
-	0x11, 0x12, 0x31, 0x2a, 0xbd, 0x21, 0x2e, 0x2d, 0x50, 0x55, 0x94, 0x0a, 0x16, 0xa8, 0xe0, 0x84,
-	0x9c, 0x74, 0x63, 0x87, 0xda, 0xd9, 0x74, 0x6d, 0x93, 0xf6, 0xc6, 0x0b, 0x20, 0x71, 0xe5, 0x0d,
-	0x78, 0x0a, 0xc4, 0xb1, 0xc7, 0x72, 0xab, 0x38, 0x54, 0x34, 0xbd, 0x70, 0xec, 0x23, 0xa0, 0x8c,
-	0x63, 0x3b, 0x1f, 0x6d, 0x91, 0x4a, 0x4f, 0xd9, 0xf9, 0xcd, 0x6f, 0x66, 0xe7, 0x37, 0xe3, 0xd9,
-	0xc0, 0xc3, 0xd6, 0xa6, 0x6d, 0xfa, 0x81, 0x54, 0x96, 0x2d, 0xf0, 0x57, 0xf8, 0xa6, 0xef, 0x34,
-	0x5a, 0x2d, 0xa1, 0xcc, 0x46, 0x73, 0x43, 0x6c, 0xdb, 0x56, 0x20, 0xda, 0xd6, 0xce, 0x80, 0xd1,
-	0xaa, 0x9a, 0xbd, 0x53, 0xa5, 0xa5, 0x64, 0x20, 0xe9, 0xbf, 0x83, 0x5e, 0xfd, 0x8e, 0xdd, 0x08,
-	0x9c, 0xb0, 0x5a, 0xa9, 0x49, 0xcf, 0xb4, 0xa5, 0x2d, 0x4d, 0xa4, 0x55, 0xc3, 0x3a, 0x5a, 0x68,
-	0xe0, 0x29, 0x0a, 0xd7, 0xaf, 0x75, 0x8b, 0x70, 0xa5, 0x1d, 0x39, 0xe2, 0x43, 0xe4, 0x34, 0x3e,
-	0x66, 0xa0, 0xb4, 0x6a, 0x55, 0x85, 0xbb, 0x6e, 0xb9, 0xa1, 0xf0, 0x1f, 0x4b, 0xf5, 0x54, 0x04,
-	0xaa, 0x51, 0x5b, 0xb3, 0x3c, 0xc1, 0xc5, 0x56, 0x28, 0xfc, 0x80, 0x96, 0x60, 0xca, 0x43, 0xf0,
-	0x6d, 0xd3, 0xf2, 0x44, 0x91, 0xcc, 0x92, 0x72, 0x9e, 0x83, 0x97, 0xf0, 0xe8, 0x0c, 0x80, 0xdb,
-	0xcd, 0x11, 0xf9, 0x33, 0xe8, 0xcf, 0x23, 0x82, 0xee, 0x25, 0xc8, 0xd5, 0x95, 0xf4, 0x8a, 0xd9,
-	0x59, 0x52, 0xce, 0x2e, 0x9a, 0xbb, 0x07, 0x25, 0xed, 0xc7, 0x41, 0x69, 0xae, 0x4f, 0x45, 0x4b,
-	0x49, 0x4f, 0x04, 0x8e, 0x08, 0x7d, 0xb3, 0x26, 0x3d, 0x4f, 0x36, 0x4d, 0x4f, 0x6e, 0x08, 0xb7,
-	0xf2, 0xb2, 0xe1, 0x09, 0x8e, 0xc1, 0x74, 0x05, 0x26, 0x02, 0x47, 0xc9, 0xd0, 0x76, 0x8a, 0xb9,
-	0xf3, 0xe5, 0x89, 0xe3, 0xa9, 0x0e, 0x93, 0x9e, 0x15, 0xd4, 0x1c, 0xa1, 0xfc, 0xe2, 0x18, 0x16,
-	0x9b, 0xd8, 0xc6, 0x77, 0x02, 0x6c, 0x35, 0xae, 0xfc, 0x9c, 0xed, 0x88, 0xf5, 0x66, 0x2e, 0x48,
-	0x6f, 0xf6, 0xef, 0xf4, 0x1a, 0x73, 0xf0, 0x0f, 0x4a, 0xe2, 0xc2, 0x6f, 0xc9, 0xa6, 0x2f, 0xe8,
-	0x15, 0x18, 0x7f, 0x8f, 0xe3, 0x2e, 0x92, 0xd9, 0x6c, 0x39, 0xcf, 0x7b, 0x96, 0xf1, 0x8d, 0x00,
-	0x5d, 0x16, 0xc1, 0x92, 0x13, 0x36, 0x37, 0xb9, 0xa8, 0xc7, 0x82, 0x63, 0x3d, 0xe4, 0x82, 0xf4,
-	0x64, 0x2e, 0x70, 0x7e, 0xd9, 0xa1, 0xf9, 0x3d, 0x80, 0xe9, 0x01, 0x05, 0x3d, 0xc5, 0xb7, 0x20,
-	0xa7, 0x44, 0x3d, 0xd2, 0x3b, 0x35, 0x4f, 0x2b, 0xc9, 0x16, 0x24, 0x4c, 0xf4, 0x1b, 0x6f, 0x80,
-	0x3e, 0x0f, 0x85, 0xda, 0x59, 0xe9, 0x6e, 0x5c, 0x12, 0xad, 0xc3, 0x24, 0xa2, 0x4f, 0xc4, 0x4e,
-	0x6f, 0xdc, 0x89, 0x4d, 0xe7, 0x20, 0xa7, 0x64, 0xdb, 0x2f, 0x66, 0x30, 0xf3, 0x74, 0x65, 0x70,
-	0x57, 0x2b, 0x5c, 0xb6, 0x39, 0x12, 0x8c, 0xfb, 0x90, 0xe5, 0xb2, 0x4d, 0x19, 0x80, 0xb2, 0x9a,
-	0xb6, 0xc0, 0x7d, 0xc3, 0x6c, 0x05, 0xde, 0x87, 0xd0, 0xcb, 0x30, 0x86, 0xd3, 0xc0, 0x2e, 0x15,
-	0x78, 0x64, 0x18, 0x2b, 0x70, 0xa9, 0xbf, 0xae, 0x68, 0x2e, 0x0b, 0x30, 0xd1, 0x05, 0x1b, 0x22,
-	0xd6, 0xa5, 0x0f, 0xdf, 0x8e, 0x74, 0x0c, 0xe4, 0x31, 0xd5, 0xf8, 0x4a, 0x00, 0x52, 0x9c, 0x5e,
-	0x87, 0x7c, 0x60, 0x55, 0x5d, 0xb1, 0x96, 0x7e, 0xcb, 0x29, 0xd0, 0xf5, 0x3a, 0x96, 0xef, 0xac,
-	0x27, 0x15, 0xe5, 0x79, 0x0a, 0xd0, 0xdb, 0xf0, 0x7f, 0x5a, 0xf9, 0x33, 0x25, 0xea, 0x8d, 0x6d,
-	0x1c, 0x48, 0x81, 0x8f, 0xe0, 0xb4, 0x0c, 0xff, 0xa5, 0xd8, 0x8b, 0xc0, 0x52, 0x01, 0xee, 0x71,
-	0x81, 0x0f, 0xc3, 0xdd, 0x0e, 0xa1, 0xe8, 0x47, 0x5b, 0xa1, 0xe5, 0xe2, 0x82, 0x16, 0x78, 0x1f,
-	0x32, 0xff, 0x39, 0x0b, 0x05, 0x14, 0xb0, 0x1c, 0xe9, 0xa4, 0xaf, 0x00, 0xd2, 0xe6, 0xd0, 0x1b,
-	0xc3, 0x4d, 0x18, 0x69, 0x9c, 0x6e, 0x9c, 0x45, 0x89, 0x66, 0x7e, 0x97, 0xd0, 0xd7, 0x30, 0xd5,
-	0xf7, 0x29, 0xd1, 0x91, 0xa0, 0xd1, 0x4d, 0xd1, 0x6f, 0x9e, 0xc9, 0x89, 0x32, 0x1b, 0x1a, 0x7d,
-	0x07, 0x57, 0x4f, 0x79, 0x63, 0x68, 0x65, 0x38, 0xc3, 0xd9, 0x8f, 0x91, 0x3e, 0x73, 0x22, 0xbf,
-	0xef, 0x2e, 0x17, 0x8a, 0xa7, 0xbd, 0xef, 0xd4, 0x3c, 0x31, 0xf8, 0xf4, 0x7f, 0x82, 0x3f, 0xde,
-	0xb6, 0xb8, 0xb0, 0x77, 0xc8, 0xb4, 0xfd, 0x43, 0xa6, 0x1d, 0x1f, 0x32, 0xf2, 0xa1, 0xc3, 0xc8,
-	0x97, 0x0e, 0x23, 0xbb, 0x1d, 0x46, 0xf6, 0x3a, 0x8c, 0xfc, 0xec, 0x30, 0xf2, 0xab, 0xc3, 0xb4,
-	0xe3, 0x0e, 0x23, 0x9f, 0x8e, 0x98, 0xb6, 0x77, 0xc4, 0xb4, 0xfd, 0x23, 0xa6, 0x55, 0xc7, 0x71,
-	0x17, 0xef, 0xfd, 0x0e, 0x00, 0x00, 0xff, 0xff, 0x75, 0xf7, 0x88, 0x4a, 0x2f, 0x07, 0x00, 0x00,
+	// 790 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x55, 0x4f, 0x8f, 0xdb, 0x54,
+	0x10, 0xf7, 0x4b, 0xd2, 0xed, 0x66, 0x36, 0x40, 0xfb, 0x8a, 0x20, 0x72, 0x5b, 0x27, 0x35, 0x12,
+	0x1b, 0x21, 0x11, 0xa3, 0xd2, 0x03, 0x12, 0xe2, 0xb2, 0x05, 0xa2, 0x88, 0xb6, 0xa2, 0xaf, 0x50,
+	0xc1, 0x09, 0x39, 0xd9, 0x89, 0x6d, 0x6a, 0xe7, 0xa5, 0xcf, 0xcf, 0xa4, 0x7b, 0xe3, 0x0b, 0x20,
+	0xf1, 0x31, 0xf8, 0x04, 0x1c, 0x11, 0xc7, 0x1e, 0x97, 0x5b, 0xc5, 0xa1, 0x62, 0xb3, 0x17, 0x8e,
+	0xfd, 0x08, 0xc8, 0xe3, 0xd8, 0xce, 0xbf, 0x0d, 0xd2, 0xb2, 0x17, 0x4e, 0x79, 0xf3, 0x9b, 0xdf,
+	0xcc, 0x9b, 0xdf, 0x4c, 0xde, 0x18, 0x3e, 0x9d, 0x3c, 0xf1, 0x9c, 0x58, 0x4b, 0xe5, 0x7a, 0x48,
+	0xbf, 0x18, 0x3b, 0xb1, 0x1f, 0x4c, 0x26, 0xa8, 0x9c, 0x60, 0x7c, 0x88, 0xcf, 0x3c, 0x57, 0xe3,
+	0xd4, 0x3d, 0x5a, 0x32, 0x26, 0x03, 0x67, 0x7e, 0xea, 0x4e, 0x94, 0xd4, 0x92, 0xbf, 0xbe, 0xec,
+	0x35, 0xdf, 0xf7, 0x02, 0xed, 0x27, 0x83, 0xee, 0x50, 0x46, 0x8e, 0x27, 0x3d, 0xe9, 0x10, 0x6d,
+	0x90, 0x8c, 0xc8, 0x22, 0x83, 0x4e, 0x59, 0xb8, 0x79, 0x3d, 0x2d, 0x22, 0x94, 0x5e, 0xe6, 0xc8,
+	0x0f, 0x99, 0xd3, 0xfe, 0xa9, 0x02, 0xad, 0x7b, 0xee, 0x00, 0xc3, 0xc7, 0x6e, 0x98, 0x60, 0xfc,
+	0xb9, 0x54, 0xf7, 0x51, 0xab, 0x60, 0xf8, 0xc0, 0x8d, 0x50, 0xe0, 0xd3, 0x04, 0x63, 0xcd, 0x5b,
+	0xb0, 0x17, 0x11, 0xf8, 0xdd, 0xd8, 0x8d, 0xb0, 0xc9, 0xda, 0xac, 0x53, 0x17, 0x10, 0x15, 0x3c,
+	0x7e, 0x13, 0x20, 0x4c, 0x73, 0x64, 0xfe, 0x0a, 0xf9, 0xeb, 0x84, 0x90, 0xfb, 0x2e, 0xd4, 0x46,
+	0x4a, 0x46, 0xcd, 0x6a, 0x9b, 0x75, 0xaa, 0x07, 0xce, 0xf3, 0x97, 0x2d, 0xe3, 0xcf, 0x97, 0xad,
+	0xfd, 0x05, 0x15, 0x13, 0x25, 0x23, 0xd4, 0x3e, 0x26, 0xb1, 0x33, 0x94, 0x51, 0x24, 0xc7, 0x4e,
+	0x24, 0x0f, 0x31, 0xec, 0x7e, 0x15, 0x44, 0x28, 0x28, 0x98, 0xf7, 0xe1, 0xb2, 0xf6, 0x95, 0x4c,
+	0x3c, 0xbf, 0x59, 0x3b, 0x5f, 0x9e, 0x3c, 0x9e, 0x9b, 0xb0, 0x1b, 0xb9, 0x7a, 0xe8, 0xa3, 0x8a,
+	0x9b, 0x97, 0xa8, 0xd8, 0xc2, 0xb6, 0xff, 0x60, 0x60, 0xdd, 0xcb, 0x2b, 0x3f, 0x67, 0x3b, 0x72,
+	0xbd, 0x95, 0x0b, 0xd2, 0x5b, 0xfd, 0x6f, 0x7a, 0xed, 0x7d, 0x78, 0x8d, 0x24, 0x09, 0x8c, 0x27,
+	0x72, 0x1c, 0x23, 0x7f, 0x0b, 0x76, 0x7e, 0xa0, 0x71, 0x37, 0x59, 0xbb, 0xda, 0xa9, 0x8b, 0xb9,
+	0x65, 0xff, 0xce, 0x80, 0xf7, 0x50, 0xdf, 0xf5, 0x93, 0xf1, 0x13, 0x81, 0xa3, 0x5c, 0x70, 0xae,
+	0x87, 0x5d, 0x90, 0x9e, 0xca, 0x05, 0xce, 0xaf, 0xba, 0x32, 0xbf, 0x4f, 0xe0, 0xda, 0x92, 0x82,
+	0xb9, 0xe2, 0x77, 0xa1, 0xa6, 0x70, 0x94, 0xe9, 0xdd, 0xbb, 0xcd, 0xbb, 0xc5, 0x2b, 0x28, 0x98,
+	0xe4, 0xb7, 0x7f, 0x63, 0x70, 0xa5, 0x87, 0xfa, 0x11, 0xaa, 0x00, 0xe3, 0xff, 0xa3, 0xfe, 0xfb,
+	0x70, 0x75, 0xa1, 0xfe, 0xb9, 0xfa, 0x8f, 0x60, 0x27, 0x26, 0x64, 0xae, 0xdf, 0x2c, 0xf5, 0x67,
+	0xcc, 0xfe, 0x21, 0x8e, 0x75, 0x30, 0x0a, 0x50, 0x1d, 0xd4, 0xd2, 0xb2, 0xc4, 0x9c, 0x6f, 0x7f,
+	0x0b, 0xfc, 0x61, 0x82, 0xea, 0xa8, 0x9f, 0x6e, 0xa0, 0x22, 0x9f, 0x09, 0xbb, 0x84, 0x7e, 0x81,
+	0x47, 0xf3, 0xbf, 0x7f, 0x61, 0xf3, 0x7d, 0xa8, 0x29, 0x39, 0x8d, 0x9b, 0x15, 0xba, 0xe9, 0x5a,
+	0x77, 0x79, 0x77, 0x75, 0x85, 0x9c, 0x0a, 0x22, 0xd8, 0x1f, 0x43, 0x55, 0xc8, 0x29, 0xb7, 0x00,
+	0x94, 0x3b, 0xf6, 0x90, 0xf6, 0x0f, 0x65, 0x6b, 0x88, 0x05, 0x84, 0xbf, 0x09, 0x97, 0xe8, 0xdf,
+	0x49, 0x5d, 0x6b, 0x88, 0xcc, 0xb0, 0xfb, 0x70, 0x75, 0xb1, 0xae, 0x6c, 0x4e, 0x77, 0xe0, 0xf2,
+	0xc3, 0x64, 0x59, 0xe7, 0xca, 0xed, 0x44, 0xa7, 0x40, 0x91, 0x53, 0xd3, 0x91, 0x43, 0x89, 0xf3,
+	0x1b, 0x50, 0xd7, 0xee, 0x20, 0xc4, 0x07, 0xe5, 0xdb, 0x2e, 0x81, 0xd4, 0xeb, 0xbb, 0xb1, 0xff,
+	0xb8, 0xa8, 0xa8, 0x2e, 0x4a, 0x80, 0xbf, 0x07, 0x57, 0xca, 0xca, 0xbf, 0x54, 0x38, 0x0a, 0x9e,
+	0xd1, 0x80, 0x1a, 0x62, 0x0d, 0xe7, 0x1d, 0x78, 0xa3, 0xc4, 0x1e, 0x69, 0x57, 0x69, 0xda, 0x6b,
+	0x0d, 0xb1, 0x0a, 0xa7, 0x1d, 0x22, 0xd1, 0x9f, 0x3d, 0x4d, 0xdc, 0x90, 0x16, 0x56, 0x43, 0x2c,
+	0x20, 0xb7, 0x7f, 0xad, 0x41, 0x83, 0x04, 0xf4, 0x32, 0x9d, 0xfc, 0x6b, 0x80, 0xb2, 0x39, 0xfc,
+	0xd6, 0x6a, 0x13, 0xd6, 0x1a, 0x67, 0xda, 0xdb, 0x28, 0xd9, 0xcc, 0x3f, 0x60, 0xfc, 0x1b, 0xd8,
+	0x5b, 0x78, 0x5a, 0x7c, 0x2d, 0x68, 0x7d, 0x73, 0x98, 0xef, 0x6c, 0xe5, 0x64, 0x99, 0x6d, 0x83,
+	0x7f, 0x0f, 0x6f, 0x9f, 0xb1, 0x73, 0x79, 0x77, 0x35, 0xc3, 0xf6, 0xe5, 0x6c, 0xde, 0xdc, 0xc8,
+	0x5f, 0xb8, 0x2b, 0x84, 0xe6, 0x59, 0xdf, 0x3b, 0xee, 0x6c, 0x0c, 0x3e, 0xfb, 0xcb, 0xf8, 0xef,
+	0xb7, 0x09, 0xa8, 0x17, 0xcf, 0x91, 0xb7, 0x37, 0x74, 0x63, 0x69, 0xd3, 0x98, 0xb7, 0xb6, 0x30,
+	0x8a, 0x9c, 0x3d, 0xd8, 0x4d, 0x61, 0xed, 0xea, 0x98, 0x5f, 0x2f, 0x5f, 0x32, 0x8d, 0x8b, 0xd0,
+	0x3c, 0xdb, 0x8d, 0xcd, 0xce, 0x3c, 0xd1, 0xc1, 0x9d, 0xe3, 0x13, 0xcb, 0x78, 0x71, 0x62, 0x19,
+	0xaf, 0x4e, 0x2c, 0xf6, 0xe3, 0xcc, 0x62, 0xbf, 0xcc, 0x2c, 0xf6, 0x7c, 0x66, 0xb1, 0xe3, 0x99,
+	0xc5, 0xfe, 0x9a, 0x59, 0xec, 0xef, 0x99, 0x65, 0xbc, 0x9a, 0x59, 0xec, 0xe7, 0x53, 0xcb, 0x38,
+	0x3e, 0xb5, 0x8c, 0x17, 0xa7, 0x96, 0x31, 0xd8, 0xa1, 0x8c, 0x1f, 0xfe, 0x33, 0x00, 0xe0, 0x3c,
+	0xe0, 0x67, 0xdc, 0x08, 0x00, 0x00,
 }
 
 func (this *LabelValuesForMetricNameRequest) Equal(that interface{}) bool {
@@ -707,6 +803,65 @@ func (this *GetChunkRefResponse) Equal(that interface{}) bool {
 	}
 	return true
 }
+func (this *GetSeriesRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*GetSeriesRequest)
+	if !ok {
+		that2, ok := that.(GetSeriesRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.From.Equal(that1.From) {
+		return false
+	}
+	if !this.Through.Equal(that1.Through) {
+		return false
+	}
+	if this.Matchers != that1.Matchers {
+		return false
+	}
+	return true
+}
+func (this *GetSeriesResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*GetSeriesResponse)
+	if !ok {
+		that2, ok := that.(GetSeriesResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.Series) != len(that1.Series) {
+		return false
+	}
+	for i := range this.Series {
+		if !this.Series[i].Equal(&that1.Series[i]) {
+			return false
+		}
+	}
+	return true
+}
 func (this *QueryIndexResponse) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
@@ -891,6 +1046,34 @@ func (this *GetChunkRefResponse) GoString() string {
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
+func (this *GetSeriesRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&indexgatewaypb.GetSeriesRequest{")
+	s = append(s, "From: "+fmt.Sprintf("%#v", this.From)+",\n")
+	s = append(s, "Through: "+fmt.Sprintf("%#v", this.Through)+",\n")
+	s = append(s, "Matchers: "+fmt.Sprintf("%#v", this.Matchers)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *GetSeriesResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&indexgatewaypb.GetSeriesResponse{")
+	if this.Series != nil {
+		vs := make([]logproto.SeriesIdentifier, len(this.Series))
+		for i := range vs {
+			vs[i] = this.Series[i]
+		}
+		s = append(s, "Series: "+fmt.Sprintf("%#v", vs)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
 func (this *QueryIndexResponse) GoString() string {
 	if this == nil {
 		return "nil"
@@ -969,6 +1152,10 @@ type IndexGatewayClient interface {
 	GetChunkRef(ctx context.Context, in *GetChunkRefRequest, opts ...grpc.CallOption) (*GetChunkRefResponse, error)
 	LabelNamesForMetricName(ctx context.Context, in *LabelNamesForMetricNameRequest, opts ...grpc.CallOption) (*LabelResponse, error)
 	LabelValuesForMetricName(ctx context.Context, in *LabelValuesForMetricNameRequest, opts ...grpc.CallOption) (*LabelResponse, error)
+	/// GetSeries returns the series that match the provided label matchers
+	GetSeries(ctx context.Context, in *GetSeriesRequest, opts ...grpc.CallOption) (*GetSeriesResponse, error)
+	/// GetStats returns the stats of the streams and chunks that match the provided label matchers
+	GetStats(ctx context.Context, in *logproto.IndexStatsRequest, opts ...grpc.CallOption) (*logproto.IndexStatsResponse, error)
 }
 
 type indexGatewayClient struct {
@@ -1038,6 +1225,24 @@ func (c *indexGatewayClient) LabelValuesForMetricName(ctx context.Context, in *L
 	return out, nil
 }
 
+func (c *indexGatewayClient) GetSeries(ctx context.Context, in *GetSeriesRequest, opts ...grpc.CallOption) (*GetSeriesResponse, error) {
+	out := new(GetSeriesResponse)
+	err := c.cc.Invoke(ctx, "/indexgatewaypb.IndexGateway/GetSeries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexGatewayClient) GetStats(ctx context.Context, in *logproto.IndexStatsRequest, opts ...grpc.CallOption) (*logproto.IndexStatsResponse, error) {
+	out := new(logproto.IndexStatsResponse)
+	err := c.cc.Invoke(ctx, "/indexgatewaypb.IndexGateway/GetStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // IndexGatewayServer is the server API for IndexGateway service.
 type IndexGatewayServer interface {
 	/// QueryIndex reads the indexes required for given query & sends back the batch of rows
@@ -1047,6 +1252,10 @@ type IndexGatewayServer interface {
 	GetChunkRef(context.Context, *GetChunkRefRequest) (*GetChunkRefResponse, error)
 	LabelNamesForMetricName(context.Context, *LabelNamesForMetricNameRequest) (*LabelResponse, error)
 	LabelValuesForMetricName(context.Context, *LabelValuesForMetricNameRequest) (*LabelResponse, error)
+	/// GetSeries returns the series that match the provided label matchers
+	GetSeries(context.Context, *GetSeriesRequest) (*GetSeriesResponse, error)
+	/// GetStats returns the stats of the streams and chunks that match the provided label matchers
+	GetStats(context.Context, *logproto.IndexStatsRequest) (*logproto.IndexStatsResponse, error)
 }
 
 // UnimplementedIndexGatewayServer can be embedded to have forward compatible implementations.
@@ -1065,6 +1274,12 @@ func (*UnimplementedIndexGatewayServer) LabelNamesForMetricName(ctx context.Cont
 func (*UnimplementedIndexGatewayServer) LabelValuesForMetricName(ctx context.Context, req *LabelValuesForMetricNameRequest) (*LabelResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method LabelValuesForMetricName not implemented")
 }
+func (*UnimplementedIndexGatewayServer) GetSeries(ctx context.Context, req *GetSeriesRequest) (*GetSeriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSeries not implemented")
+}
+func (*UnimplementedIndexGatewayServer) GetStats(ctx context.Context, req *logproto.IndexStatsRequest) (*logproto.IndexStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStats not implemented")
+}
 
 func RegisterIndexGatewayServer(s *grpc.Server, srv IndexGatewayServer) {
 	s.RegisterService(&_IndexGateway_serviceDesc, srv)
@@ -1145,6 +1360,42 @@ func _IndexGateway_LabelValuesForMetricName_Handler(srv interface{}, ctx context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _IndexGateway_GetSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSeriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexGatewayServer).GetSeries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/indexgatewaypb.IndexGateway/GetSeries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexGatewayServer).GetSeries(ctx, req.(*GetSeriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IndexGateway_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(logproto.IndexStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexGatewayServer).GetStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/indexgatewaypb.IndexGateway/GetStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexGatewayServer).GetStats(ctx, req.(*logproto.IndexStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _IndexGateway_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "indexgatewaypb.IndexGateway",
 	HandlerType: (*IndexGatewayServer)(nil),
@@ -1161,6 +1412,14 @@ var _IndexGateway_serviceDesc = grpc.ServiceDesc{
 			MethodName: "LabelValuesForMetricName",
 			Handler:    _IndexGateway_LabelValuesForMetricName_Handler,
 		},
+		{
+			MethodName: "GetSeries",
+			Handler:    _IndexGateway_GetSeries_Handler,
+		},
+		{
+			MethodName: "GetStats",
+			Handler:    _IndexGateway_GetStats_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1375,6 +1634,83 @@ func (m *GetChunkRefResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *GetSeriesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetSeriesRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetSeriesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Matchers) > 0 {
+		i -= len(m.Matchers)
+		copy(dAtA[i:], m.Matchers)
+		i = encodeVarintGateway(dAtA, i, uint64(len(m.Matchers)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Through != 0 {
+		i = encodeVarintGateway(dAtA, i, uint64(m.Through))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.From != 0 {
+		i = encodeVarintGateway(dAtA, i, uint64(m.From))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetSeriesResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetSeriesResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetSeriesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Series) > 0 {
+		for iNdEx := len(m.Series) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Series[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGateway(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *QueryIndexResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1608,22 +1944,56 @@ func (m *LabelNamesForMetricNameRequest) Size() (n int) {
 	return n
 }
 
-func (m *LabelResponse) Size() (n int) {
+func (m *LabelResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Values) > 0 {
+		for _, s := range m.Values {
+			l = len(s)
+			n += 1 + l + sovGateway(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *GetChunkRefRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.From != 0 {
+		n += 1 + sovGateway(uint64(m.From))
+	}
+	if m.Through != 0 {
+		n += 1 + sovGateway(uint64(m.Through))
+	}
+	l = len(m.Matchers)
+	if l > 0 {
+		n += 1 + l + sovGateway(uint64(l))
+	}
+	return n
+}
+
+func (m *GetChunkRefResponse) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if len(m.Values) > 0 {
-		for _, s := range m.Values {
-			l = len(s)
+	if len(m.Refs) > 0 {
+		for _, e := range m.Refs {
+			l = e.Size()
 			n += 1 + l + sovGateway(uint64(l))
 		}
 	}
 	return n
 }
 
-func (m *GetChunkRefRequest) Size() (n int) {
+func (m *GetSeriesRequest) Size() (n int) {
 	if m == nil {
 		return 0
 	}
@@ -1642,14 +2012,14 @@ func (m *GetChunkRefRequest) Size() (n int) {
 	return n
 }
 
-func (m *GetChunkRefResponse) Size() (n int) {
+func (m *GetSeriesResponse) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if len(m.Refs) > 0 {
-		for _, e := range m.Refs {
+	if len(m.Series) > 0 {
+		for _, e := range m.Series {
 			l = e.Size()
 			n += 1 + l + sovGateway(uint64(l))
 		}
@@ -1806,6 +2176,33 @@ func (this *GetChunkRefResponse) String() string {
 	}, "")
 	return s
 }
+func (this *GetSeriesRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&GetSeriesRequest{`,
+		`From:` + fmt.Sprintf("%v", this.From) + `,`,
+		`Through:` + fmt.Sprintf("%v", this.Through) + `,`,
+		`Matchers:` + fmt.Sprintf("%v", this.Matchers) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *GetSeriesResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForSeries := "[]SeriesIdentifier{"
+	for _, f := range this.Series {
+		repeatedStringForSeries += fmt.Sprintf("%v", f) + ","
+	}
+	repeatedStringForSeries += "}"
+	s := strings.Join([]string{`&GetSeriesResponse{`,
+		`Series:` + repeatedStringForSeries + `,`,
+		`}`,
+	}, "")
+	return s
+}
 func (this *QueryIndexResponse) String() string {
 	if this == nil {
 		return "nil"
@@ -2475,6 +2872,216 @@ func (m *GetChunkRefResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *GetSeriesRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGateway
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetSeriesRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetSeriesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field From", wireType)
+			}
+			m.From = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGateway
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.From |= github_com_prometheus_common_model.Time(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Through", wireType)
+			}
+			m.Through = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGateway
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Through |= github_com_prometheus_common_model.Time(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Matchers", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGateway
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGateway
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGateway
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Matchers = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGateway(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGateway
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthGateway
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetSeriesResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGateway
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetSeriesResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetSeriesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Series", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGateway
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGateway
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGateway
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Series = append(m.Series, logproto.SeriesIdentifier{})
+			if err := m.Series[len(m.Series)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGateway(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGateway
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthGateway
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *QueryIndexResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -3024,6 +3631,7 @@ func (m *IndexQuery) Unmarshal(dAtA []byte) error {
 func skipGateway(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
+	depth := 0
 	for iNdEx < l {
 		var wire uint64
 		for shift := uint(0); ; shift += 7 {
@@ -3055,10 +3663,8 @@ func skipGateway(dAtA []byte) (n int, err error) {
 					break
 				}
 			}
-			return iNdEx, nil
 		case 1:
 			iNdEx += 8
-			return iNdEx, nil
 		case 2:
 			var length int
 			for shift := uint(0); ; shift += 7 {
@@ -3079,55 +3685,30 @@ func skipGateway(dAtA []byte) (n int, err error) {
 				return 0, ErrInvalidLengthGateway
 			}
 			iNdEx += length
-			if iNdEx < 0 {
-				return 0, ErrInvalidLengthGateway
-			}
-			return iNdEx, nil
 		case 3:
-			for {
-				var innerWire uint64
-				var start int = iNdEx
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return 0, ErrIntOverflowGateway
-					}
-					if iNdEx >= l {
-						return 0, io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					innerWire |= (uint64(b) & 0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				innerWireType := int(innerWire & 0x7)
-				if innerWireType == 4 {
-					break
-				}
-				next, err := skipGateway(dAtA[start:])
-				if err != nil {
-					return 0, err
-				}
-				iNdEx = start + next
-				if iNdEx < 0 {
-					return 0, ErrInvalidLengthGateway
-				}
-			}
-			return iNdEx, nil
+			depth++
 		case 4:
-			return iNdEx, nil
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupGateway
+			}
+			depth--
 		case 5:
 			iNdEx += 4
-			return iNdEx, nil
 		default:
 			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
 		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthGateway
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
 	}
-	panic("unreachable")
+	return 0, io.ErrUnexpectedEOF
 }
 
 var (
-	ErrInvalidLengthGateway = fmt.Errorf("proto: negative length found during unmarshaling")
-	ErrIntOverflowGateway   = fmt.Errorf("proto: integer overflow")
+	ErrInvalidLengthGateway        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowGateway          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupGateway = fmt.Errorf("proto: unexpected end of group")
 )
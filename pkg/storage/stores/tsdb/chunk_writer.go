@@ -0,0 +1,101 @@
+package tsdb
+
+import (
+	"context"
+
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+
+	"github.com/grafana/loki/pkg/chunkenc"
+	"github.com/grafana/loki/pkg/storage/chunk"
+	"github.com/grafana/loki/pkg/storage/chunk/fetcher"
+	"github.com/grafana/loki/pkg/storage/config"
+	"github.com/grafana/loki/pkg/storage/stores/tsdb/index"
+	"github.com/grafana/loki/pkg/util/spanlogger"
+)
+
+// IndexWriter adds the chunks of a series to the index of a table.
+type IndexWriter interface {
+	Append(table, userID string, ls labels.Labels, chks index.ChunkMetas) error
+}
+
+// ChunkWriter writes chunks to the chunk store and indexes them in TSDB.
+type ChunkWriter struct {
+	schemaCfg   config.SchemaConfig
+	periodCfg   config.PeriodConfig
+	fetcher     *fetcher.Fetcher
+	indexWriter IndexWriter
+}
+
+// NewChunkWriter creates a ChunkWriter. A nil indexWriter makes the writer fail to write any chunk,
+// which is used by the components running the index in read only mode.
+func NewChunkWriter(fetcher *fetcher.Fetcher, schemaCfg config.SchemaConfig, periodCfg config.PeriodConfig, indexWriter IndexWriter) *ChunkWriter {
+	if indexWriter == nil {
+		indexWriter = readOnlyIndexWriter{}
+	}
+
+	return &ChunkWriter{
+		schemaCfg:   schemaCfg,
+		periodCfg:   periodCfg,
+		fetcher:     fetcher,
+		indexWriter: indexWriter,
+	}
+}
+
+func (w *ChunkWriter) Put(ctx context.Context, chunks []chunk.Chunk) error {
+	for _, chunk := range chunks {
+		if err := w.PutOne(ctx, chunk.From, chunk.Through, chunk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *ChunkWriter) PutOne(ctx context.Context, from, through model.Time, chk chunk.Chunk) error {
+	log, ctx := spanlogger.New(ctx, "TSDBStore.PutOne")
+	defer log.Finish()
+
+	// If this chunk is in cache it must already be in the storage so we don't need to write it again.
+	found, _, _, _ := w.fetcher.Cache().Fetch(ctx, []string{w.schemaCfg.ExternalKey(chk.ChunkRef)})
+	writeChunk := len(found) == 0
+
+	if writeChunk {
+		if err := w.fetcher.Client().PutChunks(ctx, []chunk.Chunk{chk}); err != nil {
+			return err
+		}
+	}
+
+	// the chunk is always indexed since the index of each ingester is built independently.
+	chks := index.ChunkMetas{
+		{
+			Checksum: chk.ChunkRef.Checksum,
+			MinTime:  int64(chk.ChunkRef.From),
+			MaxTime:  int64(chk.ChunkRef.Through),
+			KB:       uint32(chk.Data.Size()+1<<9) >> 10, // rounded to the nearest KB
+			Entries:  uint32(chunkEntries(chk)),
+		},
+	}
+
+	for _, table := range tablesForRange(w.periodCfg.IndexTables, from, through) {
+		if err := w.indexWriter.Append(table, chk.UserID, chk.Metric, chks); err != nil {
+			return err
+		}
+	}
+
+	// we already have the chunk in the cache so don't write it back to the cache.
+	if writeChunk {
+		if cacheErr := w.fetcher.WriteBackCache(ctx, []chunk.Chunk{chk}); cacheErr != nil {
+			level.Warn(log).Log("msg", "could not store chunks in chunk cache", "err", cacheErr)
+		}
+	}
+
+	return nil
+}
+
+func chunkEntries(chk chunk.Chunk) int {
+	if f, ok := chk.Data.(*chunkenc.Facade); ok && f.LokiChunk() != nil {
+		return f.LokiChunk().Size()
+	}
+	return 0
+}
@@ -13,6 +13,7 @@
 package tsdb
 
 import (
+	"math"
 	"sync"
 
 	"github.com/go-kit/log"
@@ -87,7 +88,7 @@ type Head struct {
 }
 
 func NewHead(tenant string, metrics *HeadMetrics, logger log.Logger) *Head {
-	return &Head{
+	h := &Head{
 		tenant:    tenant,
 		metrics:   metrics,
 		logger:    logger,
@@ -96,6 +97,12 @@ func NewHead(tenant string, metrics *HeadMetrics, logger log.Logger) *Head {
 		closedMtx: sync.Mutex{},
 		closed:    false,
 	}
+
+	// set the bounds to their extremes so that the first append sets them correctly.
+	h.minTime.Store(math.MaxInt64)
+	h.maxTime.Store(math.MinInt64)
+
+	return h
 }
 
 // MinTime returns the lowest time bound on visible data in the head.
@@ -0,0 +1,546 @@
+package tsdb
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+
+	chunk_util "github.com/grafana/loki/pkg/storage/chunk/client/util"
+	"github.com/grafana/loki/pkg/storage/stores/indexshipper"
+	"github.com/grafana/loki/pkg/storage/stores/tsdb/index"
+)
+
+/*
+The HeadManager accumulates the index entries of flushed chunks in per tenant heads,
+one set of heads per index table.
+Every rotation period, the active heads are swapped with new ones and the previous heads are built into TSDB files,
+which are then handed over to the index shipper to be uploaded.
+The previous heads remain queryable until their TSDB files are available via the shipper.
+If building them fails, they are kept along with their WAL and the build is retried at the next rotation.
+
+All the index entries are written to a WAL which is replayed on startup to rebuild the TSDB files
+which were not built before the process stopped.
+
+Directory layout:
+	<dir>/wal/<rotation-ts-nanos>/ 		-> WAL of the heads started at <rotation-ts-nanos>
+	<dir>/tsdb/<table>/<tenant>/*.tsdb 	-> TSDB files built from the heads
+*/
+
+const (
+	defaultRotationPeriod = 15 * time.Minute
+
+	walDirName  = "wal"
+	tsdbDirName = "tsdb"
+)
+
+type HeadManagerMetrics struct {
+	headMetrics     *HeadMetrics
+	walTruncations  *prometheus.CounterVec
+	tsdbBuilds      *prometheus.CounterVec
+	tsdbBuildsBytes prometheus.Counter
+}
+
+func NewHeadManagerMetrics(r prometheus.Registerer) *HeadManagerMetrics {
+	return &HeadManagerMetrics{
+		headMetrics: NewHeadMetrics(r),
+		walTruncations: promauto.With(r).NewCounterVec(prometheus.CounterOpts{
+			Namespace: "loki",
+			Name:      "tsdb_head_wal_truncations_total",
+			Help:      "Total number of head WAL truncations (removals of WALs whose heads have been built into TSDB files).",
+		}, []string{"status"}),
+		tsdbBuilds: promauto.With(r).NewCounterVec(prometheus.CounterOpts{
+			Namespace: "loki",
+			Name:      "tsdb_builds_total",
+			Help:      "Total number of TSDB files built from the heads.",
+		}, []string{"status"}),
+		tsdbBuildsBytes: promauto.With(r).NewCounter(prometheus.CounterOpts{
+			Namespace: "loki",
+			Name:      "tsdb_build_bytes_total",
+			Help:      "Total number of bytes of the TSDB files built from the heads.",
+		}),
+	}
+}
+
+const (
+	statusSuccess = "success"
+	statusFailure = "failure"
+)
+
+type HeadManager struct {
+	log     log.Logger
+	dir     string
+	metrics *HeadManagerMetrics
+	shipper indexshipper.IndexShipper
+
+	// mtx protects the heads and the wal from being swapped while they are being appended to.
+	mtx         sync.RWMutex
+	activeHeads *tenantHeads
+	// prevHeads are the heads of the previous rotations which are being built into TSDB files, oldest first.
+	// They remain queryable until the TSDB files are handed over to the shipper.
+	prevHeads []*rotatedHeads
+	wal       *headWAL
+
+	period time.Duration
+	cancel chan struct{}
+	wg     sync.WaitGroup
+}
+
+func NewHeadManager(logger log.Logger, dir string, metrics *HeadManagerMetrics, shipper indexshipper.IndexShipper) *HeadManager {
+	return &HeadManager{
+		log:     log.With(logger, "component", "tsdb-head-manager"),
+		dir:     dir,
+		metrics: metrics,
+		shipper: shipper,
+		period:  defaultRotationPeriod,
+		cancel:  make(chan struct{}),
+	}
+}
+
+// Start recovers the heads from previous runs, makes sure their TSDB files are built and shipped,
+// and starts the rotation loop.
+func (m *HeadManager) Start() error {
+	for _, d := range []string{m.walDir(), m.tsdbDir()} {
+		if err := chunk_util.EnsureDirectory(d); err != nil {
+			return errors.Wrapf(err, "creating tsdb directory %s", d)
+		}
+	}
+
+	if err := m.loadBuiltTSDBs(); err != nil {
+		return errors.Wrap(err, "loading previously built tsdb files")
+	}
+
+	if err := m.recoverWALs(); err != nil {
+		return errors.Wrap(err, "recovering tsdb head wals")
+	}
+
+	now := time.Now()
+	wal, err := newHeadWAL(m.log, m.walDirFor(now))
+	if err != nil {
+		return err
+	}
+
+	m.wal = wal
+	m.activeHeads = newTenantHeads(now, m.log, m.metrics.headMetrics)
+
+	m.wg.Add(1)
+	go m.loop()
+
+	return nil
+}
+
+func (m *HeadManager) loop() {
+	defer m.wg.Done()
+
+	ticker := time.NewTicker(m.period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := m.Rotate(time.Now()); err != nil {
+				level.Error(m.log).Log("msg", "failed to rotate tsdb heads", "err", err)
+			}
+		case <-m.cancel:
+			return
+		}
+	}
+}
+
+// Stop stops the rotation loop and builds the TSDB files of the active heads.
+func (m *HeadManager) Stop() error {
+	close(m.cancel)
+	m.wg.Wait()
+
+	if err := m.Rotate(time.Now()); err != nil {
+		return err
+	}
+
+	// nothing can be appended anymore, remove the WAL created by the last rotation.
+	if err := m.wal.Stop(); err != nil {
+		return err
+	}
+	return os.RemoveAll(m.wal.dir)
+}
+
+// Append adds the chunks of the series identified by the given labels to the head of the tenant for the given table.
+// The metric name is not indexed since all the streams share the same one.
+func (m *HeadManager) Append(table, userID string, ls labels.Labels, chks index.ChunkMetas) error {
+	ls = labels.NewBuilder(ls).Del(labels.MetricName).Labels()
+
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	rec := &WALRecord{
+		Table:  table,
+		UserID: userID,
+		Series: ls,
+		Chks:   chks,
+	}
+	if err := m.wal.Log(rec); err != nil {
+		return err
+	}
+
+	m.activeHeads.Append(table, userID, ls, chks)
+	return nil
+}
+
+// rotatedHeads are the heads of a previous rotation along with their WAL.
+type rotatedHeads struct {
+	heads *tenantHeads
+	wal   *headWAL
+}
+
+// Rotate swaps the active heads with new ones, builds the TSDB files of the previous heads
+// and hands them over to the shipper.
+// The previous heads and their WAL are only removed once their TSDB files were built successfully,
+// otherwise the build is retried at the next rotation, or from the WAL on the next start.
+func (m *HeadManager) Rotate(t time.Time) error {
+	nextWAL, err := newHeadWAL(m.log, m.walDirFor(t))
+	if err != nil {
+		return errors.Wrap(err, "creating tsdb head wal")
+	}
+
+	m.mtx.Lock()
+	prevWAL := m.wal
+	m.wal = nextWAL
+	m.prevHeads = append(m.prevHeads, &rotatedHeads{heads: m.activeHeads, wal: prevWAL})
+	prevHeads := m.prevHeads
+	m.activeHeads = newTenantHeads(t, m.log, m.metrics.headMetrics)
+	m.mtx.Unlock()
+
+	if err := prevWAL.Stop(); err != nil {
+		level.Error(m.log).Log("msg", "failed to stop tsdb head wal", "dir", prevWAL.dir, "err", err)
+	}
+
+	var (
+		failed   []*rotatedHeads
+		firstErr error
+	)
+	for _, prev := range prevHeads {
+		if err := m.buildTSDBs(prev.heads); err != nil {
+			level.Error(m.log).Log("msg", "failed to build tsdb files, retrying at the next rotation", "wal", prev.wal.dir, "err", err)
+			failed = append(failed, prev)
+			if firstErr == nil {
+				firstErr = errors.Wrap(err, "building tsdb files")
+			}
+			continue
+		}
+
+		if err := os.RemoveAll(prev.wal.dir); err != nil {
+			m.metrics.walTruncations.WithLabelValues(statusFailure).Inc()
+			if firstErr == nil {
+				firstErr = errors.Wrapf(err, "removing tsdb head wal %s", prev.wal.dir)
+			}
+			continue
+		}
+		m.metrics.walTruncations.WithLabelValues(statusSuccess).Inc()
+	}
+
+	m.mtx.Lock()
+	m.prevHeads = failed
+	m.mtx.Unlock()
+
+	return firstErr
+}
+
+// buildTSDBs builds one TSDB file per table and tenant and adds them to the shipper.
+// The heads whose TSDB files were added to the shipper are removed, so that they aren't built again
+// if building the others fails.
+func (m *HeadManager) buildTSDBs(heads *tenantHeads) error {
+	type head struct{ table, userID string }
+	var built []head
+
+	err := heads.forAll(func(table, userID string, h *Head) error {
+		if err := m.buildTSDB(table, userID, h); err != nil {
+			m.metrics.tsdbBuilds.WithLabelValues(statusFailure).Inc()
+			return err
+		}
+		m.metrics.tsdbBuilds.WithLabelValues(statusSuccess).Inc()
+		built = append(built, head{table: table, userID: userID})
+		return nil
+	})
+
+	for _, h := range built {
+		heads.remove(h.table, h.userID)
+	}
+	return err
+}
+
+func (m *HeadManager) buildTSDB(table, userID string, h *Head) error {
+	reader, err := h.Index()
+	if err != nil {
+		return err
+	}
+
+	b := index.NewBuilder()
+	if err := NewTSDBIndex(reader).forSeries(
+		nil,
+		func(ls labels.Labels, _ model.Fingerprint, chks []index.ChunkMeta) {
+			// AddSeries copies chks into it's own slice
+			b.AddSeries(ls.Copy(), chks)
+		},
+		labels.MustNewMatcher(labels.MatchEqual, "", ""),
+	); err != nil {
+		return err
+	}
+
+	dir := filepath.Join(m.tsdbDir(), table)
+	id, err := b.Build(context.Background(), dir, userID)
+	if err != nil {
+		return errors.Wrapf(err, "building tsdb for table %s and tenant %s", table, userID)
+	}
+
+	return m.addTSDB(table, userID, id.FilePath(dir))
+}
+
+func (m *HeadManager) addTSDB(table, userID, path string) error {
+	idx, err := NewTSDBFile(path)
+	if err != nil {
+		return errors.Wrapf(err, "opening tsdb file %s", path)
+	}
+
+	m.metrics.tsdbBuildsBytes.Add(float64(idx.reader.Size()))
+	return m.shipper.AddIndex(table, userID, idx)
+}
+
+// loadBuiltTSDBs adds the TSDB files left behind by a previous run to the shipper.
+// Files which have already been uploaded would just be uploaded again with the same name.
+func (m *HeadManager) loadBuiltTSDBs() error {
+	tables, err := ioutil.ReadDir(m.tsdbDir())
+	if err != nil {
+		return err
+	}
+
+	for _, table := range tables {
+		if !table.IsDir() {
+			continue
+		}
+
+		tableDir := filepath.Join(m.tsdbDir(), table.Name())
+		tenants, err := ioutil.ReadDir(tableDir)
+		if err != nil {
+			return err
+		}
+
+		for _, tenant := range tenants {
+			if !tenant.IsDir() {
+				continue
+			}
+
+			tenantDir := filepath.Join(tableDir, tenant.Name())
+			files, err := ioutil.ReadDir(tenantDir)
+			if err != nil {
+				return err
+			}
+
+			for _, f := range files {
+				path := filepath.Join(tenantDir, f.Name())
+				if f.IsDir() || filepath.Ext(f.Name()) != ".tsdb" {
+					// remove the leftovers of interrupted builds.
+					if err := os.RemoveAll(path); err != nil {
+						level.Warn(m.log).Log("msg", "failed to remove unexpected file", "path", path, "err", err)
+					}
+					continue
+				}
+
+				if err := m.addTSDB(table.Name(), tenant.Name(), path); err != nil {
+					// the file would have been corrupted by a crash while being built, it will be rebuilt from the WAL.
+					level.Warn(m.log).Log("msg", "failed to load tsdb file, removing it", "path", path, "err", err)
+					if err := os.Remove(path); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// recoverWALs rebuilds the heads from the WALs left behind by a previous run and ships their TSDB files.
+func (m *HeadManager) recoverWALs() error {
+	walDirs, err := ioutil.ReadDir(m.walDir())
+	if err != nil {
+		return err
+	}
+
+	type walDir struct {
+		ts   int64
+		path string
+	}
+
+	dirs := make([]walDir, 0, len(walDirs))
+	for _, d := range walDirs {
+		ts, err := strconv.ParseInt(d.Name(), 10, 64)
+		if !d.IsDir() || err != nil {
+			level.Warn(m.log).Log("msg", "ignoring unexpected file in tsdb head wal directory", "name", d.Name())
+			continue
+		}
+		dirs = append(dirs, walDir{ts: ts, path: filepath.Join(m.walDir(), d.Name())})
+	}
+	sort.Slice(dirs, func(i, j int) bool { return dirs[i].ts < dirs[j].ts })
+
+	for _, d := range dirs {
+		heads := newTenantHeads(time.Unix(0, d.ts), m.log, m.metrics.headMetrics)
+		if err := recoverHeadWAL(d.path, func(rec *WALRecord) error {
+			heads.Append(rec.Table, rec.UserID, rec.Series, rec.Chks)
+			return nil
+		}); err != nil {
+			return errors.Wrapf(err, "replaying tsdb head wal %s", d.path)
+		}
+
+		if err := m.buildTSDBs(heads); err != nil {
+			return err
+		}
+
+		if err := os.RemoveAll(d.path); err != nil {
+			return err
+		}
+		level.Info(m.log).Log("msg", "recovered tsdb head wal", "dir", d.path)
+	}
+
+	return nil
+}
+
+// forIndices calls fn with the indices of the heads of the tenant which may hold index entries within [from, through].
+func (m *HeadManager) forIndices(_ context.Context, userID string, from, through model.Time, fn func(Index) error) error {
+	m.mtx.RLock()
+	heads := make([]*tenantHeads, 0, len(m.prevHeads)+1)
+	heads = append(heads, m.activeHeads)
+	for _, prev := range m.prevHeads {
+		heads = append(heads, prev.heads)
+	}
+	m.mtx.RUnlock()
+
+	for _, hs := range heads {
+		for _, h := range hs.tenantHeads(userID) {
+			if int64(through) < h.MinTime() || int64(from) > h.MaxTime() {
+				continue
+			}
+
+			reader, err := h.Index()
+			if err != nil {
+				return err
+			}
+
+			if err := fn(NewTSDBIndex(reader)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *HeadManager) walDir() string {
+	return filepath.Join(m.dir, walDirName)
+}
+
+func (m *HeadManager) walDirFor(t time.Time) string {
+	return filepath.Join(m.walDir(), fmt.Sprint(t.UnixNano()))
+}
+
+func (m *HeadManager) tsdbDir() string {
+	return filepath.Join(m.dir, tsdbDirName)
+}
+
+// tenantHeads holds the heads of all the tenants for each table.
+type tenantHeads struct {
+	start   time.Time
+	log     log.Logger
+	metrics *HeadMetrics
+
+	mtx    sync.RWMutex
+	tables map[string]map[string]*Head
+}
+
+func newTenantHeads(start time.Time, logger log.Logger, metrics *HeadMetrics) *tenantHeads {
+	return &tenantHeads{
+		start:   start,
+		log:     logger,
+		metrics: metrics,
+		tables:  make(map[string]map[string]*Head),
+	}
+}
+
+func (t *tenantHeads) Append(table, userID string, ls labels.Labels, chks index.ChunkMetas) {
+	t.getOrCreate(table, userID).Append(ls, chks)
+}
+
+func (t *tenantHeads) getOrCreate(table, userID string) *Head {
+	t.mtx.RLock()
+	h, ok := t.tables[table][userID]
+	t.mtx.RUnlock()
+	if ok {
+		return h
+	}
+
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	tenants, ok := t.tables[table]
+	if !ok {
+		tenants = make(map[string]*Head)
+		t.tables[table] = tenants
+	}
+
+	h, ok = tenants[userID]
+	if !ok {
+		h = NewHead(userID, t.metrics, t.log)
+		tenants[userID] = h
+	}
+	return h
+}
+
+// tenantHeads returns the heads of the tenant across all the tables.
+func (t *tenantHeads) tenantHeads(userID string) []*Head {
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+
+	var heads []*Head
+	for _, tenants := range t.tables {
+		if h, ok := tenants[userID]; ok {
+			heads = append(heads, h)
+		}
+	}
+	return heads
+}
+
+// remove removes the head of the tenant for the table.
+func (t *tenantHeads) remove(table, userID string) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	delete(t.tables[table], userID)
+	if len(t.tables[table]) == 0 {
+		delete(t.tables, table)
+	}
+}
+
+func (t *tenantHeads) forAll(fn func(table, userID string, h *Head) error) error {
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+
+	for table, tenants := range t.tables {
+		for userID, h := range tenants {
+			if err := fn(table, userID, h); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
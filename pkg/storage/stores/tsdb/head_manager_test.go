@@ -0,0 +1,184 @@
+package tsdb
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/loki/pkg/logproto"
+	"github.com/grafana/loki/pkg/storage/config"
+	shipper_index "github.com/grafana/loki/pkg/storage/stores/indexshipper/index"
+	"github.com/grafana/loki/pkg/storage/stores/tsdb/index"
+)
+
+type mockShipper struct {
+	mtx     sync.Mutex
+	indices map[string]map[string][]shipper_index.Index
+	// err is returned by AddIndex when set.
+	err error
+}
+
+func newMockShipper() *mockShipper {
+	return &mockShipper{indices: map[string]map[string][]shipper_index.Index{}}
+}
+
+func (s *mockShipper) AddIndex(tableName, userID string, idx shipper_index.Index) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.err != nil {
+		return s.err
+	}
+	if _, ok := s.indices[tableName]; !ok {
+		s.indices[tableName] = map[string][]shipper_index.Index{}
+	}
+	s.indices[tableName][userID] = append(s.indices[tableName][userID], idx)
+	return nil
+}
+
+func (s *mockShipper) ForEach(_ context.Context, tableName, userID string, callback func(index shipper_index.Index) error) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	for _, idx := range s.indices[tableName][userID] {
+		if err := callback(idx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *mockShipper) Stop() {}
+
+func TestWALRecord_Encoding(t *testing.T) {
+	rec := &WALRecord{
+		Table:  "index_1",
+		UserID: "fake",
+		Series: mustParseLabels(`{foo="bar", bazz="buzz"}`),
+		Chks: index.ChunkMetas{
+			{Checksum: 1, MinTime: 1, MaxTime: 10, KB: 2, Entries: 20},
+			{Checksum: 2, MinTime: 5, MaxTime: 15, KB: 3, Entries: 30},
+		},
+	}
+
+	var decoded WALRecord
+	require.NoError(t, decodeWALRecord(rec.encode(nil), &decoded))
+	require.Equal(t, *rec, decoded)
+
+	require.Error(t, decodeWALRecord([]byte{0}, &decoded))
+}
+
+func TestHeadManager(t *testing.T) {
+	var (
+		dir     = t.TempDir()
+		shipper = newMockShipper()
+		metrics = NewHeadManagerMetrics(nil)
+		tables  = config.PeriodicTableConfig{Prefix: "index_", Period: 24 * time.Hour}
+		table   = tables.TableFor(0)
+		ls      = labels.Labels{{Name: "__name__", Value: "logs"}, {Name: "foo", Value: "bar"}}
+		chks    = index.ChunkMetas{{Checksum: 1, MinTime: 1, MaxTime: 10, KB: 1, Entries: 10}}
+		matcher = labels.MustNewMatcher(labels.MatchEqual, "foo", "bar")
+		ctx     = context.Background()
+	)
+
+	expected := []logproto.ChunkRef{
+		{Fingerprint: ls[1:].Hash(), UserID: "fake", From: 1, Through: 10, Checksum: 1},
+	}
+
+	newClient := func(m *HeadManager) *IndexClient {
+		return NewIndexClient(m, newIndexShipperQuerier(shipper, tables))
+	}
+
+	m := NewHeadManager(log.NewNopLogger(), dir, metrics, shipper)
+	require.NoError(t, m.Start())
+	require.NoError(t, m.Append(table, "fake", ls, chks))
+
+	// the chunks are queryable from the active heads.
+	refs, err := newClient(m).GetChunkRefs(ctx, "fake", 0, 20, matcher)
+	require.NoError(t, err)
+	require.Equal(t, expected, refs)
+
+	// after a rotation the chunks are queryable from the shipped TSDB files.
+	require.NoError(t, m.Rotate(time.Now()))
+	require.Len(t, shipper.indices[table]["fake"], 1)
+	refs, err = newClient(m).GetChunkRefs(ctx, "fake", 0, 20, matcher)
+	require.NoError(t, err)
+	require.Equal(t, expected, refs)
+
+	series, err := newClient(m).GetSeries(ctx, "fake", 0, 20, matcher)
+	require.NoError(t, err)
+	require.Equal(t, []labels.Labels{ls[1:]}, series)
+
+	stats, err := newClient(m).Stats(ctx, "fake", 0, 20, matcher)
+	require.NoError(t, err)
+	require.Equal(t, &logproto.IndexStatsResponse{Streams: 1, Chunks: 1, Bytes: 1 << 10, Entries: 10}, stats)
+
+	// appending again and crashing, the heads are rebuilt from the WAL on the next start.
+	require.NoError(t, m.Append(table, "fake", ls, index.ChunkMetas{{Checksum: 2, MinTime: 5, MaxTime: 15, KB: 1, Entries: 10}}))
+	close(m.cancel)
+	m.wg.Wait()
+	require.NoError(t, m.wal.Stop())
+
+	shipper = newMockShipper()
+	m = NewHeadManager(log.NewNopLogger(), dir, metrics, shipper)
+	require.NoError(t, m.Start())
+	defer func() {
+		require.NoError(t, m.Stop())
+	}()
+
+	refs, err = newClient(m).GetChunkRefs(ctx, "fake", 0, 20, matcher)
+	require.NoError(t, err)
+	require.ElementsMatch(t, append(expected, logproto.ChunkRef{
+		Fingerprint: ls[1:].Hash(), UserID: "fake", From: 5, Through: 15, Checksum: 2,
+	}), refs)
+
+	// the stats account for the chunks of all the TSDB files of the tenant.
+	stats, err = newClient(m).Stats(ctx, "fake", 0, 20, matcher)
+	require.NoError(t, err)
+	require.Equal(t, &logproto.IndexStatsResponse{Streams: 1, Chunks: 2, Bytes: 2 << 10, Entries: 20}, stats)
+}
+
+func TestHeadManager_RetryFailedBuilds(t *testing.T) {
+	var (
+		shipper = newMockShipper()
+		tables  = config.PeriodicTableConfig{Prefix: "index_", Period: 24 * time.Hour}
+		table   = tables.TableFor(0)
+		ls      = labels.Labels{{Name: "foo", Value: "bar"}}
+		matcher = labels.MustNewMatcher(labels.MatchEqual, "foo", "bar")
+		ctx     = context.Background()
+	)
+
+	m := NewHeadManager(log.NewNopLogger(), t.TempDir(), NewHeadManagerMetrics(nil), shipper)
+	require.NoError(t, m.Start())
+	defer func() {
+		require.NoError(t, m.Stop())
+	}()
+	client := NewIndexClient(m, newIndexShipperQuerier(shipper, tables))
+
+	shipper.err = errors.New("unavailable")
+	require.NoError(t, m.Append(table, "fake", ls, index.ChunkMetas{{Checksum: 1, MinTime: 1, MaxTime: 10, KB: 1, Entries: 10}}))
+	require.Error(t, m.Rotate(time.Now()))
+	require.NoError(t, m.Append(table, "fake", ls, index.ChunkMetas{{Checksum: 2, MinTime: 5, MaxTime: 15, KB: 1, Entries: 10}}))
+	require.Error(t, m.Rotate(time.Now()))
+
+	// the heads which failed to be built remain queryable.
+	require.Len(t, m.prevHeads, 2)
+	refs, err := client.GetChunkRefs(ctx, "fake", 0, 20, matcher)
+	require.NoError(t, err)
+	require.Len(t, refs, 2)
+
+	// they are built at the next rotation, along with the heads of the last one.
+	shipper.err = nil
+	require.NoError(t, m.Rotate(time.Now()))
+	require.Empty(t, m.prevHeads)
+	require.Len(t, shipper.indices[table]["fake"], 2)
+	refs, err = client.GetChunkRefs(ctx, "fake", 0, 20, matcher)
+	require.NoError(t, err)
+	require.Len(t, refs, 2)
+}
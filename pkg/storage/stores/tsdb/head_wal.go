@@ -0,0 +1,151 @@
+package tsdb
+
+import (
+	"github.com/go-kit/log"
+	"github.com/pkg/errors"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/tsdb/wal"
+
+	"github.com/grafana/loki/pkg/storage/stores/tsdb/index"
+	"github.com/grafana/loki/pkg/util/encoding"
+)
+
+// WALRecordType represents the type of the head WAL record.
+type WALRecordType byte
+
+const (
+	_ = iota // ignore first value so the zero value doesn't look like a record type.
+	// WALRecordSeriesV1 is the type for the WAL record holding a series and some of its chunks.
+	WALRecordSeriesV1 WALRecordType = iota
+)
+
+// WALRecord holds the index entries added to a head for a single series.
+type WALRecord struct {
+	Table  string
+	UserID string
+	Series labels.Labels
+	Chks   index.ChunkMetas
+}
+
+func (r *WALRecord) encode(b []byte) []byte {
+	buf := encoding.EncWith(b)
+	buf.PutByte(byte(WALRecordSeriesV1))
+	buf.PutUvarintStr(r.Table)
+	buf.PutUvarintStr(r.UserID)
+
+	buf.PutUvarint(len(r.Series))
+	for _, l := range r.Series {
+		buf.PutUvarintStr(l.Name)
+		buf.PutUvarintStr(l.Value)
+	}
+
+	buf.PutUvarint(len(r.Chks))
+	for _, chk := range r.Chks {
+		buf.PutBE32(chk.Checksum)
+		buf.PutVarint64(chk.MinTime)
+		buf.PutVarint64(chk.MaxTime)
+		buf.PutUvarint32(chk.KB)
+		buf.PutUvarint32(chk.Entries)
+	}
+
+	return buf.Get()
+}
+
+func decodeWALRecord(b []byte, rec *WALRecord) error {
+	dec := encoding.DecWith(b)
+
+	switch t := WALRecordType(dec.Byte()); t {
+	case WALRecordSeriesV1:
+	default:
+		return errors.Errorf("unexpected tsdb head wal record type: %d", t)
+	}
+
+	rec.Table = dec.UvarintStr()
+	rec.UserID = dec.UvarintStr()
+
+	nLabels := dec.Uvarint()
+	rec.Series = make(labels.Labels, 0, nLabels)
+	for i := 0; i < nLabels; i++ {
+		rec.Series = append(rec.Series, labels.Label{
+			Name:  dec.UvarintStr(),
+			Value: dec.UvarintStr(),
+		})
+	}
+
+	nChks := dec.Uvarint()
+	rec.Chks = make(index.ChunkMetas, 0, nChks)
+	for i := 0; i < nChks; i++ {
+		rec.Chks = append(rec.Chks, index.ChunkMeta{
+			Checksum: dec.Be32(),
+			MinTime:  dec.Varint64(),
+			MaxTime:  dec.Varint64(),
+			KB:       uint32(dec.Uvarint()),
+			Entries:  uint32(dec.Uvarint()),
+		})
+	}
+
+	if err := dec.Err(); err != nil {
+		return errors.Wrap(err, "decoding tsdb head wal record")
+	}
+	if len(dec.B) > 0 {
+		return errors.Errorf("unexpected %d bytes left in tsdb head wal record", len(dec.B))
+	}
+	return nil
+}
+
+// headWAL persists the records appended to the heads so they can be recovered after a restart.
+type headWAL struct {
+	log log.Logger
+	dir string
+	wal *wal.WAL
+}
+
+func newHeadWAL(log log.Logger, dir string) (*headWAL, error) {
+	// NB: if we use a non-nil Prometheus Registerer, ensure
+	// that the underlying metrics won't conflict with existing WAL metrics in the ingester.
+	// Likely, this can be done by adding extra label(s)
+	wal, err := wal.NewSize(log, nil, dir, wal.DefaultSegmentSize, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &headWAL{
+		log: log,
+		dir: dir,
+		wal: wal,
+	}, nil
+}
+
+func (w *headWAL) Log(record *WALRecord) error {
+	if record == nil || len(record.Chks) == 0 {
+		return nil
+	}
+
+	// TODO(owen-d): use pooling
+	return w.wal.Log(record.encode(nil))
+}
+
+func (w *headWAL) Stop() error {
+	return w.wal.Close()
+}
+
+// recoverHeadWAL reads all the records of the WAL found in the given dir, calling fn for each of them.
+func recoverHeadWAL(dir string, fn func(*WALRecord) error) error {
+	segmentReader, err := wal.NewSegmentsReader(dir)
+	if err != nil {
+		return err
+	}
+	defer segmentReader.Close()
+
+	reader := wal.NewReader(segmentReader)
+	for reader.Next() {
+		var rec WALRecord
+		if err := decodeWALRecord(reader.Record(), &rec); err != nil {
+			return err
+		}
+		if err := fn(&rec); err != nil {
+			return err
+		}
+	}
+	return reader.Err()
+}
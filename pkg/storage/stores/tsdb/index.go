@@ -47,4 +47,7 @@ type Index interface {
 	Series(ctx context.Context, userID string, from, through model.Time, res []Series, shard *index.ShardAnnotation, matchers ...*labels.Matcher) ([]Series, error)
 	LabelNames(ctx context.Context, userID string, from, through model.Time, matchers ...*labels.Matcher) ([]string, error)
 	LabelValues(ctx context.Context, userID string, from, through model.Time, name string, matchers ...*labels.Matcher) ([]string, error)
+	// Stats accumulates into acc the stats of the series matching the matchers and their chunks overlapping [from, through].
+	// Shard follows the same semantics as GetChunkRefs.
+	Stats(ctx context.Context, userID string, from, through model.Time, acc *IndexStats, shard *index.ShardAnnotation, matchers ...*labels.Matcher) error
 }
@@ -1835,6 +1835,12 @@ func (r *Reader) Size() int64 {
 	return int64(r.b.Len())
 }
 
+// RawFileReader returns a reader over the raw bytes of the index.
+// The returned reader must not be used after the Reader has been closed.
+func (r *Reader) RawFileReader() (io.ReadSeeker, error) {
+	return bytes.NewReader(r.b.Range(0, r.b.Len())), nil
+}
+
 // LabelNames returns all the unique label names present in the index.
 // TODO(twilkie) implement support for matchers
 func (r *Reader) LabelNames(matchers ...*labels.Matcher) ([]string, error) {
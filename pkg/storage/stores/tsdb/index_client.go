@@ -0,0 +1,305 @@
+package tsdb
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+
+	"github.com/grafana/loki/pkg/logproto"
+	"github.com/grafana/loki/pkg/querier/astmapper"
+	"github.com/grafana/loki/pkg/storage/chunk"
+	series_index "github.com/grafana/loki/pkg/storage/stores/series/index"
+	"github.com/grafana/loki/pkg/util"
+)
+
+// metricName is the metric name of all the log streams, which is used to compute the series shards.
+const metricName = "logs"
+
+// indexIterator gives access to the indices which may hold the index entries of a tenant within a time range.
+type indexIterator interface {
+	// forIndices calls fn with each index. The indices must not be used after fn returns.
+	forIndices(ctx context.Context, userID string, from, through model.Time, fn func(Index) error) error
+}
+
+// IndexClient implements the stores.Index interface on top of TSDB heads and files.
+type IndexClient struct {
+	indices       []indexIterator
+	chunkFilterer chunk.RequestChunkFilterer
+}
+
+func NewIndexClient(indices ...indexIterator) *IndexClient {
+	return &IndexClient{
+		indices: indices,
+	}
+}
+
+func (c *IndexClient) forIndices(ctx context.Context, userID string, from, through model.Time, fn func(Index) error) error {
+	for _, idx := range c.indices {
+		if err := idx.forIndices(ctx, userID, from, through, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cleanMatchers removes the metric name and shard matchers which are not indexed by TSDB,
+// returning the shard if any.
+func cleanMatchers(matchers ...*labels.Matcher) ([]*labels.Matcher, *astmapper.ShardAnnotation, error) {
+	shard, shardLabelIndex, err := astmapper.ShardFromMatchers(matchers)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := make([]*labels.Matcher, 0, len(matchers))
+	for i, m := range matchers {
+		if m.Name == labels.MetricName || (shard != nil && i == shardLabelIndex) {
+			continue
+		}
+		result = append(result, m)
+	}
+
+	// TSDB needs at least one matcher, match all the series.
+	if len(result) == 0 {
+		result = append(result, labels.MustNewMatcher(labels.MatchEqual, "", ""))
+	}
+
+	return result, shard, nil
+}
+
+// inShard tells whether the series belongs to the given shard.
+// The shards are computed the same way as the series are sharded in the ingesters and
+// in the row shards of the boltdb index, so the query sharding stays consistent across all of them.
+func inShard(ls labels.Labels, shard *astmapper.ShardAnnotation) bool {
+	ls = labels.NewBuilder(ls).Set(labels.MetricName, metricName).Labels()
+	return series_index.RowShardForLabels(ls, uint32(shard.Of)) == uint32(shard.Shard)
+}
+
+// GetChunkRefs returns the chunk refs of the series matching the given matchers.
+func (c *IndexClient) GetChunkRefs(ctx context.Context, userID string, from, through model.Time, allMatchers ...*labels.Matcher) ([]logproto.ChunkRef, error) {
+	matchers, shard, err := cleanMatchers(allMatchers...)
+	if err != nil {
+		return nil, err
+	}
+
+	// the chunks are only matched when they strictly overlap the query bounds,
+	// widen them to include the chunks ending or starting at the edges.
+	from, through = from-1, through+1
+
+	var fpsInShard map[model.Fingerprint]struct{}
+	if shard != nil {
+		series, err := c.series(ctx, userID, from, through, shard, matchers...)
+		if err != nil {
+			return nil, err
+		}
+
+		fpsInShard = make(map[model.Fingerprint]struct{}, len(series))
+		for _, s := range series {
+			fpsInShard[s.Fingerprint] = struct{}{}
+		}
+	}
+
+	var (
+		seen   = make(map[ChunkRef]struct{})
+		result []logproto.ChunkRef
+	)
+	if err := c.forIndices(ctx, userID, from, through, func(idx Index) error {
+		refs, err := idx.GetChunkRefs(ctx, userID, from, through, nil, nil, matchers...)
+		if err != nil {
+			return err
+		}
+		defer ChunkRefsPool.Put(refs)
+
+		for _, ref := range refs {
+			if fpsInShard != nil {
+				if _, ok := fpsInShard[ref.Fingerprint]; !ok {
+					continue
+				}
+			}
+
+			// the same chunk can be indexed by multiple indices, i.e. when replicated across ingesters.
+			if _, ok := seen[ref]; ok {
+				continue
+			}
+			seen[ref] = struct{}{}
+
+			result = append(result, logproto.ChunkRef{
+				Fingerprint: uint64(ref.Fingerprint),
+				UserID:      ref.User,
+				From:        ref.Start,
+				Through:     ref.End,
+				Checksum:    ref.Checksum,
+			})
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// series returns the deduplicated series matching the given matchers and shard.
+// The labels are copied since the indices they are read from could be closed after the query.
+func (c *IndexClient) series(ctx context.Context, userID string, from, through model.Time, shard *astmapper.ShardAnnotation, matchers ...*labels.Matcher) ([]Series, error) {
+	var (
+		seen   = make(map[model.Fingerprint]struct{})
+		result []Series
+	)
+	if err := c.forIndices(ctx, userID, from, through, func(idx Index) error {
+		series, err := idx.Series(ctx, userID, from, through, nil, nil, matchers...)
+		if err != nil {
+			return err
+		}
+		defer SeriesPool.Put(series)
+
+		for _, s := range series {
+			if _, ok := seen[s.Fingerprint]; ok {
+				continue
+			}
+			seen[s.Fingerprint] = struct{}{}
+
+			if shard != nil && !inShard(s.Labels, shard) {
+				continue
+			}
+
+			result = append(result, Series{
+				Labels:      copyLabels(s.Labels),
+				Fingerprint: s.Fingerprint,
+			})
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetSeries returns the labels of the series matching the given matchers, without the metric name.
+func (c *IndexClient) GetSeries(ctx context.Context, userID string, from, through model.Time, allMatchers ...*labels.Matcher) ([]labels.Labels, error) {
+	matchers, shard, err := cleanMatchers(allMatchers...)
+	if err != nil {
+		return nil, err
+	}
+
+	series, err := c.series(ctx, userID, from-1, through+1, shard, matchers...)
+	if err != nil {
+		return nil, err
+	}
+
+	var chunkFilterer chunk.Filterer
+	if c.chunkFilterer != nil {
+		chunkFilterer = c.chunkFilterer.ForRequest(ctx)
+	}
+
+	result := make([]labels.Labels, 0, len(series))
+	for _, s := range series {
+		if chunkFilterer != nil && chunkFilterer.ShouldFilter(s.Labels) {
+			continue
+		}
+		result = append(result, s.Labels)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return labels.Compare(result[i], result[j]) < 0
+	})
+	return result, nil
+}
+
+// LabelValuesForMetricName retrieves all label values for a single label name and metric name.
+func (c *IndexClient) LabelValuesForMetricName(ctx context.Context, userID string, from, through model.Time, _ string, labelName string, allMatchers ...*labels.Matcher) ([]string, error) {
+	var matchers []*labels.Matcher
+	if len(allMatchers) > 0 {
+		var err error
+		// sharding is not supported for label values.
+		if matchers, _, err = cleanMatchers(allMatchers...); err != nil {
+			return nil, err
+		}
+	}
+
+	var result util.UniqueStrings
+	if err := c.forIndices(ctx, userID, from, through, func(idx Index) error {
+		values, err := idx.LabelValues(ctx, userID, from, through, labelName, matchers...)
+		if err != nil {
+			return err
+		}
+
+		for _, v := range values {
+			result.Add(copyString(v))
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return result.Strings(), nil
+}
+
+// LabelNamesForMetricName retrieves all label names for a metric name.
+func (c *IndexClient) LabelNamesForMetricName(ctx context.Context, userID string, from, through model.Time, _ string) ([]string, error) {
+	var result util.UniqueStrings
+	if err := c.forIndices(ctx, userID, from, through, func(idx Index) error {
+		names, err := idx.LabelNames(ctx, userID, from, through)
+		if err != nil {
+			return err
+		}
+
+		for _, n := range names {
+			result.Add(copyString(n))
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return result.Strings(), nil
+}
+
+// Stats returns the stats of the series matching the given matchers and of their chunks overlapping [from, through].
+func (c *IndexClient) Stats(ctx context.Context, userID string, from, through model.Time, allMatchers ...*labels.Matcher) (*logproto.IndexStatsResponse, error) {
+	matchers, shard, err := cleanMatchers(allMatchers...)
+	if err != nil {
+		return nil, err
+	}
+	if shard != nil {
+		return nil, errors.New("sharding is not supported for index stats")
+	}
+
+	// the chunks are only matched when they strictly overlap the query bounds,
+	// widen them to include the chunks ending or starting at the edges.
+	from, through = from-1, through+1
+
+	acc := NewIndexStats()
+	if err := c.forIndices(ctx, userID, from, through, func(idx Index) error {
+		return idx.Stats(ctx, userID, from, through, acc, nil, matchers...)
+	}); err != nil {
+		return nil, err
+	}
+
+	return acc.Stats(), nil
+}
+
+// SetChunkFilterer sets a chunk filter to be used when retrieving series.
+func (c *IndexClient) SetChunkFilterer(chunkFilter chunk.RequestChunkFilterer) {
+	c.chunkFilterer = chunkFilter
+}
+
+// copyLabels makes a deep copy of the labels
+// since the strings could be backed by the memory mapped index files.
+func copyLabels(ls labels.Labels) labels.Labels {
+	result := make(labels.Labels, len(ls))
+	for i, l := range ls {
+		result[i] = labels.Label{
+			Name:  copyString(l.Name),
+			Value: copyString(l.Value),
+		}
+	}
+	return result
+}
+
+func copyString(s string) string {
+	return string([]byte(s))
+}
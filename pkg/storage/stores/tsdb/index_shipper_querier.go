@@ -0,0 +1,65 @@
+package tsdb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/grafana/loki/pkg/storage/config"
+	"github.com/grafana/loki/pkg/storage/stores/indexshipper"
+	shipper_index "github.com/grafana/loki/pkg/storage/stores/indexshipper/index"
+)
+
+// indexShipperQuerier queries the TSDB files managed by the index shipper,
+// i.e the ones downloaded from the object store and the ones built locally which are waiting to be uploaded.
+type indexShipperQuerier struct {
+	shipper     indexshipper.IndexShipper
+	tableRanges config.PeriodicTableConfig
+}
+
+func newIndexShipperQuerier(shipper indexshipper.IndexShipper, tableRanges config.PeriodicTableConfig) *indexShipperQuerier {
+	return &indexShipperQuerier{
+		shipper:     shipper,
+		tableRanges: tableRanges,
+	}
+}
+
+// forIndices calls fn with each of the TSDB files of the tenant from the tables overlapping [from, through].
+// The indices must not be used after fn returns since they could be closed by the shipper.
+func (i *indexShipperQuerier) forIndices(ctx context.Context, userID string, from, through model.Time, fn func(Index) error) error {
+	for _, table := range tablesForRange(i.tableRanges, from, through) {
+		if err := i.shipper.ForEach(ctx, table, userID, func(idx shipper_index.Index) error {
+			tsdbFile, ok := idx.(*TSDBFile)
+			if !ok {
+				return fmt.Errorf("unexpected index type %T", idx)
+			}
+
+			if lower, upper := tsdbFile.Bounds(); from > upper || through < lower {
+				return nil
+			}
+
+			return fn(tsdbFile)
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// tablesForRange returns the names of the tables overlapping [from, through].
+func tablesForRange(cfg config.PeriodicTableConfig, from, through model.Time) []string {
+	if cfg.Period == 0 {
+		return []string{cfg.Prefix}
+	}
+
+	periodSecs := int64(cfg.Period / time.Second)
+	tables := make([]string, 0, (through.Unix()-from.Unix())/periodSecs+1)
+	for i := from.Unix() / periodSecs; i <= through.Unix()/periodSecs; i++ {
+		tables = append(tables, cfg.TableFor(model.TimeFromUnix(i*periodSecs)))
+	}
+
+	return tables
+}
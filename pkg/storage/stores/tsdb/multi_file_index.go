@@ -222,3 +222,11 @@ func (i *MultiIndex) LabelValues(ctx context.Context, userID string, from, throu
 
 	return results, nil
 }
+
+func (i *MultiIndex) Stats(ctx context.Context, userID string, from, through model.Time, acc *IndexStats, shard *index.ShardAnnotation, matchers ...*labels.Matcher) error {
+	// the accumulator dedupes the series and chunks shared by multiple indices.
+	_, err := i.forIndices(ctx, from, through, func(ctx context.Context, idx *TSDBIndex) (interface{}, error) {
+		return nil, idx.Stats(ctx, userID, from, through, acc, shard, matchers...)
+	})
+	return err
+}
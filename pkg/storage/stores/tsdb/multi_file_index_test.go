@@ -8,6 +8,7 @@ import (
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/stretchr/testify/require"
 
+	"github.com/grafana/loki/pkg/logproto"
 	"github.com/grafana/loki/pkg/storage/stores/tsdb/index"
 )
 
@@ -104,6 +105,18 @@ func TestMultiIndex(t *testing.T) {
 		require.Equal(t, expected, refs)
 	})
 
+	t.Run("Stats", func(t *testing.T) {
+		acc := NewIndexStats()
+		err := idx.Stats(context.Background(), "fake", 2, 5, acc, nil, labels.MustNewMatcher(labels.MatchEqual, "foo", "bar"))
+		require.Nil(t, err)
+
+		// the series and chunks duplicated across the indices are only accounted for once
+		require.Equal(t, &logproto.IndexStatsResponse{
+			Streams: 2,
+			Chunks:  4,
+		}, acc.Stats())
+	})
+
 	t.Run("Series", func(t *testing.T) {
 		xs, err := idx.Series(context.Background(), "fake", 2, 5, nil, nil, labels.MustNewMatcher(labels.MatchEqual, "foo", "bar"))
 		require.Nil(t, err)
@@ -2,10 +2,13 @@ package tsdb
 
 import (
 	"context"
+	"io"
+	"path/filepath"
 
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/model/labels"
 
+	shipper_index "github.com/grafana/loki/pkg/storage/stores/indexshipper/index"
 	"github.com/grafana/loki/pkg/storage/stores/tsdb/index"
 )
 
@@ -22,6 +25,45 @@ func LoadTSDB(name string) (*TSDBIndex, error) {
 	return NewTSDBIndex(reader), nil
 }
 
+// TSDBFile is a TSDBIndex backed by a file on disk which can be managed by the index shipper.
+// nolint
+type TSDBFile struct {
+	*TSDBIndex
+	path   string
+	reader *index.Reader
+}
+
+// OpenShippableTSDB opens the TSDB file at the given path.
+// It implements index.OpenIndexFileFunc of the index shipper.
+func OpenShippableTSDB(path string) (shipper_index.Index, error) {
+	return NewTSDBFile(path)
+}
+
+func NewTSDBFile(path string) (*TSDBFile, error) {
+	reader, err := index.NewFileReader(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TSDBFile{
+		TSDBIndex: NewTSDBIndex(reader),
+		path:      path,
+		reader:    reader,
+	}, nil
+}
+
+func (f *TSDBFile) Name() string {
+	return filepath.Base(f.path)
+}
+
+func (f *TSDBFile) Path() string {
+	return f.path
+}
+
+func (f *TSDBFile) Reader() (io.ReadSeeker, error) {
+	return f.reader.RawFileReader()
+}
+
 // nolint
 type TSDBIndex struct {
 	reader IndexReader
@@ -150,6 +192,28 @@ func (i *TSDBIndex) LabelValues(_ context.Context, _ string, _, _ model.Time, na
 	return labelValuesWithMatchers(i.reader, name, matchers...)
 }
 
+func (i *TSDBIndex) Stats(_ context.Context, _ string, from, through model.Time, acc *IndexStats, shard *index.ShardAnnotation, matchers ...*labels.Matcher) error {
+	queryBounds := newBounds(from, through)
+	var inRange []index.ChunkMeta
+
+	return i.forSeries(shard,
+		func(_ labels.Labels, fp model.Fingerprint, chks []index.ChunkMeta) {
+			inRange = inRange[:0]
+			// TODO(owen-d): use logarithmic approach
+			for _, chk := range chks {
+				if Overlap(queryBounds, chk) {
+					inRange = append(inRange, chk)
+				}
+			}
+
+			// only account for the series having at least one chunk in the desired range
+			if len(inRange) > 0 {
+				acc.AddChunks(fp, from, through, inRange)
+			}
+		},
+		matchers...)
+}
+
 func (i *TSDBIndex) Checksum() uint32 {
 	return i.reader.Checksum()
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/stretchr/testify/require"
 
+	"github.com/grafana/loki/pkg/logproto"
 	"github.com/grafana/loki/pkg/storage/stores/tsdb/index"
 )
 
@@ -22,16 +23,22 @@ func TestSingleIdx(t *testing.T) {
 					MinTime:  0,
 					MaxTime:  3,
 					Checksum: 0,
+					KB:       1,
+					Entries:  10,
 				},
 				{
 					MinTime:  1,
 					MaxTime:  4,
 					Checksum: 1,
+					KB:       2,
+					Entries:  20,
 				},
 				{
 					MinTime:  2,
 					MaxTime:  5,
 					Checksum: 2,
+					KB:       3,
+					Entries:  30,
 				},
 			},
 		},
@@ -42,6 +49,8 @@ func TestSingleIdx(t *testing.T) {
 					MinTime:  1,
 					MaxTime:  10,
 					Checksum: 3,
+					KB:       4,
+					Entries:  40,
 				},
 			},
 		},
@@ -52,6 +61,8 @@ func TestSingleIdx(t *testing.T) {
 					MinTime:  1,
 					MaxTime:  7,
 					Checksum: 4,
+					KB:       5,
+					Entries:  50,
 				},
 			},
 		},
@@ -138,6 +149,38 @@ func TestSingleIdx(t *testing.T) {
 
 			})
 
+			t.Run("Stats", func(t *testing.T) {
+				acc := NewIndexStats()
+				err := idx.Stats(context.Background(), "fake", 1, 5, acc, nil, labels.MustNewMatcher(labels.MatchEqual, "foo", "bar"))
+				require.Nil(t, err)
+
+				// 2 series and 4 chunks of 1, 2, 3 and 4 KB, the first chunk [0, 3] and the last one [1, 10]
+				// only partially overlap the range, so 2/3 and 4/9 of their bytes and entries are accounted for.
+				require.Equal(t, &logproto.IndexStatsResponse{
+					Streams: 2,
+					Chunks:  4,
+					Bytes:   682 + 2<<10 + 3<<10 + 1820,
+					Entries: 6 + 20 + 30 + 17,
+				}, acc.Stats())
+			})
+
+			t.Run("StatsSharded", func(t *testing.T) {
+				shard := index.ShardAnnotation{
+					Shard: 1,
+					Of:    2,
+				}
+				acc := NewIndexStats()
+				err := idx.Stats(context.Background(), "fake", 1, 5, acc, &shard, labels.MustNewMatcher(labels.MatchEqual, "foo", "bar"))
+				require.Nil(t, err)
+
+				require.Equal(t, &logproto.IndexStatsResponse{
+					Streams: 1,
+					Chunks:  1,
+					Bytes:   1820,
+					Entries: 17,
+				}, acc.Stats())
+			})
+
 			t.Run("Series", func(t *testing.T) {
 				xs, err := idx.Series(context.Background(), "fake", 8, 9, nil, nil, labels.MustNewMatcher(labels.MatchEqual, "foo", "bar"))
 				require.Nil(t, err)
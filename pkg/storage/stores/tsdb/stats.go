@@ -0,0 +1,93 @@
+package tsdb
+
+import (
+	"sync"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/grafana/loki/pkg/logproto"
+	"github.com/grafana/loki/pkg/storage/stores/tsdb/index"
+)
+
+// chunkKey identifies a chunk of a series regardless of the index it was read from.
+type chunkKey struct {
+	fp               model.Fingerprint
+	minTime, maxTime int64
+	checksum         uint32
+}
+
+// IndexStats accumulates the stats of the series and chunks read from one or more indices.
+// Each series and chunk is only accounted for once since the same chunk can be indexed by multiple indices,
+// i.e. when replicated across ingesters.
+// The bytes and entries of the chunks partially overlapping the queried range are prorated by their overlap,
+// so that the stats of adjacent ranges add up to the stats of the whole range.
+// It is safe for concurrent use.
+type IndexStats struct {
+	mtx     sync.Mutex
+	streams map[model.Fingerprint]struct{}
+	chunks  map[chunkKey]struct{}
+	stats   logproto.IndexStatsResponse
+}
+
+func NewIndexStats() *IndexStats {
+	return &IndexStats{
+		streams: make(map[model.Fingerprint]struct{}),
+		chunks:  make(map[chunkKey]struct{}),
+	}
+}
+
+// AddChunks accounts for the series with the given fingerprint and its chunks overlapping [from, through].
+func (s *IndexStats) AddChunks(fp model.Fingerprint, from, through model.Time, chks []index.ChunkMeta) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if _, ok := s.streams[fp]; !ok {
+		s.streams[fp] = struct{}{}
+		s.stats.Streams++
+	}
+
+	for _, chk := range chks {
+		key := chunkKey{
+			fp:       fp,
+			minTime:  chk.MinTime,
+			maxTime:  chk.MaxTime,
+			checksum: chk.Checksum,
+		}
+		if _, ok := s.chunks[key]; ok {
+			continue
+		}
+		s.chunks[key] = struct{}{}
+
+		ratio := overlapRatio(from, through, chk)
+		s.stats.Chunks++
+		s.stats.Bytes += uint64(float64(uint64(chk.KB)<<10) * ratio)
+		s.stats.Entries += uint64(float64(chk.Entries) * ratio)
+	}
+}
+
+// overlapRatio returns the ratio of the time range of the chunk overlapping [from, through].
+func overlapRatio(from, through model.Time, chk index.ChunkMeta) float64 {
+	if chk.MaxTime <= chk.MinTime {
+		return 1
+	}
+	start, end := chk.MinTime, chk.MaxTime
+	if int64(from) > start {
+		start = int64(from)
+	}
+	if int64(through) < end {
+		end = int64(through)
+	}
+	if end <= start {
+		return 0
+	}
+	return float64(end-start) / float64(chk.MaxTime-chk.MinTime)
+}
+
+// Stats returns the accumulated stats.
+func (s *IndexStats) Stats() *logproto.IndexStatsResponse {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	stats := s.stats
+	return &stats
+}
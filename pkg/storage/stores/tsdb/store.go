@@ -0,0 +1,83 @@
+package tsdb
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/prometheus/model/labels"
+
+	"github.com/grafana/loki/pkg/storage/chunk/client"
+	"github.com/grafana/loki/pkg/storage/chunk/fetcher"
+	"github.com/grafana/loki/pkg/storage/config"
+	"github.com/grafana/loki/pkg/storage/stores/indexshipper"
+	"github.com/grafana/loki/pkg/storage/stores/indexshipper/downloads"
+	"github.com/grafana/loki/pkg/storage/stores/tsdb/index"
+	util_log "github.com/grafana/loki/pkg/util/log"
+)
+
+var errReadOnly = errors.New("tsdb index store is running in read only mode")
+
+// Store holds the index shipper and the heads shared by all the periods using the tsdb index type.
+type Store struct {
+	indexShipper indexshipper.IndexShipper
+	// headManager is only set when the index can be written to, i.e. in the ingesters.
+	headManager *HeadManager
+	stopOnce    sync.Once
+}
+
+// NewStore creates a tsdb index store, recovering the heads left behind by a previous run when the index can be written to.
+func NewStore(cfg indexshipper.Config, objectClient client.ObjectClient, limits downloads.Limits, reg prometheus.Registerer) (*Store, error) {
+	indexShipper, err := indexshipper.NewIndexShipper(cfg, objectClient, limits, OpenShippableTSDB)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Store{
+		indexShipper: indexShipper,
+	}
+
+	if cfg.Mode != indexshipper.ModeReadOnly {
+		s.headManager = NewHeadManager(util_log.Logger, cfg.ActiveIndexDirectory, NewHeadManagerMetrics(reg), indexShipper)
+		if err := s.headManager.Start(); err != nil {
+			indexShipper.Stop()
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// ForPeriod returns the chunk writer and the index client for the given period.
+func (s *Store) ForPeriod(f *fetcher.Fetcher, schemaCfg config.SchemaConfig, p config.PeriodConfig) (*ChunkWriter, *IndexClient) {
+	var (
+		indexWriter IndexWriter
+		indices     = []indexIterator{newIndexShipperQuerier(s.indexShipper, p.IndexTables)}
+	)
+
+	if s.headManager != nil {
+		indexWriter = s.headManager
+		indices = append(indices, s.headManager)
+	}
+
+	return NewChunkWriter(f, schemaCfg, p, indexWriter), NewIndexClient(indices...)
+}
+
+// Stop builds the TSDB files of the heads and stops the shipper. It is safe to call it more than once.
+func (s *Store) Stop() {
+	s.stopOnce.Do(func() {
+		if s.headManager != nil {
+			if err := s.headManager.Stop(); err != nil {
+				level.Error(util_log.Logger).Log("msg", "failed to stop tsdb head manager", "err", err)
+			}
+		}
+		s.indexShipper.Stop()
+	})
+}
+
+type readOnlyIndexWriter struct{}
+
+func (readOnlyIndexWriter) Append(_, _ string, _ labels.Labels, _ index.ChunkMetas) error {
+	return errReadOnly
+}
@@ -0,0 +1,11 @@
+package util
+
+import "hash/fnv"
+
+// HashedQuery returns a hash of the query text.
+// It allows to identify a query without its text, for instance in the blocked queries limits.
+func HashedQuery(query string) uint32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(query))
+	return h.Sum32()
+}
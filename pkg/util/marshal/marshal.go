@@ -86,3 +86,12 @@ type seriesResponseAdapter struct {
 	Status string              `json:"status"`
 	Data   []map[string]string `json:"data"`
 }
+
+// WriteIndexStatsResponseJSON marshals a logproto.IndexStatsResponse to JSON and then
+// writes it to the provided io.Writer.
+func WriteIndexStatsResponseJSON(r *logproto.IndexStatsResponse, w io.Writer) error {
+	if r == nil {
+		r = &logproto.IndexStatsResponse{}
+	}
+	return jsoniter.NewEncoder(w).Encode(r)
+}
@@ -58,6 +58,14 @@ func NewResultValue(v parser.Value) (loghttp.ResultValue, error) {
 		}
 
 		value = NewMatrix(m)
+	case loghttp.ResultTypeQuantileSketches:
+		s, ok := v.(logqlmodel.QuantileSketches)
+
+		if !ok {
+			return nil, fmt.Errorf("unexpected type %T for quantile sketches", s)
+		}
+
+		value = loghttp.QuantileSketches(s)
 	default:
 		return nil, fmt.Errorf("v1 endpoints do not support type %s", v.Type())
 	}
@@ -103,8 +111,9 @@ func NewStream(s logproto.Stream) (loghttp.Stream, error) {
 // NewEntry constructs an Entry from a logproto.Entry
 func NewEntry(e logproto.Entry) loghttp.Entry {
 	return loghttp.Entry{
-		Timestamp: e.Timestamp,
-		Line:      e.Line,
+		Timestamp:          e.Timestamp,
+		Line:               e.Line,
+		StructuredMetadata: logproto.FromLabelAdaptersToLabels(e.StructuredMetadata),
 	}
 }
 
@@ -45,6 +45,40 @@ var pushTests = []struct {
 			]
 		}`,
 	},
+	{
+		[]logproto.Stream{
+			{
+				Entries: []logproto.Entry{
+					{
+						Timestamp: time.Unix(0, 123456789012345),
+						Line:      "super line",
+						StructuredMetadata: []logproto.LabelAdapter{
+							{Name: "traceID", Value: "2e8cc1c5d7c4d2b1"},
+							{Name: "user", Value: "alice"},
+						},
+					},
+					{
+						Timestamp: time.Unix(0, 123456789012346),
+						Line:      "super line without metadata",
+					},
+				},
+				Labels: `{test="test"}`,
+			},
+		},
+		`{
+			"streams": [
+				{
+					"stream": {
+						"test": "test"
+					},
+					"values":[
+						[ "123456789012345", "super line", { "traceID": "2e8cc1c5d7c4d2b1", "user": "alice" } ],
+						[ "123456789012346", "super line without metadata" ]
+					]
+				}
+			]
+		}`,
+	},
 }
 
 func Test_DecodePushRequest(t *testing.T) {
@@ -0,0 +1,79 @@
+package validation
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/prometheus/prometheus/model/labels"
+
+	"github.com/grafana/loki/pkg/logql/log"
+	"github.com/grafana/loki/pkg/logql/syntax"
+	"github.com/grafana/loki/pkg/util"
+)
+
+// DropRule is a rule dropping the entries pushed by a tenant to the streams matching a selector,
+// optionally only the ones whose line matches a line filter expression, but for a ratio of them
+// which is kept.
+type DropRule struct {
+	Selector   string  `yaml:"selector" json:"selector"`
+	LineFilter string  `yaml:"line_filter,omitempty" json:"line_filter,omitempty"`
+	KeepRatio  float64 `yaml:"keep_ratio,omitempty" json:"keep_ratio,omitempty"`
+
+	Matchers []*labels.Matcher `yaml:"-" json:"-"` // populated during validation.
+	Filter   log.Filterer      `yaml:"-" json:"-"` // populated during validation.
+}
+
+// Validate validates that the drop rule is valid, and parses its selector and line filter.
+func (r *DropRule) Validate() error {
+	if r.KeepRatio < 0 || r.KeepRatio >= 1 {
+		return fmt.Errorf("keep ratio of drop rule %q must be >= 0 and < 1, was %v", r.Selector, r.KeepRatio)
+	}
+	expr, err := syntax.ParseLogSelector(r.Selector+" "+r.LineFilter, true)
+	if err != nil {
+		return fmt.Errorf("invalid drop rule %q %q: %w", r.Selector, r.LineFilter, err)
+	}
+	r.Matchers = expr.Matchers()
+	r.Filter = nil
+
+	pipeline, ok := expr.(*syntax.PipelineExpr)
+	if !ok {
+		return nil
+	}
+	filters := make([]log.Filterer, 0, len(pipeline.MultiStages))
+	for _, stage := range pipeline.MultiStages {
+		lineFilter, ok := stage.(*syntax.LineFilterExpr)
+		if !ok {
+			return fmt.Errorf("invalid drop rule %q %q: only line filters are supported", r.Selector, r.LineFilter)
+		}
+		filter, err := lineFilter.Filter()
+		if err != nil {
+			return fmt.Errorf("invalid drop rule %q %q: %w", r.Selector, r.LineFilter, err)
+		}
+		filters = append(filters, filter)
+	}
+	r.Filter = filters[0]
+	if len(filters) > 1 {
+		r.Filter = log.NewAndFilters(filters)
+	}
+	return nil
+}
+
+// MatchesStream returns whether the rule applies to the stream with the given labels.
+func (r *DropRule) MatchesStream(ls labels.Labels) bool {
+	for _, m := range r.Matchers {
+		if !m.Matches(ls.Get(m.Name)) {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchesLine returns whether the line of an entry of a matching stream is matched by the line filter of the rule.
+func (r *DropRule) MatchesLine(line string) bool {
+	return r.Filter == nil || r.Filter.Filter(util.YoloBuf(line))
+}
+
+// Drop returns whether a matched entry is dropped, given the ratio of entries kept by the rule.
+func (r *DropRule) Drop() bool {
+	return r.KeepRatio == 0 || rand.Float64() >= r.KeepRatio
+}
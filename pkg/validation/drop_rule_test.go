@@ -0,0 +1,47 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDropRule_Validate(t *testing.T) {
+	for _, tc := range []struct {
+		desc    string
+		rule    DropRule
+		wantErr bool
+	}{
+		{desc: "selector", rule: DropRule{Selector: `{app="foo"}`}},
+		{desc: "line filter", rule: DropRule{Selector: `{app="foo"}`, LineFilter: `|= "debug" != "error"`, KeepRatio: 0.1}},
+		{desc: "missing selector", rule: DropRule{LineFilter: `|= "debug"`}, wantErr: true},
+		{desc: "invalid line filter", rule: DropRule{Selector: `{app="foo"}`, LineFilter: `|~ "("`}, wantErr: true},
+		{desc: "parser", rule: DropRule{Selector: `{app="foo"}`, LineFilter: `| json`}, wantErr: true},
+		{desc: "negative keep ratio", rule: DropRule{Selector: `{app="foo"}`, KeepRatio: -0.1}, wantErr: true},
+		{desc: "keep all", rule: DropRule{Selector: `{app="foo"}`, KeepRatio: 1}, wantErr: true},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tc.rule.Validate()
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestDropRule_Matches(t *testing.T) {
+	rule := DropRule{Selector: `{app="foo", env=~"dev|staging"}`, LineFilter: `|= "level=debug" != "keep"`}
+	require.NoError(t, rule.Validate())
+
+	require.True(t, rule.MatchesStream(labels.FromStrings("app", "foo", "env", "dev")))
+	require.False(t, rule.MatchesStream(labels.FromStrings("app", "foo", "env", "prod")))
+	require.False(t, rule.MatchesStream(labels.FromStrings("env", "dev")))
+
+	require.True(t, rule.MatchesLine("level=debug msg=hello"))
+	require.False(t, rule.MatchesLine("level=debug msg=keep"))
+	require.False(t, rule.MatchesLine("level=info msg=hello"))
+	require.True(t, rule.Drop())
+}
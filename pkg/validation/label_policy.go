@@ -0,0 +1,50 @@
+package validation
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// LabelPolicy constrains the labels of the streams pushed by a tenant.
+// The streams missing a required label, with a forbidden label or with a label value
+// not matching its pattern are rejected. When ForbiddenLabelsFallback is set, the values
+// of the forbidden labels are replaced with it instead of rejecting the streams.
+type LabelPolicy struct {
+	RequiredLabels          []string          `yaml:"required_labels,omitempty" json:"required_labels,omitempty"`
+	ForbiddenLabels         []string          `yaml:"forbidden_labels,omitempty" json:"forbidden_labels,omitempty"`
+	ForbiddenLabelsFallback string            `yaml:"forbidden_labels_fallback_value,omitempty" json:"forbidden_labels_fallback_value,omitempty"`
+	LabelValuePatterns      map[string]string `yaml:"label_value_patterns,omitempty" json:"label_value_patterns,omitempty"`
+
+	ValueRegexps map[string]*regexp.Regexp `yaml:"-" json:"-"` // populated during validation.
+}
+
+// IsEmpty returns true if the policy does not constrain any label.
+func (p *LabelPolicy) IsEmpty() bool {
+	return len(p.RequiredLabels) == 0 && len(p.ForbiddenLabels) == 0 && len(p.LabelValuePatterns) == 0
+}
+
+// Validate validates that the label policy is valid and compiles the label value patterns.
+func (p *LabelPolicy) Validate() error {
+	forbidden := make(map[string]struct{}, len(p.ForbiddenLabels))
+	for _, name := range p.ForbiddenLabels {
+		forbidden[name] = struct{}{}
+	}
+	for _, name := range p.RequiredLabels {
+		if _, ok := forbidden[name]; ok {
+			return fmt.Errorf("label %q of the label policy can't be both required and forbidden", name)
+		}
+	}
+
+	p.ValueRegexps = make(map[string]*regexp.Regexp, len(p.LabelValuePatterns))
+	for name, pattern := range p.LabelValuePatterns {
+		if _, ok := forbidden[name]; ok {
+			return fmt.Errorf("label %q of the label policy can't be both forbidden and constrained by a pattern", name)
+		}
+		re, err := regexp.Compile("^(?:" + pattern + ")$")
+		if err != nil {
+			return fmt.Errorf("invalid label value pattern %q for label %q: %w", pattern, name, err)
+		}
+		p.ValueRegexps[name] = re
+	}
+	return nil
+}
@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -13,6 +15,8 @@ import (
 	"golang.org/x/time/rate"
 	"gopkg.in/yaml.v2"
 
+	"github.com/grafana/loki/pkg/distributor/shardstreams"
+	"github.com/grafana/loki/pkg/loghttp/push"
 	"github.com/grafana/loki/pkg/logql/syntax"
 	"github.com/grafana/loki/pkg/ruler/util"
 	"github.com/grafana/loki/pkg/util/flagext"
@@ -46,19 +50,29 @@ const (
 // to support user-friendly duration format (e.g: "1h30m45s") in JSON value.
 type Limits struct {
 	// Distributor enforced limits.
-	IngestionRateStrategy   string           `yaml:"ingestion_rate_strategy" json:"ingestion_rate_strategy"`
-	IngestionRateMB         float64          `yaml:"ingestion_rate_mb" json:"ingestion_rate_mb"`
-	IngestionBurstSizeMB    float64          `yaml:"ingestion_burst_size_mb" json:"ingestion_burst_size_mb"`
-	MaxLabelNameLength      int              `yaml:"max_label_name_length" json:"max_label_name_length"`
-	MaxLabelValueLength     int              `yaml:"max_label_value_length" json:"max_label_value_length"`
-	MaxLabelNamesPerSeries  int              `yaml:"max_label_names_per_series" json:"max_label_names_per_series"`
-	RejectOldSamples        bool             `yaml:"reject_old_samples" json:"reject_old_samples"`
-	RejectOldSamplesMaxAge  model.Duration   `yaml:"reject_old_samples_max_age" json:"reject_old_samples_max_age"`
-	CreationGracePeriod     model.Duration   `yaml:"creation_grace_period" json:"creation_grace_period"`
-	EnforceMetricName       bool             `yaml:"enforce_metric_name" json:"enforce_metric_name"`
-	MaxLineSize             flagext.ByteSize `yaml:"max_line_size" json:"max_line_size"`
-	MaxLineSizeTruncate     bool             `yaml:"max_line_size_truncate" json:"max_line_size_truncate"`
-	FudgeDuplicateTimestamp bool             `yaml:"fudge_duplicate_timestamp" json:"fudge_duplicate_timestamp"`
+	IngestionRateStrategy   string              `yaml:"ingestion_rate_strategy" json:"ingestion_rate_strategy"`
+	IngestionRateMB         float64             `yaml:"ingestion_rate_mb" json:"ingestion_rate_mb"`
+	IngestionBurstSizeMB    float64             `yaml:"ingestion_burst_size_mb" json:"ingestion_burst_size_mb"`
+	MaxLabelNameLength      int                 `yaml:"max_label_name_length" json:"max_label_name_length"`
+	MaxLabelValueLength     int                 `yaml:"max_label_value_length" json:"max_label_value_length"`
+	MaxLabelNamesPerSeries  int                 `yaml:"max_label_names_per_series" json:"max_label_names_per_series"`
+	RejectOldSamples        bool                `yaml:"reject_old_samples" json:"reject_old_samples"`
+	RejectOldSamplesMaxAge  model.Duration      `yaml:"reject_old_samples_max_age" json:"reject_old_samples_max_age"`
+	CreationGracePeriod     model.Duration      `yaml:"creation_grace_period" json:"creation_grace_period"`
+	EnforceMetricName       bool                `yaml:"enforce_metric_name" json:"enforce_metric_name"`
+	MaxLineSize             flagext.ByteSize    `yaml:"max_line_size" json:"max_line_size"`
+	MaxLineSizeTruncate     bool                `yaml:"max_line_size_truncate" json:"max_line_size_truncate"`
+	FudgeDuplicateTimestamp bool                `yaml:"fudge_duplicate_timestamp" json:"fudge_duplicate_timestamp"`
+	AllowStructuredMetadata bool                `yaml:"allow_structured_metadata" json:"allow_structured_metadata"`
+	ShardStreams            shardstreams.Config `yaml:"shard_streams" json:"shard_streams"`
+	OTLPConfig              push.OTLPConfig     `yaml:"otlp_config" json:"otlp_config"`
+	LabelPolicy             LabelPolicy         `yaml:"label_policy" json:"label_policy"`
+	RedactionRules          []*RedactionRule    `yaml:"redaction_rules,omitempty" json:"redaction_rules,omitempty"`
+	DropRules               []*DropRule         `yaml:"drop_rules,omitempty" json:"drop_rules,omitempty"`
+	AcceptHASamples         bool                `yaml:"accept_ha_samples" json:"accept_ha_samples"`
+	HAClusterLabel          string              `yaml:"ha_cluster_label" json:"ha_cluster_label"`
+	HAReplicaLabel          string              `yaml:"ha_replica_label" json:"ha_replica_label"`
+	HAMaxClusters           int                 `yaml:"ha_max_clusters" json:"ha_max_clusters"`
 
 	// Ingester enforced limits.
 	MaxLocalStreamsPerUser  int              `yaml:"max_streams_per_user" json:"max_streams_per_user"`
@@ -82,8 +96,12 @@ type Limits struct {
 	QueryReadyIndexNumDays     int            `yaml:"query_ready_index_num_days" json:"query_ready_index_num_days"`
 
 	// Query frontend enforced limits. The default is actually parameterized by the queryrange config.
-	QuerySplitDuration  model.Duration `yaml:"split_queries_by_interval" json:"split_queries_by_interval"`
-	MinShardingLookback model.Duration `yaml:"min_sharding_lookback" json:"min_sharding_lookback"`
+	QuerySplitDuration    model.Duration   `yaml:"split_queries_by_interval" json:"split_queries_by_interval"`
+	MinShardingLookback   model.Duration   `yaml:"min_sharding_lookback" json:"min_sharding_lookback"`
+	ShardQuantileOverTime bool             `yaml:"shard_quantile_over_time" json:"shard_quantile_over_time"`
+	BlockedQueries        []*BlockedQuery  `yaml:"blocked_queries,omitempty" json:"blocked_queries,omitempty"`
+	MaxQueryBytesRead     flagext.ByteSize `yaml:"max_query_bytes_read" json:"max_query_bytes_read"`
+	MaxQuerierBytesRead   flagext.ByteSize `yaml:"max_querier_bytes_read" json:"max_querier_bytes_read"`
 
 	// Ruler defaults and limits.
 	RulerEvaluationDelay        model.Duration `yaml:"ruler_evaluation_delay_duration" json:"ruler_evaluation_delay_duration"`
@@ -125,6 +143,47 @@ type StreamRetention struct {
 	Matchers []*labels.Matcher `yaml:"-" json:"-"` // populated during validation.
 }
 
+const (
+	// BlockedQueryTypeMetric blocks metric queries.
+	BlockedQueryTypeMetric = "metric"
+	// BlockedQueryTypeLog blocks log queries, with or without line filters.
+	BlockedQueryTypeLog = "log"
+	// BlockedQueryTypeFilter blocks log queries with line filters.
+	BlockedQueryTypeFilter = "filter"
+)
+
+// BlockedQuery is a rule blocking the queries of a tenant in the query frontend.
+// A query is blocked if its hash is equal to Hash when set, otherwise if it matches the Pattern,
+// either exactly or as a regular expression. An empty pattern matches all queries.
+// When Types is set, only the queries of the given types are blocked.
+type BlockedQuery struct {
+	Pattern string   `yaml:"pattern" json:"pattern"`
+	Regex   bool     `yaml:"regex" json:"regex"`
+	Hash    uint32   `yaml:"hash" json:"hash"`
+	Types   []string `yaml:"types" json:"types"`
+
+	Regexp *regexp.Regexp `yaml:"-" json:"-"` // populated during validation.
+}
+
+// Validate validates that the blocked query rule is valid and compiles its regular expression.
+func (b *BlockedQuery) Validate() error {
+	if b.Regex {
+		re, err := regexp.Compile(strings.TrimSpace(b.Pattern))
+		if err != nil {
+			return fmt.Errorf("invalid blocked query regex %q: %w", b.Pattern, err)
+		}
+		b.Regexp = re
+	}
+	for _, t := range b.Types {
+		switch t {
+		case BlockedQueryTypeMetric, BlockedQueryTypeLog, BlockedQueryTypeFilter:
+		default:
+			return fmt.Errorf("invalid blocked query type %q, must be one of %s, %s or %s", t, BlockedQueryTypeMetric, BlockedQueryTypeLog, BlockedQueryTypeFilter)
+		}
+	}
+	return nil
+}
+
 // RegisterFlags adds the flags required to config this to the given FlagSet
 func (l *Limits) RegisterFlags(f *flag.FlagSet) {
 	f.StringVar(&l.IngestionRateStrategy, "distributor.ingestion-rate-limit-strategy", "global", "Whether the ingestion rate limit should be applied individually to each distributor instance (local), or evenly shared across the cluster (global).")
@@ -137,6 +196,7 @@ func (l *Limits) RegisterFlags(f *flag.FlagSet) {
 	f.IntVar(&l.MaxLabelNamesPerSeries, "validation.max-label-names-per-series", 30, "Maximum number of label names per series.")
 	f.BoolVar(&l.RejectOldSamples, "validation.reject-old-samples", true, "Reject old samples.")
 	f.BoolVar(&l.FudgeDuplicateTimestamp, "validation.fudge-duplicate-timestamps", false, "Fudge the timestamp of a log line by one nanosecond in the future from a previous entry for the same stream with the same timestamp, guarantees sort order at query time.")
+	f.BoolVar(&l.AllowStructuredMetadata, "validation.allow-structured-metadata", false, "Allow user to send structured metadata (key/value pairs attached to each log line without being indexed) in the push payload. Only the streams with unordered writes keep it. Streams already in memory when enabling it only keep it once flushed and recreated.")
 
 	_ = l.RejectOldSamplesMaxAge.Set("7d")
 	f.Var(&l.RejectOldSamplesMaxAge, "validation.reject-old-samples.max-age", "Maximum accepted sample age before rejecting.")
@@ -145,6 +205,14 @@ func (l *Limits) RegisterFlags(f *flag.FlagSet) {
 	f.BoolVar(&l.EnforceMetricName, "validation.enforce-metric-name", true, "Enforce every sample has a metric name.")
 	f.IntVar(&l.MaxEntriesLimitPerQuery, "validation.max-entries-limit", 5000, "Per-user entries limit per query")
 
+	l.ShardStreams.RegisterFlagsWithPrefix("distributor.shard-streams", f)
+	l.OTLPConfig.RegisterFlagsWithPrefix("distributor.otlp", f)
+
+	f.BoolVar(&l.AcceptHASamples, "distributor.ha-tracker.enable-for-all-users", false, "Deduplicate the pushes of the HA pairs of clients of the tenants, when the HA tracker is enabled.")
+	f.StringVar(&l.HAClusterLabel, "distributor.ha-tracker.cluster", "cluster", "Label identifying the cluster of the replicas of an HA pair of clients.")
+	f.StringVar(&l.HAReplicaLabel, "distributor.ha-tracker.replica", "__replica__", "Label identifying the replica of an HA pair of clients, removed from the accepted streams.")
+	f.IntVar(&l.HAMaxClusters, "distributor.ha-tracker.max-clusters", 0, "Maximum number of HA clusters of a tenant. 0 to disable.")
+
 	f.IntVar(&l.MaxLocalStreamsPerUser, "ingester.max-streams-per-user", 0, "Maximum number of active streams per user, per ingester. 0 to disable.")
 	f.IntVar(&l.MaxGlobalStreamsPerUser, "ingester.max-global-streams-per-user", 5000, "Maximum number of active streams per user, across the cluster. 0 to disable.")
 	f.BoolVar(&l.UnorderedWrites, "ingester.unordered-writes", true, "Allow out of order writes.")
@@ -169,6 +237,11 @@ func (l *Limits) RegisterFlags(f *flag.FlagSet) {
 
 	_ = l.MinShardingLookback.Set("0s")
 	f.Var(&l.MinShardingLookback, "frontend.min-sharding-lookback", "Limit the sharding time range.Queries with time range that fall between now and now minus the sharding lookback are not sharded. 0 to disable.")
+	_ = l.MaxQueryBytesRead.Set("0B")
+	f.Var(&l.MaxQueryBytesRead, "frontend.max-query-bytes-read", "Max number of bytes a query can read, estimated from the index before running the query. The query is rejected if the estimate is above the limit. This limit is enforced in the query frontend. 0 to disable.")
+	_ = l.MaxQuerierBytesRead.Set("0B")
+	f.Var(&l.MaxQuerierBytesRead, "frontend.max-querier-bytes-read", "Max number of bytes a query can read in a single querier, once split by interval and sharded, estimated from the index before running the query. The query is rejected if the estimate is above the limit. This limit is enforced in the query frontend. 0 to disable.")
+	f.BoolVar(&l.ShardQuantileOverTime, "frontend.shard-quantile-over-time", false, "Shard quantile_over_time queries using mergeable quantile sketches. The sharded queries return approximated quantiles, with a relative error of at most 1%, instead of the exact ones.")
 
 	_ = l.MaxCacheFreshness.Set("1m")
 	f.Var(&l.MaxCacheFreshness, "frontend.max-cache-freshness", "Most recent allowed cacheable result per-tenant, to prevent caching very recent results that might still be in flux.")
@@ -228,7 +301,25 @@ func (l *Limits) Validate() error {
 			l.StreamRetention[i].Matchers = matchers
 		}
 	}
-	return nil
+	for _, q := range l.BlockedQueries {
+		if err := q.Validate(); err != nil {
+			return err
+		}
+	}
+	if err := l.LabelPolicy.Validate(); err != nil {
+		return err
+	}
+	for _, r := range l.RedactionRules {
+		if err := r.Validate(); err != nil {
+			return err
+		}
+	}
+	for _, r := range l.DropRules {
+		if err := r.Validate(); err != nil {
+			return err
+		}
+	}
+	return l.OTLPConfig.Validate()
 }
 
 // When we load YAML from disk, we want the various per-customer limits
@@ -392,6 +483,26 @@ func (o *Overrides) MinShardingLookback(userID string) time.Duration {
 	return time.Duration(o.getOverridesForUser(userID).MinShardingLookback)
 }
 
+// ShardQuantileOverTime returns whether the quantile_over_time queries of the tenant can be sharded using quantile sketches.
+func (o *Overrides) ShardQuantileOverTime(userID string) bool {
+	return o.getOverridesForUser(userID).ShardQuantileOverTime
+}
+
+// BlockedQueries returns the rules blocking the queries of the tenant in the query frontend.
+func (o *Overrides) BlockedQueries(userID string) []*BlockedQuery {
+	return o.getOverridesForUser(userID).BlockedQueries
+}
+
+// MaxQueryBytesRead returns the maximum number of bytes a query of the tenant can read.
+func (o *Overrides) MaxQueryBytesRead(userID string) int {
+	return o.getOverridesForUser(userID).MaxQueryBytesRead.Val()
+}
+
+// MaxQuerierBytesRead returns the maximum number of bytes a single querier can read for a query of the tenant.
+func (o *Overrides) MaxQuerierBytesRead(userID string) int {
+	return o.getOverridesForUser(userID).MaxQuerierBytesRead.Val()
+}
+
 // QuerySplitDuration returns the tenant specific splitby interval applied in the query frontend.
 func (o *Overrides) QuerySplitDuration(userID string) time.Duration {
 	return time.Duration(o.getOverridesForUser(userID).QuerySplitDuration)
@@ -543,6 +654,56 @@ func (o *Overrides) FudgeDuplicateTimestamps(userID string) bool {
 	return o.getOverridesForUser(userID).FudgeDuplicateTimestamp
 }
 
+// AllowStructuredMetadata returns whether the tenant may attach structured metadata to its entries.
+func (o *Overrides) AllowStructuredMetadata(userID string) bool {
+	return o.getOverridesForUser(userID).AllowStructuredMetadata
+}
+
+// AcceptHASamples returns whether the pushes of the HA pairs of clients of the user are deduplicated.
+func (o *Overrides) AcceptHASamples(userID string) bool {
+	return o.getOverridesForUser(userID).AcceptHASamples
+}
+
+// HAClusterLabel returns the label identifying the cluster of the replicas of an HA pair of clients of the user.
+func (o *Overrides) HAClusterLabel(userID string) string {
+	return o.getOverridesForUser(userID).HAClusterLabel
+}
+
+// HAReplicaLabel returns the label identifying the replica of an HA pair of clients of the user.
+func (o *Overrides) HAReplicaLabel(userID string) string {
+	return o.getOverridesForUser(userID).HAReplicaLabel
+}
+
+// HAMaxClusters returns the maximum number of HA clusters of the user.
+func (o *Overrides) HAMaxClusters(userID string) int {
+	return o.getOverridesForUser(userID).HAMaxClusters
+}
+
+// ShardStreams returns the configuration of the automatic sharding of the streams of the tenant.
+func (o *Overrides) ShardStreams(userID string) shardstreams.Config {
+	return o.getOverridesForUser(userID).ShardStreams
+}
+
+// OTLPConfig returns the configuration of the conversion of the OTLP log records of the tenant into streams.
+func (o *Overrides) OTLPConfig(userID string) push.OTLPConfig {
+	return o.getOverridesForUser(userID).OTLPConfig
+}
+
+// LabelPolicy returns the policy constraining the labels of the streams pushed by the user.
+func (o *Overrides) LabelPolicy(userID string) *LabelPolicy {
+	return &o.getOverridesForUser(userID).LabelPolicy
+}
+
+// RedactionRules returns the rules redacting the lines, structured metadata and label values pushed by the user.
+func (o *Overrides) RedactionRules(userID string) []*RedactionRule {
+	return o.getOverridesForUser(userID).RedactionRules
+}
+
+// DropRules returns the rules dropping or sampling the entries pushed by the user.
+func (o *Overrides) DropRules(userID string) []*DropRule {
+	return o.getOverridesForUser(userID).DropRules
+}
+
 func (o *Overrides) getOverridesForUser(userID string) *Limits {
 	if o.tenantLimits != nil {
 		l := o.tenantLimits.TenantLimits(userID)
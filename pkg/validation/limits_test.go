@@ -239,3 +239,55 @@ reject_old_samples: true
 		})
 	}
 }
+
+func TestLimitsValidateBlockedQueries(t *testing.T) {
+	for _, tc := range []struct {
+		desc string
+		yaml string
+		err  bool
+	}{
+		{
+			desc: "valid",
+			yaml: `
+blocked_queries:
+  - pattern: '.*foo.*'
+    regex: true
+    types: [metric, filter]
+  - pattern: '{app="bar"}'
+  - hash: 2866891521
+    types: [log]
+`,
+		},
+		{
+			desc: "invalid regex",
+			yaml: `
+blocked_queries:
+  - pattern: '(foo'
+    regex: true
+`,
+			err: true,
+		},
+		{
+			desc: "invalid type",
+			yaml: `
+blocked_queries:
+  - pattern: '{app="bar"}'
+    types: [stream]
+`,
+			err: true,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			var limits Limits
+			require.NoError(t, yaml.Unmarshal([]byte(tc.yaml), &limits))
+			if tc.err {
+				require.Error(t, limits.Validate())
+				return
+			}
+			require.NoError(t, limits.Validate())
+			for _, q := range limits.BlockedQueries {
+				require.Equal(t, q.Regex, q.Regexp != nil)
+			}
+		})
+	}
+}
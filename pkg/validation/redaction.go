@@ -0,0 +1,168 @@
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	// RedactionDetectorPAN detects the payment card numbers, of 13 to 19 digits optionally separated by
+	// spaces or dashes, and passing the Luhn check, including when followed or preceded by other groups
+	// of digits.
+	RedactionDetectorPAN = "pan"
+	// RedactionDetectorJWT detects the JSON web tokens.
+	RedactionDetectorJWT = "jwt"
+	// RedactionDetectorAWSKeys detects the AWS access key IDs, and the AWS secret access keys assigned to a
+	// key named like aws_secret_access_key.
+	RedactionDetectorAWSKeys = "aws_keys"
+
+	redactionRegexRuleName      = "regex"
+	defaultRedactionReplacement = "[REDACTED]"
+)
+
+var redactionDetectors = map[string]string{
+	RedactionDetectorPAN:     `\b[2-6](?:[ -]?\d){12,18}\b`,
+	RedactionDetectorJWT:     `\beyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`,
+	RedactionDetectorAWSKeys: `\b((?:A3T[A-Z0-9]|AKIA|ASIA|ABIA|ACCA|AGPA|AIDA|AIPA|ANPA|ANVA|AROA|APKA)[A-Z2-7]{16})\b|(?i:aws_?secret_?access_?key)["']?\s*[:=]\s*["']?([A-Za-z0-9/+=]{40})`,
+}
+
+// RedactionRule is a rule redacting the matches of a regular expression, or of a built-in detector, from the
+// lines, the structured metadata values and the label values pushed by a tenant. When the regular expression
+// has capturing groups, only the text of the first group taking part in a match is replaced, so that the
+// context of the match can be kept.
+type RedactionRule struct {
+	Name        string `yaml:"name,omitempty" json:"name,omitempty"`
+	Regex       string `yaml:"regex,omitempty" json:"regex,omitempty"`
+	Detector    string `yaml:"detector,omitempty" json:"detector,omitempty"`
+	Replacement string `yaml:"replacement,omitempty" json:"replacement,omitempty"`
+
+	Regexp *regexp.Regexp `yaml:"-" json:"-"` // populated during validation.
+}
+
+// Validate validates that the redaction rule is valid, compiles its regular expression and
+// sets the defaults of its name and replacement.
+func (r *RedactionRule) Validate() error {
+	pattern := r.Regex
+	switch {
+	case r.Regex != "" && r.Detector != "":
+		return fmt.Errorf("redaction rule %q can't have both a regex and a detector", r.Name)
+	case r.Detector != "":
+		var ok bool
+		if pattern, ok = redactionDetectors[r.Detector]; !ok {
+			return fmt.Errorf("invalid redaction detector %q, must be one of %s, %s or %s", r.Detector, RedactionDetectorPAN, RedactionDetectorJWT, RedactionDetectorAWSKeys)
+		}
+		if r.Name == "" {
+			r.Name = r.Detector
+		}
+	case r.Regex == "":
+		return fmt.Errorf("redaction rule %q must have either a regex or a detector", r.Name)
+	case r.Name == "":
+		r.Name = redactionRegexRuleName
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("invalid redaction regex %q: %w", r.Regex, err)
+	}
+	r.Regexp = re
+	if r.Replacement == "" {
+		r.Replacement = defaultRedactionReplacement
+	}
+	return nil
+}
+
+// Redact returns the line with the matches of the rule replaced, along with the number of replaced matches.
+func (r *RedactionRule) Redact(line string) (string, int) {
+	matches := r.Regexp.FindAllStringSubmatchIndex(line, -1)
+	if len(matches) == 0 {
+		return line, 0
+	}
+
+	var b strings.Builder
+	last, n := 0, 0
+	replace := func(start, end int) {
+		b.WriteString(line[last:start])
+		b.WriteString(r.Replacement)
+		last = end
+		n++
+	}
+	for _, m := range matches {
+		start, end := m[0], m[1]
+		for g := 2; g < len(m); g += 2 {
+			if m[g] >= 0 {
+				start, end = m[g], m[g+1]
+				break
+			}
+		}
+		if r.Detector != RedactionDetectorPAN {
+			replace(start, end)
+			continue
+		}
+		// the pattern is greedy, so the card numbers are looked for within the digits it matched.
+		for _, span := range luhnValidSpans(line[start:end]) {
+			replace(start+span[0], start+span[1])
+		}
+	}
+	if n == 0 {
+		return line, 0
+	}
+	b.WriteString(line[last:])
+	return b.String(), n
+}
+
+// luhnValidSpans returns the spans of the digits, separated by spaces or dashes, which are card numbers:
+// they start with a digit from 2 to 6, have 13 to 19 digits and pass the Luhn check. The spans start and end
+// at the boundaries of the groups of digits, and the longest span is returned first from each start.
+func luhnValidSpans(digits string) [][2]int {
+	isSeparator := func(i int) bool { return digits[i] == ' ' || digits[i] == '-' }
+
+	var spans [][2]int
+	for start := 0; start < len(digits); start++ {
+		if start > 0 && !isSeparator(start-1) || digits[start] < '2' || digits[start] > '6' {
+			continue
+		}
+		for end := len(digits); end > start; end-- {
+			if end < len(digits) && !isSeparator(end) || isSeparator(end-1) {
+				continue
+			}
+			if n := countDigits(digits[start:end]); n >= 13 && n <= 19 && luhnValid(digits[start:end]) {
+				spans = append(spans, [2]int{start, end})
+				start = end
+				break
+			}
+		}
+	}
+	return spans
+}
+
+func countDigits(s string) int {
+	n := 0
+	for i := 0; i < len(s); i++ {
+		if s[i] >= '0' && s[i] <= '9' {
+			n++
+		}
+	}
+	return n
+}
+
+// luhnValid returns whether the digits of the number, ignoring its separators, pass the Luhn check.
+func luhnValid(number string) bool {
+	sum, double := 0, false
+	for i := len(number) - 1; i >= 0; i-- {
+		c := number[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
@@ -23,6 +23,12 @@ const (
 	// LineTooLong is a reason for discarding too long log lines.
 	LineTooLong         = "line_too_long"
 	LineTooLongErrorMsg = "Max entry size '%d' bytes exceeded for stream '%s' while adding an entry with length '%d' bytes"
+	// DisallowedStructuredMetadata is a reason for discarding entries with structured metadata when the tenant is not allowed to send it.
+	DisallowedStructuredMetadata         = "disallowed_structured_metadata"
+	DisallowedStructuredMetadataErrorMsg = "stream '%s' includes structured metadata, but this feature is disallowed. Please see `limits_config.allow_structured_metadata` or contact your Loki administrator to enable it."
+	// InvalidStructuredMetadata is a reason for discarding entries with invalid structured metadata names.
+	InvalidStructuredMetadata         = "invalid_structured_metadata"
+	InvalidStructuredMetadataErrorMsg = "stream '%s' has invalid structured metadata name: '%s'"
 	// StreamLimit is a reason for discarding lines when we can't create a new stream
 	// because the limit of active streams has been reached.
 	StreamLimit         = "stream_limit"
@@ -50,6 +56,20 @@ const (
 	// DuplicateLabelNames is a reason for discarding a log line which has duplicate label names
 	DuplicateLabelNames         = "duplicate_label_names"
 	DuplicateLabelNamesErrorMsg = "stream '%s' has duplicate label name: '%s'"
+	// MissingRequiredLabel is a reason for discarding a log line which is missing a label required by the label policy
+	MissingRequiredLabel         = "missing_required_label"
+	MissingRequiredLabelErrorMsg = "stream '%s' is missing required label: '%s'"
+	// ForbiddenLabel is a reason for discarding a log line which has a label forbidden by the label policy,
+	// or for mutating it when its value is replaced with the fallback value of the policy
+	ForbiddenLabel         = "forbidden_label"
+	ForbiddenLabelErrorMsg = "stream '%s' has forbidden label: '%s'"
+	// InvalidLabelValue is a reason for discarding a log line which has a label value not matching its pattern in the label policy
+	InvalidLabelValue         = "invalid_label_value"
+	InvalidLabelValueErrorMsg = "stream '%s' has label '%s' with value '%s' not matching the pattern: '%s'"
+	// DroppedByRule is a reason for discarding a log line which was dropped by a drop rule of the tenant
+	DroppedByRule = "dropped_by_rule"
+	// Redacted is a reason for mutating a log line which matched a redaction rule of the tenant
+	Redacted = "redacted"
 )
 
 type ErrStreamRateLimit struct {